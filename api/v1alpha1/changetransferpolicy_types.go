@@ -46,6 +46,12 @@ type ChangeTransferPolicySpec struct {
 	// +kubebuilder:default:=true
 	AutoMerge *bool `json:"autoMerge,omitempty"`
 
+	// ConflictResolution determines what happens when the proposed branch conflicts with the active branch.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:=ours
+	// +kubebuilder:validation:Enum=ours;fail;rebuild
+	ConflictResolution ConflictResolution `json:"conflictResolution,omitempty"`
+
 	// ActiveCommitStatuses lists the statuses to be monitored on the active branch
 	// +kubebuilder:validation:Optional
 	// +listType:=map
@@ -57,6 +63,32 @@ type ChangeTransferPolicySpec struct {
 	// +listType:=map
 	// +listMapKey=key
 	ProposedCommitStatuses []CommitStatusSelector `json:"proposedCommitStatuses"`
+
+	// PullRequest configures the pull request opened to promote the proposed branch.
+	// +kubebuilder:validation:Optional
+	PullRequest *PullRequestOptions `json:"pullRequest,omitempty"`
+}
+
+// ConflictResolution is the strategy used when the proposed branch conflicts with the active branch.
+type ConflictResolution string
+
+const (
+	// ConflictResolutionOurs merges the active branch into the proposed branch with the "ours" strategy, so the
+	// proposed branch content wins. This is the default.
+	ConflictResolutionOurs ConflictResolution = "ours"
+	// ConflictResolutionFail leaves both branches untouched and reports the conflict on the ChangeTransferPolicy.
+	ConflictResolutionFail ConflictResolution = "fail"
+	// ConflictResolutionRebuild leaves both branches untouched and asks the hydrator to re-hydrate the proposed
+	// branch on top of the active branch.
+	ConflictResolutionRebuild ConflictResolution = "rebuild"
+)
+
+// GetConflictResolution returns the value of the ConflictResolution field, defaulting to "ours" if the field is empty.
+func (s *ChangeTransferPolicySpec) GetConflictResolution() ConflictResolution {
+	if s.ConflictResolution == "" {
+		return ConflictResolutionOurs
+	}
+	return s.ConflictResolution
 }
 
 // ChangeRequestPolicyCommitStatusPhase defines the phase of a commit status in a ChangeTransferPolicy.
@@ -95,6 +127,28 @@ type CommitBranchState struct {
 	// +listType:=map
 	// +listMapKey=key
 	CommitStatuses []ChangeRequestPolicyCommitStatusPhase `json:"commitStatuses,omitempty"`
+	// Changes summarizes what the hydrated commit changes on the active branch. For the proposed branch, it is the diff
+	// from the active hydrated commit. For history entries, it is the diff from the previous active hydrated commit.
+	// +kubebuilder:validation:Optional
+	Changes *ChangeSummary `json:"changes,omitempty"`
+}
+
+// ChangeSummary is a bounded summary of the differences between two hydrated commits.
+type ChangeSummary struct {
+	// FilesChanged is the number of files added, modified or deleted. Changes to hydrator.metadata are not counted in
+	// any of the fields.
+	FilesChanged int `json:"filesChanged,omitempty"`
+	// Additions is the number of lines added. Binary files are not counted.
+	Additions int `json:"additions,omitempty"`
+	// Deletions is the number of lines removed. Binary files are not counted.
+	Deletions int `json:"deletions,omitempty"`
+	// Directories lists the directories containing changed files, sorted. With the usual one-directory-per-app layout
+	// of hydrated branches, these are the apps touched by the change. The list is truncated to the first 20 entries;
+	// DirectoryCount holds the total.
+	Directories []string `json:"directories,omitempty"`
+	// DirectoryCount is the total number of directories containing changed files, including any that were truncated
+	// from Directories.
+	DirectoryCount int `json:"directoryCount,omitempty"`
 }
 
 // HydratorMetadata contains metadata about the hydrated commit.
@@ -171,6 +225,10 @@ type ChangeTransferPolicyStatus struct {
 	// History is in reverse chronological order (newest is first).
 	History []History `json:"history,omitempty"`
 
+	// Conflict describes the merge conflict detected between the proposed and active branches for the current
+	// proposed dry commit. It is cleared once the proposed dry commit changes or has been promoted.
+	Conflict *ConflictStatus `json:"conflict,omitempty"`
+
 	// Conditions Represents the observations of the current state.
 	// +patchMergeKey=type
 	// +patchStrategy=merge
@@ -179,6 +237,24 @@ type ChangeTransferPolicyStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
 }
 
+// ConflictStatus describes a merge conflict between the proposed and active branches.
+type ConflictStatus struct {
+	// DrySha is the proposed dry SHA for which the conflict was detected.
+	// +kubebuilder:validation:MaxLength=64
+	// +kubebuilder:validation:Pattern=`^([a-f0-9]{40}|[a-f0-9]{64})$`
+	DrySha string `json:"drySha,omitempty"`
+	// Resolution is the conflict resolution strategy that was applied.
+	// +kubebuilder:validation:Enum=ours;fail;rebuild
+	Resolution ConflictResolution `json:"resolution,omitempty"`
+	// Paths lists the conflicting paths reported by git merge-tree. The list is truncated to a bounded number of
+	// entries; PathCount holds the full count.
+	Paths []string `json:"paths,omitempty"`
+	// PathCount is the total number of conflicting paths, including any that were truncated from Paths.
+	PathCount int `json:"pathCount,omitempty"`
+	// DetectedTime is the time the conflict was first detected.
+	DetectedTime metav1.Time `json:"detectedTime,omitempty"`
+}
+
 // History describes a particular change that was promoted by the ChangeTransferPolicy.
 type History struct {
 	// Proposed is the state of the proposed branch at the time the PR was merged.
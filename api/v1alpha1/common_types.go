@@ -15,11 +15,39 @@ type GitHub struct {
 	// +kubebuilder:validation:Minimum=0
 	AppID int64 `json:"appID"`
 	// InstallationID is the GitHub App Installation ID. If you want to use this ScmProvider for multiple
-	// GitHub orgs, do not specify this field. The installation ID will be inferred from the repo owner
-	// when needed.
+	// GitHub orgs, do not specify this field. The installation ID will be discovered from the owner of each
+	// GitRepository that uses this ScmProvider, and cached along with its installation tokens.
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:validation:Minimum=0
 	InstallationID int64 `json:"installationID,omitempty"`
+	// CommitStatusMode selects how commit statuses are published: as check runs, as classic commit statuses, or as
+	// both. Check runs require the App's "Checks" permission, classic statuses the "Commit statuses" permission. Use
+	// classic statuses if branch protection rules require status contexts.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:=checkRuns
+	// +kubebuilder:validation:Enum=checkRuns;statuses;both
+	CommitStatusMode GitHubCommitStatusMode `json:"commitStatusMode,omitempty"`
+}
+
+// GitHubCommitStatusMode selects the GitHub API used to publish commit statuses.
+type GitHubCommitStatusMode string
+
+const (
+	// GitHubCommitStatusModeCheckRuns publishes commit statuses as check runs. This is the default.
+	GitHubCommitStatusModeCheckRuns GitHubCommitStatusMode = "checkRuns"
+	// GitHubCommitStatusModeStatuses publishes commit statuses as classic commit statuses, with the CommitStatus name as
+	// the context.
+	GitHubCommitStatusModeStatuses GitHubCommitStatusMode = "statuses"
+	// GitHubCommitStatusModeBoth publishes commit statuses both as check runs and as classic commit statuses.
+	GitHubCommitStatusModeBoth GitHubCommitStatusMode = "both"
+)
+
+// GetCommitStatusMode returns the value of the CommitStatusMode field, defaulting to "checkRuns" if the field is empty.
+func (g *GitHub) GetCommitStatusMode() GitHubCommitStatusMode {
+	if g.CommitStatusMode == "" {
+		return GitHubCommitStatusModeCheckRuns
+	}
+	return g.CommitStatusMode
 }
 
 // GitLab is a GitLab SCM provider configuration. It is used to configure the GitLab settings.
@@ -28,11 +56,27 @@ type GitLab struct {
 	// empty.
 	// +kubebuilder:validation:XValidation:rule=`self != "gitlab.com"`, message="Instead of setting the domain to gitlab.com, leave the field blank"
 	Domain string `json:"domain,omitempty"`
+	// MergeWhenPipelineSucceeds sets merge requests to merge automatically when their pipeline succeeds (auto-merge)
+	// instead of merging them directly. The PullRequest stays open until GitLab merges the merge request.
+	// +kubebuilder:validation:Optional
+	MergeWhenPipelineSucceeds bool `json:"mergeWhenPipelineSucceeds,omitempty"`
 }
 
 // BitbucketCloud is a Bitbucket Cloud SCM provider configuration. It is used to configure the Bitbucket Cloud settings.
 type BitbucketCloud struct{}
 
+// BitbucketServer is a Bitbucket Data Center (formerly Bitbucket Server) SCM provider configuration. It is used to
+// configure the Bitbucket Data Center settings.
+type BitbucketServer struct {
+	// Domain is the Bitbucket Data Center domain, such as "bitbucket.mycompany.com". It may include a port and a context
+	// path, such as "git.mycompany.com:7990/bitbucket". There is no default domain since Bitbucket Data Center is
+	// self-hosted.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Domain string `json:"domain"`
+}
+
 // Forgejo is a Forgejo SCM provider configuration. It is used to configure the Forgejo settings.
 type Forgejo struct {
 	// Domain is the Forgejo domain, such as "codeberg.org" or "forgejo.mycompany.com".
@@ -63,6 +107,81 @@ type AzureDevOps struct {
 	// Domain is the Azure DevOps domain, such as "dev.azure.com". If using the default Azure DevOps domain, leave this field empty.
 	// +kubebuilder:validation:XValidation:rule=`self != "dev.azure.com"`, message="Instead of setting the domain to dev.azure.com, leave the field blank"
 	Domain string `json:"domain,omitempty"`
+	// AutoComplete sets pull requests to complete automatically once their branch policies pass, instead of completing
+	// them directly. The PullRequest stays open until Azure DevOps completes the pull request.
+	// +kubebuilder:validation:Optional
+	AutoComplete bool `json:"autoComplete,omitempty"`
+	// MergeStrategy is the strategy used to complete pull requests. The "rebase" strategy does not create a merge
+	// commit, so the commit message of the PullRequest is not used.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:=noFastForward
+	// +kubebuilder:validation:Enum=noFastForward;squash;rebase;rebaseMerge
+	MergeStrategy AzureDevOpsMergeStrategy `json:"mergeStrategy,omitempty"`
+	// LinkWorkItems links pull requests to the Azure Boards work items mentioned as AB#<id> in the message of the dry
+	// commit they promote, such as in an "AB#1234" trailer. The token must be allowed to read and write work items.
+	// +kubebuilder:validation:Optional
+	LinkWorkItems bool `json:"linkWorkItems,omitempty"`
+}
+
+// AzureDevOpsMergeStrategy is the strategy used to complete an Azure DevOps pull request.
+type AzureDevOpsMergeStrategy string
+
+const (
+	// AzureDevOpsMergeStrategyNoFastForward creates a two-parent merge commit. This is the default.
+	AzureDevOpsMergeStrategyNoFastForward AzureDevOpsMergeStrategy = "noFastForward"
+	// AzureDevOpsMergeStrategySquash puts all changes of the pull request into a single-parent commit.
+	AzureDevOpsMergeStrategySquash AzureDevOpsMergeStrategy = "squash"
+	// AzureDevOpsMergeStrategyRebase rebases the source branch on the target branch and fast-forwards the target
+	// branch.
+	AzureDevOpsMergeStrategyRebase AzureDevOpsMergeStrategy = "rebase"
+	// AzureDevOpsMergeStrategyRebaseMerge rebases the source branch on the target branch and creates a two-parent
+	// merge commit.
+	AzureDevOpsMergeStrategyRebaseMerge AzureDevOpsMergeStrategy = "rebaseMerge"
+)
+
+// GetMergeStrategy returns the value of the MergeStrategy field, defaulting to "noFastForward" if the field is empty.
+func (a *AzureDevOps) GetMergeStrategy() AzureDevOpsMergeStrategy {
+	if a.MergeStrategy == "" {
+		return AzureDevOpsMergeStrategyNoFastForward
+	}
+	return a.MergeStrategy
+}
+
+// Gerrit is a Gerrit SCM provider configuration. Git operations and REST API calls authenticate with basic auth using
+// the "username" and "password" (the user's HTTP password) keys of the ScmProvider's secret. Promotions are Gerrit
+// changes: a merge commit of the proposed branch is pushed to refs/for/<active branch> for review and submitted to
+// merge it.
+type Gerrit struct {
+	// Domain is the Gerrit domain, such as "gerrit.mycompany.com". It may include a port and a context path, such as
+	// "git.mycompany.com:8443/gerrit". There is no default domain since Gerrit is self-hosted.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Domain string `json:"domain"`
+	// CommitStatusLabels maps commit status keys, as used in a PromotionStrategy, to the Gerrit labels they vote on,
+	// such as {"argocd-health": "Verified"}. A successful status votes +1, a failed status votes -1 and a pending status
+	// resets the vote to 0. The vote is cast on the open change that promotes the commit the status is for. Commit
+	// statuses whose keys are not mapped are only recorded on the CommitStatus resource.
+	// +kubebuilder:validation:Optional
+	CommitStatusLabels map[string]string `json:"commitStatusLabels,omitempty"`
+	// AllowUnsignedWebhooks makes the webhook receiver accept unsigned ref-updated deliveries for the repositories of
+	// this provider. The Gerrit webhooks plugin cannot sign deliveries, so they are rejected unless this is set. Anyone
+	// who can reach the webhook receiver can then trigger reconciles of these repositories.
+	// +kubebuilder:validation:Optional
+	AllowUnsignedWebhooks bool `json:"allowUnsignedWebhooks,omitempty"`
+}
+
+// Generic is a plain git HTTP server SCM provider configuration, for servers without a pull request or commit status
+// API such as gitolite or cgit. Git operations authenticate with basic auth using the "username" and "password" keys of
+// the ScmProvider's secret. Pull requests only exist as PullRequest resources and are merged by pushing a merge commit
+// directly to the target branch, and commit statuses are only recorded on CommitStatus resources.
+type Generic struct {
+	// URL is the base URL that repository paths are appended to, such as "https://git.mycompany.com/git".
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=2048
+	// +kubebuilder:validation:Pattern=`^https?://\S+$`
+	URL string `json:"url"`
 }
 
 // Fake is a placeholder for a fake SCM provider, used for testing purposes.
@@ -73,6 +192,68 @@ type Fake struct {
 	Domain string `json:"domain,omitempty"`
 }
 
+// GitSSH configures git operations (clone, fetch, push, and ls-remote) to use SSH instead of HTTPS. It can be used
+// with any SCM provider; API calls to the provider (pull requests, commit statuses) still use HTTPS.
+//
+// The private key is read from the "sshPrivateKey" key of the ScmProvider's secret, and the known_hosts entries used to
+// verify the server are read from the "sshKnownHosts" key. Both are required; host key verification cannot be disabled.
+type GitSSH struct {
+	// Host is the SSH host, such as "ssh.git.mycompany.com". Defaults to the host of the provider's HTTPS repository URL.
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Optional
+	Host string `json:"host,omitempty"`
+	// Port is the SSH port. Defaults to 22.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +kubebuilder:validation:Optional
+	Port int `json:"port,omitempty"`
+	// User is the SSH user. Defaults to "git".
+	// +kubebuilder:validation:MaxLength=64
+	// +kubebuilder:validation:Optional
+	User string `json:"user,omitempty"`
+	// RepoURLTemplate is a Go template for the SSH repository URL, for servers whose SSH paths differ from their HTTPS
+	// paths (for example Azure DevOps: `{{ .User }}@{{ .Host }}:v3/{{ replace "/_git/" "/" .Path }}`). The template receives Host, Port,
+	// User, Path (the path of the HTTPS repository URL, without a leading slash), and GitRepository. When empty, the URL
+	// is built as ssh://<user>@<host>[:<port>]/<path>.
+	// +kubebuilder:validation:MaxLength=1024
+	// +kubebuilder:validation:Optional
+	RepoURLTemplate string `json:"repoURLTemplate,omitempty"`
+}
+
+// CommitAuthor is the identity GitOps Promoter uses as the author and committer of the commits it creates.
+type CommitAuthor struct {
+	// Name is the author name, such as "GitOps Promoter".
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	Name string `json:"name"`
+	// Email is the author email, such as "gitops-promoter@example.com".
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	Email string `json:"email"`
+}
+
+// CommitSigningFormat is the format of the key used to sign commits.
+type CommitSigningFormat string
+
+const (
+	// CommitSigningFormatGPG signs commits with an OpenPGP key.
+	CommitSigningFormatGPG CommitSigningFormat = "gpg"
+	// CommitSigningFormatSSH signs commits with an SSH key.
+	CommitSigningFormatSSH CommitSigningFormat = "ssh"
+)
+
+// CommitSigning configures signing of the commits GitOps Promoter creates, such as the merge commits pushed to resolve
+// conflicts. The private key is read from the "commitSigningKey" key of the ScmProvider's secret and must not be
+// protected by a passphrase.
+type CommitSigning struct {
+	// Format is the signing key format: "gpg" for an ASCII-armored OpenPGP private key, or "ssh" for an SSH private key.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=gpg;ssh
+	Format CommitSigningFormat `json:"format"`
+}
+
 // ObjectReference is a reference to an object by name. It is used to refer to objects in the same namespace.
 type ObjectReference struct {
 	// Name is the name of the object to refer to.
@@ -158,6 +339,23 @@ type BitbucketCloudRepo struct {
 	Name string `json:"name"`
 }
 
+// BitbucketServerRepo is a repository in Bitbucket Data Center, identified by its project key and slug.
+type BitbucketServerRepo struct {
+	// Project is the key of the project that owns the repository, such as "PROJ". For a personal repository, use "~"
+	// followed by the user slug, such as "~jdoe".
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=128
+	// +kubebuilder:validation:Pattern="^~?[a-zA-Z0-9_.-]+$"
+	Project string `json:"project"`
+	// Name is the slug of the repository.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=128
+	// +kubebuilder:validation:Pattern="^[a-zA-Z0-9_.-]+$"
+	Name string `json:"name"`
+}
+
 // AzureDevOpsRepo is a repository in Azure DevOps, identified by its project and name.
 type AzureDevOpsRepo struct {
 	// Project is the project name in Azure DevOps.
@@ -172,6 +370,26 @@ type AzureDevOpsRepo struct {
 	Name string `json:"name"`
 }
 
+// GerritRepo is a repository in Gerrit, identified by its project name.
+type GerritRepo struct {
+	// Project is the name of the Gerrit project, such as "platform/deployments".
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=255
+	// +kubebuilder:validation:Pattern=`^[^/\s]\S*$`
+	Project string `json:"project"`
+}
+
+// GenericRepo is a repository on a plain git HTTP server, identified by its path relative to the provider's URL.
+type GenericRepo struct {
+	// Path is the path of the repository relative to the provider's URL, such as "team/app.git".
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=1024
+	// +kubebuilder:validation:Pattern=`^[^/\s]\S*$`
+	Path string `json:"path"`
+}
+
 // FakeRepo is a placeholder for a repository in the fake SCM provider, used for testing purposes.
 type FakeRepo struct {
 	// Owner is the owner of the repository.
@@ -22,6 +22,9 @@ const TimedCommitStatusLabel = "promoter.argoproj.io/timed-commit-status"
 // WebRequestCommitStatusLabel the web request commit status which the commit status is associated with.
 const WebRequestCommitStatusLabel = "promoter.argoproj.io/web-request-commit-status"
 
+// ScmCheckCommitStatusLabel the SCM check commit status which the commit status is associated with.
+const ScmCheckCommitStatusLabel = "promoter.argoproj.io/scm-check-commit-status"
+
 // PreviousEnvironmentCommitStatusKey the commit status key name used to indicate the previous environment health
 const PreviousEnvironmentCommitStatusKey = "promoter-previous-environment"
 
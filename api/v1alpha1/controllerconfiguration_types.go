@@ -69,6 +69,17 @@ type ControllerConfigurationSpec struct {
 	// including WorkQueue settings that control reconciliation behavior.
 	// +required
 	WebRequestCommitStatus WebRequestCommitStatusConfiguration `json:"webRequestCommitStatus"`
+
+	// ScmCheckCommitStatus contains the configuration for the ScmCheckCommitStatus controller,
+	// including WorkQueue settings that control reconciliation behavior.
+	// +required
+	ScmCheckCommitStatus ScmCheckCommitStatusConfiguration `json:"scmCheckCommitStatus"`
+
+	// WebhookReceiver contains the configuration for the webhook receiver, including the limits that protect it from
+	// oversized, replayed and excessive deliveries.
+	// +optional
+	// +kubebuilder:default:={}
+	WebhookReceiver WebhookReceiverConfiguration `json:"webhookReceiver,omitempty"`
 }
 
 // PromotionStrategyConfiguration defines the configuration for the PromotionStrategy controller.
@@ -91,6 +102,42 @@ type ChangeTransferPolicyConfiguration struct {
 	// This includes requeue duration, maximum concurrent reconciles, and rate limiter settings.
 	// +required
 	WorkQueue WorkQueue `json:"workQueue"`
+
+	// MaxConcurrentStatusOperations is the maximum number of network-bound operations (git fetches, git reads, and
+	// commit status lookups) a single ChangeTransferPolicy reconcile runs in parallel while calculating its status.
+	// Set to 1 to run them sequentially.
+	// +optional
+	// +kubebuilder:default:=4
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=32
+	MaxConcurrentStatusOperations int `json:"maxConcurrentStatusOperations,omitempty"`
+}
+
+// WebhookReceiverConfiguration defines the configuration for the webhook receiver.
+//
+// This configuration limits the size of the deliveries the webhook receiver accepts, how often it processes the same
+// delivery, and how many deliveries it accepts from each source.
+type WebhookReceiverConfiguration struct {
+	// MaxPayloadBytes is the maximum size of the body of a webhook delivery, in bytes. Larger deliveries are rejected
+	// with 413 Payload Too Large.
+	// +optional
+	// +kubebuilder:default:=26214400
+	// +kubebuilder:validation:Minimum=1024
+	MaxPayloadBytes int64 `json:"maxPayloadBytes,omitempty"`
+
+	// DeliveryDeduplicationWindow is how long the delivery IDs of accepted deliveries are remembered. A delivery with
+	// the same delivery ID as one accepted within the window is acknowledged without being processed again, which
+	// ignores replayed deliveries and SCM retries. Set to "0s" to disable.
+	// Format follows Go's time.Duration syntax (e.g., "10m" for 10 minutes).
+	// +optional
+	// +kubebuilder:default:="10m"
+	DeliveryDeduplicationWindow *metav1.Duration `json:"deliveryDeduplicationWindow,omitempty"`
+
+	// RateLimit limits the deliveries accepted from each source IP address with a token bucket. Deliveries over the
+	// limit are rejected with 429 Too Many Requests. If unset, deliveries are not rate limited. The source is the
+	// address of the connection, so behind an ingress or load balancer all deliveries share the proxy's bucket.
+	// +optional
+	RateLimit *Bucket `json:"rateLimit,omitempty"`
 }
 
 // PullRequestConfiguration defines the configuration for the PullRequest controller.
@@ -172,6 +219,18 @@ type WebRequestCommitStatusConfiguration struct {
 	WorkQueue WorkQueue `json:"workQueue"`
 }
 
+// ScmCheckCommitStatusConfiguration defines the configuration for the ScmCheckCommitStatus controller.
+//
+// This configuration controls how the ScmCheckCommitStatus controller processes reconciliation
+// requests, including requeue intervals, concurrency limits, and rate limiting behavior. The requeue
+// duration is also how often the checks are read from the SCM until all of them have succeeded.
+type ScmCheckCommitStatusConfiguration struct {
+	// WorkQueue contains the work queue configuration for the ScmCheckCommitStatus controller.
+	// This includes requeue duration, maximum concurrent reconciles, and rate limiter settings.
+	// +required
+	WorkQueue WorkQueue `json:"workQueue"`
+}
+
 // WorkQueue defines the work queue configuration for a controller.
 //
 // This configuration directly correlates to parameters used with Kubernetes client-go work queues.
@@ -24,15 +24,18 @@ import (
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
 // GitRepositorySpec defines the desired state of GitRepository
-// +kubebuilder:validation:ExactlyOneOf=github;gitlab;forgejo;gitea;bitbucketCloud;azureDevOps;fake
+// +kubebuilder:validation:ExactlyOneOf=github;gitlab;forgejo;gitea;bitbucketCloud;bitbucketServer;azureDevOps;gerrit;generic;fake
 type GitRepositorySpec struct {
-	GitHub         *GitHubRepo         `json:"github,omitempty"`
-	GitLab         *GitLabRepo         `json:"gitlab,omitempty"`
-	Forgejo        *ForgejoRepo        `json:"forgejo,omitempty"`
-	Gitea          *GiteaRepo          `json:"gitea,omitempty"`
-	BitbucketCloud *BitbucketCloudRepo `json:"bitbucketCloud,omitempty"`
-	AzureDevOps    *AzureDevOpsRepo    `json:"azureDevOps,omitempty"`
-	Fake           *FakeRepo           `json:"fake,omitempty"`
+	GitHub          *GitHubRepo          `json:"github,omitempty"`
+	GitLab          *GitLabRepo          `json:"gitlab,omitempty"`
+	Forgejo         *ForgejoRepo         `json:"forgejo,omitempty"`
+	Gitea           *GiteaRepo           `json:"gitea,omitempty"`
+	BitbucketCloud  *BitbucketCloudRepo  `json:"bitbucketCloud,omitempty"`
+	BitbucketServer *BitbucketServerRepo `json:"bitbucketServer,omitempty"`
+	AzureDevOps     *AzureDevOpsRepo     `json:"azureDevOps,omitempty"`
+	Gerrit          *GerritRepo          `json:"gerrit,omitempty"`
+	Generic         *GenericRepo         `json:"generic,omitempty"`
+	Fake            *FakeRepo            `json:"fake,omitempty"`
 	// +kubebuilder:validation:Required
 	ScmProviderRef ScmProviderObjectReference `json:"scmProviderRef"`
 }
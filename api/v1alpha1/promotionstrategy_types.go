@@ -53,6 +53,13 @@ type PromotionStrategySpec struct {
 	// +listMapKey=key
 	ProposedCommitStatuses []CommitStatusSelector `json:"proposedCommitStatuses,omitempty"`
 
+	// PullRequest configures the pull requests opened to promote dry commits.
+	//
+	// The configuration specified in this field applies to all environments in the promotion sequence. You can also
+	// specify it for individual environments in the `environments` field.
+	// +kubebuilder:validation:Optional
+	PullRequest *PullRequestOptions `json:"pullRequest,omitempty"`
+
 	// Environments is the sequence of environments that a dry commit will be promoted through.
 	// +kubebuilder:validation:MinItems:=1
 	// +listType:=map
@@ -71,6 +78,15 @@ type Environment struct {
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:default:=true
 	AutoMerge *bool `json:"autoMerge,omitempty"`
+	// ConflictResolution determines what happens when the proposed branch conflicts with the active branch, for
+	// example because someone pushed a manual hotfix to the active branch.
+	//
+	// "ours" (the default) merges the active branch into the proposed branch with the "ours" strategy, so the
+	// proposed content wins. "fail" pushes nothing and reports the conflict as a condition and event. "rebuild" pushes
+	// nothing and asks the hydrator to re-hydrate the proposed branch on top of the active branch.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=ours;fail;rebuild
+	ConflictResolution ConflictResolution `json:"conflictResolution,omitempty"`
 	// ActiveCommitStatuses are commit statuses describing an actively running dry commit. If an active commit status
 	// is failing for an environment, subsequent environments will not deploy the failing commit.
 	//
@@ -90,6 +106,50 @@ type Environment struct {
 	// +listType:=map
 	// +listMapKey=key
 	ProposedCommitStatuses []CommitStatusSelector `json:"proposedCommitStatuses,omitempty"`
+	// PullRequest configures the pull requests opened to promote dry commits to this environment.
+	//
+	// The configuration specified in this field applies to this environment only. Lists are combined with those in
+	// `spec.pullRequest`, with the environment's entries first.
+	// +kubebuilder:validation:Optional
+	PullRequest *PullRequestOptions `json:"pullRequest,omitempty"`
+}
+
+// PullRequestOptions configures the pull requests opened to promote dry commits. Each entry of the lists is a Go
+// template rendered with the ChangeTransferPolicy and PromotionStrategy, like the pull request template. Entries that
+// render to an empty string are dropped. The lists are applied when a pull request is opened; reviewers, assignees and
+// labels added by others are left in place.
+type PullRequestOptions struct {
+	// Reviewers are the users or teams whose review is requested on promotion pull requests. Teams are written as
+	// "<organization>/<team>" on GitHub, Gitea and Forgejo. On Bitbucket Cloud, reviewers are account UUIDs such as
+	// "{5e1c1b2a-...}"; on Bitbucket Data Center, they are user slugs.
+	// +kubebuilder:validation:Optional
+	// +listType:=set
+	// +kubebuilder:validation:items:MinLength=1
+	Reviewers []string `json:"reviewers,omitempty"`
+	// RequiredReviewers are the users or groups whose review is required before a promotion pull request can be
+	// merged, such as "jane@example.com" or "[Platform]\Release Approvers". Only Azure DevOps supports required
+	// reviewers.
+	// +kubebuilder:validation:Optional
+	// +listType:=set
+	// +kubebuilder:validation:items:MinLength=1
+	RequiredReviewers []string `json:"requiredReviewers,omitempty"`
+	// Assignees are the users assigned to promotion pull requests. Bitbucket and Azure DevOps do not support assignees.
+	// +kubebuilder:validation:Optional
+	// +listType:=set
+	// +kubebuilder:validation:items:MinLength=1
+	Assignees []string `json:"assignees,omitempty"`
+	// Draft opens promotion pull requests as drafts, or work in progress on SCMs without drafts, and marks them ready
+	// for review once all proposed commit statuses pass, including the previous environment's gate, so that reviewers
+	// are not asked to review pull requests that cannot be merged yet. A value set on an environment overrides the
+	// value in `spec.pullRequest`.
+	// +kubebuilder:validation:Optional
+	Draft *bool `json:"draft,omitempty"`
+	// Labels are added to promotion pull requests, such as "promotion" or "env/{{ .ChangeTransferPolicy.Spec.ActiveBranch }}".
+	// On Gitea and Forgejo, the labels must exist in the repository. Bitbucket does not support labels.
+	// +kubebuilder:validation:Optional
+	// +listType:=set
+	// +kubebuilder:validation:items:MinLength=1
+	Labels []string `json:"labels,omitempty"`
 }
 
 // GetAutoMerge returns the value of the AutoMerge field, defaulting to true if the field is nil.
@@ -61,6 +61,37 @@ type PullRequestSpec struct {
 	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:Enum=closed;merged;open
 	State PullRequestState `json:"state"`
+	// Reviewers are the users or teams whose review is requested when the pull request is opened.
+	// +kubebuilder:validation:Optional
+	// +listType:=set
+	// +kubebuilder:validation:items:MinLength=1
+	Reviewers []string `json:"reviewers,omitempty"`
+	// RequiredReviewers are the users or groups whose review is required before the pull request can be merged. They
+	// are added to the pull request by SCM providers that support required reviewers.
+	// +kubebuilder:validation:Optional
+	// +listType:=set
+	// +kubebuilder:validation:items:MinLength=1
+	RequiredReviewers []string `json:"requiredReviewers,omitempty"`
+	// Assignees are the users assigned to the pull request when it is opened, by SCM providers that support assignees.
+	// +kubebuilder:validation:Optional
+	// +listType:=set
+	// +kubebuilder:validation:items:MinLength=1
+	Assignees []string `json:"assignees,omitempty"`
+	// Draft is whether the pull request should be a draft, or work in progress on SCMs without drafts. The pull request
+	// is opened as a draft and marked ready for review when this changes to false.
+	// +kubebuilder:validation:Optional
+	Draft bool `json:"draft,omitempty"`
+	// Labels are added to the pull request when it is opened, by SCM providers that support labels.
+	// +kubebuilder:validation:Optional
+	// +listType:=set
+	// +kubebuilder:validation:items:MinLength=1
+	Labels []string `json:"labels,omitempty"`
+	// CommitStatuses are the proposed commit statuses of the ChangeTransferPolicy, including the previous environment
+	// gate. They are summarized in a comment on the pull request that is kept up to date as they change.
+	// +kubebuilder:validation:Optional
+	// +listType:=map
+	// +listMapKey=key
+	CommitStatuses []ChangeRequestPolicyCommitStatusPhase `json:"commitStatuses,omitempty"`
 }
 
 // CommitConfiguration defines the commit configuration for how we will merge/squash/etc the pull request.
@@ -81,6 +112,9 @@ type PullRequestStatus struct {
 	State PullRequestState `json:"state,omitempty"`
 	// PRCreationTime the time the PR was created
 	PRCreationTime metav1.Time `json:"prCreationTime,omitempty"`
+	// Draft is whether the pull request was last opened or updated on the SCM as a draft. The ChangeTransferPolicy
+	// does not merge the pull request while it is a draft.
+	Draft bool `json:"draft,omitempty"`
 	// Url is the URL of the pull request.
 	// +kubebuilder:validation:XValidation:rule="self == '' || isURL(self)",message="must be a valid URL"
 	// +kubebuilder:validation:Pattern="^(https?://.*)?$"
@@ -91,6 +125,11 @@ type PullRequestStatus struct {
 	// The PullRequest resource will be deleted after this flag is set, but the status is preserved in
 	// the owning ChangeTransferPolicy to maintain a record of the external action.
 	ExternallyMergedOrClosed *bool `json:"externallyMergedOrClosed,omitempty"`
+	// GateSummaryComment is the comment on the pull request that summarizes its commit statuses.
+	GateSummaryComment *PullRequestComment `json:"gateSummaryComment,omitempty"`
+	// MergeQueue is the state of the pull request in the merge queue (GitHub) or merge train (GitLab) of its target
+	// branch. It is only set once the pull request was added to one.
+	MergeQueue *PullRequestMergeQueueStatus `json:"mergeQueue,omitempty"`
 
 	// Conditions Represents the observations of the current state.
 	// +patchMergeKey=type
@@ -100,6 +139,37 @@ type PullRequestStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
 }
 
+// PullRequestComment identifies a comment the controller posted on a pull request.
+type PullRequestComment struct {
+	// ID is the unique identifier of the comment, set by the SCM. It is empty on SCMs whose comments cannot be edited,
+	// where a new comment is posted each time the body changes.
+	ID string `json:"id,omitempty"`
+	// BodyHash is the SHA-256 hash of the comment body that was last posted. The comment is only updated when the body
+	// changes.
+	BodyHash string `json:"bodyHash,omitempty"`
+}
+
+// PullRequestMergeQueueStatus is the state of a pull request in the merge queue of its target branch.
+type PullRequestMergeQueueStatus struct {
+	// State is queued while the pull request waits in the merge queue, merged once the queue merged it, and dequeued
+	// if the SCM removed it from the queue without merging it.
+	// +kubebuilder:validation:Enum=queued;merged;dequeued
+	State MergeQueueState `json:"state"`
+	// Position is the position of the pull request in the merge queue, starting at 1, on SCMs that report it.
+	// +kubebuilder:validation:Optional
+	Position int `json:"position,omitempty"`
+	// EnqueuedAt is when the pull request was added to the merge queue.
+	// +kubebuilder:validation:Optional
+	EnqueuedAt metav1.Time `json:"enqueuedAt,omitempty"`
+	// MergeSha is the spec.mergeSha the pull request was added to the merge queue for. A pull request that was dequeued
+	// is only added to the queue again once its merge SHA changes.
+	// +kubebuilder:validation:Optional
+	MergeSha string `json:"mergeSha,omitempty"`
+	// Message describes the state of the pull request in the merge queue.
+	// +kubebuilder:validation:Optional
+	Message string `json:"message,omitempty"`
+}
+
 // GetConditions returns the conditions of the PullRequest.
 func (ps *PullRequest) GetConditions() *[]metav1.Condition {
 	return &ps.Status.Conditions
@@ -149,3 +219,15 @@ const (
 	// PullRequestMerged indicates that the pull request has been merged.
 	PullRequestMerged PullRequestState = "merged"
 )
+
+// MergeQueueState represents the state of a pull request in a merge queue.
+type MergeQueueState string
+
+const (
+	// MergeQueueQueued indicates that the pull request is waiting in the merge queue.
+	MergeQueueQueued MergeQueueState = "queued"
+	// MergeQueueMerged indicates that the merge queue merged the pull request.
+	MergeQueueMerged MergeQueueState = "merged"
+	// MergeQueueDequeued indicates that the SCM removed the pull request from the merge queue without merging it.
+	MergeQueueDequeued MergeQueueState = "dequeued"
+)
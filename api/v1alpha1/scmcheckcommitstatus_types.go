@@ -0,0 +1,189 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ScmCheckCommitStatusSpec defines the desired state of ScmCheckCommitStatus
+type ScmCheckCommitStatusSpec struct {
+	// PromotionStrategyRef is a reference to the promotion strategy that this commit status applies to.
+	// The controller will mirror checks for ALL environments in the referenced PromotionStrategy
+	// where this ScmCheckCommitStatus.Spec.Key matches an entry in either:
+	//   - PromotionStrategy.Spec.ProposedCommitStatuses (applies to all environments), OR
+	//   - Environment.ProposedCommitStatuses (applies to specific environment)
+	// +required
+	PromotionStrategyRef ObjectReference `json:"promotionStrategyRef"`
+
+	// Key is the commit status key the check results are mirrored into.
+	// This key is matched against PromotionStrategy's proposedCommitStatuses to determine which
+	// environments the checks are read for.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
+	Key string `json:"key"`
+
+	// Description is a human-readable description that will be shown in the SCM provider
+	// (GitHub, GitLab, etc.) as the commit status description.
+	// If not specified, the description summarizes how many of the checks succeeded.
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Checks are the SCM checks that are mirrored into the commit status. A check matches the check runs and
+	// commit statuses reported on the proposed hydrated commit with the same name, such as the name of a GitHub
+	// Actions job or the context of a commit status.
+	//
+	// The commit status is successful once every check has succeeded, failed as soon as any check has failed,
+	// and pending otherwise, including while a check has not been reported yet.
+	// +required
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=20
+	// +listType=map
+	// +listMapKey=name
+	Checks []ScmCheck `json:"checks"`
+}
+
+// ScmCheck selects a check reported on a commit by the SCM.
+type ScmCheck struct {
+	// Name is the name of the check run, or the context of the commit status, reported by the SCM.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=255
+	Name string `json:"name"`
+}
+
+// ScmCheckCommitStatusStatus defines the observed state of ScmCheckCommitStatus.
+type ScmCheckCommitStatusStatus struct {
+	// Environments holds the mirrored check results for each environment where this commit status applies.
+	// Each entry corresponds to an environment from the PromotionStrategy where the Key matches
+	// either global or environment-specific proposedCommitStatuses.
+	// +listType=map
+	// +listMapKey=branch
+	// +optional
+	Environments []ScmCheckCommitStatusEnvironmentStatus `json:"environments,omitempty"`
+
+	// Conditions represent the latest available observations of the ScmCheckCommitStatus's state.
+	// Standard condition types include "Ready" which aggregates the status of all environments.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// ScmCheckCommitStatusEnvironmentStatus defines the observed check results for a specific environment.
+type ScmCheckCommitStatusEnvironmentStatus struct {
+	// Branch is the environment branch name.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	Branch string `json:"branch"`
+
+	// ProposedHydratedSha is the proposed hydrated commit SHA whose checks are read, and where the
+	// commit status is reported.
+	// Supports both SHA-1 (40 chars) and SHA-256 (64 chars) Git hash formats.
+	// +required
+	// +kubebuilder:validation:MinLength=40
+	// +kubebuilder:validation:MaxLength=64
+	// +kubebuilder:validation:Pattern=`^([a-f0-9]{40}|[a-f0-9]{64})$`
+	ProposedHydratedSha string `json:"proposedHydratedSha"`
+
+	// Phase is the combined phase of the checks.
+	// - "pending": a check is still running or has not been reported yet
+	// - "success": every check succeeded
+	// - "failure": at least one check failed
+	// +kubebuilder:validation:Enum=pending;success;failure
+	// +required
+	Phase string `json:"phase"`
+
+	// Checks holds the result of each check, in the order of the spec.
+	// +optional
+	Checks []ScmCheckResult `json:"checks,omitempty"`
+}
+
+// ScmCheckResult is the result of a check read from the SCM.
+type ScmCheckResult struct {
+	// Name is the name of the check.
+	// +required
+	Name string `json:"name"`
+
+	// Phase is the phase of the check. A check that has not been reported is pending.
+	// +kubebuilder:validation:Enum=pending;success;failure
+	// +required
+	Phase string `json:"phase"`
+
+	// Description is the description of the check reported by the SCM.
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Url is the URL of the check's details page.
+	// +optional
+	Url string `json:"url,omitempty"`
+}
+
+// +kubebuilder:ac:generate=true
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Key",type=string,JSONPath=`.spec.key`
+// +kubebuilder:printcolumn:name="PromotionStrategy",type=string,JSONPath=`.spec.promotionStrategyRef.name`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+
+// ScmCheckCommitStatus is the Schema for the scmcheckcommitstatuses API.
+//
+// It reads the check runs and commit statuses that CI systems report on the proposed hydrated commit of
+// PromotionStrategy environments, and mirrors them into CommitStatus resources, so that promotions can be
+// gated on existing CI.
+//
+// Workflow:
+//  1. Controller reads PromotionStrategy to get the ProposedHydratedSha of each environment
+//  2. Controller reads the checks reported on that SHA from the SCM
+//  3. Controller combines the phases of the selected checks
+//  4. Controller creates/updates a CommitStatus with the combined phase attached to the PROPOSED SHA
+//  5. PromotionStrategy checks CommitStatus on PROPOSED SHA before allowing promotion
+type ScmCheckCommitStatus struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// spec defines the desired state of ScmCheckCommitStatus
+	// +required
+	Spec ScmCheckCommitStatusSpec `json:"spec"`
+
+	// status defines the observed state of ScmCheckCommitStatus
+	// +optional
+	Status ScmCheckCommitStatusStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ScmCheckCommitStatusList contains a list of ScmCheckCommitStatus
+type ScmCheckCommitStatusList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ScmCheckCommitStatus `json:"items"`
+}
+
+// GetConditions returns the conditions of the ScmCheckCommitStatus.
+func (s *ScmCheckCommitStatus) GetConditions() *[]metav1.Condition {
+	return &s.Status.Conditions
+}
+
+func init() {
+	SchemeBuilder.Register(&ScmCheckCommitStatus{}, &ScmCheckCommitStatusList{})
+}
@@ -31,7 +31,7 @@ import (
 var ScmProviderKind = reflect.TypeOf(ScmProvider{}).Name()
 
 // ScmProviderSpec defines the desired state of ScmProvider
-// +kubebuilder:validation:ExactlyOneOf=github;gitlab;forgejo;gitea;bitbucketCloud;azureDevOps;fake
+// +kubebuilder:validation:ExactlyOneOf=github;gitlab;forgejo;gitea;bitbucketCloud;bitbucketServer;azureDevOps;gerrit;generic;fake
 type ScmProviderSpec struct {
 	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
@@ -39,6 +39,20 @@ type ScmProviderSpec struct {
 	// SecretRef contains the credentials required to auth to a specific provider
 	SecretRef *v1.LocalObjectReference `json:"secretRef,omitempty"`
 
+	// SSH configures git operations to use SSH with a deploy key from the secret instead of HTTPS. It can be combined
+	// with any SCM provider.
+	// +kubebuilder:validation:Optional
+	SSH *GitSSH `json:"ssh,omitempty"`
+
+	// CommitAuthor is the identity used for commits GitOps Promoter creates. Defaults to
+	// "GitOps Promoter <GitOpsPromoter@argoproj.io>".
+	// +kubebuilder:validation:Optional
+	CommitAuthor *CommitAuthor `json:"commitAuthor,omitempty"`
+
+	// CommitSigning enables signing of commits GitOps Promoter creates, for branches that require signed commits.
+	// +kubebuilder:validation:Optional
+	CommitSigning *CommitSigning `json:"commitSigning,omitempty"`
+
 	// GitHub required configuration for GitHub as the SCM provider
 	GitHub *GitHub `json:"github,omitempty"`
 
@@ -54,9 +68,19 @@ type ScmProviderSpec struct {
 	// BitbucketCloud required configuration for Bitbucket Cloud as the SCM provider
 	BitbucketCloud *BitbucketCloud `json:"bitbucketCloud,omitempty"`
 
+	// BitbucketServer required configuration for Bitbucket Data Center (formerly Bitbucket Server) as the SCM provider
+	BitbucketServer *BitbucketServer `json:"bitbucketServer,omitempty"`
+
 	// AzureDevOps required configuration for Azure DevOps as the SCM provider
 	AzureDevOps *AzureDevOps `json:"azureDevOps,omitempty"`
 
+	// Gerrit required configuration for Gerrit as the SCM provider
+	Gerrit *Gerrit `json:"gerrit,omitempty"`
+
+	// Generic required configuration for a plain git HTTP server without a pull request or commit status API as the SCM
+	// provider
+	Generic *Generic `json:"generic,omitempty"`
+
 	// Fake required configuration for Fake as the SCM provider
 	Fake *Fake `json:"fake,omitempty"`
 }
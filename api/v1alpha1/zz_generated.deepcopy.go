@@ -272,6 +272,36 @@ func (in *BitbucketCloudRepo) DeepCopy() *BitbucketCloudRepo {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BitbucketServer) DeepCopyInto(out *BitbucketServer) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BitbucketServer.
+func (in *BitbucketServer) DeepCopy() *BitbucketServer {
+	if in == nil {
+		return nil
+	}
+	out := new(BitbucketServer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BitbucketServerRepo) DeepCopyInto(out *BitbucketServerRepo) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BitbucketServerRepo.
+func (in *BitbucketServerRepo) DeepCopy() *BitbucketServerRepo {
+	if in == nil {
+		return nil
+	}
+	out := new(BitbucketServerRepo)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Bucket) DeepCopyInto(out *Bucket) {
 	*out = *in
@@ -302,6 +332,26 @@ func (in *ChangeRequestPolicyCommitStatusPhase) DeepCopy() *ChangeRequestPolicyC
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChangeSummary) DeepCopyInto(out *ChangeSummary) {
+	*out = *in
+	if in.Directories != nil {
+		in, out := &in.Directories, &out.Directories
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChangeSummary.
+func (in *ChangeSummary) DeepCopy() *ChangeSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(ChangeSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ChangeTransferPolicy) DeepCopyInto(out *ChangeTransferPolicy) {
 	*out = *in
@@ -396,6 +446,11 @@ func (in *ChangeTransferPolicySpec) DeepCopyInto(out *ChangeTransferPolicySpec)
 		*out = make([]CommitStatusSelector, len(*in))
 		copy(*out, *in)
 	}
+	if in.PullRequest != nil {
+		in, out := &in.PullRequest, &out.PullRequest
+		*out = new(PullRequestOptions)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChangeTransferPolicySpec.
@@ -425,6 +480,11 @@ func (in *ChangeTransferPolicyStatus) DeepCopyInto(out *ChangeTransferPolicyStat
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Conflict != nil {
+		in, out := &in.Conflict, &out.Conflict
+		*out = new(ConflictStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
@@ -503,6 +563,21 @@ func (in *ClusterScmProviderList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CommitAuthor) DeepCopyInto(out *CommitAuthor) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommitAuthor.
+func (in *CommitAuthor) DeepCopy() *CommitAuthor {
+	if in == nil {
+		return nil
+	}
+	out := new(CommitAuthor)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CommitBranchState) DeepCopyInto(out *CommitBranchState) {
 	*out = *in
@@ -518,6 +593,11 @@ func (in *CommitBranchState) DeepCopyInto(out *CommitBranchState) {
 		*out = make([]ChangeRequestPolicyCommitStatusPhase, len(*in))
 		copy(*out, *in)
 	}
+	if in.Changes != nil {
+		in, out := &in.Changes, &out.Changes
+		*out = new(ChangeSummary)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommitBranchState.
@@ -608,6 +688,21 @@ func (in *CommitShaState) DeepCopy() *CommitShaState {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CommitSigning) DeepCopyInto(out *CommitSigning) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommitSigning.
+func (in *CommitSigning) DeepCopy() *CommitSigning {
+	if in == nil {
+		return nil
+	}
+	out := new(CommitSigning)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CommitStatus) DeepCopyInto(out *CommitStatus) {
 	*out = *in
@@ -736,6 +831,27 @@ func (in *CommitStatusStatus) DeepCopy() *CommitStatusStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConflictStatus) DeepCopyInto(out *ConflictStatus) {
+	*out = *in
+	if in.Paths != nil {
+		in, out := &in.Paths, &out.Paths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.DetectedTime.DeepCopyInto(&out.DetectedTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConflictStatus.
+func (in *ConflictStatus) DeepCopy() *ConflictStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ConflictStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ControllerConfiguration) DeepCopyInto(out *ControllerConfiguration) {
 	*out = *in
@@ -806,6 +922,8 @@ func (in *ControllerConfigurationSpec) DeepCopyInto(out *ControllerConfiguration
 	in.TimedCommitStatus.DeepCopyInto(&out.TimedCommitStatus)
 	in.GitCommitStatus.DeepCopyInto(&out.GitCommitStatus)
 	in.WebRequestCommitStatus.DeepCopyInto(&out.WebRequestCommitStatus)
+	in.ScmCheckCommitStatus.DeepCopyInto(&out.ScmCheckCommitStatus)
+	in.WebhookReceiver.DeepCopyInto(&out.WebhookReceiver)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerConfigurationSpec.
@@ -851,6 +969,11 @@ func (in *Environment) DeepCopyInto(out *Environment) {
 		*out = make([]CommitStatusSelector, len(*in))
 		copy(*out, *in)
 	}
+	if in.PullRequest != nil {
+		in, out := &in.PullRequest, &out.PullRequest
+		*out = new(PullRequestOptions)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Environment.
@@ -993,6 +1116,73 @@ func (in *ForgejoRepo) DeepCopy() *ForgejoRepo {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Generic) DeepCopyInto(out *Generic) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Generic.
+func (in *Generic) DeepCopy() *Generic {
+	if in == nil {
+		return nil
+	}
+	out := new(Generic)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GenericRepo) DeepCopyInto(out *GenericRepo) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GenericRepo.
+func (in *GenericRepo) DeepCopy() *GenericRepo {
+	if in == nil {
+		return nil
+	}
+	out := new(GenericRepo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Gerrit) DeepCopyInto(out *Gerrit) {
+	*out = *in
+	if in.CommitStatusLabels != nil {
+		in, out := &in.CommitStatusLabels, &out.CommitStatusLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Gerrit.
+func (in *Gerrit) DeepCopy() *Gerrit {
+	if in == nil {
+		return nil
+	}
+	out := new(Gerrit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GerritRepo) DeepCopyInto(out *GerritRepo) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GerritRepo.
+func (in *GerritRepo) DeepCopy() *GerritRepo {
+	if in == nil {
+		return nil
+	}
+	out := new(GerritRepo)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GitCommitStatus) DeepCopyInto(out *GitCommitStatus) {
 	*out = *in
@@ -1280,11 +1470,26 @@ func (in *GitRepositorySpec) DeepCopyInto(out *GitRepositorySpec) {
 		*out = new(BitbucketCloudRepo)
 		**out = **in
 	}
+	if in.BitbucketServer != nil {
+		in, out := &in.BitbucketServer, &out.BitbucketServer
+		*out = new(BitbucketServerRepo)
+		**out = **in
+	}
 	if in.AzureDevOps != nil {
 		in, out := &in.AzureDevOps, &out.AzureDevOps
 		*out = new(AzureDevOpsRepo)
 		**out = **in
 	}
+	if in.Gerrit != nil {
+		in, out := &in.Gerrit, &out.Gerrit
+		*out = new(GerritRepo)
+		**out = **in
+	}
+	if in.Generic != nil {
+		in, out := &in.Generic, &out.Generic
+		*out = new(GenericRepo)
+		**out = **in
+	}
 	if in.Fake != nil {
 		in, out := &in.Fake, &out.Fake
 		*out = new(FakeRepo)
@@ -1325,6 +1530,21 @@ func (in *GitRepositoryStatus) DeepCopy() *GitRepositoryStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitSSH) DeepCopyInto(out *GitSSH) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitSSH.
+func (in *GitSSH) DeepCopy() *GitSSH {
+	if in == nil {
+		return nil
+	}
+	out := new(GitSSH)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Gitea) DeepCopyInto(out *Gitea) {
 	*out = *in
@@ -1669,6 +1889,11 @@ func (in *PromotionStrategySpec) DeepCopyInto(out *PromotionStrategySpec) {
 		*out = make([]CommitStatusSelector, len(*in))
 		copy(*out, *in)
 	}
+	if in.PullRequest != nil {
+		in, out := &in.PullRequest, &out.PullRequest
+		*out = new(PullRequestOptions)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Environments != nil {
 		in, out := &in.Environments, &out.Environments
 		*out = make([]Environment, len(*in))
@@ -1722,7 +1947,7 @@ func (in *PullRequest) DeepCopyInto(out *PullRequest) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -1744,6 +1969,21 @@ func (in *PullRequest) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PullRequestComment) DeepCopyInto(out *PullRequestComment) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PullRequestComment.
+func (in *PullRequestComment) DeepCopy() *PullRequestComment {
+	if in == nil {
+		return nil
+	}
+	out := new(PullRequestComment)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PullRequestCommonStatus) DeepCopyInto(out *PullRequestCommonStatus) {
 	*out = *in
@@ -1815,11 +2055,92 @@ func (in *PullRequestList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PullRequestMergeQueueStatus) DeepCopyInto(out *PullRequestMergeQueueStatus) {
+	*out = *in
+	in.EnqueuedAt.DeepCopyInto(&out.EnqueuedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PullRequestMergeQueueStatus.
+func (in *PullRequestMergeQueueStatus) DeepCopy() *PullRequestMergeQueueStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PullRequestMergeQueueStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PullRequestOptions) DeepCopyInto(out *PullRequestOptions) {
+	*out = *in
+	if in.Reviewers != nil {
+		in, out := &in.Reviewers, &out.Reviewers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RequiredReviewers != nil {
+		in, out := &in.RequiredReviewers, &out.RequiredReviewers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Assignees != nil {
+		in, out := &in.Assignees, &out.Assignees
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Draft != nil {
+		in, out := &in.Draft, &out.Draft
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PullRequestOptions.
+func (in *PullRequestOptions) DeepCopy() *PullRequestOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(PullRequestOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PullRequestSpec) DeepCopyInto(out *PullRequestSpec) {
 	*out = *in
 	out.RepositoryReference = in.RepositoryReference
 	out.Commit = in.Commit
+	if in.Reviewers != nil {
+		in, out := &in.Reviewers, &out.Reviewers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RequiredReviewers != nil {
+		in, out := &in.RequiredReviewers, &out.RequiredReviewers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Assignees != nil {
+		in, out := &in.Assignees, &out.Assignees
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CommitStatuses != nil {
+		in, out := &in.CommitStatuses, &out.CommitStatuses
+		*out = make([]ChangeRequestPolicyCommitStatusPhase, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PullRequestSpec.
@@ -1841,6 +2162,16 @@ func (in *PullRequestStatus) DeepCopyInto(out *PullRequestStatus) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.GateSummaryComment != nil {
+		in, out := &in.GateSummaryComment, &out.GateSummaryComment
+		*out = new(PullRequestComment)
+		**out = **in
+	}
+	if in.MergeQueue != nil {
+		in, out := &in.MergeQueue, &out.MergeQueue
+		*out = new(PullRequestMergeQueueStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
@@ -2068,6 +2399,181 @@ func (in *Scm) DeepCopy() *Scm {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScmCheck) DeepCopyInto(out *ScmCheck) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScmCheck.
+func (in *ScmCheck) DeepCopy() *ScmCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(ScmCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScmCheckCommitStatus) DeepCopyInto(out *ScmCheckCommitStatus) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScmCheckCommitStatus.
+func (in *ScmCheckCommitStatus) DeepCopy() *ScmCheckCommitStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ScmCheckCommitStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ScmCheckCommitStatus) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScmCheckCommitStatusConfiguration) DeepCopyInto(out *ScmCheckCommitStatusConfiguration) {
+	*out = *in
+	in.WorkQueue.DeepCopyInto(&out.WorkQueue)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScmCheckCommitStatusConfiguration.
+func (in *ScmCheckCommitStatusConfiguration) DeepCopy() *ScmCheckCommitStatusConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(ScmCheckCommitStatusConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScmCheckCommitStatusEnvironmentStatus) DeepCopyInto(out *ScmCheckCommitStatusEnvironmentStatus) {
+	*out = *in
+	if in.Checks != nil {
+		in, out := &in.Checks, &out.Checks
+		*out = make([]ScmCheckResult, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScmCheckCommitStatusEnvironmentStatus.
+func (in *ScmCheckCommitStatusEnvironmentStatus) DeepCopy() *ScmCheckCommitStatusEnvironmentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ScmCheckCommitStatusEnvironmentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScmCheckCommitStatusList) DeepCopyInto(out *ScmCheckCommitStatusList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ScmCheckCommitStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScmCheckCommitStatusList.
+func (in *ScmCheckCommitStatusList) DeepCopy() *ScmCheckCommitStatusList {
+	if in == nil {
+		return nil
+	}
+	out := new(ScmCheckCommitStatusList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ScmCheckCommitStatusList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScmCheckCommitStatusSpec) DeepCopyInto(out *ScmCheckCommitStatusSpec) {
+	*out = *in
+	out.PromotionStrategyRef = in.PromotionStrategyRef
+	if in.Checks != nil {
+		in, out := &in.Checks, &out.Checks
+		*out = make([]ScmCheck, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScmCheckCommitStatusSpec.
+func (in *ScmCheckCommitStatusSpec) DeepCopy() *ScmCheckCommitStatusSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ScmCheckCommitStatusSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScmCheckCommitStatusStatus) DeepCopyInto(out *ScmCheckCommitStatusStatus) {
+	*out = *in
+	if in.Environments != nil {
+		in, out := &in.Environments, &out.Environments
+		*out = make([]ScmCheckCommitStatusEnvironmentStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScmCheckCommitStatusStatus.
+func (in *ScmCheckCommitStatusStatus) DeepCopy() *ScmCheckCommitStatusStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ScmCheckCommitStatusStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScmCheckResult) DeepCopyInto(out *ScmCheckResult) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScmCheckResult.
+func (in *ScmCheckResult) DeepCopy() *ScmCheckResult {
+	if in == nil {
+		return nil
+	}
+	out := new(ScmCheckResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScmProvider) DeepCopyInto(out *ScmProvider) {
 	*out = *in
@@ -2150,6 +2656,21 @@ func (in *ScmProviderSpec) DeepCopyInto(out *ScmProviderSpec) {
 		*out = new(corev1.LocalObjectReference)
 		**out = **in
 	}
+	if in.SSH != nil {
+		in, out := &in.SSH, &out.SSH
+		*out = new(GitSSH)
+		**out = **in
+	}
+	if in.CommitAuthor != nil {
+		in, out := &in.CommitAuthor, &out.CommitAuthor
+		*out = new(CommitAuthor)
+		**out = **in
+	}
+	if in.CommitSigning != nil {
+		in, out := &in.CommitSigning, &out.CommitSigning
+		*out = new(CommitSigning)
+		**out = **in
+	}
 	if in.GitHub != nil {
 		in, out := &in.GitHub, &out.GitHub
 		*out = new(GitHub)
@@ -2175,11 +2696,26 @@ func (in *ScmProviderSpec) DeepCopyInto(out *ScmProviderSpec) {
 		*out = new(BitbucketCloud)
 		**out = **in
 	}
+	if in.BitbucketServer != nil {
+		in, out := &in.BitbucketServer, &out.BitbucketServer
+		*out = new(BitbucketServer)
+		**out = **in
+	}
 	if in.AzureDevOps != nil {
 		in, out := &in.AzureDevOps, &out.AzureDevOps
 		*out = new(AzureDevOps)
 		**out = **in
 	}
+	if in.Gerrit != nil {
+		in, out := &in.Gerrit, &out.Gerrit
+		*out = new(Gerrit)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Generic != nil {
+		in, out := &in.Generic, &out.Generic
+		*out = new(Generic)
+		**out = **in
+	}
 	if in.Fake != nil {
 		in, out := &in.Fake, &out.Fake
 		*out = new(Fake)
@@ -2692,6 +3228,31 @@ func (in *WebRequestCommitStatusStatus) DeepCopy() *WebRequestCommitStatusStatus
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookReceiverConfiguration) DeepCopyInto(out *WebhookReceiverConfiguration) {
+	*out = *in
+	if in.DeliveryDeduplicationWindow != nil {
+		in, out := &in.DeliveryDeduplicationWindow, &out.DeliveryDeduplicationWindow
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.RateLimit != nil {
+		in, out := &in.RateLimit, &out.RateLimit
+		*out = new(Bucket)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookReceiverConfiguration.
+func (in *WebhookReceiverConfiguration) DeepCopy() *WebhookReceiverConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookReceiverConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WhenSpec) DeepCopyInto(out *WhenSpec) {
 	*out = *in
@@ -17,6 +17,10 @@ limitations under the License.
 
 package v1alpha1
 
+import (
+	apiv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+)
+
 // AzureDevOpsApplyConfiguration represents a declarative configuration of the AzureDevOps type for use
 // with apply.
 //
@@ -26,6 +30,15 @@ type AzureDevOpsApplyConfiguration struct {
 	Organization *string `json:"organization,omitempty"`
 	// Domain is the Azure DevOps domain, such as "dev.azure.com". If using the default Azure DevOps domain, leave this field empty.
 	Domain *string `json:"domain,omitempty"`
+	// AutoComplete sets pull requests to complete automatically once their branch policies pass, instead of completing
+	// them directly. The PullRequest stays open until Azure DevOps completes the pull request.
+	AutoComplete *bool `json:"autoComplete,omitempty"`
+	// MergeStrategy is the strategy used to complete pull requests. The "rebase" strategy does not create a merge
+	// commit, so the commit message of the PullRequest is not used.
+	MergeStrategy *apiv1alpha1.AzureDevOpsMergeStrategy `json:"mergeStrategy,omitempty"`
+	// LinkWorkItems links pull requests to the Azure Boards work items mentioned as AB#<id> in the message of the dry
+	// commit they promote, such as in an "AB#1234" trailer. The token must be allowed to read and write work items.
+	LinkWorkItems *bool `json:"linkWorkItems,omitempty"`
 }
 
 // AzureDevOpsApplyConfiguration constructs a declarative configuration of the AzureDevOps type for use with
@@ -49,3 +62,27 @@ func (b *AzureDevOpsApplyConfiguration) WithDomain(value string) *AzureDevOpsApp
 	b.Domain = &value
 	return b
 }
+
+// WithAutoComplete sets the AutoComplete field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the AutoComplete field is set to the value of the last call.
+func (b *AzureDevOpsApplyConfiguration) WithAutoComplete(value bool) *AzureDevOpsApplyConfiguration {
+	b.AutoComplete = &value
+	return b
+}
+
+// WithMergeStrategy sets the MergeStrategy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MergeStrategy field is set to the value of the last call.
+func (b *AzureDevOpsApplyConfiguration) WithMergeStrategy(value apiv1alpha1.AzureDevOpsMergeStrategy) *AzureDevOpsApplyConfiguration {
+	b.MergeStrategy = &value
+	return b
+}
+
+// WithLinkWorkItems sets the LinkWorkItems field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LinkWorkItems field is set to the value of the last call.
+func (b *AzureDevOpsApplyConfiguration) WithLinkWorkItems(value bool) *AzureDevOpsApplyConfiguration {
+	b.LinkWorkItems = &value
+	return b
+}
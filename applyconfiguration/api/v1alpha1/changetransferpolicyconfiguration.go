@@ -28,6 +28,10 @@ type ChangeTransferPolicyConfigurationApplyConfiguration struct {
 	// WorkQueue contains the work queue configuration for the ChangeTransferPolicy controller.
 	// This includes requeue duration, maximum concurrent reconciles, and rate limiter settings.
 	WorkQueue *WorkQueueApplyConfiguration `json:"workQueue,omitempty"`
+	// MaxConcurrentStatusOperations is the maximum number of network-bound operations (git fetches, git reads, and
+	// commit status lookups) a single ChangeTransferPolicy reconcile runs in parallel while calculating its status.
+	// Set to 1 to run them sequentially.
+	MaxConcurrentStatusOperations *int `json:"maxConcurrentStatusOperations,omitempty"`
 }
 
 // ChangeTransferPolicyConfigurationApplyConfiguration constructs a declarative configuration of the ChangeTransferPolicyConfiguration type for use with
@@ -43,3 +47,11 @@ func (b *ChangeTransferPolicyConfigurationApplyConfiguration) WithWorkQueue(valu
 	b.WorkQueue = value
 	return b
 }
+
+// WithMaxConcurrentStatusOperations sets the MaxConcurrentStatusOperations field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaxConcurrentStatusOperations field is set to the value of the last call.
+func (b *ChangeTransferPolicyConfigurationApplyConfiguration) WithMaxConcurrentStatusOperations(value int) *ChangeTransferPolicyConfigurationApplyConfiguration {
+	b.MaxConcurrentStatusOperations = &value
+	return b
+}
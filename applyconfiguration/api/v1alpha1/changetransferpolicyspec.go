@@ -17,6 +17,10 @@ limitations under the License.
 
 package v1alpha1
 
+import (
+	apiv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+)
+
 // ChangeTransferPolicySpecApplyConfiguration represents a declarative configuration of the ChangeTransferPolicySpec type for use
 // with apply.
 //
@@ -31,10 +35,14 @@ type ChangeTransferPolicySpecApplyConfiguration struct {
 	// ActiveBranch staging hydrated branch
 	ActiveBranch *string `json:"activeBranch,omitempty"`
 	AutoMerge    *bool   `json:"autoMerge,omitempty"`
+	// ConflictResolution determines what happens when the proposed branch conflicts with the active branch.
+	ConflictResolution *apiv1alpha1.ConflictResolution `json:"conflictResolution,omitempty"`
 	// ActiveCommitStatuses lists the statuses to be monitored on the active branch
 	ActiveCommitStatuses []CommitStatusSelectorApplyConfiguration `json:"activeCommitStatuses,omitempty"`
 	// ProposedCommitStatuses lists the statuses to be monitored on the proposed branch
 	ProposedCommitStatuses []CommitStatusSelectorApplyConfiguration `json:"proposedCommitStatuses,omitempty"`
+	// PullRequest configures the pull request opened to promote the proposed branch.
+	PullRequest *PullRequestOptionsApplyConfiguration `json:"pullRequest,omitempty"`
 }
 
 // ChangeTransferPolicySpecApplyConfiguration constructs a declarative configuration of the ChangeTransferPolicySpec type for use with
@@ -75,6 +83,14 @@ func (b *ChangeTransferPolicySpecApplyConfiguration) WithAutoMerge(value bool) *
 	return b
 }
 
+// WithConflictResolution sets the ConflictResolution field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ConflictResolution field is set to the value of the last call.
+func (b *ChangeTransferPolicySpecApplyConfiguration) WithConflictResolution(value apiv1alpha1.ConflictResolution) *ChangeTransferPolicySpecApplyConfiguration {
+	b.ConflictResolution = &value
+	return b
+}
+
 // WithActiveCommitStatuses adds the given value to the ActiveCommitStatuses field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, values provided by each call will be appended to the ActiveCommitStatuses field.
@@ -100,3 +116,11 @@ func (b *ChangeTransferPolicySpecApplyConfiguration) WithProposedCommitStatuses(
 	}
 	return b
 }
+
+// WithPullRequest sets the PullRequest field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PullRequest field is set to the value of the last call.
+func (b *ChangeTransferPolicySpecApplyConfiguration) WithPullRequest(value *PullRequestOptionsApplyConfiguration) *ChangeTransferPolicySpecApplyConfiguration {
+	b.PullRequest = value
+	return b
+}
@@ -38,6 +38,9 @@ type ChangeTransferPolicyStatusApplyConfiguration struct {
 	// History is constructed on a best-effort basis and should be used for informational purposes only.
 	// History is in reverse chronological order (newest is first).
 	History []HistoryApplyConfiguration `json:"history,omitempty"`
+	// Conflict describes the merge conflict detected between the proposed and active branches for the current
+	// proposed dry commit. It is cleared once the proposed dry commit changes or has been promoted.
+	Conflict *ConflictStatusApplyConfiguration `json:"conflict,omitempty"`
 	// Conditions Represents the observations of the current state.
 	Conditions []v1.ConditionApplyConfiguration `json:"conditions,omitempty"`
 }
@@ -85,6 +88,14 @@ func (b *ChangeTransferPolicyStatusApplyConfiguration) WithHistory(values ...*Hi
 	return b
 }
 
+// WithConflict sets the Conflict field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Conflict field is set to the value of the last call.
+func (b *ChangeTransferPolicyStatusApplyConfiguration) WithConflict(value *ConflictStatusApplyConfiguration) *ChangeTransferPolicyStatusApplyConfiguration {
+	b.Conflict = value
+	return b
+}
+
 // WithConditions adds the given value to the Conditions field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, values provided by each call will be appended to the Conditions field.
@@ -30,6 +30,9 @@ type CommitBranchStateApplyConfiguration struct {
 	Note *HydratorMetadataApplyConfiguration `json:"note,omitempty"`
 	// CommitStatuses is a list of commit statuses that are being monitored for this branch.
 	CommitStatuses []ChangeRequestPolicyCommitStatusPhaseApplyConfiguration `json:"commitStatuses,omitempty"`
+	// Changes summarizes what the hydrated commit changes on the active branch. For the proposed branch, it is the diff
+	// from the active hydrated commit. For history entries, it is the diff from the previous active hydrated commit.
+	Changes *ChangeSummaryApplyConfiguration `json:"changes,omitempty"`
 }
 
 // CommitBranchStateApplyConfiguration constructs a declarative configuration of the CommitBranchState type for use with
@@ -74,3 +77,11 @@ func (b *CommitBranchStateApplyConfiguration) WithCommitStatuses(values ...*Chan
 	}
 	return b
 }
+
+// WithChanges sets the Changes field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Changes field is set to the value of the last call.
+func (b *CommitBranchStateApplyConfiguration) WithChanges(value *ChangeSummaryApplyConfiguration) *CommitBranchStateApplyConfiguration {
+	b.Changes = value
+	return b
+}
@@ -53,6 +53,12 @@ type ControllerConfigurationSpecApplyConfiguration struct {
 	// WebRequestCommitStatus contains the configuration for the WebRequestCommitStatus controller,
 	// including WorkQueue settings that control reconciliation behavior.
 	WebRequestCommitStatus *WebRequestCommitStatusConfigurationApplyConfiguration `json:"webRequestCommitStatus,omitempty"`
+	// ScmCheckCommitStatus contains the configuration for the ScmCheckCommitStatus controller,
+	// including WorkQueue settings that control reconciliation behavior.
+	ScmCheckCommitStatus *ScmCheckCommitStatusConfigurationApplyConfiguration `json:"scmCheckCommitStatus,omitempty"`
+	// WebhookReceiver contains the configuration for the webhook receiver, including the limits that protect it from
+	// oversized, replayed and excessive deliveries.
+	WebhookReceiver *WebhookReceiverConfigurationApplyConfiguration `json:"webhookReceiver,omitempty"`
 }
 
 // ControllerConfigurationSpecApplyConfiguration constructs a declarative configuration of the ControllerConfigurationSpec type for use with
@@ -124,3 +130,19 @@ func (b *ControllerConfigurationSpecApplyConfiguration) WithWebRequestCommitStat
 	b.WebRequestCommitStatus = value
 	return b
 }
+
+// WithScmCheckCommitStatus sets the ScmCheckCommitStatus field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ScmCheckCommitStatus field is set to the value of the last call.
+func (b *ControllerConfigurationSpecApplyConfiguration) WithScmCheckCommitStatus(value *ScmCheckCommitStatusConfigurationApplyConfiguration) *ControllerConfigurationSpecApplyConfiguration {
+	b.ScmCheckCommitStatus = value
+	return b
+}
+
+// WithWebhookReceiver sets the WebhookReceiver field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the WebhookReceiver field is set to the value of the last call.
+func (b *ControllerConfigurationSpecApplyConfiguration) WithWebhookReceiver(value *WebhookReceiverConfigurationApplyConfiguration) *ControllerConfigurationSpecApplyConfiguration {
+	b.WebhookReceiver = value
+	return b
+}
@@ -17,6 +17,10 @@ limitations under the License.
 
 package v1alpha1
 
+import (
+	apiv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+)
+
 // EnvironmentApplyConfiguration represents a declarative configuration of the Environment type for use
 // with apply.
 //
@@ -27,6 +31,13 @@ type EnvironmentApplyConfiguration struct {
 	// AutoMerge determines whether the dry commit should be automatically merged into the next branch in the sequence.
 	// If false, the dry commit will be proposed but not merged.
 	AutoMerge *bool `json:"autoMerge,omitempty"`
+	// ConflictResolution determines what happens when the proposed branch conflicts with the active branch, for
+	// example because someone pushed a manual hotfix to the active branch.
+	//
+	// "ours" (the default) merges the active branch into the proposed branch with the "ours" strategy, so the
+	// proposed content wins. "fail" pushes nothing and reports the conflict as a condition and event. "rebuild" pushes
+	// nothing and asks the hydrator to re-hydrate the proposed branch on top of the active branch.
+	ConflictResolution *apiv1alpha1.ConflictResolution `json:"conflictResolution,omitempty"`
 	// ActiveCommitStatuses are commit statuses describing an actively running dry commit. If an active commit status
 	// is failing for an environment, subsequent environments will not deploy the failing commit.
 	//
@@ -40,6 +51,11 @@ type EnvironmentApplyConfiguration struct {
 	// The commit statuses specified in this field apply to this environment only. You can also specify commit statuses
 	// for all environments in the `spec.proposedCommitStatuses` field.
 	ProposedCommitStatuses []CommitStatusSelectorApplyConfiguration `json:"proposedCommitStatuses,omitempty"`
+	// PullRequest configures the pull requests opened to promote dry commits to this environment.
+	//
+	// The configuration specified in this field applies to this environment only. Lists are combined with those in
+	// `spec.pullRequest`, with the environment's entries first.
+	PullRequest *PullRequestOptionsApplyConfiguration `json:"pullRequest,omitempty"`
 }
 
 // EnvironmentApplyConfiguration constructs a declarative configuration of the Environment type for use with
@@ -64,6 +80,14 @@ func (b *EnvironmentApplyConfiguration) WithAutoMerge(value bool) *EnvironmentAp
 	return b
 }
 
+// WithConflictResolution sets the ConflictResolution field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ConflictResolution field is set to the value of the last call.
+func (b *EnvironmentApplyConfiguration) WithConflictResolution(value apiv1alpha1.ConflictResolution) *EnvironmentApplyConfiguration {
+	b.ConflictResolution = &value
+	return b
+}
+
 // WithActiveCommitStatuses adds the given value to the ActiveCommitStatuses field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, values provided by each call will be appended to the ActiveCommitStatuses field.
@@ -89,3 +113,11 @@ func (b *EnvironmentApplyConfiguration) WithProposedCommitStatuses(values ...*Co
 	}
 	return b
 }
+
+// WithPullRequest sets the PullRequest field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PullRequest field is set to the value of the last call.
+func (b *EnvironmentApplyConfiguration) WithPullRequest(value *PullRequestOptionsApplyConfiguration) *EnvironmentApplyConfiguration {
+	b.PullRequest = value
+	return b
+}
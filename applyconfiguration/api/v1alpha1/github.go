@@ -17,6 +17,10 @@ limitations under the License.
 
 package v1alpha1
 
+import (
+	apiv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+)
+
 // GitHubApplyConfiguration represents a declarative configuration of the GitHub type for use
 // with apply.
 //
@@ -28,9 +32,13 @@ type GitHubApplyConfiguration struct {
 	// AppID is the GitHub App ID.
 	AppID *int64 `json:"appID,omitempty"`
 	// InstallationID is the GitHub App Installation ID. If you want to use this ScmProvider for multiple
-	// GitHub orgs, do not specify this field. The installation ID will be inferred from the repo owner
-	// when needed.
+	// GitHub orgs, do not specify this field. The installation ID will be discovered from the owner of each
+	// GitRepository that uses this ScmProvider, and cached along with its installation tokens.
 	InstallationID *int64 `json:"installationID,omitempty"`
+	// CommitStatusMode selects how commit statuses are published: as check runs, as classic commit statuses, or as
+	// both. Check runs require the App's "Checks" permission, classic statuses the "Commit statuses" permission. Use
+	// classic statuses if branch protection rules require status contexts.
+	CommitStatusMode *apiv1alpha1.GitHubCommitStatusMode `json:"commitStatusMode,omitempty"`
 }
 
 // GitHubApplyConfiguration constructs a declarative configuration of the GitHub type for use with
@@ -62,3 +70,11 @@ func (b *GitHubApplyConfiguration) WithInstallationID(value int64) *GitHubApplyC
 	b.InstallationID = &value
 	return b
 }
+
+// WithCommitStatusMode sets the CommitStatusMode field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CommitStatusMode field is set to the value of the last call.
+func (b *GitHubApplyConfiguration) WithCommitStatusMode(value apiv1alpha1.GitHubCommitStatusMode) *GitHubApplyConfiguration {
+	b.CommitStatusMode = &value
+	return b
+}
@@ -25,6 +25,9 @@ type GitLabApplyConfiguration struct {
 	// Domain is the GitLab domain, such as "gitlab.mycompany.com". If using the default GitLab domain, leave this field
 	// empty.
 	Domain *string `json:"domain,omitempty"`
+	// MergeWhenPipelineSucceeds sets merge requests to merge automatically when their pipeline succeeds (auto-merge)
+	// instead of merging them directly. The PullRequest stays open until GitLab merges the merge request.
+	MergeWhenPipelineSucceeds *bool `json:"mergeWhenPipelineSucceeds,omitempty"`
 }
 
 // GitLabApplyConfiguration constructs a declarative configuration of the GitLab type for use with
@@ -40,3 +43,11 @@ func (b *GitLabApplyConfiguration) WithDomain(value string) *GitLabApplyConfigur
 	b.Domain = &value
 	return b
 }
+
+// WithMergeWhenPipelineSucceeds sets the MergeWhenPipelineSucceeds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MergeWhenPipelineSucceeds field is set to the value of the last call.
+func (b *GitLabApplyConfiguration) WithMergeWhenPipelineSucceeds(value bool) *GitLabApplyConfiguration {
+	b.MergeWhenPipelineSucceeds = &value
+	return b
+}
@@ -24,14 +24,17 @@ package v1alpha1
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 // GitRepositorySpec defines the desired state of GitRepository
 type GitRepositorySpecApplyConfiguration struct {
-	GitHub         *GitHubRepoApplyConfiguration                 `json:"github,omitempty"`
-	GitLab         *GitLabRepoApplyConfiguration                 `json:"gitlab,omitempty"`
-	Forgejo        *ForgejoRepoApplyConfiguration                `json:"forgejo,omitempty"`
-	Gitea          *GiteaRepoApplyConfiguration                  `json:"gitea,omitempty"`
-	BitbucketCloud *BitbucketCloudRepoApplyConfiguration         `json:"bitbucketCloud,omitempty"`
-	AzureDevOps    *AzureDevOpsRepoApplyConfiguration            `json:"azureDevOps,omitempty"`
-	Fake           *FakeRepoApplyConfiguration                   `json:"fake,omitempty"`
-	ScmProviderRef *ScmProviderObjectReferenceApplyConfiguration `json:"scmProviderRef,omitempty"`
+	GitHub          *GitHubRepoApplyConfiguration                 `json:"github,omitempty"`
+	GitLab          *GitLabRepoApplyConfiguration                 `json:"gitlab,omitempty"`
+	Forgejo         *ForgejoRepoApplyConfiguration                `json:"forgejo,omitempty"`
+	Gitea           *GiteaRepoApplyConfiguration                  `json:"gitea,omitempty"`
+	BitbucketCloud  *BitbucketCloudRepoApplyConfiguration         `json:"bitbucketCloud,omitempty"`
+	BitbucketServer *BitbucketServerRepoApplyConfiguration        `json:"bitbucketServer,omitempty"`
+	AzureDevOps     *AzureDevOpsRepoApplyConfiguration            `json:"azureDevOps,omitempty"`
+	Gerrit          *GerritRepoApplyConfiguration                 `json:"gerrit,omitempty"`
+	Generic         *GenericRepoApplyConfiguration                `json:"generic,omitempty"`
+	Fake            *FakeRepoApplyConfiguration                   `json:"fake,omitempty"`
+	ScmProviderRef  *ScmProviderObjectReferenceApplyConfiguration `json:"scmProviderRef,omitempty"`
 }
 
 // GitRepositorySpecApplyConfiguration constructs a declarative configuration of the GitRepositorySpec type for use with
@@ -80,6 +83,14 @@ func (b *GitRepositorySpecApplyConfiguration) WithBitbucketCloud(value *Bitbucke
 	return b
 }
 
+// WithBitbucketServer sets the BitbucketServer field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the BitbucketServer field is set to the value of the last call.
+func (b *GitRepositorySpecApplyConfiguration) WithBitbucketServer(value *BitbucketServerRepoApplyConfiguration) *GitRepositorySpecApplyConfiguration {
+	b.BitbucketServer = value
+	return b
+}
+
 // WithAzureDevOps sets the AzureDevOps field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the AzureDevOps field is set to the value of the last call.
@@ -88,6 +99,22 @@ func (b *GitRepositorySpecApplyConfiguration) WithAzureDevOps(value *AzureDevOps
 	return b
 }
 
+// WithGerrit sets the Gerrit field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Gerrit field is set to the value of the last call.
+func (b *GitRepositorySpecApplyConfiguration) WithGerrit(value *GerritRepoApplyConfiguration) *GitRepositorySpecApplyConfiguration {
+	b.Gerrit = value
+	return b
+}
+
+// WithGeneric sets the Generic field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Generic field is set to the value of the last call.
+func (b *GitRepositorySpecApplyConfiguration) WithGeneric(value *GenericRepoApplyConfiguration) *GitRepositorySpecApplyConfiguration {
+	b.Generic = value
+	return b
+}
+
 // WithFake sets the Fake field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Fake field is set to the value of the last call.
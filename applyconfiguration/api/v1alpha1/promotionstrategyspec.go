@@ -39,6 +39,11 @@ type PromotionStrategySpecApplyConfiguration struct {
 	// The commit statuses specified in this field apply to all environments in the promotion sequence. You can also
 	// specify commit statuses for individual environments in the `environments` field.
 	ProposedCommitStatuses []CommitStatusSelectorApplyConfiguration `json:"proposedCommitStatuses,omitempty"`
+	// PullRequest configures the pull requests opened to promote dry commits.
+	//
+	// The configuration specified in this field applies to all environments in the promotion sequence. You can also
+	// specify it for individual environments in the `environments` field.
+	PullRequest *PullRequestOptionsApplyConfiguration `json:"pullRequest,omitempty"`
 	// Environments is the sequence of environments that a dry commit will be promoted through.
 	Environments []EnvironmentApplyConfiguration `json:"environments,omitempty"`
 }
@@ -83,6 +88,14 @@ func (b *PromotionStrategySpecApplyConfiguration) WithProposedCommitStatuses(val
 	return b
 }
 
+// WithPullRequest sets the PullRequest field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PullRequest field is set to the value of the last call.
+func (b *PromotionStrategySpecApplyConfiguration) WithPullRequest(value *PullRequestOptionsApplyConfiguration) *PromotionStrategySpecApplyConfiguration {
+	b.PullRequest = value
+	return b
+}
+
 // WithEnvironments adds the given value to the Environments field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, values provided by each call will be appended to the Environments field.
@@ -47,6 +47,21 @@ type PullRequestSpecApplyConfiguration struct {
 	// State of the pull request (closed, merged, or open). Must always be "open" when creating a new pull request.
 	// This value may not be changed to "closed" or "merged" unless the pull request status.id is set.
 	State *apiv1alpha1.PullRequestState `json:"state,omitempty"`
+	// Reviewers are the users or teams whose review is requested when the pull request is opened.
+	Reviewers []string `json:"reviewers,omitempty"`
+	// RequiredReviewers are the users or groups whose review is required before the pull request can be merged. They
+	// are added to the pull request by SCM providers that support required reviewers.
+	RequiredReviewers []string `json:"requiredReviewers,omitempty"`
+	// Assignees are the users assigned to the pull request when it is opened, by SCM providers that support assignees.
+	Assignees []string `json:"assignees,omitempty"`
+	// Draft is whether the pull request should be a draft, or work in progress on SCMs without drafts. The pull request
+	// is opened as a draft and marked ready for review when this changes to false.
+	Draft *bool `json:"draft,omitempty"`
+	// Labels are added to the pull request when it is opened, by SCM providers that support labels.
+	Labels []string `json:"labels,omitempty"`
+	// CommitStatuses are the proposed commit statuses of the ChangeTransferPolicy, including the previous environment
+	// gate. They are summarized in a comment on the pull request that is kept up to date as they change.
+	CommitStatuses []ChangeRequestPolicyCommitStatusPhaseApplyConfiguration `json:"commitStatuses,omitempty"`
 }
 
 // PullRequestSpecApplyConfiguration constructs a declarative configuration of the PullRequestSpec type for use with
@@ -118,3 +133,64 @@ func (b *PullRequestSpecApplyConfiguration) WithState(value apiv1alpha1.PullRequ
 	b.State = &value
 	return b
 }
+
+// WithReviewers adds the given value to the Reviewers field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Reviewers field.
+func (b *PullRequestSpecApplyConfiguration) WithReviewers(values ...string) *PullRequestSpecApplyConfiguration {
+	for i := range values {
+		b.Reviewers = append(b.Reviewers, values[i])
+	}
+	return b
+}
+
+// WithRequiredReviewers adds the given value to the RequiredReviewers field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the RequiredReviewers field.
+func (b *PullRequestSpecApplyConfiguration) WithRequiredReviewers(values ...string) *PullRequestSpecApplyConfiguration {
+	for i := range values {
+		b.RequiredReviewers = append(b.RequiredReviewers, values[i])
+	}
+	return b
+}
+
+// WithAssignees adds the given value to the Assignees field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Assignees field.
+func (b *PullRequestSpecApplyConfiguration) WithAssignees(values ...string) *PullRequestSpecApplyConfiguration {
+	for i := range values {
+		b.Assignees = append(b.Assignees, values[i])
+	}
+	return b
+}
+
+// WithDraft sets the Draft field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Draft field is set to the value of the last call.
+func (b *PullRequestSpecApplyConfiguration) WithDraft(value bool) *PullRequestSpecApplyConfiguration {
+	b.Draft = &value
+	return b
+}
+
+// WithLabels adds the given value to the Labels field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Labels field.
+func (b *PullRequestSpecApplyConfiguration) WithLabels(values ...string) *PullRequestSpecApplyConfiguration {
+	for i := range values {
+		b.Labels = append(b.Labels, values[i])
+	}
+	return b
+}
+
+// WithCommitStatuses adds the given value to the CommitStatuses field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the CommitStatuses field.
+func (b *PullRequestSpecApplyConfiguration) WithCommitStatuses(values ...*ChangeRequestPolicyCommitStatusPhaseApplyConfiguration) *PullRequestSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithCommitStatuses")
+		}
+		b.CommitStatuses = append(b.CommitStatuses, *values[i])
+	}
+	return b
+}
@@ -34,6 +34,9 @@ type PullRequestStatusApplyConfiguration struct {
 	State *apiv1alpha1.PullRequestState `json:"state,omitempty"`
 	// PRCreationTime the time the PR was created
 	PRCreationTime *v1.Time `json:"prCreationTime,omitempty"`
+	// Draft is whether the pull request was last opened or updated on the SCM as a draft. The ChangeTransferPolicy
+	// does not merge the pull request while it is a draft.
+	Draft *bool `json:"draft,omitempty"`
 	// Url is the URL of the pull request.
 	Url *string `json:"url,omitempty"`
 	// ExternallyMergedOrClosed indicates that the pull request was merged or closed externally.
@@ -42,6 +45,11 @@ type PullRequestStatusApplyConfiguration struct {
 	// The PullRequest resource will be deleted after this flag is set, but the status is preserved in
 	// the owning ChangeTransferPolicy to maintain a record of the external action.
 	ExternallyMergedOrClosed *bool `json:"externallyMergedOrClosed,omitempty"`
+	// GateSummaryComment is the comment on the pull request that summarizes its commit statuses.
+	GateSummaryComment *PullRequestCommentApplyConfiguration `json:"gateSummaryComment,omitempty"`
+	// MergeQueue is the state of the pull request in the merge queue (GitHub) or merge train (GitLab) of its target
+	// branch. It is only set once the pull request was added to one.
+	MergeQueue *PullRequestMergeQueueStatusApplyConfiguration `json:"mergeQueue,omitempty"`
 	// Conditions Represents the observations of the current state.
 	Conditions []metav1.ConditionApplyConfiguration `json:"conditions,omitempty"`
 }
@@ -76,6 +84,14 @@ func (b *PullRequestStatusApplyConfiguration) WithPRCreationTime(value v1.Time)
 	return b
 }
 
+// WithDraft sets the Draft field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Draft field is set to the value of the last call.
+func (b *PullRequestStatusApplyConfiguration) WithDraft(value bool) *PullRequestStatusApplyConfiguration {
+	b.Draft = &value
+	return b
+}
+
 // WithUrl sets the Url field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Url field is set to the value of the last call.
@@ -92,6 +108,22 @@ func (b *PullRequestStatusApplyConfiguration) WithExternallyMergedOrClosed(value
 	return b
 }
 
+// WithGateSummaryComment sets the GateSummaryComment field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the GateSummaryComment field is set to the value of the last call.
+func (b *PullRequestStatusApplyConfiguration) WithGateSummaryComment(value *PullRequestCommentApplyConfiguration) *PullRequestStatusApplyConfiguration {
+	b.GateSummaryComment = value
+	return b
+}
+
+// WithMergeQueue sets the MergeQueue field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MergeQueue field is set to the value of the last call.
+func (b *PullRequestStatusApplyConfiguration) WithMergeQueue(value *PullRequestMergeQueueStatusApplyConfiguration) *PullRequestStatusApplyConfiguration {
+	b.MergeQueue = value
+	return b
+}
+
 // WithConditions adds the given value to the Conditions field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, values provided by each call will be appended to the Conditions field.
@@ -29,6 +29,14 @@ import (
 type ScmProviderSpecApplyConfiguration struct {
 	// SecretRef contains the credentials required to auth to a specific provider
 	SecretRef *v1.LocalObjectReference `json:"secretRef,omitempty"`
+	// SSH configures git operations to use SSH with a deploy key from the secret instead of HTTPS. It can be combined
+	// with any SCM provider.
+	SSH *GitSSHApplyConfiguration `json:"ssh,omitempty"`
+	// CommitAuthor is the identity used for commits GitOps Promoter creates. Defaults to
+	// "GitOps Promoter <GitOpsPromoter@argoproj.io>".
+	CommitAuthor *CommitAuthorApplyConfiguration `json:"commitAuthor,omitempty"`
+	// CommitSigning enables signing of commits GitOps Promoter creates, for branches that require signed commits.
+	CommitSigning *CommitSigningApplyConfiguration `json:"commitSigning,omitempty"`
 	// GitHub required configuration for GitHub as the SCM provider
 	GitHub *GitHubApplyConfiguration `json:"github,omitempty"`
 	// GitLab required configuration for GitLab as the SCM provider
@@ -39,8 +47,15 @@ type ScmProviderSpecApplyConfiguration struct {
 	Gitea *GiteaApplyConfiguration `json:"gitea,omitempty"`
 	// BitbucketCloud required configuration for Bitbucket Cloud as the SCM provider
 	BitbucketCloud *apiv1alpha1.BitbucketCloud `json:"bitbucketCloud,omitempty"`
+	// BitbucketServer required configuration for Bitbucket Data Center (formerly Bitbucket Server) as the SCM provider
+	BitbucketServer *BitbucketServerApplyConfiguration `json:"bitbucketServer,omitempty"`
 	// AzureDevOps required configuration for Azure DevOps as the SCM provider
 	AzureDevOps *AzureDevOpsApplyConfiguration `json:"azureDevOps,omitempty"`
+	// Gerrit required configuration for Gerrit as the SCM provider
+	Gerrit *GerritApplyConfiguration `json:"gerrit,omitempty"`
+	// Generic required configuration for a plain git HTTP server without a pull request or commit status API as the SCM
+	// provider
+	Generic *GenericApplyConfiguration `json:"generic,omitempty"`
 	// Fake required configuration for Fake as the SCM provider
 	Fake *FakeApplyConfiguration `json:"fake,omitempty"`
 }
@@ -59,6 +74,30 @@ func (b *ScmProviderSpecApplyConfiguration) WithSecretRef(value v1.LocalObjectRe
 	return b
 }
 
+// WithSSH sets the SSH field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SSH field is set to the value of the last call.
+func (b *ScmProviderSpecApplyConfiguration) WithSSH(value *GitSSHApplyConfiguration) *ScmProviderSpecApplyConfiguration {
+	b.SSH = value
+	return b
+}
+
+// WithCommitAuthor sets the CommitAuthor field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CommitAuthor field is set to the value of the last call.
+func (b *ScmProviderSpecApplyConfiguration) WithCommitAuthor(value *CommitAuthorApplyConfiguration) *ScmProviderSpecApplyConfiguration {
+	b.CommitAuthor = value
+	return b
+}
+
+// WithCommitSigning sets the CommitSigning field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CommitSigning field is set to the value of the last call.
+func (b *ScmProviderSpecApplyConfiguration) WithCommitSigning(value *CommitSigningApplyConfiguration) *ScmProviderSpecApplyConfiguration {
+	b.CommitSigning = value
+	return b
+}
+
 // WithGitHub sets the GitHub field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the GitHub field is set to the value of the last call.
@@ -99,6 +138,14 @@ func (b *ScmProviderSpecApplyConfiguration) WithBitbucketCloud(value apiv1alpha1
 	return b
 }
 
+// WithBitbucketServer sets the BitbucketServer field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the BitbucketServer field is set to the value of the last call.
+func (b *ScmProviderSpecApplyConfiguration) WithBitbucketServer(value *BitbucketServerApplyConfiguration) *ScmProviderSpecApplyConfiguration {
+	b.BitbucketServer = value
+	return b
+}
+
 // WithAzureDevOps sets the AzureDevOps field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the AzureDevOps field is set to the value of the last call.
@@ -107,6 +154,22 @@ func (b *ScmProviderSpecApplyConfiguration) WithAzureDevOps(value *AzureDevOpsAp
 	return b
 }
 
+// WithGerrit sets the Gerrit field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Gerrit field is set to the value of the last call.
+func (b *ScmProviderSpecApplyConfiguration) WithGerrit(value *GerritApplyConfiguration) *ScmProviderSpecApplyConfiguration {
+	b.Gerrit = value
+	return b
+}
+
+// WithGeneric sets the Generic field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Generic field is set to the value of the last call.
+func (b *ScmProviderSpecApplyConfiguration) WithGeneric(value *GenericApplyConfiguration) *ScmProviderSpecApplyConfiguration {
+	b.Generic = value
+	return b
+}
+
 // WithFake sets the Fake field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Fake field is set to the value of the last call.
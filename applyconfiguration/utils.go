@@ -51,10 +51,16 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &apiv1alpha1.BearerAuthApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("BitbucketCloudRepo"):
 		return &apiv1alpha1.BitbucketCloudRepoApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("BitbucketServer"):
+		return &apiv1alpha1.BitbucketServerApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("BitbucketServerRepo"):
+		return &apiv1alpha1.BitbucketServerRepoApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("Bucket"):
 		return &apiv1alpha1.BucketApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("ChangeRequestPolicyCommitStatusPhase"):
 		return &apiv1alpha1.ChangeRequestPolicyCommitStatusPhaseApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ChangeSummary"):
+		return &apiv1alpha1.ChangeSummaryApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("ChangeTransferPolicy"):
 		return &apiv1alpha1.ChangeTransferPolicyApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("ChangeTransferPolicyConfiguration"):
@@ -65,6 +71,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &apiv1alpha1.ChangeTransferPolicyStatusApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("ClusterScmProvider"):
 		return &apiv1alpha1.ClusterScmProviderApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("CommitAuthor"):
+		return &apiv1alpha1.CommitAuthorApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("CommitBranchState"):
 		return &apiv1alpha1.CommitBranchStateApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("CommitBranchStateHistoryProposed"):
@@ -75,6 +83,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &apiv1alpha1.CommitMetadataApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("CommitShaState"):
 		return &apiv1alpha1.CommitShaStateApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("CommitSigning"):
+		return &apiv1alpha1.CommitSigningApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("CommitStatus"):
 		return &apiv1alpha1.CommitStatusApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("CommitStatusConfiguration"):
@@ -85,6 +95,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &apiv1alpha1.CommitStatusSpecApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("CommitStatusStatus"):
 		return &apiv1alpha1.CommitStatusStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ConflictStatus"):
+		return &apiv1alpha1.ConflictStatusApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("ControllerConfiguration"):
 		return &apiv1alpha1.ControllerConfigurationApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("ControllerConfigurationSpec"):
@@ -105,6 +117,14 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &apiv1alpha1.ForgejoApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("ForgejoRepo"):
 		return &apiv1alpha1.ForgejoRepoApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("Generic"):
+		return &apiv1alpha1.GenericApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("GenericRepo"):
+		return &apiv1alpha1.GenericRepoApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("Gerrit"):
+		return &apiv1alpha1.GerritApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("GerritRepo"):
+		return &apiv1alpha1.GerritRepoApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("GitCommitStatus"):
 		return &apiv1alpha1.GitCommitStatusApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("GitCommitStatusConfiguration"):
@@ -133,6 +153,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &apiv1alpha1.GitRepositorySpecApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("GitRepositoryStatus"):
 		return &apiv1alpha1.GitRepositoryStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("GitSSH"):
+		return &apiv1alpha1.GitSSHApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("HealthyDryShas"):
 		return &apiv1alpha1.HealthyDryShasApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("History"):
@@ -163,10 +185,16 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &apiv1alpha1.PromotionStrategyStatusApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("PullRequest"):
 		return &apiv1alpha1.PullRequestApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("PullRequestComment"):
+		return &apiv1alpha1.PullRequestCommentApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("PullRequestCommonStatus"):
 		return &apiv1alpha1.PullRequestCommonStatusApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("PullRequestConfiguration"):
 		return &apiv1alpha1.PullRequestConfigurationApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("PullRequestMergeQueueStatus"):
+		return &apiv1alpha1.PullRequestMergeQueueStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("PullRequestOptions"):
+		return &apiv1alpha1.PullRequestOptionsApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("PullRequestSpec"):
 		return &apiv1alpha1.PullRequestSpecApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("PullRequestStatus"):
@@ -185,6 +213,20 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &apiv1alpha1.RevertCommitSpecApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("RevisionReference"):
 		return &apiv1alpha1.RevisionReferenceApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ScmCheck"):
+		return &apiv1alpha1.ScmCheckApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ScmCheckCommitStatus"):
+		return &apiv1alpha1.ScmCheckCommitStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ScmCheckCommitStatusConfiguration"):
+		return &apiv1alpha1.ScmCheckCommitStatusConfigurationApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ScmCheckCommitStatusEnvironmentStatus"):
+		return &apiv1alpha1.ScmCheckCommitStatusEnvironmentStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ScmCheckCommitStatusSpec"):
+		return &apiv1alpha1.ScmCheckCommitStatusSpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ScmCheckCommitStatusStatus"):
+		return &apiv1alpha1.ScmCheckCommitStatusStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ScmCheckResult"):
+		return &apiv1alpha1.ScmCheckResultApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("ScmProvider"):
 		return &apiv1alpha1.ScmProviderApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("ScmProviderObjectReference"):
@@ -229,6 +271,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &apiv1alpha1.WebRequestCommitStatusSpecApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("WebRequestCommitStatusStatus"):
 		return &apiv1alpha1.WebRequestCommitStatusStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("WebhookReceiverConfiguration"):
+		return &apiv1alpha1.WebhookReceiverConfigurationApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("WhenSpec"):
 		return &apiv1alpha1.WhenSpecApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("WhenWithOutputSpec"):
@@ -41,6 +41,7 @@ import (
 	"github.com/spf13/pflag"
 	"golang.org/x/sync/errgroup"
 
+	"github.com/argoproj-labs/gitops-promoter/internal/gitauth"
 	"github.com/argoproj-labs/gitops-promoter/internal/settings"
 	"github.com/argoproj-labs/gitops-promoter/internal/types/constants"
 	"github.com/argoproj-labs/gitops-promoter/internal/utils/gitpaths"
@@ -206,12 +207,13 @@ func runController(
 
 	processSignalsCtx := ctrl.SetupSignalHandler()
 
-	if err = (&controller.PullRequestReconciler{
+	prReconciler := &controller.PullRequestReconciler{
 		Client:      localManager.GetClient(),
 		Scheme:      localManager.GetScheme(),
 		Recorder:    localManager.GetEventRecorder("PullRequest"),
 		SettingsMgr: settingsMgr,
-	}).SetupWithManager(processSignalsCtx, localManager); err != nil {
+	}
+	if err = prReconciler.SetupWithManager(processSignalsCtx, localManager); err != nil {
 		panic(fmt.Errorf("unable to create PullRequest controller: %w", err))
 	}
 	if err = (&controller.RevertCommitReconciler{
@@ -244,13 +246,14 @@ func runController(
 		panic(fmt.Errorf("unable to create CommitStatus controller: %w", err))
 	}
 
-	if err = (&controller.PromotionStrategyReconciler{
+	psReconciler := &controller.PromotionStrategyReconciler{
 		Client:      localManager.GetClient(),
 		Scheme:      localManager.GetScheme(),
 		Recorder:    localManager.GetEventRecorder("PromotionStrategy"),
 		SettingsMgr: settingsMgr,
 		EnqueueCTP:  ctpReconciler.GetEnqueueFunc(),
-	}).SetupWithManager(processSignalsCtx, localManager); err != nil {
+	}
+	if err = psReconciler.SetupWithManager(processSignalsCtx, localManager); err != nil {
 		panic(fmt.Errorf("unable to create PromotionStrategy controller: %w", err))
 	}
 	if err = (&controller.ScmProviderReconciler{
@@ -319,6 +322,16 @@ func runController(
 		setupLog.Error(err, "unable to create controller", "controller", "WebRequestCommitStatus")
 		panic(fmt.Errorf("unable to create WebRequestCommitStatus controller: %w", err))
 	}
+	if err := (&controller.ScmCheckCommitStatusReconciler{
+		Client:      localManager.GetClient(),
+		Scheme:      localManager.GetScheme(),
+		Recorder:    localManager.GetEventRecorder("ScmCheckCommitStatus"),
+		SettingsMgr: settingsMgr,
+		EnqueueCTP:  ctpReconciler.GetEnqueueFunc(),
+	}).SetupWithManager(processSignalsCtx, localManager); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ScmCheckCommitStatus")
+		panic(fmt.Errorf("unable to create ScmCheckCommitStatus controller: %w", err))
+	}
 	//+kubebuilder:scaffold:builder
 
 	if err := localManager.AddHealthzCheck("healthz", healthz.Ping); err != nil {
@@ -328,7 +341,7 @@ func runController(
 		panic(fmt.Errorf("unable to set up ready check: %w", err))
 	}
 
-	whr := webhookreceiver.NewWebhookReceiver(localManager, webhookreceiver.EnqueueFunc(ctpReconciler.GetEnqueueFunc()))
+	whr := webhookreceiver.NewWebhookReceiver(localManager, settingsMgr, webhookreceiver.EnqueueFunc(ctpReconciler.GetEnqueueFunc()), webhookreceiver.EnqueueFunc(prReconciler.GetEnqueueFunc()), webhookreceiver.EnqueueFunc(psReconciler.GetNotesPushFunc()))
 
 	g, ctx := errgroup.WithContext(processSignalsCtx)
 
@@ -371,6 +384,11 @@ func runController(
 		}
 		setupLog.Info("cleaning directory", "directory", path)
 	}
+
+	setupLog.Info("Cleaning up key material")
+	if err := gitauth.RemoveKeyMaterial(); err != nil {
+		setupLog.Error(err, "failed to cleanup key material")
+	}
 	return nil
 }
 
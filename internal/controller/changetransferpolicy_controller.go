@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"path"
 	"reflect"
 	"slices"
 	"strings"
@@ -30,13 +31,16 @@ import (
 
 	"github.com/argoproj-labs/gitops-promoter/internal/git"
 	"github.com/argoproj-labs/gitops-promoter/internal/gitauth"
+	"github.com/argoproj-labs/gitops-promoter/internal/metrics"
 	"github.com/argoproj-labs/gitops-promoter/internal/settings"
 	"github.com/argoproj-labs/gitops-promoter/internal/utils"
+	"golang.org/x/sync/errgroup"
 	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 	acmetav1 "k8s.io/client-go/applyconfigurations/meta/v1"
 	"k8s.io/client-go/tools/events"
 	"k8s.io/utils/ptr"
@@ -158,7 +162,7 @@ func (r *ChangeTransferPolicyReconciler) Reconcile(ctx context.Context, req ctrl
 		return ctrl.Result{}, fmt.Errorf("failed to calculate ChangeTransferPolicy status: %w", err)
 	}
 
-	err = r.gitMergeStrategyOurs(ctx, gitOperations, &ctp)
+	conflictUnresolved, err := r.resolveConflicts(ctx, gitOperations, &ctp)
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed to git merge for conflict resolution: %w", err)
 	}
@@ -172,13 +176,20 @@ func (r *ChangeTransferPolicyReconciler) Reconcile(ctx context.Context, req ctrl
 		utils.InheritNotReadyConditionFromObjects(&ctp, promoterConditions.PullRequestNotReady, pr)
 	}
 
-	pr, err = r.mergePullRequests(ctx, &ctp)
-	if err != nil {
-		return ctrl.Result{}, fmt.Errorf("failed to merge pull requests: %w", err)
-	}
+	if conflictUnresolved {
+		// The branches conflict and the configured strategy does not let us resolve it ourselves, so merging the PR
+		// would either fail or require a human anyway. Report the conflict through the Ready condition instead.
+		setConflictCondition(&ctp)
+	} else {
+		meta.RemoveStatusCondition(ctp.GetConditions(), string(promoterConditions.Conflicted))
+		pr, err = r.mergePullRequests(ctx, &ctp)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to merge pull requests: %w", err)
+		}
 
-	if pr != nil {
-		utils.InheritNotReadyConditionFromObjects(&ctp, promoterConditions.PullRequestNotReady, pr)
+		if pr != nil {
+			utils.InheritNotReadyConditionFromObjects(&ctp, promoterConditions.PullRequestNotReady, pr)
+		}
 	}
 
 	// calculateHistory is done at a best effort so we do not return any errors here, we just log them instead.
@@ -238,6 +249,7 @@ func (r *ChangeTransferPolicyReconciler) buildHistoryEntry(ctx context.Context,
 	}
 
 	r.populateActiveMetadata(ctx, &historyEntry, sha, gitOperations)
+	r.populateChanges(ctx, &historyEntry, sha, gitOperations)
 	r.populateProposedMetadata(ctx, &historyEntry, activeTrailers, gitOperations)
 	r.populatePullRequestMetadata(ctx, &historyEntry, activeTrailers)
 	r.populateCommitStatuses(ctx, &historyEntry, activeTrailers)
@@ -253,6 +265,17 @@ func getFirstTrailerValue(trailers map[string][]string, key string) string {
 	return ""
 }
 
+// populateChanges populates the summary of changes between the previous active commit and the given one.
+func (r *ChangeTransferPolicyReconciler) populateChanges(ctx context.Context, h *promoterv1alpha1.History, sha string, gitOperations *git.EnvironmentOperations) {
+	logger := log.FromContext(ctx)
+	changes, err := gitOperations.GetChangeSummary(ctx, sha+"^1", sha)
+	if err != nil {
+		logger.V(4).Info("failed to get historic change summary from git", "sha", sha, "error", err)
+		return
+	}
+	h.Active.Changes = changes
+}
+
 // populateActiveMetadata populates the active metadata for a history entry
 func (r *ChangeTransferPolicyReconciler) populateActiveMetadata(ctx context.Context, h *promoterv1alpha1.History, sha string, gitOperations *git.EnvironmentOperations) {
 	logger := log.FromContext(ctx)
@@ -456,6 +479,22 @@ func (r *ChangeTransferPolicyReconciler) SetupWithManager(ctx context.Context, m
 		return fmt.Errorf("failed to set field index for .status.active.hydrated.sha: %w", err)
 	}
 
+	// These get used by the webhook server to find the ChangeTransferPolicies of the branches that a push updated
+	if err := mgr.GetFieldIndexer().IndexField(ctx, &promoterv1alpha1.ChangeTransferPolicy{}, ".spec.proposedBranch", func(rawObj client.Object) []string {
+		//nolint:forcetypeassert // type is guaranteed by the IndexField API
+		ctp := rawObj.(*promoterv1alpha1.ChangeTransferPolicy)
+		return []string{ctp.Spec.ProposedBranch}
+	}); err != nil {
+		return fmt.Errorf("failed to set field index for .spec.proposedBranch: %w", err)
+	}
+	if err := mgr.GetFieldIndexer().IndexField(ctx, &promoterv1alpha1.ChangeTransferPolicy{}, ".spec.activeBranch", func(rawObj client.Object) []string {
+		//nolint:forcetypeassert // type is guaranteed by the IndexField API
+		ctp := rawObj.(*promoterv1alpha1.ChangeTransferPolicy)
+		return []string{ctp.Spec.ActiveBranch}
+	}); err != nil {
+		return fmt.Errorf("failed to set field index for .spec.activeBranch: %w", err)
+	}
+
 	// Use Direct methods to read configuration from the API server without cache during setup.
 	// The cache is not started during SetupWithManager, so we must use the non-cached API reader.
 	rateLimiter, err := settings.GetRateLimiterDirect[promoterv1alpha1.ChangeTransferPolicyConfiguration, ctrl.Request](ctx, r.SettingsMgr)
@@ -519,20 +558,54 @@ func (r *ChangeTransferPolicyReconciler) SetupWithManager(ctx context.Context, m
 func (r *ChangeTransferPolicyReconciler) calculateStatus(ctx context.Context, ctp *promoterv1alpha1.ChangeTransferPolicy, gitOperations *git.EnvironmentOperations) error {
 	logger := log.FromContext(ctx)
 
-	// TODO: consider parallelizing parts of this function that are network-bound work.
+	// The network-bound work is split into phases. Work within a phase is independent and runs in parallel; each
+	// phase depends on the results of the previous one. Only read-only git commands run in parallel.
+	concurrency, err := r.SettingsMgr.GetChangeTransferPolicyMaxConcurrentStatusOperations(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get max concurrent status operations: %w", err)
+	}
+
+	gitRepository := types.NamespacedName{Namespace: ctp.Namespace, Name: ctp.Spec.RepositoryReference.Name}
 
-	proposedShas, err := gitOperations.GetBranchShas(ctx, ctp.Spec.ProposedBranch)
+	// Fetches write to the clone, so both branches are fetched by one git fetch rather than in parallel.
+	err = runStatusPhase(ctx, gitRepository, metrics.ChangeTransferPolicyStatusPhaseFetch, 1,
+		func(ctx context.Context) error {
+			err := gitOperations.FetchBranches(ctx, ctp.Spec.ProposedBranch, ctp.Spec.ActiveBranch)
+			if err != nil {
+				// If the proposed branch doesn't exist, it's likely because the hydrator hasn't run yet
+				if strings.Contains(err.Error(), "couldn't find remote ref "+ctp.Spec.ProposedBranch) {
+					return fmt.Errorf("failed to fetch proposed branch %q: %w (this branch may not exist yet - check if your hydrator is running and has processed this branch)", ctp.Spec.ProposedBranch, err)
+				}
+				return fmt.Errorf("failed to fetch branches: %w", err)
+			}
+			return nil
+		},
+	)
 	if err != nil {
-		// If the proposed branch doesn't exist, it's likely because the hydrator hasn't run yet
-		if strings.Contains(err.Error(), "couldn't find remote ref") {
-			return fmt.Errorf("failed to get SHAs for proposed branch %q: %w (this branch may not exist yet - check if your hydrator is running and has processed this branch)", ctp.Spec.ProposedBranch, err)
-		}
-		return fmt.Errorf("failed to get SHAs for proposed branch %q: %w", ctp.Spec.ProposedBranch, err)
+		return err
 	}
 
-	activeShas, err := gitOperations.GetBranchShas(ctx, ctp.Spec.ActiveBranch)
+	var proposedShas, activeShas git.BranchShas
+	err = runStatusPhase(ctx, gitRepository, metrics.ChangeTransferPolicyStatusPhaseBranchShas, concurrency,
+		func(ctx context.Context) error {
+			var err error
+			proposedShas, err = gitOperations.GetFetchedBranchShas(ctx, ctp.Spec.ProposedBranch)
+			if err != nil {
+				return fmt.Errorf("failed to get SHAs for proposed branch %q: %w", ctp.Spec.ProposedBranch, err)
+			}
+			return nil
+		},
+		func(ctx context.Context) error {
+			var err error
+			activeShas, err = gitOperations.GetFetchedBranchShas(ctx, ctp.Spec.ActiveBranch)
+			if err != nil {
+				return fmt.Errorf("failed to get SHAs for active branch %q: %w", ctp.Spec.ActiveBranch, err)
+			}
+			return nil
+		},
+	)
 	if err != nil {
-		return fmt.Errorf("failed to get SHAs for active branch %q: %w", ctp.Spec.ActiveBranch, err)
+		return err
 	}
 
 	logger.Info("Branch SHAs", "branchShas", map[string]git.BranchShas{
@@ -540,35 +613,65 @@ func (r *ChangeTransferPolicyReconciler) calculateStatus(ctx context.Context, ct
 		ctp.Spec.ProposedBranch: proposedShas,
 	})
 
-	err = r.setCommitMetadata(ctx, ctp, gitOperations, activeShas.Hydrated, proposedShas.Hydrated)
+	err = r.setCommitMetadata(ctx, ctp, gitOperations, activeShas.Hydrated, proposedShas.Hydrated, concurrency)
 	if err != nil {
 		return fmt.Errorf("failed to set commit metadata: %w", err)
 	}
 
-	err = r.setCommitStatusState(ctx, &ctp.Status.Active, ctp.Spec.ActiveCommitStatuses)
+	err = runStatusPhase(ctx, gitRepository, metrics.ChangeTransferPolicyStatusPhaseCommitStatuses, concurrency,
+		func(ctx context.Context) error {
+			err := r.setCommitStatusState(ctx, &ctp.Status.Active, ctp.Spec.ActiveCommitStatuses)
+			if err != nil {
+				var tooManyMatchingShaError *TooManyMatchingShaError
+				if errors.As(err, &tooManyMatchingShaError) {
+					r.Recorder.Eventf(ctp, nil, "Warning", constants.TooManyMatchingShaReason, "EvaluatingPromotion", constants.TooManyMatchingShaActiveMessage)
+				}
+				return fmt.Errorf("failed to set active commit status state: %w", err)
+			}
+			return nil
+		},
+		func(ctx context.Context) error {
+			err := r.setCommitStatusState(ctx, &ctp.Status.Proposed, ctp.Spec.ProposedCommitStatuses)
+			if err != nil {
+				var tooManyMatchingShaError *TooManyMatchingShaError
+				if errors.As(err, &tooManyMatchingShaError) {
+					r.Recorder.Eventf(ctp, nil, "Warning", constants.TooManyMatchingShaReason, "EvaluatingPromotion", constants.TooManyMatchingShaProposedMessage)
+				}
+				return fmt.Errorf("failed to set proposed commit status state: %w", err)
+			}
+			return nil
+		},
+		func(ctx context.Context) error {
+			if err := r.setPullRequestState(ctx, ctp); err != nil {
+				return fmt.Errorf("failed to set pull request status state: %w", err)
+			}
+			return nil
+		},
+	)
 	if err != nil {
-		var tooManyMatchingShaError *TooManyMatchingShaError
-		if errors.As(err, &tooManyMatchingShaError) {
-			r.Recorder.Eventf(ctp, nil, "Warning", constants.TooManyMatchingShaReason, "EvaluatingPromotion", constants.TooManyMatchingShaActiveMessage)
-		}
-		return fmt.Errorf("failed to set active commit status state: %w", err)
+		return err
 	}
 
-	err = r.setCommitStatusState(ctx, &ctp.Status.Proposed, ctp.Spec.ProposedCommitStatuses)
-	if err != nil {
-		var tooManyMatchingShaError *TooManyMatchingShaError
-		if errors.As(err, &tooManyMatchingShaError) {
-			r.Recorder.Eventf(ctp, nil, "Warning", constants.TooManyMatchingShaReason, "EvaluatingPromotion", constants.TooManyMatchingShaProposedMessage)
-		}
-		return fmt.Errorf("failed to set proposed commit status state: %w", err)
-	}
+	return nil
+}
 
-	err = r.setPullRequestState(ctx, ctp)
-	if err != nil {
-		return fmt.Errorf("failed to set pull request status state: %w", err)
+// runStatusPhase runs the tasks of one calculateStatus phase in parallel, at most limit at a time, and records how long
+// the phase took. The context passed to the tasks is canceled as soon as one of them fails, and the first error is
+// returned. Tasks must not write to the same fields.
+func runStatusPhase(ctx context.Context, gitRepository types.NamespacedName, phase metrics.ChangeTransferPolicyStatusPhase, limit int, tasks ...func(ctx context.Context) error) error {
+	start := time.Now()
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(limit)
+	for _, task := range tasks {
+		g.Go(func() error {
+			return task(gctx)
+		})
 	}
+	err := g.Wait()
 
-	return nil
+	metrics.RecordChangeTransferPolicyStatusPhase(gitRepository, phase, err, time.Since(start))
+	return err //nolint:wrapcheck // The tasks wrap their own errors.
 }
 
 // NewTooManyMatchingShaError creates a new TooManyMatchingShaError. This error indicates that there are too many
@@ -605,40 +708,80 @@ func (e *TooManyMatchingShaError) Error() string {
 	return msg.String()
 }
 
-func (r *ChangeTransferPolicyReconciler) setCommitMetadata(ctx context.Context, ctp *promoterv1alpha1.ChangeTransferPolicy, gitOperations *git.EnvironmentOperations, activeHydratedSha, proposedHydratedSha string) error {
+func (r *ChangeTransferPolicyReconciler) setCommitMetadata(ctx context.Context, ctp *promoterv1alpha1.ChangeTransferPolicy, gitOperations *git.EnvironmentOperations, activeHydratedSha, proposedHydratedSha string, concurrency int) error {
 	logger := log.FromContext(ctx)
 
-	activeCommitMetadata, err := gitOperations.GetShaMetadataFromFile(ctx, activeHydratedSha)
-	if err != nil {
-		return fmt.Errorf("failed to get commit metadata for hydrated SHA %q: %w", activeHydratedSha, err)
-	}
-	ctp.Status.Active.Dry = activeCommitMetadata
-
-	proposedCommitMetadata, err := gitOperations.GetShaMetadataFromFile(ctx, proposedHydratedSha)
+	var activeDry, proposedDry, activeHydrated, proposedHydrated promoterv1alpha1.CommitShaState
+	var proposedNote git.HydratorMetadata
+	var proposedChanges *promoterv1alpha1.ChangeSummary
+	gitRepository := types.NamespacedName{Namespace: ctp.Namespace, Name: ctp.Spec.RepositoryReference.Name}
+	err := runStatusPhase(ctx, gitRepository, metrics.ChangeTransferPolicyStatusPhaseMetadata, concurrency,
+		func(ctx context.Context) error {
+			var err error
+			activeDry, err = gitOperations.GetShaMetadataFromFile(ctx, activeHydratedSha)
+			if err != nil {
+				return fmt.Errorf("failed to get commit metadata for hydrated SHA %q: %w", activeHydratedSha, err)
+			}
+			return nil
+		},
+		func(ctx context.Context) error {
+			var err error
+			proposedDry, err = gitOperations.GetShaMetadataFromFile(ctx, proposedHydratedSha)
+			if err != nil {
+				return fmt.Errorf("failed to get commit metadata for hydrated SHA %q: %w", proposedHydratedSha, err)
+			}
+			return nil
+		},
+		func(ctx context.Context) error {
+			var err error
+			activeHydrated, err = gitOperations.GetShaMetadataFromGit(ctx, activeHydratedSha)
+			if err != nil {
+				return fmt.Errorf("failed to get commit active metadata for hydrated SHA %q: %w", activeHydratedSha, err)
+			}
+			return nil
+		},
+		func(ctx context.Context) error {
+			var err error
+			proposedHydrated, err = gitOperations.GetShaMetadataFromGit(ctx, proposedHydratedSha)
+			if err != nil {
+				return fmt.Errorf("failed to get commit proposed metadata for hydrated SHA %q: %w", proposedHydratedSha, err)
+			}
+			return nil
+		},
+		func(ctx context.Context) error {
+			// Read the git note for the proposed hydrated commit to get the Note.DrySha.
+			// This is used by downstream environments to verify that hydration is complete
+			// for a given dry commit before allowing promotion.
+			var err error
+			proposedNote, err = gitOperations.GetHydratorNote(ctx, proposedHydratedSha)
+			if err != nil {
+				return fmt.Errorf("failed to get hydrator note for proposed hydrated SHA %q: %w", proposedHydratedSha, err)
+			}
+			return nil
+		},
+		func(ctx context.Context) error {
+			// The change summary is informational, so failing to compute it does not fail the reconcile.
+			if activeHydratedSha == proposedHydratedSha {
+				return nil
+			}
+			var err error
+			proposedChanges, err = gitOperations.GetChangeSummary(ctx, activeHydratedSha, proposedHydratedSha)
+			if err != nil {
+				logger.V(4).Info("failed to get change summary for proposed hydrated SHA", "proposedHydratedSha", proposedHydratedSha, "error", err)
+			}
+			return nil
+		},
+	)
 	if err != nil {
-		return fmt.Errorf("failed to get commit metadata for hydrated SHA %q: %w", activeHydratedSha, err)
+		return err
 	}
-	ctp.Status.Proposed.Dry = proposedCommitMetadata
 
-	activeCommitMetadata, err = gitOperations.GetShaMetadataFromGit(ctx, activeHydratedSha)
-	if err != nil {
-		return fmt.Errorf("failed to get commit active metadata for hydrated SHA %q: %w", activeHydratedSha, err)
-	}
-	ctp.Status.Active.Hydrated = activeCommitMetadata
+	ctp.Status.Active.Dry = activeDry
+	ctp.Status.Proposed.Dry = proposedDry
+	ctp.Status.Active.Hydrated = activeHydrated
 	ctp.Status.Active.Hydrated.Body = removeKnownTrailers(ctp.Status.Active.Hydrated.Body)
-	proposedCommitMetadata, err = gitOperations.GetShaMetadataFromGit(ctx, proposedHydratedSha)
-	if err != nil {
-		return fmt.Errorf("failed to get commit proposed metadata for hydrated SHA %q: %w", proposedHydratedSha, err)
-	}
-	ctp.Status.Proposed.Hydrated = proposedCommitMetadata
-
-	// Read the git note for the proposed hydrated commit to get the Note.DrySha.
-	// This is used by downstream environments to verify that hydration is complete
-	// for a given dry commit before allowing promotion.
-	proposedNote, err := gitOperations.GetHydratorNote(ctx, proposedHydratedSha)
-	if err != nil {
-		return fmt.Errorf("failed to get hydrator note for proposed hydrated SHA %q: %w", proposedHydratedSha, err)
-	}
+	ctp.Status.Proposed.Hydrated = proposedHydrated
+	ctp.Status.Proposed.Changes = proposedChanges
 	ctp.Status.Proposed.Note = &promoterv1alpha1.HydratorMetadata{
 		DrySha: proposedNote.DrySha,
 	}
@@ -937,8 +1080,16 @@ func (r *ChangeTransferPolicyReconciler) creatOrUpdatePullRequest(ctx context.Co
 		prName = utils.GetPullRequestName(gitRepo.Spec.Fake.Owner, gitRepo.Spec.Fake.Name, ctp.Spec.ProposedBranch, ctp.Spec.ActiveBranch)
 	case gitRepo.Spec.BitbucketCloud != nil:
 		prName = utils.GetPullRequestName(gitRepo.Spec.BitbucketCloud.Owner, gitRepo.Spec.BitbucketCloud.Name, ctp.Spec.ProposedBranch, ctp.Spec.ActiveBranch)
+	case gitRepo.Spec.BitbucketServer != nil:
+		prName = utils.GetPullRequestName(gitRepo.Spec.BitbucketServer.Project, gitRepo.Spec.BitbucketServer.Name, ctp.Spec.ProposedBranch, ctp.Spec.ActiveBranch)
 	case gitRepo.Spec.AzureDevOps != nil:
 		prName = utils.GetPullRequestName(gitRepo.Spec.AzureDevOps.Project, gitRepo.Spec.AzureDevOps.Name, ctp.Spec.ProposedBranch, ctp.Spec.ActiveBranch)
+	case gitRepo.Spec.Gerrit != nil:
+		owner, name := path.Split(gitRepo.Spec.Gerrit.Project)
+		prName = utils.GetPullRequestName(strings.Trim(owner, "/"), name, ctp.Spec.ProposedBranch, ctp.Spec.ActiveBranch)
+	case gitRepo.Spec.Generic != nil:
+		owner, name := path.Split(strings.TrimSuffix(strings.TrimSuffix(gitRepo.Spec.Generic.Path, "/"), ".git"))
+		prName = utils.GetPullRequestName(strings.Trim(owner, "/"), name, ctp.Spec.ProposedBranch, ctp.Spec.ActiveBranch)
 	default:
 		return nil, errors.New("unsupported git repository type")
 	}
@@ -964,6 +1115,9 @@ func (r *ChangeTransferPolicyReconciler) creatOrUpdatePullRequest(ctx context.Co
 	if err != nil {
 		return nil, fmt.Errorf("failed to template pull request: %w", err)
 	}
+	if conflictSection := conflictDescription(ctp); conflictSection != "" {
+		description = strings.TrimRight(description, "\n") + "\n\n" + conflictSection
+	}
 
 	// Check if the PR already exists to determine the commit message
 	existingPR := &promoterv1alpha1.PullRequest{}
@@ -1039,6 +1193,31 @@ func (r *ChangeTransferPolicyReconciler) creatOrUpdatePullRequest(ctx context.Co
 			WithCommit(acv1alpha1.CommitConfiguration().WithMessage(commitMessage)).
 			WithMergeSha(ctp.Status.Proposed.Hydrated.Sha).
 			WithState(prState))
+	if ctp.Spec.PullRequest != nil {
+		options, err := TemplatePullRequestOptions(*ctp.Spec.PullRequest, templateData)
+		if err != nil {
+			return nil, fmt.Errorf("failed to template pull request options: %w", err)
+		}
+		if len(options.Reviewers) > 0 {
+			prApply.Spec.WithReviewers(options.Reviewers...)
+		}
+		if len(options.RequiredReviewers) > 0 {
+			prApply.Spec.WithRequiredReviewers(options.RequiredReviewers...)
+		}
+		if len(options.Assignees) > 0 {
+			prApply.Spec.WithAssignees(options.Assignees...)
+		}
+		if len(options.Labels) > 0 {
+			prApply.Spec.WithLabels(options.Labels...)
+		}
+		// Draft pull requests are marked ready for review once the promotion gates pass.
+		if ptr.Deref(ctp.Spec.PullRequest.Draft, false) && !proposedCommitStatusesSucceeded(ctp) {
+			prApply.Spec.WithDraft(true)
+		}
+	}
+	if len(ctp.Status.Proposed.CommitStatuses) > 0 {
+		prApply.Spec.WithCommitStatuses(commitStatusPhases(ctp.Status.Proposed.CommitStatuses)...)
+	}
 
 	// Apply using Server-Side Apply with Patch to get the result directly
 	pr := &promoterv1alpha1.PullRequest{}
@@ -1117,6 +1296,13 @@ func (r *ChangeTransferPolicyReconciler) mergePullRequests(ctx context.Context,
 		return &pullRequest, nil
 	}
 
+	if pullRequest.Spec.Draft || pullRequest.Status.Draft {
+		// The pull request is marked ready for review before it is merged, since SCMs refuse to merge drafts.
+		logger.Info("Pull request is still a draft, waiting for it to be marked ready for review", "pr", pullRequest.Name)
+
+		return &pullRequest, nil
+	}
+
 	if pullRequest.Status.ID == "" {
 		// We could rely on XValidation to catch the missing ID when setting the PR to merged, but this gives a
 		// better error message.
@@ -1157,6 +1343,21 @@ func (r *ChangeTransferPolicyReconciler) mergePullRequests(ctx context.Context,
 	if pullRequest.Spec.Commit.Message != "" {
 		prSpec = prSpec.WithCommit(acv1alpha1.CommitConfiguration().WithMessage(pullRequest.Spec.Commit.Message))
 	}
+	if len(pullRequest.Spec.Reviewers) > 0 {
+		prSpec = prSpec.WithReviewers(pullRequest.Spec.Reviewers...)
+	}
+	if len(pullRequest.Spec.RequiredReviewers) > 0 {
+		prSpec = prSpec.WithRequiredReviewers(pullRequest.Spec.RequiredReviewers...)
+	}
+	if len(pullRequest.Spec.Assignees) > 0 {
+		prSpec = prSpec.WithAssignees(pullRequest.Spec.Assignees...)
+	}
+	if len(pullRequest.Spec.Labels) > 0 {
+		prSpec = prSpec.WithLabels(pullRequest.Spec.Labels...)
+	}
+	if len(pullRequest.Spec.CommitStatuses) > 0 {
+		prSpec = prSpec.WithCommitStatuses(commitStatusPhases(pullRequest.Spec.CommitStatuses)...)
+	}
 
 	prApply := acv1alpha1.PullRequest(pullRequest.Name, pullRequest.Namespace).
 		WithLabels(pullRequest.Labels).
@@ -1176,35 +1377,142 @@ func (r *ChangeTransferPolicyReconciler) mergePullRequests(ctx context.Context,
 	return pr, nil
 }
 
-// gitMergeStrategyOurs tests if there is a conflict between the active and proposed branches. If there is, we
-// perform a merge with ours as the strategy. This is to prevent conflicts in the pull request by assuming that
-// the proposed branch is the source of truth.
-func (r *ChangeTransferPolicyReconciler) gitMergeStrategyOurs(ctx context.Context, gitOperations *git.EnvironmentOperations, ctp *promoterv1alpha1.ChangeTransferPolicy) error {
+// maxConflictPaths is the maximum number of conflicting paths recorded in the ChangeTransferPolicy status and in the
+// pull request description. Large conflicts would otherwise bloat the resource and the PR body.
+const maxConflictPaths = 50
+
+// resolveConflicts tests if there is a conflict between the active and proposed branches and handles it according to
+// the ChangeTransferPolicy's conflictResolution setting. With "ours", we perform a merge with ours as the strategy,
+// assuming that the proposed branch is the source of truth. With "fail" and "rebuild", nothing is pushed; the conflict
+// is recorded in the status so that users (or the hydrator, for "rebuild") can act on it. The returned bool is true
+// when a conflict exists that was left unresolved.
+func (r *ChangeTransferPolicyReconciler) resolveConflicts(ctx context.Context, gitOperations *git.EnvironmentOperations, ctp *promoterv1alpha1.ChangeTransferPolicy) (bool, error) {
 	logger := log.FromContext(ctx)
+
+	if ctp.Status.Proposed.Dry.Sha == ctp.Status.Active.Dry.Sha {
+		// Nothing to promote, so there is nothing that could conflict.
+		ctp.Status.Conflict = nil
+		return false, nil
+	}
+
 	logger.Info("Testing for conflicts between branches", "proposed", ctp.Spec.ProposedBranch, "active", ctp.Spec.ActiveBranch)
 
-	// Check if there's a conflict between branches
-	hasConflict, err := gitOperations.HasConflict(ctx, ctp.Spec.ProposedBranch, ctp.Spec.ActiveBranch)
+	paths, err := gitOperations.GetConflictingPaths(ctx, ctp.Spec.ProposedBranch, ctp.Spec.ActiveBranch)
 	if err != nil {
-		return fmt.Errorf("failed to check for conflicts between branches %q and %q: %w", ctp.Spec.ProposedBranch, ctp.Spec.ActiveBranch, err)
+		return false, fmt.Errorf("failed to check for conflicts between branches %q and %q: %w", ctp.Spec.ProposedBranch, ctp.Spec.ActiveBranch, err)
 	}
 
-	if !hasConflict {
+	if len(paths) == 0 {
 		logger.V(4).Info("No conflicts detected between branches", "proposed", ctp.Spec.ProposedBranch, "active", ctp.Spec.ActiveBranch)
-		return nil // No conflict, nothing to do
+		// Keep a conflict that was resolved with "ours" for the same dry sha, so the PR description keeps listing the
+		// paths that were overwritten. Any other recorded conflict has been resolved (e.g. by re-hydrating).
+		conflict := ctp.Status.Conflict
+		if conflict != nil && (conflict.DrySha != ctp.Status.Proposed.Dry.Sha || conflict.Resolution != promoterv1alpha1.ConflictResolutionOurs) {
+			ctp.Status.Conflict = nil
+		}
+		return false, nil
+	}
+
+	resolution := ctp.Spec.GetConflictResolution()
+	conflict := &promoterv1alpha1.ConflictStatus{
+		DrySha:       ctp.Status.Proposed.Dry.Sha,
+		Resolution:   resolution,
+		Paths:        paths[:min(len(paths), maxConflictPaths)],
+		PathCount:    len(paths),
+		DetectedTime: metav1.Now(),
+	}
+	previous := ctp.Status.Conflict
+	if previous != nil && previous.DrySha == conflict.DrySha {
+		conflict.DetectedTime = previous.DetectedTime
+	}
+	ctp.Status.Conflict = conflict
+
+	if resolution != promoterv1alpha1.ConflictResolutionOurs {
+		logger.Info("Conflicts detected, leaving branches untouched", "proposed", ctp.Spec.ProposedBranch, "active", ctp.Spec.ActiveBranch, "conflictResolution", resolution, "paths", len(paths))
+		// Only record an event when the conflict is first detected or its reason changes, not on every reconcile.
+		if previous == nil || previous.DrySha != conflict.DrySha || previous.Resolution != resolution {
+			if resolution == promoterv1alpha1.ConflictResolutionRebuild {
+				r.Recorder.Eventf(ctp, nil, "Warning", constants.RehydrationRequestedReason, "DetectingConflict", constants.RehydrationRequestedMessage,
+					ctp.Spec.ProposedBranch, ctp.Spec.ActiveBranch, conflict.PathCount, conflict.DrySha)
+			} else {
+				r.Recorder.Eventf(ctp, nil, "Warning", constants.MergeConflictReason, "DetectingConflict", constants.MergeConflictMessage,
+					ctp.Spec.ProposedBranch, ctp.Spec.ActiveBranch, conflict.PathCount)
+			}
+		}
+		return true, nil
 	}
 
 	// If we have a conflict, perform a merge with "ours" strategy
 	logger.Info("Conflicts detected, performing merge with 'ours' strategy", "proposed", ctp.Spec.ProposedBranch, "active", ctp.Spec.ActiveBranch)
 
-	err = gitOperations.MergeWithOursStrategy(ctx, ctp.Spec.ProposedBranch, ctp.Spec.ActiveBranch)
+	err = gitOperations.MergeWithOursStrategy(ctx, ctp.Spec.ProposedBranch, ctp.Spec.ActiveBranch, conflict.Paths)
 	if err != nil {
-		return fmt.Errorf("failed to merge branches %q and %q with 'ours' strategy: %w", ctp.Spec.ProposedBranch, ctp.Spec.ActiveBranch, err)
+		return false, fmt.Errorf("failed to merge branches %q and %q with 'ours' strategy: %w", ctp.Spec.ProposedBranch, ctp.Spec.ActiveBranch, err)
 	}
 
 	r.Recorder.Eventf(ctp, nil, "Normal", constants.ResolvedConflictReason, "ResolvingConflict", constants.ResolvedConflictMessage, ctp.Spec.ProposedBranch, ctp.Spec.ActiveBranch)
 
-	return nil
+	return false, nil
+}
+
+// setConflictCondition sets the Conflicted condition for a conflict that was left unresolved by resolveConflicts. The
+// Ready condition is set to False for the conflict as well, unless it was already set from the pull request, so that
+// the reason the pull request is not ready is kept.
+func setConflictCondition(ctp *promoterv1alpha1.ChangeTransferPolicy) {
+	conflict := ctp.Status.Conflict
+	reason := promoterConditions.MergeConflict
+	message := fmt.Sprintf("Branch %q conflicts with %q in %d path(s): %s", ctp.Spec.ProposedBranch, ctp.Spec.ActiveBranch, conflict.PathCount, strings.Join(conflict.Paths, ", "))
+	if conflict.Resolution == promoterv1alpha1.ConflictResolutionRebuild {
+		reason = promoterConditions.RehydrationRequested
+		message = fmt.Sprintf("Branch %q conflicts with %q in %d path(s), waiting for the hydrator to re-hydrate dry commit %q on top of %q",
+			ctp.Spec.ProposedBranch, ctp.Spec.ActiveBranch, conflict.PathCount, conflict.DrySha, ctp.Spec.ActiveBranch)
+	}
+
+	meta.SetStatusCondition(ctp.GetConditions(), metav1.Condition{
+		Type:               string(promoterConditions.Conflicted),
+		Status:             metav1.ConditionTrue,
+		Reason:             string(reason),
+		Message:            message,
+		ObservedGeneration: ctp.GetGeneration(),
+	})
+
+	if meta.FindStatusCondition(*ctp.GetConditions(), string(promoterConditions.Ready)) != nil {
+		return
+	}
+	meta.SetStatusCondition(ctp.GetConditions(), metav1.Condition{
+		Type:               string(promoterConditions.Ready),
+		Status:             metav1.ConditionFalse,
+		Reason:             string(reason),
+		Message:            message,
+		ObservedGeneration: ctp.GetGeneration(),
+	})
+}
+
+// conflictDescription returns a markdown section listing the conflicting paths recorded for the current proposed dry
+// sha, or an empty string if there is no such conflict.
+func conflictDescription(ctp *promoterv1alpha1.ChangeTransferPolicy) string {
+	conflict := ctp.Status.Conflict
+	if conflict == nil || conflict.DrySha != ctp.Status.Proposed.Dry.Sha || len(conflict.Paths) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("### Conflicting paths\n\n")
+	switch conflict.Resolution {
+	case promoterv1alpha1.ConflictResolutionFail:
+		fmt.Fprintf(&b, "`%s` conflicts with `%s`. Resolve the conflict manually before merging.\n\n", ctp.Spec.ProposedBranch, ctp.Spec.ActiveBranch)
+	case promoterv1alpha1.ConflictResolutionRebuild:
+		fmt.Fprintf(&b, "`%s` conflicts with `%s`. Waiting for the hydrator to re-hydrate on top of `%s`.\n\n", ctp.Spec.ProposedBranch, ctp.Spec.ActiveBranch, ctp.Spec.ActiveBranch)
+	default:
+		fmt.Fprintf(&b, "`%s` was merged into `%s` with the `ours` strategy. Changes to these paths on `%s` are overwritten by this promotion.\n\n", ctp.Spec.ActiveBranch, ctp.Spec.ProposedBranch, ctp.Spec.ActiveBranch)
+	}
+	for _, p := range conflict.Paths {
+		fmt.Fprintf(&b, "- `%s`\n", p)
+	}
+	if conflict.PathCount > len(conflict.Paths) {
+		fmt.Fprintf(&b, "- ...and %d more\n", conflict.PathCount-len(conflict.Paths))
+	}
+	return b.String()
 }
 
 // TemplatePullRequest renders the title and description of a pull request using the provided data map.
@@ -1222,6 +1530,61 @@ func TemplatePullRequest(prt promoterv1alpha1.PullRequestTemplate, data map[stri
 	return title, description, nil
 }
 
+// proposedCommitStatusesSucceeded returns whether all proposed commit statuses of the ChangeTransferPolicy, including
+// the previous environment's gate, are successful.
+func proposedCommitStatusesSucceeded(ctp *promoterv1alpha1.ChangeTransferPolicy) bool {
+	for _, status := range ctp.Status.Proposed.CommitStatuses {
+		if status.Phase != string(promoterv1alpha1.CommitPhaseSuccess) {
+			return false
+		}
+	}
+	return true
+}
+
+// commitStatusPhases returns apply configurations for the commit status phases, to copy them to a PullRequest.
+func commitStatusPhases(statuses []promoterv1alpha1.ChangeRequestPolicyCommitStatusPhase) []*acv1alpha1.ChangeRequestPolicyCommitStatusPhaseApplyConfiguration {
+	phases := make([]*acv1alpha1.ChangeRequestPolicyCommitStatusPhaseApplyConfiguration, 0, len(statuses))
+	for _, status := range statuses {
+		phase := acv1alpha1.ChangeRequestPolicyCommitStatusPhase().WithKey(status.Key).WithPhase(status.Phase)
+		if status.Url != "" {
+			phase.WithUrl(status.Url)
+		}
+		if status.Description != "" {
+			phase.WithDescription(status.Description)
+		}
+		phases = append(phases, phase)
+	}
+	return phases
+}
+
+// TemplatePullRequestOptions renders each reviewer, assignee and label of the pull request options using the provided
+// data map. Entries that render to an empty string are dropped, as are duplicates.
+func TemplatePullRequestOptions(options promoterv1alpha1.PullRequestOptions, data map[string]any) (promoterv1alpha1.PullRequestOptions, error) {
+	var rendered promoterv1alpha1.PullRequestOptions
+	for _, field := range []struct {
+		name   string
+		values []string
+		result *[]string
+	}{
+		{"reviewer", options.Reviewers, &rendered.Reviewers},
+		{"required reviewer", options.RequiredReviewers, &rendered.RequiredReviewers},
+		{"assignee", options.Assignees, &rendered.Assignees},
+		{"label", options.Labels, &rendered.Labels},
+	} {
+		for _, value := range field.values {
+			result, err := utils.RenderStringTemplate(value, data)
+			if err != nil {
+				return promoterv1alpha1.PullRequestOptions{}, fmt.Errorf("failed to render pull request %s template %q: %w", field.name, value, err)
+			}
+			result = strings.TrimSpace(result)
+			if result != "" && !slices.Contains(*field.result, result) {
+				*field.result = append(*field.result, result)
+			}
+		}
+	}
+	return rendered, nil
+}
+
 // boolPtrEqual compares two *bool pointers for equality.
 // Returns true if both are nil, or if both are non-nil and point to equal values.
 func boolPtrEqual(a, b *bool) bool {
@@ -19,11 +19,15 @@ package controller
 import (
 	"context"
 	_ "embed"
+	stderrors "errors"
 	"fmt"
 	"os"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/metrics"
 	"github.com/argoproj-labs/gitops-promoter/internal/types/constants"
 	"github.com/argoproj-labs/gitops-promoter/internal/utils"
 	. "github.com/onsi/ginkgo/v2"
@@ -33,6 +37,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 )
 
 //go:embed testdata/ChangeTransferPolicy.yaml
@@ -729,6 +734,35 @@ var _ = Describe("TemplatePullRequest", func() {
 	})
 })
 
+var _ = Describe("TemplatePullRequestOptions", func() {
+	It("renders each entry and drops empty and duplicate results", func() {
+		ctp := &promoterv1alpha1.ChangeTransferPolicy{
+			Spec: promoterv1alpha1.ChangeTransferPolicySpec{ActiveBranch: testBranchDevelopment},
+		}
+		ps := &promoterv1alpha1.PromotionStrategy{ObjectMeta: metav1.ObjectMeta{Name: "checkout"}}
+		options := promoterv1alpha1.PullRequestOptions{
+			Reviewers:         []string{"example-org/{{ .PromotionStrategy.Name }}-owners", "example-org/checkout-owners"},
+			RequiredReviewers: []string{"jane@example.com"},
+			Assignees:         []string{`{{ if eq .ChangeTransferPolicy.Spec.ActiveBranch "environment/production" }}oncall{{ end }}`},
+			Labels:            []string{"promotion", "env/{{ .ChangeTransferPolicy.Spec.ActiveBranch }}"},
+		}
+		data := map[string]any{"ChangeTransferPolicy": ctp, "PromotionStrategy": ps}
+
+		rendered, err := TemplatePullRequestOptions(options, data)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rendered).To(Equal(promoterv1alpha1.PullRequestOptions{
+			Reviewers:         []string{"example-org/checkout-owners"},
+			RequiredReviewers: []string{"jane@example.com"},
+			Labels:            []string{"promotion", "env/" + testBranchDevelopment},
+		}))
+	})
+
+	It("returns an error for an invalid template", func() {
+		_, err := TemplatePullRequestOptions(promoterv1alpha1.PullRequestOptions{Labels: []string{"{{ .Missing"}}, map[string]any{})
+		Expect(err).To(MatchError(ContainSubstring(`failed to render pull request label template "{{ .Missing"`)))
+	})
+})
+
 var _ = Describe("tooManyPRsError", func() {
 	Context("When formatting tooManyPRsError", func() {
 		It("returns an error listing all PR names if 3 or fewer", func() {
@@ -763,6 +797,78 @@ var _ = Describe("tooManyPRsError", func() {
 	})
 })
 
+var _ = Describe("runStatusPhase", func() {
+	gitRepository := types.NamespacedName{Namespace: "status-phase", Name: "deployments"}
+
+	// phaseCount returns how often the phase was recorded for the GitRepository with the given result.
+	phaseCount := func(phase metrics.ChangeTransferPolicyStatusPhase, result metrics.GitOperationResult) uint64 {
+		families, err := ctrlmetrics.Registry.Gather()
+		Expect(err).NotTo(HaveOccurred())
+		for _, family := range families {
+			if family.GetName() != "change_transfer_policy_status_phase_duration_seconds" {
+				continue
+			}
+			for _, metric := range family.GetMetric() {
+				labels := map[string]string{}
+				for _, label := range metric.GetLabel() {
+					labels[label.GetName()] = label.GetValue()
+				}
+				if labels["git_repository"] == "status-phase/deployments" && labels["phase"] == string(phase) && labels["result"] == string(result) {
+					return metric.GetHistogram().GetSampleCount()
+				}
+			}
+		}
+		return 0
+	}
+
+	It("runs at most limit tasks at a time", func() {
+		var running, maxRunning atomic.Int32
+		task := func(ctx context.Context) error {
+			n := running.Add(1)
+			defer running.Add(-1)
+			for {
+				current := maxRunning.Load()
+				if n <= current || maxRunning.CompareAndSwap(current, n) {
+					break
+				}
+			}
+			time.Sleep(20 * time.Millisecond)
+			return nil
+		}
+
+		before := phaseCount(metrics.ChangeTransferPolicyStatusPhaseMetadata, metrics.GitOperationResultSuccess)
+		err := runStatusPhase(context.Background(), gitRepository, metrics.ChangeTransferPolicyStatusPhaseMetadata, 2,
+			task, task, task, task, task)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(maxRunning.Load()).To(Equal(int32(2)))
+		Expect(phaseCount(metrics.ChangeTransferPolicyStatusPhaseMetadata, metrics.GitOperationResultSuccess)).To(Equal(before + 1))
+	})
+
+	It("cancels the other tasks and returns the first error", func() {
+		errFirst := stderrors.New("first")
+		var canceled atomic.Int32
+		waitForCancel := func(ctx context.Context) error {
+			select {
+			case <-ctx.Done():
+				canceled.Add(1)
+				return fmt.Errorf("canceled: %w", ctx.Err())
+			case <-time.After(10 * time.Second):
+				return nil
+			}
+		}
+
+		before := phaseCount(metrics.ChangeTransferPolicyStatusPhaseCommitStatuses, metrics.GitOperationResultFailure)
+		err := runStatusPhase(context.Background(), gitRepository, metrics.ChangeTransferPolicyStatusPhaseCommitStatuses, 3,
+			waitForCancel,
+			func(context.Context) error { return errFirst },
+			waitForCancel,
+		)
+		Expect(err).To(MatchError(errFirst))
+		Expect(canceled.Load()).To(Equal(int32(2)))
+		Expect(phaseCount(metrics.ChangeTransferPolicyStatusPhaseCommitStatuses, metrics.GitOperationResultFailure)).To(Equal(before + 1))
+	})
+})
+
 //nolint:unparam // namespace is always "default" in tests but kept for consistency with other test helpers
 func changeTransferPolicyResources(ctx context.Context, name, namespace string) (string, *v1.Secret, *promoterv1alpha1.ScmProvider, *promoterv1alpha1.GitRepository, *promoterv1alpha1.CommitStatus, *promoterv1alpha1.ChangeTransferPolicy) {
 	name = name + "-" + utils.KubeSafeUniqueName(ctx, randomString(15))
@@ -29,6 +29,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
 	"github.com/argoproj-labs/gitops-promoter/internal/settings"
@@ -76,13 +77,15 @@ func (r *ClusterScmProviderReconciler) Reconcile(ctx context.Context, req ctrl.R
 		return ctrl.Result{}, fmt.Errorf("failed to ensure Secret finalizer: %w", err)
 	}
 
-	return ctrl.Result{}, nil
+	// Requeue when the throttling is expected to end, so that the condition is cleared.
+	return ctrl.Result{RequeueAfter: setThrottledCondition(&clusterScmProvider, &clusterScmProvider.Status.Conditions, r.SettingsMgr.GetControllerNamespace())}, nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *ClusterScmProviderReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
 	err := ctrl.NewControllerManagedBy(mgr).
 		For(&promoterv1alpha1.ClusterScmProvider{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		WatchesRawSource(throttledSecretSource(r.clusterScmProvidersForSecret)).
 		Named("clusterscmprovider").
 		Complete(r)
 	if err != nil {
@@ -92,6 +95,27 @@ func (r *ClusterScmProviderReconciler) SetupWithManager(ctx context.Context, mgr
 	return nil
 }
 
+// clusterScmProvidersForSecret returns reconcile requests for the ClusterScmProviders that use the secret.
+func (r *ClusterScmProviderReconciler) clusterScmProvidersForSecret(ctx context.Context, secret client.Object) []reconcile.Request {
+	if secret.GetNamespace() != r.SettingsMgr.GetControllerNamespace() {
+		return nil
+	}
+
+	var clusterScmProviders promoterv1alpha1.ClusterScmProviderList
+	if err := r.List(ctx, &clusterScmProviders); err != nil {
+		log.FromContext(ctx).Error(err, "failed to list ClusterScmProviders for throttled secret", "secret", secret.GetName())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, clusterScmProvider := range clusterScmProviders.Items {
+		if clusterScmProvider.Spec.SecretRef != nil && clusterScmProvider.Spec.SecretRef.Name == secret.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&clusterScmProvider)})
+		}
+	}
+	return requests
+}
+
 func (r *ClusterScmProviderReconciler) handleFinalizer(ctx context.Context, clusterScmProvider *promoterv1alpha1.ClusterScmProvider) (bool, error) {
 	// Check for dependent GitRepositories across all namespaces before allowing deletion
 	checkDependencies := func() ([]string, error) {
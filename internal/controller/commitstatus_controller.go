@@ -25,8 +25,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	bitbucket_cloud "github.com/argoproj-labs/gitops-promoter/internal/scms/bitbucket_cloud"
+	bitbucket_server "github.com/argoproj-labs/gitops-promoter/internal/scms/bitbucket_server"
 	"github.com/argoproj-labs/gitops-promoter/internal/scms/fake"
 	"github.com/argoproj-labs/gitops-promoter/internal/scms/forgejo"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms/generic"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms/gerrit"
 	"github.com/argoproj-labs/gitops-promoter/internal/scms/gitea"
 	"github.com/argoproj-labs/gitops-promoter/internal/settings"
 
@@ -38,8 +41,10 @@ import (
 	promoterConditions "github.com/argoproj-labs/gitops-promoter/internal/types/conditions"
 	"github.com/argoproj-labs/gitops-promoter/internal/types/constants"
 	"github.com/argoproj-labs/gitops-promoter/internal/utils"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/events"
@@ -136,59 +141,88 @@ func (r *CommitStatusReconciler) SetupWithManager(ctx context.Context, mgr ctrl.
 }
 
 func (r *CommitStatusReconciler) getCommitStatusProvider(ctx context.Context, commitStatus promoterv1alpha1.CommitStatus) (scms.CommitStatusProvider, error) {
-	scmProvider, secret, err := utils.GetScmProviderAndSecretFromRepositoryReference(ctx, r.Client, r.SettingsMgr.GetControllerNamespace(), commitStatus.Spec.RepositoryReference, &commitStatus)
+	p, _, err := getCommitStatusProviderForRepository(ctx, r.Client, r.SettingsMgr, commitStatus.Spec.RepositoryReference, &commitStatus)
+	return p, err
+}
+
+// getCommitStatusProviderForRepository returns the commit status provider of the SCM that hosts the referenced
+// repository, along with the GitRepository. obj is the object referencing the repository, and determines its namespace.
+func getCommitStatusProviderForRepository(ctx context.Context, k8sClient client.Client, settingsMgr *settings.Manager, repositoryRef promoterv1alpha1.ObjectReference, obj metav1.Object) (scms.CommitStatusProvider, *promoterv1alpha1.GitRepository, error) {
+	scmProvider, secret, gitRepo, err := utils.GetScmProviderSecretAndGitRepositoryFromRepositoryReference(ctx, k8sClient, settingsMgr.GetControllerNamespace(), repositoryRef, obj)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get ScmProvider and secret for repo %q: %w", commitStatus.Spec.RepositoryReference.Name, err)
+		return nil, nil, fmt.Errorf("failed to get ScmProvider and secret for repo %q: %w", repositoryRef.Name, err)
 	}
 
-	gitRepo, err := utils.GetGitRepositoryFromObjectKey(ctx, r.Client, client.ObjectKey{Namespace: commitStatus.Namespace, Name: commitStatus.Spec.RepositoryReference.Name})
+	p, err := newCommitStatusProvider(ctx, k8sClient, scmProvider, secret, gitRepo)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get GitRepository for repo %q: %w", commitStatus.Spec.RepositoryReference.Name, err)
+		return nil, nil, err
 	}
+	return p, gitRepo, nil
+}
 
+// newCommitStatusProvider creates the commit status provider for the given ScmProvider. It returns nil if the
+// ScmProvider does not configure a known SCM.
+func newCommitStatusProvider(ctx context.Context, k8sClient client.Client, scmProvider promoterv1alpha1.GenericScmProvider, secret *corev1.Secret, gitRepo *promoterv1alpha1.GitRepository) (scms.CommitStatusProvider, error) {
+	var err error
 	switch {
 	case scmProvider.GetSpec().GitHub != nil:
 		var p *github.CommitStatus
-		p, err = github.NewGithubCommitStatusProvider(ctx, r.Client, scmProvider, *secret, gitRepo.Spec.GitHub.Owner)
+		p, err = github.NewGithubCommitStatusProvider(ctx, k8sClient, scmProvider, *secret, gitRepo.Spec.GitHub.Owner)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get GitHub provider for domain %q with secret %q: %w", scmProvider.GetSpec().GitHub.Domain, secret.Name, err)
 		}
 		return p, nil
 	case scmProvider.GetSpec().GitLab != nil:
 		var p *gitlab.CommitStatus
-		p, err = gitlab.NewGitlabCommitStatusProvider(r.Client, *secret, scmProvider.GetSpec().GitLab.Domain)
+		p, err = gitlab.NewGitlabCommitStatusProvider(k8sClient, *secret, scmProvider.GetSpec().GitLab.Domain)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get GitLab provider for domain %q with secret %q: %w", scmProvider.GetSpec().GitLab.Domain, secret.Name, err)
 		}
 		return p, nil
 	case scmProvider.GetSpec().BitbucketCloud != nil:
 		var p *bitbucket_cloud.CommitStatus
-		p, err = bitbucket_cloud.NewBitbucketCloudCommitStatusProvider(r.Client, *secret)
+		p, err = bitbucket_cloud.NewBitbucketCloudCommitStatusProvider(k8sClient, *secret)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get Bitbucket Cloud provider with secret %q: %w", secret.Name, err)
 		}
 		return p, nil
+	case scmProvider.GetSpec().BitbucketServer != nil:
+		var p *bitbucket_server.CommitStatus
+		p, err = bitbucket_server.NewBitbucketServerCommitStatusProvider(k8sClient, *secret, scmProvider.GetSpec().BitbucketServer.Domain)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get Bitbucket Data Center provider for domain %q with secret %q: %w", scmProvider.GetSpec().BitbucketServer.Domain, secret.Name, err)
+		}
+		return p, nil
 	case scmProvider.GetSpec().Forgejo != nil:
 		var p *forgejo.CommitStatus
-		p, err = forgejo.NewForgejoCommitStatusProvider(r.Client, scmProvider, *secret)
+		p, err = forgejo.NewForgejoCommitStatusProvider(k8sClient, scmProvider, *secret)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get Forgejo provider for domain %q with secret %q: %w", scmProvider.GetSpec().Forgejo.Domain, secret.Name, err)
 		}
 		return p, nil
 	case scmProvider.GetSpec().Gitea != nil:
 		var p *gitea.CommitStatus
-		p, err = gitea.NewGiteaCommitStatusProvider(r.Client, scmProvider, *secret)
+		p, err = gitea.NewGiteaCommitStatusProvider(k8sClient, scmProvider, *secret)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get Gitea provider for domain %q with secret %q: %w", scmProvider.GetSpec().Gitea.Domain, secret.Name, err)
 		}
 		return p, nil
 	case scmProvider.GetSpec().AzureDevOps != nil:
 		var p *azuredevops.CommitStatus
-		p, err = azuredevops.NewAzureDevopsCommitStatusProvider(ctx, r.Client, scmProvider, *secret, scmProvider.GetSpec().AzureDevOps.Organization)
+		p, err = azuredevops.NewAzureDevopsCommitStatusProvider(ctx, k8sClient, scmProvider, *secret, scmProvider.GetSpec().AzureDevOps.Organization)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get Azure DevOps provider for organization %q with secret %q: %w", scmProvider.GetSpec().AzureDevOps.Organization, secret.Name, err)
 		}
 		return p, nil
+	case scmProvider.GetSpec().Gerrit != nil:
+		var p *gerrit.CommitStatus
+		p, err = gerrit.NewGerritCommitStatusProvider(k8sClient, *secret, scmProvider.GetSpec().Gerrit.Domain, scmProvider.GetSpec().Gerrit.CommitStatusLabels)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get Gerrit provider for domain %q with secret %q: %w", scmProvider.GetSpec().Gerrit.Domain, secret.Name, err)
+		}
+		return p, nil
+	case scmProvider.GetSpec().Generic != nil:
+		return generic.NewGenericCommitStatusProvider(), nil
 	case scmProvider.GetSpec().Fake != nil:
 		//nolint:wrapcheck // error wrapping not needed for fake provider
 		return fake.NewFakeCommitStatusProvider(*secret)
@@ -244,6 +244,28 @@ var _ = Describe("ControllerConfiguration Controller", func() {
 								},
 							},
 						},
+						ScmCheckCommitStatus: promoterv1alpha1.ScmCheckCommitStatusConfiguration{
+							WorkQueue: promoterv1alpha1.WorkQueue{
+								RequeueDuration:         metav1.Duration{Duration: time.Minute * 5},
+								MaxConcurrentReconciles: 10,
+								RateLimiter: promoterv1alpha1.RateLimiter{
+									MaxOf: []promoterv1alpha1.RateLimiterTypes{
+										{
+											Bucket: &promoterv1alpha1.Bucket{
+												Qps:    100,
+												Bucket: 1000,
+											},
+										},
+										{
+											ExponentialFailure: &promoterv1alpha1.ExponentialFailure{
+												BaseDelay: metav1.Duration{Duration: time.Millisecond * 5},
+												MaxDelay:  metav1.Duration{Duration: time.Minute * 1},
+											},
+										},
+									},
+								},
+							},
+						},
 					},
 				}
 				Expect(k8sClient.Create(ctx, resource)).To(Succeed())
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"slices"
 	"sync"
 	"time"
 
@@ -62,12 +63,33 @@ type PromotionStrategyReconciler struct {
 	// EnqueueCTP is a function to enqueue CTP reconcile requests without modifying the CTP object.
 	EnqueueCTP CTPEnqueueFunc
 
+	// notesPushes records when the webhook receiver last reported a push to the hydrator notes ref of a GitRepository.
+	// Key is client.ObjectKey of the GitRepository, value is the time.Time of the push.
+	notesPushes sync.Map
+
 	// enqueueStates tracks rate limiting state for out-of-sync CTP enqueues.
 	// Key is client.ObjectKey of the CTP. Protected by enqueueStateMutex.
 	enqueueStates     map[client.ObjectKey]*ctpEnqueueState
 	enqueueStateMutex sync.Mutex
 }
 
+// NotesPushFunc is a function type that can be used to report a push to the hydrator notes ref of a GitRepository. It
+// is used by the webhook receiver when the SCM sends webhooks for pushes to refs other than branches.
+type NotesPushFunc func(namespace, name string)
+
+// notesPushValidity is how long after a webhook for a push to the hydrator notes ref of a GitRepository the
+// PromotionStrategy controller relies on webhooks to refresh git notes, instead of enqueueing out-of-sync CTPs.
+const notesPushValidity = time.Hour
+
+// GetNotesPushFunc returns a function that reports a push to the hydrator notes ref of a GitRepository. While the SCM
+// keeps sending webhooks for such pushes, enqueueOutOfSyncCTPs leaves refreshing git notes to the webhook receiver and
+// the CTPs' regular requeue.
+func (r *PromotionStrategyReconciler) GetNotesPushFunc() NotesPushFunc {
+	return func(namespace, name string) {
+		r.notesPushes.Store(client.ObjectKey{Namespace: namespace, Name: name}, time.Now())
+	}
+}
+
 //+kubebuilder:rbac:groups=promoter.argoproj.io,resources=promotionstrategies,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=promoter.argoproj.io,resources=promotionstrategies/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=promoter.argoproj.io,resources=promotionstrategies/finalizers,verbs=update
@@ -136,7 +158,7 @@ func (r *PromotionStrategyReconciler) Reconcile(ctx context.Context, req ctrl.Re
 	}
 
 	// Check if any environments need to refresh their git notes.
-	// SCM's do not send webhooks when git notes are pushed, so we need to
+	// Most SCMs do not send webhooks when git notes are pushed, so we need to
 	// trigger CTP reconciliation when we detect stale NoteDrySha values.
 	// This is done AFTER updating the PromotionStrategy status to avoid conflicts.
 	// When CTPs reconcile and update their status, the .Owns() watch will automatically
@@ -243,6 +265,14 @@ func (r *PromotionStrategyReconciler) upsertChangeTransferPolicy(ctx context.Con
 		ctpSpec = ctpSpec.WithAutoMerge(*environment.AutoMerge)
 	}
 
+	if environment.ConflictResolution != "" {
+		ctpSpec = ctpSpec.WithConflictResolution(environment.ConflictResolution)
+	}
+
+	if pullRequestOptions := pullRequestOptions(ps, environment); pullRequestOptions != nil {
+		ctpSpec = ctpSpec.WithPullRequest(pullRequestOptions)
+	}
+
 	// Build the apply configuration
 	ctpApply := acv1alpha1.ChangeTransferPolicy(ctpName, ps.Namespace).
 		WithLabels(map[string]string{
@@ -271,6 +301,58 @@ func (r *PromotionStrategyReconciler) upsertChangeTransferPolicy(ctx context.Con
 	return ctp, nil
 }
 
+// pullRequestOptions returns the pull request options of the environment combined with those of the
+// PromotionStrategy. Each list holds the environment's entries followed by the PromotionStrategy's, without
+// duplicates, and the environment's draft setting overrides the PromotionStrategy's. It returns nil if neither
+// configures pull requests.
+func pullRequestOptions(ps *promoterv1alpha1.PromotionStrategy, environment promoterv1alpha1.Environment) *acv1alpha1.PullRequestOptionsApplyConfiguration {
+	var reviewers, requiredReviewers, assignees, labels []string
+	var draft *bool
+	for _, options := range []*promoterv1alpha1.PullRequestOptions{environment.PullRequest, ps.Spec.PullRequest} {
+		if options == nil {
+			continue
+		}
+		if draft == nil {
+			draft = options.Draft
+		}
+		reviewers = appendUnique(reviewers, options.Reviewers...)
+		requiredReviewers = appendUnique(requiredReviewers, options.RequiredReviewers...)
+		assignees = appendUnique(assignees, options.Assignees...)
+		labels = appendUnique(labels, options.Labels...)
+	}
+	if len(reviewers)+len(requiredReviewers)+len(assignees)+len(labels) == 0 && draft == nil {
+		return nil
+	}
+
+	options := acv1alpha1.PullRequestOptions()
+	if len(reviewers) > 0 {
+		options = options.WithReviewers(reviewers...)
+	}
+	if len(requiredReviewers) > 0 {
+		options = options.WithRequiredReviewers(requiredReviewers...)
+	}
+	if len(assignees) > 0 {
+		options = options.WithAssignees(assignees...)
+	}
+	if len(labels) > 0 {
+		options = options.WithLabels(labels...)
+	}
+	if draft != nil {
+		options = options.WithDraft(*draft)
+	}
+	return options
+}
+
+// appendUnique appends the values that are not already in list.
+func appendUnique(list []string, values ...string) []string {
+	for _, value := range values {
+		if !slices.Contains(list, value) {
+			list = append(list, value)
+		}
+	}
+	return list
+}
+
 // cleanupOrphanedChangeTransferPolicies deletes ChangeTransferPolicies that are owned by this PromotionStrategy
 // but are not in the current list of valid CTPs (i.e., they correspond to removed or renamed environments).
 //
@@ -370,11 +452,21 @@ func (r *PromotionStrategyReconciler) calculateStatus(ps *promoterv1alpha1.Promo
 // different values need to reconcile to fetch updated git notes or proposed dry sha. This is needed
 // because GitHub doesn't send webhooks when git notes are pushed.
 // Rate limiting: Only enqueues a CTP once per 15 seconds. If rate limited, schedules a delayed enqueue.
+// Nothing is enqueued for GitRepositories whose SCM recently sent a webhook for a push to the hydrator notes ref.
 func (r *PromotionStrategyReconciler) enqueueOutOfSyncCTPs(ctx context.Context, ctps []*promoterv1alpha1.ChangeTransferPolicy) {
 	if len(ctps) == 0 {
 		return
 	}
 
+	gitRepoKey := client.ObjectKey{Namespace: ctps[0].Namespace, Name: ctps[0].Spec.RepositoryReference.Name}
+	if pushedAt, ok := r.notesPushes.Load(gitRepoKey); ok {
+		if time.Since(pushedAt.(time.Time)) < notesPushValidity { //nolint:forcetypeassert // only time.Time is stored
+			log.FromContext(ctx).V(4).Info("Skipping enqueue of out-of-sync CTPs, the SCM sends webhooks for git notes pushes", "gitRepository", gitRepoKey)
+			return
+		}
+		r.notesPushes.Delete(gitRepoKey)
+	}
+
 	// Initialize state map lazily
 	if r.enqueueStates == nil {
 		r.enqueueStateMutex.Lock()
@@ -1006,6 +1006,11 @@ var _ = Describe("PromotionStrategy Controller", func() {
 					// Verify reconciliation completed (both active and proposed have valid SHAs)
 					g.Expect(ctpDev.Status.Active.Hydrated.Sha).To(Not(BeEmpty()))
 
+					// The conflict resolved with 'ours' is recorded so the PR can list the overwritten paths
+					g.Expect(ctpDev.Status.Conflict).ToNot(BeNil())
+					g.Expect(ctpDev.Status.Conflict.Resolution).To(Equal(promoterv1alpha1.ConflictResolutionOurs))
+					g.Expect(ctpDev.Status.Conflict.Paths).To(ContainElement("manifests-fake.yaml"))
+
 					// Staging should have different proposed vs active (ready for PR)
 					g.Expect(ctpStaging.Status.Proposed.Dry.Sha).To(Not(BeEmpty()))
 					g.Expect(ctpStaging.Status.Active.Dry.Sha).To(Not(BeEmpty()))
@@ -4703,5 +4708,31 @@ var _ = Describe("PromotionStrategy Bug Tests", func() {
 			Expect(secondCount).To(Equal(2), "only ctp-2 should have enqueued in second call")
 			Expect(lastEnqueuedName).To(Equal("ctp-2"), "ctp-2 should be the last enqueued")
 		})
+
+		It("should not enqueue CTPs of a GitRepository whose SCM sends webhooks for git notes pushes", func() {
+			reconciler, enqueuedCTPs, enqueueMutex := makeReconciler()
+
+			ctp := makeCTP("test-ctp")
+			ctp.Spec.RepositoryReference.Name = "test-repo"
+			otherCTP := makeCTP("other-ctp")
+			otherCTP.Spec.RepositoryReference.Name = "other-repo"
+
+			reconciler.GetNotesPushFunc()("test-ns", "test-repo")
+			reconciler.enqueueOutOfSyncCTPs(ctx, []*promoterv1alpha1.ChangeTransferPolicy{ctp})
+			reconciler.enqueueOutOfSyncCTPs(ctx, []*promoterv1alpha1.ChangeTransferPolicy{otherCTP})
+
+			enqueueMutex.Lock()
+			Expect(*enqueuedCTPs).To(Equal([]client.ObjectKey{{Namespace: "test-ns", Name: "other-ctp"}}))
+			enqueueMutex.Unlock()
+
+			// Fall back to polling once the SCM has not reported a git notes push for a while.
+			reconciler.notesPushes.Store(client.ObjectKey{Namespace: "test-ns", Name: "test-repo"}, time.Now().Add(-notesPushValidity))
+			reconciler.enqueueOutOfSyncCTPs(ctx, []*promoterv1alpha1.ChangeTransferPolicy{ctp})
+
+			enqueueMutex.Lock()
+			Expect(*enqueuedCTPs).To(HaveLen(2))
+			Expect((*enqueuedCTPs)[1].Name).To(Equal("test-ctp"))
+			enqueueMutex.Unlock()
+		})
 	})
 })
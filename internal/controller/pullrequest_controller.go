@@ -18,9 +18,13 @@ package controller
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/argoproj-labs/gitops-promoter/internal/scms/azuredevops"
@@ -30,17 +34,22 @@ import (
 
 	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
 	"github.com/argoproj-labs/gitops-promoter/internal/git"
+	"github.com/argoproj-labs/gitops-promoter/internal/gitauth"
 	"github.com/argoproj-labs/gitops-promoter/internal/scms"
 	bitbucket_cloud "github.com/argoproj-labs/gitops-promoter/internal/scms/bitbucket_cloud"
+	bitbucket_server "github.com/argoproj-labs/gitops-promoter/internal/scms/bitbucket_server"
 	"github.com/argoproj-labs/gitops-promoter/internal/scms/fake"
 	"github.com/argoproj-labs/gitops-promoter/internal/scms/forgejo"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms/generic"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms/gerrit"
 	"github.com/argoproj-labs/gitops-promoter/internal/scms/gitea"
 	"github.com/argoproj-labs/gitops-promoter/internal/scms/github"
 	"github.com/argoproj-labs/gitops-promoter/internal/scms/gitlab"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms/ratelimit"
 	promoterConditions "github.com/argoproj-labs/gitops-promoter/internal/types/conditions"
 	"github.com/argoproj-labs/gitops-promoter/internal/types/constants"
 	"github.com/argoproj-labs/gitops-promoter/internal/utils"
-	"k8s.io/apimachinery/pkg/api/errors"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -50,16 +59,35 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
+// PullRequestEnqueueFunc is a function type that can be used to enqueue PullRequest reconcile requests without
+// modifying the PullRequest object. It is used by the webhook receiver when the SCM reports a change to a pull request.
+type PullRequestEnqueueFunc func(namespace, name string)
+
 // PullRequestReconciler reconciles a PullRequest object
 type PullRequestReconciler struct {
 	client.Client
 	Scheme      *runtime.Scheme
 	Recorder    events.EventRecorder
 	SettingsMgr *settings.Manager
+
+	// enqueueFunc is set during SetupWithManager and can be retrieved via GetEnqueueFunc.
+	enqueueFunc PullRequestEnqueueFunc
+	// notified holds the keys of the PullRequests that were enqueued through enqueueFunc and not reconciled yet. Their
+	// SCM state is known to have changed, so reconciling them is never deferred while the rate limit quota is low.
+	notified sync.Map
+}
+
+// GetEnqueueFunc returns a function that can be used to enqueue PullRequest reconcile requests.
+// This should be called after SetupWithManager has been called.
+func (r *PullRequestReconciler) GetEnqueueFunc() PullRequestEnqueueFunc {
+	return r.enqueueFunc
 }
 
 //+kubebuilder:rbac:groups=promoter.argoproj.io,resources=pullrequests,verbs=get;list;watch;create;update;patch;delete
@@ -81,13 +109,22 @@ func (r *PullRequestReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	defer utils.HandleReconciliationResult(ctx, startTime, &pr, r.Client, r.Recorder, &result, &err)
 
 	if err := r.Get(ctx, req.NamespacedName, &pr); err != nil {
-		if errors.IsNotFound(err) {
+		if k8serrors.IsNotFound(err) {
 			logger.Info("PullRequest not found", "namespace", req.Namespace, "name", req.Name)
 			return ctrl.Result{}, nil
 		}
 		return ctrl.Result{}, fmt.Errorf("failed to get PullRequest: %w", err)
 	}
 
+	// A reconcile of an open pull request that is already in sync only polls the SCM, so its calls can be deferred
+	// while the rate limit quota is low.
+	var previousReady *metav1.Condition
+	if ready := meta.FindStatusCondition(*pr.GetConditions(), string(promoterConditions.Ready)); ready != nil {
+		previousReady = ready.DeepCopy()
+	}
+	_, notified := r.notified.LoadAndDelete(req.NamespacedName)
+	polling := !notified && isPolling(pr, previousReady)
+
 	// Remove any existing Ready condition. We want to start fresh.
 	meta.RemoveStatusCondition(pr.GetConditions(), string(promoterConditions.Ready))
 
@@ -101,8 +138,18 @@ func (r *PullRequestReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{}, fmt.Errorf("failed to get PullRequest provider: %w", err)
 	}
 
-	found, prID, prCreationTime, err := provider.FindOpen(ctx, pr)
+	findCtx := ctx
+	if polling {
+		findCtx = ratelimit.WithDeferrable(ctx)
+	}
+	found, prID, prCreationTime, err := provider.FindOpen(findCtx, pr)
 	if err != nil {
+		if retryAfter, deferred := ratelimit.Deferred(err); deferred && previousReady != nil {
+			// Keep the previous Ready condition, since nothing changed as far as we know.
+			logger.Info("Deferring poll of PullRequest while the SCM rate limit quota is low", "retryAfter", retryAfter)
+			meta.SetStatusCondition(pr.GetConditions(), *previousReady)
+			return ctrl.Result{RequeueAfter: retryAfter}, nil
+		}
 		return ctrl.Result{}, fmt.Errorf("failed to check for open PR: %w", err)
 	}
 
@@ -149,6 +196,8 @@ func (r *PullRequestReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{RequeueAfter: 1 * time.Microsecond}, nil
 	}
 
+	r.updateGateSummaryComment(ctx, &pr, provider)
+
 	logger.Info("no known state transitions needed", "specState", pr.Spec.State, "statusState", pr.Status.State)
 
 	requeueDuration, err := settings.GetRequeueDuration[promoterv1alpha1.PullRequestConfiguration](ctx, r.SettingsMgr)
@@ -159,6 +208,14 @@ func (r *PullRequestReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	return ctrl.Result{RequeueAfter: requeueDuration}, nil
 }
 
+// isPolling returns whether the pull request is open, in sync with the SCM and was successfully reconciled at its
+// current generation, so that reconciling it only polls the SCM for changes.
+func isPolling(pr promoterv1alpha1.PullRequest, ready *metav1.Condition) bool {
+	return ready != nil && ready.Status == metav1.ConditionTrue && ready.ObservedGeneration == pr.Generation &&
+		pr.DeletionTimestamp.IsZero() && pr.Status.ID != "" &&
+		pr.Spec.State == promoterv1alpha1.PullRequestOpen && pr.Status.State == promoterv1alpha1.PullRequestOpen
+}
+
 // handleEmptyIDDeletion handles the case where a PullRequest is being deleted but never created a PR on the SCM.
 // Returns (handled=true, nil) if deletion was handled, (false, nil) if not applicable, or (false, err) on error.
 func (r *PullRequestReconciler) handleEmptyIDDeletion(ctx context.Context, pr *promoterv1alpha1.PullRequest) (bool, error) {
@@ -195,7 +252,7 @@ func (r *PullRequestReconciler) cleanupTerminalStates(ctx context.Context, pr *p
 	} else {
 		logger.Info("Cleaning up closed and merged pull request", "pullRequestID", pr.Status.ID)
 	}
-	if err := r.Delete(ctx, pr); err != nil && !errors.IsNotFound(err) {
+	if err := r.Delete(ctx, pr); err != nil && !k8serrors.IsNotFound(err) {
 		logger.Error(err, "Failed to delete PullRequest")
 		return false, fmt.Errorf("failed to delete PullRequest: %w", err)
 	}
@@ -221,6 +278,24 @@ func (r *PullRequestReconciler) syncStateFromProvider(ctx context.Context, pr *p
 			return false, fmt.Errorf("failed to get pull request URL: %w", err)
 		}
 		pr.Status.Url = url
+
+		if conditionsProvider, ok := provider.(scms.PullRequestConditionsProvider); ok {
+			conditions, err := conditionsProvider.GetConditions(ctx, *pr)
+			if err != nil {
+				return false, fmt.Errorf("failed to get pull request conditions: %w", err)
+			}
+			for _, condition := range conditions {
+				meta.SetStatusCondition(pr.GetConditions(), condition)
+			}
+		}
+
+		if err := r.syncBranchProtection(ctx, pr, provider); err != nil {
+			return false, err
+		}
+
+		if err := r.syncMergeQueueStatus(ctx, pr, provider); err != nil {
+			return false, err
+		}
 		return false, nil
 	}
 
@@ -249,6 +324,11 @@ func (r *PullRequestReconciler) syncStateFromProvider(ctx context.Context, pr *p
 			logger.V(4).Info("PR not found open, spec and status state are different",
 				"specState", pr.Spec.State, "statusState", pr.Status.State)
 			pr.Status.State = pr.Spec.State
+			if pr.Spec.State == promoterv1alpha1.PullRequestMerged && pr.Status.MergeQueue != nil {
+				pr.Status.MergeQueue.State = promoterv1alpha1.MergeQueueMerged
+				pr.Status.MergeQueue.Position = 0
+				pr.Status.MergeQueue.Message = "The merge queue merged the pull request"
+			}
 			return true, nil
 		}
 		logger.V(4).Info("PR not found open, spec and status state are equal", "specState", pr.Spec.State)
@@ -257,6 +337,74 @@ func (r *PullRequestReconciler) syncStateFromProvider(ctx context.Context, pr *p
 	return false, nil
 }
 
+// syncBranchProtection sets the BranchProtectionSatisfied condition of an open pull request from the protection of its
+// target branch on the SCM, or removes it if the branch is not protected or the provider cannot report it. An event is
+// recorded when the condition changes while it blocks a requested merge, rather than on every reconcile.
+func (r *PullRequestReconciler) syncBranchProtection(ctx context.Context, pr *promoterv1alpha1.PullRequest, provider scms.PullRequestProvider) error {
+	branchProtectionProvider, ok := provider.(scms.PullRequestBranchProtectionProvider)
+	if !ok {
+		return nil
+	}
+
+	protection, err := branchProtectionProvider.GetBranchProtection(ctx, *pr)
+	if err != nil {
+		return fmt.Errorf("failed to get pull request branch protection: %w", err)
+	}
+	if protection == nil {
+		meta.RemoveStatusCondition(pr.GetConditions(), string(promoterConditions.BranchProtectionSatisfied))
+		return nil
+	}
+
+	condition := metav1.Condition{
+		Type:               string(promoterConditions.BranchProtectionSatisfied),
+		Status:             metav1.ConditionTrue,
+		Reason:             string(promoterConditions.BranchProtectionMet),
+		Message:            protection.Message(),
+		ObservedGeneration: pr.Generation,
+	}
+	if !protection.Satisfied() {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = string(promoterConditions.BranchProtectionNotSatisfied)
+	}
+	if meta.SetStatusCondition(pr.GetConditions(), condition) && condition.Status == metav1.ConditionFalse && pr.Spec.State == promoterv1alpha1.PullRequestMerged {
+		r.Recorder.Eventf(pr, nil, "Normal", constants.PullRequestMergeBlockedReason, "MergingPullRequest", constants.PullRequestMergeBlockedMessage, pr.Name, condition.Message)
+	}
+	return nil
+}
+
+// syncMergeQueueStatus updates the merge queue state of a pull request that was added to a merge queue. A pull request
+// that is no longer in the queue, but still open, was removed from the queue by the SCM without being merged, e.g.
+// because its checks failed in the queue or it conflicts with the pull requests ahead of it.
+func (r *PullRequestReconciler) syncMergeQueueStatus(ctx context.Context, pr *promoterv1alpha1.PullRequest, provider scms.PullRequestProvider) error {
+	if pr.Status.MergeQueue == nil || pr.Status.MergeQueue.State != promoterv1alpha1.MergeQueueQueued {
+		return nil
+	}
+	mergeQueueProvider, ok := provider.(scms.PullRequestMergeQueueProvider)
+	if !ok {
+		return nil
+	}
+
+	status, err := mergeQueueProvider.GetMergeQueueStatus(ctx, *pr)
+	if err != nil {
+		return fmt.Errorf("failed to get pull request merge queue status: %w", err)
+	}
+	if status != nil {
+		status.MergeSha = pr.Status.MergeQueue.MergeSha
+		if status.EnqueuedAt.IsZero() {
+			status.EnqueuedAt = pr.Status.MergeQueue.EnqueuedAt
+		}
+		pr.Status.MergeQueue = status
+		return nil
+	}
+
+	log.FromContext(ctx).Info("PullRequest was removed from the merge queue without being merged", "pullRequestID", pr.Status.ID)
+	pr.Status.MergeQueue.State = promoterv1alpha1.MergeQueueDequeued
+	pr.Status.MergeQueue.Position = 0
+	pr.Status.MergeQueue.Message = "The SCM removed the pull request from the merge queue without merging it"
+	r.Recorder.Eventf(pr, nil, "Warning", constants.PullRequestDequeuedReason, "DequeuingPullRequest", constants.PullRequestDequeuedMessage, pr.Name)
+	return nil
+}
+
 // handleStateTransitions handles transitions between PullRequest states.
 // Returns (done=true, nil) if a terminal state was reached, (false, nil) otherwise, or (false, err) on error.
 func (r *PullRequestReconciler) handleStateTransitions(ctx context.Context, pr *promoterv1alpha1.PullRequest, provider scms.PullRequestProvider) (bool, error) {
@@ -269,6 +417,7 @@ func (r *PullRequestReconciler) handleStateTransitions(ctx context.Context, pr *
 		if err := r.updatePullRequest(ctx, *pr, provider); err != nil {
 			return false, fmt.Errorf("failed to update pull request: %w", err) // Top-level wrap for update errors
 		}
+		pr.Status.Draft = pr.Spec.Draft
 		return false, nil
 	}
 
@@ -282,11 +431,24 @@ func (r *PullRequestReconciler) handleStateTransitions(ctx context.Context, pr *
 			}
 		}
 	case promoterv1alpha1.PullRequestMerged:
+		if mergeQueue := pr.Status.MergeQueue; mergeQueue != nil && mergeQueue.State != promoterv1alpha1.MergeQueueMerged && mergeQueue.MergeSha == pr.Spec.MergeSha {
+			// The pull request is in the merge queue, or the SCM removed it from the queue for this merge SHA, in which
+			// case adding it again would most likely fail the same way. It is added again once the merge SHA changes.
+			logger.Info("Waiting for the merge queue", "state", mergeQueue.State, "mergeSha", mergeQueue.MergeSha)
+			return false, nil
+		}
+		if protection := meta.FindStatusCondition(*pr.GetConditions(), string(promoterConditions.BranchProtectionSatisfied)); protection != nil && protection.Status == metav1.ConditionFalse {
+			// The SCM would refuse the merge. It is attempted once the requirements are met.
+			logger.Info("Waiting for branch protection to be satisfied", "message", protection.Message)
+			return false, nil
+		}
 		logger.Info("Merging PullRequest")
-		if err := r.mergePullRequest(ctx, pr, provider); err != nil {
+		merged, err := r.mergePullRequest(ctx, pr, provider)
+		if err != nil {
 			return false, fmt.Errorf("failed to merge pull request: %w", err) // Top-level wrap for merge errors
 		}
-		return true, nil
+		// A scheduled merge leaves the pull request open. It is cleaned up once the provider no longer finds it open.
+		return merged, nil
 	case promoterv1alpha1.PullRequestClosed:
 		logger.Info("Closing PullRequest")
 		if err := r.closePullRequest(ctx, pr, provider); err != nil {
@@ -314,8 +476,37 @@ func (r *PullRequestReconciler) SetupWithManager(ctx context.Context, mgr ctrl.M
 		return fmt.Errorf("failed to get pull request max concurrent reconciles: %w", err)
 	}
 
+	// The webhook receiver finds the PullRequests that a pull request event is about by their ID on the SCM.
+	if err := mgr.GetFieldIndexer().IndexField(ctx, &promoterv1alpha1.PullRequest{}, ".status.id", func(rawObj client.Object) []string {
+		//nolint:forcetypeassert // type is guaranteed by the IndexField API
+		pr := rawObj.(*promoterv1alpha1.PullRequest)
+		return []string{pr.Status.ID}
+	}); err != nil {
+		return fmt.Errorf("failed to set field index for .status.id: %w", err)
+	}
+
+	// Create a channel for external enqueue requests, like the ChangeTransferPolicy controller does. Sends block if the
+	// buffer is full, providing natural backpressure to callers.
+	externalEnqueueChan := make(chan event.GenericEvent, 1024)
+	r.enqueueFunc = func(namespace, name string) {
+		pr := &promoterv1alpha1.PullRequest{}
+		pr.SetNamespace(namespace)
+		pr.SetName(name)
+		r.notified.Store(client.ObjectKeyFromObject(pr), struct{}{})
+
+		select {
+		case externalEnqueueChan <- event.GenericEvent{Object: pr}:
+		default:
+			log.FromContext(ctx).Info("PullRequest enqueue channel is full, blocking until space is available",
+				"namespace", namespace, "name", name)
+			externalEnqueueChan <- event.GenericEvent{Object: pr}
+		}
+	}
+
 	err = ctrl.NewControllerManagedBy(mgr).
 		For(&promoterv1alpha1.PullRequest{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		// Watch for external enqueue requests from the webhook receiver.
+		WatchesRawSource(source.Channel(externalEnqueueChan, &handler.EnqueueRequestForObject{})).
 		WithOptions(controller.Options{MaxConcurrentReconciles: maxConcurrentReconciles, RateLimiter: rateLimiter}).
 		Complete(r)
 	if err != nil {
@@ -339,15 +530,29 @@ func (r *PullRequestReconciler) getPullRequestProvider(ctx context.Context, pr p
 	case scmProvider.GetSpec().GitHub != nil:
 		return github.NewGithubPullRequestProvider(ctx, r.Client, scmProvider, *secret, gitRepository.Spec.GitHub.Owner) //nolint:wrapcheck // provider factory returns descriptive errors
 	case scmProvider.GetSpec().GitLab != nil:
-		return gitlab.NewGitlabPullRequestProvider(r.Client, *secret, scmProvider.GetSpec().GitLab.Domain) //nolint:wrapcheck // provider factory returns descriptive errors
+		return gitlab.NewGitlabPullRequestProvider(r.Client, *secret, scmProvider.GetSpec().GitLab.Domain, scmProvider.GetSpec().GitLab.MergeWhenPipelineSucceeds) //nolint:wrapcheck // provider factory returns descriptive errors
 	case scmProvider.GetSpec().BitbucketCloud != nil:
 		return bitbucket_cloud.NewBitbucketCloudPullRequestProvider(r.Client, *secret) //nolint:wrapcheck // provider factory returns descriptive errors
+	case scmProvider.GetSpec().BitbucketServer != nil:
+		return bitbucket_server.NewBitbucketServerPullRequestProvider(r.Client, *secret, scmProvider.GetSpec().BitbucketServer.Domain) //nolint:wrapcheck // provider factory returns descriptive errors
 	case scmProvider.GetSpec().Forgejo != nil:
 		return forgejo.NewForgejoPullRequestProvider(r.Client, *secret, scmProvider.GetSpec().Forgejo.Domain) //nolint:wrapcheck // provider factory returns descriptive errors
 	case scmProvider.GetSpec().Gitea != nil:
 		return gitea.NewGiteaPullRequestProvider(r.Client, *secret, scmProvider.GetSpec().Gitea.Domain) //nolint:wrapcheck // provider factory returns descriptive errors
 	case scmProvider.GetSpec().AzureDevOps != nil:
 		return azuredevops.NewAzdoPullRequestProvider(r.Client, *secret, scmProvider, scmProvider.GetSpec().AzureDevOps.Organization) //nolint:wrapcheck,contextcheck // provider factory returns descriptive errors
+	case scmProvider.GetSpec().Gerrit != nil:
+		gitOperations, err := gitauth.CreateGitOperationsProvider(ctx, r.Client, scmProvider, secret, client.ObjectKeyFromObject(gitRepository))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create git operations provider: %w", err)
+		}
+		return gerrit.NewGerritPullRequestProvider(r.Client, *secret, scmProvider.GetSpec().Gerrit.Domain, gitOperations) //nolint:wrapcheck // provider factory returns descriptive errors
+	case scmProvider.GetSpec().Generic != nil:
+		gitOperations, err := gitauth.CreateGitOperationsProvider(ctx, r.Client, scmProvider, secret, client.ObjectKeyFromObject(gitRepository))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create git operations provider: %w", err)
+		}
+		return generic.NewGenericPullRequestProvider(r.Client, gitOperations), nil
 	case scmProvider.GetSpec().Fake != nil:
 		return fake.NewFakePullRequestProvider(r.Client), nil
 	default:
@@ -405,6 +610,7 @@ func (r *PullRequestReconciler) createPullRequest(ctx context.Context, pr *promo
 	pr.Status.State = promoterv1alpha1.PullRequestOpen
 	pr.Status.PRCreationTime = metav1.Now()
 	pr.Status.ID = id
+	pr.Status.Draft = pr.Spec.Draft
 
 	url, err := provider.GetUrl(ctx, *pr)
 	if err != nil {
@@ -423,7 +629,9 @@ func (r *PullRequestReconciler) updatePullRequest(ctx context.Context, pr promot
 	return nil
 }
 
-func (r *PullRequestReconciler) mergePullRequest(ctx context.Context, pr *promoterv1alpha1.PullRequest, provider scms.PullRequestProvider) error {
+// mergePullRequest merges the pull request. It returns false if the provider scheduled the merge or added the pull
+// request to a merge queue instead of merging right away, in which case the pull request stays open.
+func (r *PullRequestReconciler) mergePullRequest(ctx context.Context, pr *promoterv1alpha1.PullRequest, provider scms.PullRequestProvider) (bool, error) {
 	mergedTime := metav1.Now()
 
 	updatedMessage, err := git.AddTrailerToCommitMessage(
@@ -433,17 +641,32 @@ func (r *PullRequestReconciler) mergePullRequest(ctx context.Context, pr *promot
 		mergedTime.Format(time.RFC3339),
 	)
 	if err != nil {
-		return fmt.Errorf("failed to add trailer to commit message: %w", err)
+		return false, fmt.Errorf("failed to add trailer to commit message: %w", err)
 	}
 
 	// Update the commit message with the new trailers
 	pr.Spec.Commit.Message = updatedMessage
 
 	if err := provider.Merge(ctx, *pr); err != nil {
-		return err //nolint:wrapcheck // Error wrapping handled at top level
+		if errors.Is(err, scms.ErrMergeQueued) {
+			log.FromContext(ctx).Info("PullRequest added to the merge queue", "pullRequestID", pr.Status.ID)
+			pr.Status.MergeQueue = &promoterv1alpha1.PullRequestMergeQueueStatus{
+				State:      promoterv1alpha1.MergeQueueQueued,
+				EnqueuedAt: mergedTime,
+				MergeSha:   pr.Spec.MergeSha,
+				Message:    "The pull request was added to the merge queue",
+			}
+			r.Recorder.Eventf(pr, nil, "Normal", constants.PullRequestEnqueuedReason, "EnqueuingPullRequest", constants.PullRequestEnqueuedMessage, pr.Name)
+			return false, nil
+		}
+		if errors.Is(err, scms.ErrMergeScheduled) {
+			log.FromContext(ctx).Info("Merge of PullRequest scheduled by the SCM", "pullRequestID", pr.Status.ID)
+			return false, nil
+		}
+		return false, err //nolint:wrapcheck // Error wrapping handled at top level
 	}
 	pr.Status.State = promoterv1alpha1.PullRequestMerged
-	return nil
+	return true, nil
 }
 
 type trailers map[string]string
@@ -473,3 +696,81 @@ func (r *PullRequestReconciler) closePullRequest(ctx context.Context, pr *promot
 	pr.Status.State = promoterv1alpha1.PullRequestClosed
 	return nil
 }
+
+// updateGateSummaryComment posts the gate summary of an open pull request as a comment, or replaces the previous one
+// when the summary changed. Failures are reported as events and retried on the next reconcile rather than failing it,
+// since a comment is informational and must not block the promotion.
+func (r *PullRequestReconciler) updateGateSummaryComment(ctx context.Context, pr *promoterv1alpha1.PullRequest, provider scms.PullRequestProvider) {
+	if pr.Spec.State != promoterv1alpha1.PullRequestOpen || pr.Status.State != promoterv1alpha1.PullRequestOpen ||
+		pr.Status.ID == "" || len(pr.Spec.CommitStatuses) == 0 {
+		return
+	}
+
+	body := gateSummary(*pr)
+	sum := sha256.Sum256([]byte(body))
+	bodyHash := hex.EncodeToString(sum[:])
+
+	commentID := ""
+	if pr.Status.GateSummaryComment != nil {
+		if pr.Status.GateSummaryComment.BodyHash == bodyHash {
+			return
+		}
+		commentID = pr.Status.GateSummaryComment.ID
+	}
+
+	commentID, err := provider.UpsertComment(ctx, *pr, commentID, body)
+	if errors.Is(err, scms.ErrCommentNotFound) {
+		log.FromContext(ctx).Info("Gate summary comment no longer exists, posting a new one", "pullRequestID", pr.Status.ID, "commentID", pr.Status.GateSummaryComment.ID)
+		pr.Status.GateSummaryComment = nil
+		commentID, err = provider.UpsertComment(ctx, *pr, "", body)
+	}
+	if err != nil {
+		log.FromContext(ctx).Error(err, "failed to post gate summary comment", "pullRequestID", pr.Status.ID)
+		r.Recorder.Eventf(pr, nil, "Warning", constants.GateSummaryCommentFailedReason, "PostingGateSummaryComment", constants.GateSummaryCommentFailedMessage, pr.Name, err.Error())
+		return
+	}
+	pr.Status.GateSummaryComment = &promoterv1alpha1.PullRequestComment{ID: commentID, BodyHash: bodyHash}
+}
+
+// gateSummary renders the commit status phases of the pull request as a markdown comment.
+func gateSummary(pr promoterv1alpha1.PullRequest) string {
+	var summary strings.Builder
+	summary.WriteString("### Promotion gates\n\n")
+
+	var previousEnvironment *promoterv1alpha1.ChangeRequestPolicyCommitStatusPhase
+	gates := 0
+	for i, status := range pr.Spec.CommitStatuses {
+		if status.Key == promoterv1alpha1.PreviousEnvironmentCommitStatusKey {
+			previousEnvironment = &pr.Spec.CommitStatuses[i]
+			continue
+		}
+		if gates == 0 {
+			summary.WriteString("| Gate | Phase | Description |\n|------|-------|-------------|\n")
+		}
+		gates++
+		gate := markdownCell(status.Key)
+		if status.Url != "" {
+			gate = fmt.Sprintf("[%s](%s)", gate, status.Url)
+		}
+		fmt.Fprintf(&summary, "| %s | %s | %s |\n", gate, status.Phase, markdownCell(status.Description))
+	}
+
+	if previousEnvironment != nil {
+		if gates > 0 {
+			summary.WriteString("\n")
+		}
+		fmt.Fprintf(&summary, "Previous environment: %s", previousEnvironment.Phase)
+		if previousEnvironment.Description != "" {
+			fmt.Fprintf(&summary, " (%s)", previousEnvironment.Description)
+		}
+		summary.WriteString("\n")
+	}
+
+	return summary.String()
+}
+
+// markdownCell escapes a value so that it stays within a single markdown table cell.
+func markdownCell(value string) string {
+	value = strings.ReplaceAll(value, "|", "\\|")
+	return strings.Join(strings.Fields(value), " ")
+}
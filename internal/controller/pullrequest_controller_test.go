@@ -17,9 +17,13 @@ limitations under the License.
 package controller
 
 import (
+	"bytes"
 	"context"
 	_ "embed"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -28,11 +32,13 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 
 	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms"
 	"github.com/argoproj-labs/gitops-promoter/internal/scms/fake"
 	"github.com/argoproj-labs/gitops-promoter/internal/utils"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -150,6 +156,164 @@ var _ = Describe("PullRequest Controller", func() {
 				}, constants.EventuallyTimeout).Should(Succeed())
 			})
 		})
+
+		Context("When the branch protection of the target branch is not satisfied", func() {
+			BeforeEach(func() {
+				By("Creating test resources")
+				name, scmSecret, scmProvider, gitRepo, pullRequest = pullRequestResources(ctx, "branch-protection")
+
+				typeNamespacedName = types.NamespacedName{
+					Name:      name,
+					Namespace: "default",
+				}
+
+				fake.SetBranchProtection(gitRepo.Name, pullRequest.Spec.TargetBranch, &scms.BranchProtection{
+					RequiredChecks:    []string{"e2e"},
+					UnmetChecks:       []string{"e2e"},
+					RequiredApprovals: 1,
+					Approvals:         1,
+				})
+				DeferCleanup(fake.SetBranchProtection, gitRepo.Name, pullRequest.Spec.TargetBranch, nil)
+
+				Expect(k8sClient.Create(ctx, scmSecret)).To(Succeed())
+				Expect(k8sClient.Create(ctx, scmProvider)).To(Succeed())
+				Expect(k8sClient.Create(ctx, gitRepo)).To(Succeed())
+				Expect(k8sClient.Create(ctx, pullRequest)).To(Succeed())
+			})
+
+			It("should not merge the pull request until the branch protection is satisfied", func() {
+				By("Reporting the unmet requirements in a condition")
+				Eventually(func(g Gomega) {
+					g.Expect(k8sClient.Get(ctx, typeNamespacedName, pullRequest)).To(Succeed())
+					g.Expect(pullRequest.Status.State).To(Equal(promoterv1alpha1.PullRequestOpen))
+					condition := meta.FindStatusCondition(pullRequest.Status.Conditions, string(conditions.BranchProtectionSatisfied))
+					g.Expect(condition).NotTo(BeNil())
+					g.Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+					g.Expect(condition.Reason).To(Equal(string(conditions.BranchProtectionNotSatisfied)))
+					g.Expect(condition.Message).To(Equal("Branch protection is not satisfied: required checks have not succeeded: e2e"))
+				}, constants.EventuallyTimeout).Should(Succeed())
+
+				By("Not merging the pull request while the requirements are unmet")
+				Eventually(func(g Gomega) {
+					g.Expect(k8sClient.Get(ctx, typeNamespacedName, pullRequest)).To(Succeed())
+					pullRequest.Spec.State = promoterv1alpha1.PullRequestMerged
+					g.Expect(k8sClient.Update(ctx, pullRequest)).To(Succeed())
+				}, constants.EventuallyTimeout).Should(Succeed())
+
+				Consistently(func(g Gomega) {
+					g.Expect(k8sClient.Get(ctx, typeNamespacedName, pullRequest)).To(Succeed())
+					g.Expect(pullRequest.Status.State).To(Equal(promoterv1alpha1.PullRequestOpen))
+					ready := meta.FindStatusCondition(pullRequest.Status.Conditions, string(conditions.Ready))
+					g.Expect(ready).NotTo(BeNil())
+					g.Expect(ready.Status).To(Equal(metav1.ConditionTrue))
+				}, 2*time.Second).Should(Succeed())
+
+				By("Merging the pull request once the requirements are met")
+				fake.SetBranchProtection(gitRepo.Name, pullRequest.Spec.TargetBranch, &scms.BranchProtection{
+					RequiredChecks:    []string{"e2e"},
+					RequiredApprovals: 1,
+					Approvals:         1,
+				})
+				// Change the spec so the pull request is reconciled again without waiting for the requeue duration.
+				Eventually(func(g Gomega) {
+					g.Expect(k8sClient.Get(ctx, typeNamespacedName, pullRequest)).To(Succeed())
+					pullRequest.Spec.Title = "Branch protection satisfied"
+					g.Expect(k8sClient.Update(ctx, pullRequest)).To(Succeed())
+				}, constants.EventuallyTimeout).Should(Succeed())
+
+				Eventually(func(g Gomega) {
+					err := k8sClient.Get(ctx, typeNamespacedName, pullRequest)
+					g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+				}, constants.EventuallyTimeout).Should(Succeed())
+			})
+		})
+	})
+
+	Context("When the PullRequest has commit statuses", func() {
+		var name string
+		var scmSecret *v1.Secret
+		var scmProvider *promoterv1alpha1.ScmProvider
+		var gitRepo *promoterv1alpha1.GitRepository
+		var pullRequest *promoterv1alpha1.PullRequest
+		var typeNamespacedName types.NamespacedName
+
+		BeforeEach(func() {
+			By("Creating test resources")
+			name, scmSecret, scmProvider, gitRepo, pullRequest = pullRequestResources(ctx, "gate-summary")
+			pullRequest.Spec.CommitStatuses = []promoterv1alpha1.ChangeRequestPolicyCommitStatusPhase{
+				{Key: "healthy", Phase: string(promoterv1alpha1.CommitPhasePending), Description: "Waiting for sync"},
+				{Key: promoterv1alpha1.PreviousEnvironmentCommitStatusKey, Phase: string(promoterv1alpha1.CommitPhaseSuccess)},
+			}
+
+			typeNamespacedName = types.NamespacedName{
+				Name:      name,
+				Namespace: "default",
+			}
+
+			Expect(k8sClient.Create(ctx, scmSecret)).To(Succeed())
+			Expect(k8sClient.Create(ctx, scmProvider)).To(Succeed())
+			Expect(k8sClient.Create(ctx, gitRepo)).To(Succeed())
+			Expect(k8sClient.Create(ctx, pullRequest)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			_ = k8sClient.Delete(ctx, pullRequest)
+		})
+
+		It("should post the gate summary as a comment and update it when a phase changes", func() {
+			fakeProvider := fake.NewFakePullRequestProvider(k8sClient)
+
+			By("Waiting for the gate summary comment to be posted")
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, typeNamespacedName, pullRequest)).To(Succeed())
+				g.Expect(pullRequest.Status.GateSummaryComment).NotTo(BeNil())
+				comment, err := fakeProvider.GetComment(ctx, *pullRequest)
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(comment).To(ContainSubstring("| healthy | pending | Waiting for sync |"))
+				g.Expect(comment).To(ContainSubstring("Previous environment: success"))
+			}, constants.EventuallyTimeout).Should(Succeed())
+			firstHash := pullRequest.Status.GateSummaryComment.BodyHash
+
+			By("Updating the phase of the gate")
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, typeNamespacedName, pullRequest)).To(Succeed())
+				pullRequest.Spec.CommitStatuses[0].Phase = string(promoterv1alpha1.CommitPhaseSuccess)
+				pullRequest.Spec.CommitStatuses[0].Description = ""
+				g.Expect(k8sClient.Update(ctx, pullRequest)).To(Succeed())
+			}, constants.EventuallyTimeout).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, typeNamespacedName, pullRequest)).To(Succeed())
+				g.Expect(pullRequest.Status.GateSummaryComment).NotTo(BeNil())
+				g.Expect(pullRequest.Status.GateSummaryComment.BodyHash).NotTo(Equal(firstHash))
+				comment, err := fakeProvider.GetComment(ctx, *pullRequest)
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(comment).To(ContainSubstring("| healthy | success |  |"))
+			}, constants.EventuallyTimeout).Should(Succeed())
+		})
+
+		It("should post a new gate summary comment when the previous one was deleted", func() {
+			fakeProvider := fake.NewFakePullRequestProvider(k8sClient)
+
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, typeNamespacedName, pullRequest)).To(Succeed())
+				g.Expect(pullRequest.Status.GateSummaryComment).NotTo(BeNil())
+			}, constants.EventuallyTimeout).Should(Succeed())
+
+			By("Deleting the comment and updating the phase of the gate")
+			Expect(fakeProvider.DeleteComment(ctx, *pullRequest)).To(Succeed())
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, typeNamespacedName, pullRequest)).To(Succeed())
+				pullRequest.Spec.CommitStatuses[0].Phase = string(promoterv1alpha1.CommitPhaseFailure)
+				g.Expect(k8sClient.Update(ctx, pullRequest)).To(Succeed())
+			}, constants.EventuallyTimeout).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				comment, err := fakeProvider.GetComment(ctx, *pullRequest)
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(comment).To(ContainSubstring("| healthy | failure | Waiting for sync |"))
+			}, constants.EventuallyTimeout).Should(Succeed())
+		})
 	})
 
 	Context("When reconciling a resource with a bad configuration", func() {
@@ -889,6 +1053,39 @@ var _ = Describe("PullRequest Controller", func() {
 				}, constants.EventuallyTimeout).Should(Succeed())
 			}
 		})
+
+		It("should detect the external close as soon as the SCM sends a pull request webhook", func() {
+			By("Simulating an external close on the fake provider")
+			fakeProvider := fake.NewFakePullRequestProvider(k8sClient)
+			Expect(fakeProvider.DeletePullRequest(ctx, *pullRequest)).To(Succeed())
+
+			By("Sending the pull request closed webhook without changing the PullRequest spec")
+			number, err := strconv.Atoi(pullRequest.Status.ID)
+			Expect(err).NotTo(HaveOccurred())
+			payload, err := json.Marshal(map[string]any{
+				"action": "closed",
+				"pull_request": map[string]any{
+					"number": number,
+					"merged": false,
+					"head":   map[string]any{"ref": pullRequest.Spec.SourceBranch},
+					"base":   map[string]any{"ref": pullRequest.Spec.TargetBranch},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("http://localhost:%d/", webhookReceiverPort), bytes.NewReader(payload))
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Github-Event", "pull_request")
+			resp, err := http.DefaultClient.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.Body.Close()).To(Succeed())
+			Expect(resp.StatusCode).To(Equal(http.StatusNoContent))
+
+			Eventually(func(g Gomega) {
+				err := k8sClient.Get(ctx, typeNamespacedName, pullRequest)
+				g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+			}, constants.EventuallyTimeout).Should(Succeed())
+		})
 	})
 })
 
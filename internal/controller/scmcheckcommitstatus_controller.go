@@ -0,0 +1,461 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	acmetav1 "k8s.io/client-go/applyconfigurations/meta/v1"
+	"k8s.io/client-go/tools/events"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	acv1alpha1 "github.com/argoproj-labs/gitops-promoter/applyconfiguration/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms"
+	"github.com/argoproj-labs/gitops-promoter/internal/settings"
+	promoterConditions "github.com/argoproj-labs/gitops-promoter/internal/types/conditions"
+	"github.com/argoproj-labs/gitops-promoter/internal/types/constants"
+	"github.com/argoproj-labs/gitops-promoter/internal/utils"
+)
+
+// ScmCheckCommitStatusReconciler reconciles a ScmCheckCommitStatus object
+type ScmCheckCommitStatusReconciler struct {
+	client.Client
+	Scheme      *runtime.Scheme
+	Recorder    events.EventRecorder
+	SettingsMgr *settings.Manager
+
+	// EnqueueCTP is a function to enqueue CTP reconcile requests without modifying the CTP object.
+	EnqueueCTP CTPEnqueueFunc
+}
+
+// +kubebuilder:rbac:groups=promoter.argoproj.io,resources=scmcheckcommitstatuses,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=promoter.argoproj.io,resources=scmcheckcommitstatuses/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=promoter.argoproj.io,resources=scmcheckcommitstatuses/finalizers,verbs=update
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+//
+// For each environment the ScmCheckCommitStatus applies to, the controller:
+// 1. Fetches the PromotionStrategy to get the proposed hydrated commit SHA
+// 2. Reads the check runs and commit statuses reported on that SHA from the SCM
+// 3. Combines the phases of the checks selected in the spec
+// 4. Creates/updates a CommitStatus resource with the combined phase
+//
+// While any environment has not succeeded, the checks are read again after the configured requeue duration.
+func (r *ScmCheckCommitStatusReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
+	logger := log.FromContext(ctx)
+	logger.Info("Reconciling ScmCheckCommitStatus", "name", req.Name)
+	startTime := time.Now()
+
+	var sccs promoterv1alpha1.ScmCheckCommitStatus
+	// This function will update the resource status at the end of the reconciliation. don't call .Status().Update manually.
+	defer utils.HandleReconciliationResult(ctx, startTime, &sccs, r.Client, r.Recorder, &result, &err)
+
+	err = r.Get(ctx, req.NamespacedName, &sccs, &client.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			logger.Info("ScmCheckCommitStatus not found")
+			return ctrl.Result{}, nil
+		}
+
+		logger.Error(err, "failed to get ScmCheckCommitStatus")
+		return ctrl.Result{}, fmt.Errorf("failed to get ScmCheckCommitStatus %q: %w", req.Name, err)
+	}
+
+	// Remove any existing Ready condition. We want to start fresh.
+	meta.RemoveStatusCondition(sccs.GetConditions(), string(promoterConditions.Ready))
+
+	// Fetch the referenced PromotionStrategy
+	var ps promoterv1alpha1.PromotionStrategy
+	psKey := client.ObjectKey{
+		Namespace: sccs.Namespace,
+		Name:      sccs.Spec.PromotionStrategyRef.Name,
+	}
+	err = r.Get(ctx, psKey, &ps)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return ctrl.Result{}, fmt.Errorf("referenced PromotionStrategy %q not found: %w", sccs.Spec.PromotionStrategyRef.Name, err)
+		}
+		return ctrl.Result{}, fmt.Errorf("failed to get PromotionStrategy %q: %w", sccs.Spec.PromotionStrategyRef.Name, err)
+	}
+
+	// Process each environment and mirror its checks
+	transitionedEnvironments, commitStatuses, err := r.processEnvironments(ctx, &sccs, &ps)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to process environments: %w", err)
+	}
+
+	// Inherit conditions from CommitStatus objects
+	utils.InheritNotReadyConditionFromObjects(&sccs, promoterConditions.CommitStatusesNotReady, commitStatuses...)
+
+	// If any checks transitioned to success, touch the corresponding ChangeTransferPolicies
+	if len(transitionedEnvironments) > 0 {
+		r.touchChangeTransferPolicies(ctx, &ps, transitionedEnvironments)
+	}
+
+	// The SCM does not notify us when checks change, so keep reading them until they have all succeeded. Checks that
+	// failed are read again too, since they may be re-run.
+	for _, envStatus := range sccs.Status.Environments {
+		if envStatus.Phase != string(promoterv1alpha1.CommitPhaseSuccess) {
+			requeueDuration, err := settings.GetRequeueDuration[promoterv1alpha1.ScmCheckCommitStatusConfiguration](ctx, r.SettingsMgr)
+			if err != nil {
+				return ctrl.Result{}, fmt.Errorf("failed to get ScmCheckCommitStatus requeue duration: %w", err)
+			}
+			return ctrl.Result{RequeueAfter: requeueDuration}, nil
+		}
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ScmCheckCommitStatusReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
+	// Use Direct methods to read configuration from the API server without cache during setup.
+	// The cache is not started during SetupWithManager, so we must use the non-cached API reader.
+	rateLimiter, err := settings.GetRateLimiterDirect[promoterv1alpha1.ScmCheckCommitStatusConfiguration, ctrl.Request](ctx, r.SettingsMgr)
+	if err != nil {
+		return fmt.Errorf("failed to get ScmCheckCommitStatus rate limiter: %w", err)
+	}
+
+	maxConcurrentReconciles, err := settings.GetMaxConcurrentReconcilesDirect[promoterv1alpha1.ScmCheckCommitStatusConfiguration](ctx, r.SettingsMgr)
+	if err != nil {
+		return fmt.Errorf("failed to get ScmCheckCommitStatus max concurrent reconciles: %w", err)
+	}
+
+	err = ctrl.NewControllerManagedBy(mgr).
+		For(&promoterv1alpha1.ScmCheckCommitStatus{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&promoterv1alpha1.PromotionStrategy{}, r.enqueueScmCheckCommitStatusForPromotionStrategy()).
+		Named("scmcheckcommitstatus").
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: maxConcurrentReconciles,
+			RateLimiter:             rateLimiter,
+		}).
+		Complete(r)
+	if err != nil {
+		return fmt.Errorf("failed to create controller: %w", err)
+	}
+	return nil
+}
+
+// getApplicableEnvironments returns the environments from the PromotionStrategy that this ScmCheckCommitStatus applies
+// to. An environment is applicable if the ScmCheckCommitStatus key is referenced in either:
+// - The global ps.Spec.ProposedCommitStatuses, or
+// - The environment-specific psEnv.ProposedCommitStatuses
+func (r *ScmCheckCommitStatusReconciler) getApplicableEnvironments(ps *promoterv1alpha1.PromotionStrategy, key string) []promoterv1alpha1.Environment {
+	// Check if globally referenced
+	globallyProposed := false
+	for _, selector := range ps.Spec.ProposedCommitStatuses {
+		if selector.Key == key {
+			globallyProposed = true
+			break
+		}
+	}
+
+	applicable := make([]promoterv1alpha1.Environment, 0, len(ps.Spec.Environments))
+	for _, env := range ps.Spec.Environments {
+		if globallyProposed {
+			applicable = append(applicable, env)
+			continue
+		}
+		for _, selector := range env.ProposedCommitStatuses {
+			if selector.Key == key {
+				applicable = append(applicable, env)
+				break
+			}
+		}
+	}
+	return applicable
+}
+
+// processEnvironments reads the checks reported on the proposed hydrated commit of each applicable environment and
+// mirrors them into a CommitStatus. Returns a list of environment branches that transitioned from non-success to
+// success and the CommitStatus objects created/updated.
+func (r *ScmCheckCommitStatusReconciler) processEnvironments(ctx context.Context, sccs *promoterv1alpha1.ScmCheckCommitStatus, ps *promoterv1alpha1.PromotionStrategy) ([]string, []*promoterv1alpha1.CommitStatus, error) {
+	logger := log.FromContext(ctx)
+
+	// Save the previous status before clearing it, so we can detect transitions
+	previousPhases := make(map[string]string, len(sccs.Status.Environments))
+	for _, envStatus := range sccs.Status.Environments {
+		previousPhases[envStatus.Branch] = envStatus.Phase
+	}
+
+	// Build a map of environment statuses for efficient lookup
+	envStatusMap := make(map[string]*promoterv1alpha1.EnvironmentStatus, len(ps.Status.Environments))
+	for i := range ps.Status.Environments {
+		envStatusMap[ps.Status.Environments[i].Branch] = &ps.Status.Environments[i]
+	}
+
+	// Get environments this ScmCheckCommitStatus applies to
+	applicableEnvs := r.getApplicableEnvironments(ps, sccs.Spec.Key)
+
+	transitionedEnvironments := make([]string, 0)
+	commitStatuses := make([]*promoterv1alpha1.CommitStatus, 0, len(applicableEnvs))
+	sccs.Status.Environments = make([]promoterv1alpha1.ScmCheckCommitStatusEnvironmentStatus, 0, len(applicableEnvs))
+	if len(applicableEnvs) == 0 {
+		return transitionedEnvironments, commitStatuses, nil
+	}
+
+	checkProvider, gitRepo, err := r.getCommitCheckProvider(ctx, sccs, ps)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, env := range applicableEnvs {
+		branch := env.Branch
+
+		envStatus, found := envStatusMap[branch]
+		if !found {
+			return nil, nil, fmt.Errorf("environment %q not found in PromotionStrategy status", branch)
+		}
+
+		// If the PromotionStrategy hasn't fully reconciled, the SHA might be empty and there is nothing to read.
+		proposedSha := envStatus.Proposed.Hydrated.Sha
+		if proposedSha == "" {
+			return nil, nil, fmt.Errorf("proposed hydrated SHA not yet available for branch %q: PromotionStrategy may not be fully reconciled", branch)
+		}
+
+		reportedChecks, err := checkProvider.GetCommitChecks(ctx, gitRepo, proposedSha)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get checks for branch %q at SHA %q: %w", branch, proposedSha, err)
+		}
+
+		results := matchCommitChecks(sccs.Spec.Checks, reportedChecks)
+		phase := combineCheckPhases(results)
+
+		if previousPhases[branch] != string(promoterv1alpha1.CommitPhaseSuccess) && phase == promoterv1alpha1.CommitPhaseSuccess {
+			transitionedEnvironments = append(transitionedEnvironments, branch)
+			logger.Info("Checks transitioned to success",
+				"branch", branch,
+				"sha", proposedSha)
+		}
+
+		sccs.Status.Environments = append(sccs.Status.Environments, promoterv1alpha1.ScmCheckCommitStatusEnvironmentStatus{
+			Branch:              branch,
+			ProposedHydratedSha: proposedSha,
+			Phase:               string(phase),
+			Checks:              results,
+		})
+
+		cs, err := r.upsertCommitStatus(ctx, sccs, ps.Spec.RepositoryReference.Name, branch, proposedSha, phase, results)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to upsert CommitStatus for environment %q: %w", branch, err)
+		}
+		commitStatuses = append(commitStatuses, cs)
+
+		logger.Info("Processed environment checks",
+			"branch", branch,
+			"proposedSha", proposedSha,
+			"phase", phase,
+			"key", sccs.Spec.Key,
+			"reportedChecks", len(reportedChecks))
+	}
+
+	return transitionedEnvironments, commitStatuses, nil
+}
+
+// getCommitCheckProvider returns the provider used to read checks from the SCM hosting the PromotionStrategy's
+// repository, along with the GitRepository.
+func (r *ScmCheckCommitStatusReconciler) getCommitCheckProvider(ctx context.Context, sccs *promoterv1alpha1.ScmCheckCommitStatus, ps *promoterv1alpha1.PromotionStrategy) (scms.CommitCheckProvider, *promoterv1alpha1.GitRepository, error) {
+	provider, gitRepo, err := getCommitStatusProviderForRepository(ctx, r.Client, r.SettingsMgr, ps.Spec.RepositoryReference, sccs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get CommitStatus provider: %w", err)
+	}
+	checkProvider, ok := provider.(scms.CommitCheckProvider)
+	if !ok {
+		return nil, nil, fmt.Errorf("the SCM provider of GitRepository %q does not support reading commit checks", gitRepo.Name)
+	}
+	return checkProvider, gitRepo, nil
+}
+
+// matchCommitChecks returns the result of each selected check, in the order of the selection. When the SCM reports
+// several checks with the same name, such as a check run and a commit status, they are combined. A check that has not
+// been reported is pending.
+func matchCommitChecks(selected []promoterv1alpha1.ScmCheck, reported []scms.CommitCheck) []promoterv1alpha1.ScmCheckResult {
+	results := make([]promoterv1alpha1.ScmCheckResult, 0, len(selected))
+	for _, check := range selected {
+		result := promoterv1alpha1.ScmCheckResult{Name: check.Name}
+		var matched []promoterv1alpha1.ScmCheckResult
+		for _, reportedCheck := range reported {
+			if reportedCheck.Name != check.Name {
+				continue
+			}
+			matched = append(matched, promoterv1alpha1.ScmCheckResult{
+				Name:        reportedCheck.Name,
+				Phase:       string(reportedCheck.Phase),
+				Description: reportedCheck.Description,
+				Url:         reportedCheck.Url,
+			})
+		}
+		if len(matched) == 0 {
+			result.Phase = string(promoterv1alpha1.CommitPhasePending)
+			result.Description = "Not reported"
+			results = append(results, result)
+			continue
+		}
+
+		// Describe the combined check with the first reported check that has the combined phase.
+		result.Phase = string(combineCheckPhases(matched))
+		for _, m := range matched {
+			if m.Phase == result.Phase {
+				result.Description = m.Description
+				result.Url = m.Url
+				break
+			}
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// combineCheckPhases returns failure if any check failed, pending if any check is pending, and success otherwise.
+func combineCheckPhases(results []promoterv1alpha1.ScmCheckResult) promoterv1alpha1.CommitStatusPhase {
+	phase := promoterv1alpha1.CommitPhaseSuccess
+	for _, result := range results {
+		switch promoterv1alpha1.CommitStatusPhase(result.Phase) {
+		case promoterv1alpha1.CommitPhaseFailure:
+			return promoterv1alpha1.CommitPhaseFailure
+		case promoterv1alpha1.CommitPhasePending:
+			phase = promoterv1alpha1.CommitPhasePending
+		}
+	}
+	return phase
+}
+
+// upsertCommitStatus creates or updates the CommitStatus resource that mirrors the checks of an environment. Unless the
+// spec sets a description, the description summarizes how many checks succeeded. The URL links to the first check that
+// has the combined phase.
+func (r *ScmCheckCommitStatusReconciler) upsertCommitStatus(ctx context.Context, sccs *promoterv1alpha1.ScmCheckCommitStatus, repositoryRefName, branch, sha string, phase promoterv1alpha1.CommitStatusPhase, results []promoterv1alpha1.ScmCheckResult) (*promoterv1alpha1.CommitStatus, error) {
+	// Generate a consistent name for the CommitStatus
+	commitStatusName := utils.KubeSafeUniqueName(ctx, fmt.Sprintf("%s-%s-scmcheck", sccs.Name, branch))
+
+	description := sccs.Spec.Description
+	if description == "" {
+		succeeded := 0
+		for _, result := range results {
+			if result.Phase == string(promoterv1alpha1.CommitPhaseSuccess) {
+				succeeded++
+			}
+		}
+		description = fmt.Sprintf("%d of %d checks succeeded", succeeded, len(results))
+	}
+
+	// Build owner reference
+	kind := reflect.TypeOf(promoterv1alpha1.ScmCheckCommitStatus{}).Name()
+	gvk := promoterv1alpha1.GroupVersion.WithKind(kind)
+
+	commitStatusSpec := acv1alpha1.CommitStatusSpec().
+		WithRepositoryReference(acv1alpha1.ObjectReference().WithName(repositoryRefName)).
+		WithName(sccs.Spec.Key + "/" + branch).
+		WithDescription(description).
+		WithPhase(phase).
+		WithSha(sha)
+	for _, result := range results {
+		if result.Phase == string(phase) && result.Url != "" {
+			commitStatusSpec = commitStatusSpec.WithUrl(result.Url)
+			break
+		}
+	}
+
+	commitStatusApply := acv1alpha1.CommitStatus(commitStatusName, sccs.Namespace).
+		WithLabels(map[string]string{
+			promoterv1alpha1.ScmCheckCommitStatusLabel: utils.KubeSafeLabel(sccs.Name),
+			promoterv1alpha1.EnvironmentLabel:          utils.KubeSafeLabel(branch),
+			promoterv1alpha1.CommitStatusLabel:         sccs.Spec.Key,
+		}).
+		WithOwnerReferences(acmetav1.OwnerReference().
+			WithAPIVersion(gvk.GroupVersion().String()).
+			WithKind(gvk.Kind).
+			WithName(sccs.Name).
+			WithUID(sccs.UID).
+			WithController(true).
+			WithBlockOwnerDeletion(true)).
+		WithSpec(commitStatusSpec)
+
+	// Apply using Server-Side Apply with Patch to get the result directly
+	commitStatus := &promoterv1alpha1.CommitStatus{}
+	commitStatus.Name = commitStatusName
+	commitStatus.Namespace = sccs.Namespace
+	if err := r.Patch(ctx, commitStatus, utils.ApplyPatch{ApplyConfig: commitStatusApply}, client.FieldOwner(constants.ScmCheckCommitStatusControllerFieldOwner), client.ForceOwnership); err != nil {
+		return nil, fmt.Errorf("failed to apply CommitStatus: %w", err)
+	}
+
+	return commitStatus, nil
+}
+
+// touchChangeTransferPolicies triggers reconciliation of the ChangeTransferPolicies
+// for the environments whose checks transitioned to success.
+// This triggers the ChangeTransferPolicy controller to reconcile and potentially merge PRs.
+func (r *ScmCheckCommitStatusReconciler) touchChangeTransferPolicies(ctx context.Context, ps *promoterv1alpha1.PromotionStrategy, transitionedEnvironments []string) {
+	logger := log.FromContext(ctx)
+
+	for _, envBranch := range transitionedEnvironments {
+		// Generate the ChangeTransferPolicy name using the same logic as the PromotionStrategy controller
+		ctpName := utils.KubeSafeUniqueName(ctx, utils.GetChangeTransferPolicyName(ps.Name, envBranch))
+
+		logger.Info("Triggering ChangeTransferPolicy reconciliation due to checks transition",
+			"changeTransferPolicy", ctpName,
+			"branch", envBranch)
+
+		// Use the enqueue function to trigger reconciliation.
+		if r.EnqueueCTP != nil {
+			r.EnqueueCTP(ps.Namespace, ctpName)
+		}
+	}
+}
+
+// enqueueScmCheckCommitStatusForPromotionStrategy returns a handler that enqueues all ScmCheckCommitStatus resources
+// that reference a PromotionStrategy when that PromotionStrategy changes.
+func (r *ScmCheckCommitStatusReconciler) enqueueScmCheckCommitStatusForPromotionStrategy() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []ctrl.Request {
+		ps, ok := obj.(*promoterv1alpha1.PromotionStrategy)
+		if !ok {
+			return nil
+		}
+
+		// List all ScmCheckCommitStatus resources in the same namespace
+		var sccsList promoterv1alpha1.ScmCheckCommitStatusList
+		if err := r.List(ctx, &sccsList, client.InNamespace(ps.Namespace)); err != nil {
+			log.FromContext(ctx).Error(err, "failed to list ScmCheckCommitStatus resources")
+			return nil
+		}
+
+		// Enqueue all ScmCheckCommitStatus resources that reference this PromotionStrategy
+		var requests []ctrl.Request
+		for _, sccs := range sccsList.Items {
+			if sccs.Spec.PromotionStrategyRef.Name == ps.Name {
+				requests = append(requests, ctrl.Request{
+					NamespacedName: client.ObjectKeyFromObject(&sccs),
+				})
+			}
+		}
+
+		return requests
+	})
+}
@@ -0,0 +1,208 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	_ "embed"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms/fake"
+	"github.com/argoproj-labs/gitops-promoter/internal/types/constants"
+	"github.com/argoproj-labs/gitops-promoter/internal/utils"
+)
+
+//go:embed testdata/ScmCheckCommitStatus.yaml
+var testScmCheckCommitStatusYAML string
+
+var _ = Describe("ScmCheckCommitStatus Controller", Ordered, func() {
+	Context("When unmarshalling the test data", func() {
+		It("should unmarshal the ScmCheckCommitStatus resource", func() {
+			err := unmarshalYamlStrict(testScmCheckCommitStatusYAML, &promoterv1alpha1.ScmCheckCommitStatus{})
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+
+	var (
+		ctx                  context.Context
+		name                 string
+		scmSecret            *v1.Secret
+		scmProvider          *promoterv1alpha1.ScmProvider
+		gitRepo              *promoterv1alpha1.GitRepository
+		promotionStrategy    *promoterv1alpha1.PromotionStrategy
+		scmCheckCommitStatus *promoterv1alpha1.ScmCheckCommitStatus
+	)
+
+	BeforeAll(func() {
+		ctx = context.Background()
+
+		By("Setting up test git repository and resources")
+		name, scmSecret, scmProvider, gitRepo, _, _, promotionStrategy = promotionStrategyResource(ctx, "scm-check-commit-status-test", "default")
+
+		// Configure ProposedCommitStatuses to check for the mirrored checks
+		promotionStrategy.Spec.ProposedCommitStatuses = []promoterv1alpha1.CommitStatusSelector{
+			{Key: "ci-checks"},
+		}
+
+		setupInitialTestGitRepoOnServer(ctx, gitRepo)
+
+		Expect(k8sClient.Create(ctx, scmSecret)).To(Succeed())
+		Expect(k8sClient.Create(ctx, scmProvider)).To(Succeed())
+		Expect(k8sClient.Create(ctx, gitRepo)).To(Succeed())
+		Expect(k8sClient.Create(ctx, promotionStrategy)).To(Succeed())
+	})
+
+	AfterAll(func() {
+		By("Cleaning up test resources")
+		if scmCheckCommitStatus != nil {
+			_ = k8sClient.Delete(ctx, scmCheckCommitStatus)
+		}
+		if promotionStrategy != nil {
+			_ = k8sClient.Delete(ctx, promotionStrategy)
+		}
+		if gitRepo != nil {
+			_ = k8sClient.Delete(ctx, gitRepo)
+		}
+		if scmProvider != nil {
+			_ = k8sClient.Delete(ctx, scmProvider)
+		}
+		if scmSecret != nil {
+			_ = k8sClient.Delete(ctx, scmSecret)
+		}
+	})
+
+	It("should mirror the checks reported on the proposed commit into a commit status", func() {
+		By("Waiting for the PromotionStrategy to report the proposed hydrated commits")
+		proposedShas := map[string]string{}
+		Eventually(func(g Gomega) {
+			var ps promoterv1alpha1.PromotionStrategy
+			g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: name, Namespace: "default"}, &ps)).To(Succeed())
+			g.Expect(ps.Status.Environments).To(HaveLen(3))
+			for _, env := range ps.Status.Environments {
+				g.Expect(env.Proposed.Hydrated.Sha).ToNot(BeEmpty())
+				proposedShas[env.Branch] = env.Proposed.Hydrated.Sha
+			}
+		}, constants.EventuallyTimeout).Should(Succeed())
+
+		By("Reporting a successful e2e check and a running lint check")
+		for _, sha := range proposedShas {
+			fake.SetCommitChecks(sha,
+				scms.CommitCheck{Name: "e2e", Phase: promoterv1alpha1.CommitPhaseSuccess, Url: "https://ci.example.com/e2e"},
+				scms.CommitCheck{Name: "lint", Phase: promoterv1alpha1.CommitPhasePending, Url: "https://ci.example.com/lint"},
+				scms.CommitCheck{Name: "unrelated", Phase: promoterv1alpha1.CommitPhaseFailure},
+			)
+		}
+
+		scmCheckCommitStatus = &promoterv1alpha1.ScmCheckCommitStatus{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+			},
+			Spec: promoterv1alpha1.ScmCheckCommitStatusSpec{
+				PromotionStrategyRef: promoterv1alpha1.ObjectReference{Name: name},
+				Key:                  "ci-checks",
+				Checks:               []promoterv1alpha1.ScmCheck{{Name: "e2e"}, {Name: "lint"}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, scmCheckCommitStatus)).To(Succeed())
+
+		commitStatusKey := types.NamespacedName{
+			Name:      utils.KubeSafeUniqueName(ctx, name+"-"+testBranchDevelopment+"-scmcheck"),
+			Namespace: "default",
+		}
+
+		Eventually(func(g Gomega) {
+			var sccs promoterv1alpha1.ScmCheckCommitStatus
+			g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(scmCheckCommitStatus), &sccs)).To(Succeed())
+			g.Expect(sccs.Status.Environments).To(HaveLen(3))
+			for _, env := range sccs.Status.Environments {
+				g.Expect(env.ProposedHydratedSha).To(Equal(proposedShas[env.Branch]))
+				g.Expect(env.Phase).To(Equal(string(promoterv1alpha1.CommitPhasePending)))
+				g.Expect(env.Checks).To(HaveLen(2))
+				g.Expect(env.Checks[0].Phase).To(Equal(string(promoterv1alpha1.CommitPhaseSuccess)))
+				g.Expect(env.Checks[1].Phase).To(Equal(string(promoterv1alpha1.CommitPhasePending)))
+			}
+
+			var cs promoterv1alpha1.CommitStatus
+			g.Expect(k8sClient.Get(ctx, commitStatusKey, &cs)).To(Succeed())
+			g.Expect(cs.Spec.Sha).To(Equal(proposedShas[testBranchDevelopment]))
+			g.Expect(cs.Spec.Name).To(Equal("ci-checks/" + testBranchDevelopment))
+			g.Expect(cs.Spec.Phase).To(Equal(promoterv1alpha1.CommitPhasePending))
+			g.Expect(cs.Spec.Description).To(Equal("1 of 2 checks succeeded"))
+			g.Expect(cs.Spec.Url).To(Equal("https://ci.example.com/lint"))
+			g.Expect(cs.Labels).To(HaveKeyWithValue(promoterv1alpha1.CommitStatusLabel, "ci-checks"))
+		}, constants.EventuallyTimeout).Should(Succeed())
+
+		By("Reporting the lint check as successful")
+		for _, sha := range proposedShas {
+			fake.SetCommitChecks(sha,
+				scms.CommitCheck{Name: "e2e", Phase: promoterv1alpha1.CommitPhaseSuccess},
+				scms.CommitCheck{Name: "lint", Phase: promoterv1alpha1.CommitPhaseSuccess},
+			)
+		}
+
+		// Change the spec so the checks are read again without waiting for the requeue duration.
+		Eventually(func(g Gomega) {
+			var sccs promoterv1alpha1.ScmCheckCommitStatus
+			g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(scmCheckCommitStatus), &sccs)).To(Succeed())
+			sccs.Spec.Description = "CI checks"
+			g.Expect(k8sClient.Update(ctx, &sccs)).To(Succeed())
+		}, constants.EventuallyTimeout).Should(Succeed())
+
+		Eventually(func(g Gomega) {
+			var cs promoterv1alpha1.CommitStatus
+			g.Expect(k8sClient.Get(ctx, commitStatusKey, &cs)).To(Succeed())
+			g.Expect(cs.Spec.Phase).To(Equal(promoterv1alpha1.CommitPhaseSuccess))
+			g.Expect(cs.Spec.Description).To(Equal("CI checks"))
+		}, constants.EventuallyTimeout).Should(Succeed())
+	})
+
+	It("should report a failure as soon as any check failed", func() {
+		var ps promoterv1alpha1.PromotionStrategy
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: name, Namespace: "default"}, &ps)).To(Succeed())
+		for _, env := range ps.Status.Environments {
+			fake.SetCommitChecks(env.Proposed.Hydrated.Sha,
+				scms.CommitCheck{Name: "e2e", Phase: promoterv1alpha1.CommitPhaseFailure, Url: "https://ci.example.com/e2e"},
+			)
+		}
+
+		Eventually(func(g Gomega) {
+			var sccs promoterv1alpha1.ScmCheckCommitStatus
+			g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(scmCheckCommitStatus), &sccs)).To(Succeed())
+			sccs.Spec.Description = ""
+			g.Expect(k8sClient.Update(ctx, &sccs)).To(Succeed())
+		}, constants.EventuallyTimeout).Should(Succeed())
+
+		Eventually(func(g Gomega) {
+			var sccs promoterv1alpha1.ScmCheckCommitStatus
+			g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(scmCheckCommitStatus), &sccs)).To(Succeed())
+			g.Expect(sccs.Status.Environments).To(HaveLen(3))
+			for _, env := range sccs.Status.Environments {
+				g.Expect(env.Phase).To(Equal(string(promoterv1alpha1.CommitPhaseFailure)))
+				g.Expect(env.Checks[1].Description).To(Equal("Not reported"))
+			}
+		}, constants.EventuallyTimeout).Should(Succeed())
+	})
+})
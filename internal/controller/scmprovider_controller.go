@@ -29,6 +29,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
 	"github.com/argoproj-labs/gitops-promoter/internal/utils"
@@ -75,13 +76,15 @@ func (r *ScmProviderReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{}, fmt.Errorf("failed to ensure Secret finalizer: %w", err)
 	}
 
-	return ctrl.Result{}, nil
+	// Requeue when the throttling is expected to end, so that the condition is cleared.
+	return ctrl.Result{RequeueAfter: setThrottledCondition(&scmProvider, &scmProvider.Status.Conditions, scmProvider.Namespace)}, nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *ScmProviderReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
 	err := ctrl.NewControllerManagedBy(mgr).
 		For(&promoterv1alpha1.ScmProvider{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		WatchesRawSource(throttledSecretSource(r.scmProvidersForSecret)).
 		Complete(r)
 	if err != nil {
 		return fmt.Errorf("failed to create controller: %w", err)
@@ -89,6 +92,23 @@ func (r *ScmProviderReconciler) SetupWithManager(ctx context.Context, mgr ctrl.M
 	return nil
 }
 
+// scmProvidersForSecret returns reconcile requests for the ScmProviders that use the secret.
+func (r *ScmProviderReconciler) scmProvidersForSecret(ctx context.Context, secret client.Object) []reconcile.Request {
+	var scmProviders promoterv1alpha1.ScmProviderList
+	if err := r.List(ctx, &scmProviders, client.InNamespace(secret.GetNamespace())); err != nil {
+		log.FromContext(ctx).Error(err, "failed to list ScmProviders for throttled secret", "secret", secret.GetName())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, scmProvider := range scmProviders.Items {
+		if scmProvider.Spec.SecretRef != nil && scmProvider.Spec.SecretRef.Name == secret.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&scmProvider)})
+		}
+	}
+	return requests
+}
+
 func (r *ScmProviderReconciler) handleFinalizer(ctx context.Context, scmProvider *promoterv1alpha1.ScmProvider) (bool, error) {
 	// Check for dependent GitRepositories before allowing deletion
 	checkDependencies := func() ([]string, error) {
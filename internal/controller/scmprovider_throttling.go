@@ -0,0 +1,99 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms/ratelimit"
+	promoterConditions "github.com/argoproj-labs/gitops-promoter/internal/types/conditions"
+)
+
+// setThrottledCondition sets the Throttled condition of an SCM provider from the rate limiters of the credentials in
+// its secret, which lives in secretNamespace. It returns how long until the throttling is expected to end, or zero if
+// the provider is not throttled.
+func setThrottledCondition(scmProvider promoterv1alpha1.GenericScmProvider, conditions *[]metav1.Condition, secretNamespace string) time.Duration {
+	secretRef := scmProvider.GetSpec().SecretRef
+	if secretRef == nil {
+		meta.RemoveStatusCondition(conditions, string(promoterConditions.Throttled))
+		return 0
+	}
+
+	throttles := ratelimit.Throttles(types.NamespacedName{Namespace: secretNamespace, Name: secretRef.Name})
+	if len(throttles) == 0 {
+		meta.SetStatusCondition(conditions, metav1.Condition{
+			Type:               string(promoterConditions.Throttled),
+			Status:             metav1.ConditionFalse,
+			Reason:             string(promoterConditions.NotThrottled),
+			Message:            "The SCM is not rate limiting requests",
+			ObservedGeneration: scmProvider.GetGeneration(),
+		})
+		return 0
+	}
+
+	reason := promoterConditions.RateLimitLow
+	var until time.Time
+	messages := make([]string, 0, len(throttles))
+	for _, throttle := range throttles {
+		if throttle.Exhausted {
+			reason = promoterConditions.RateLimitExceeded
+		}
+		if throttle.Until.After(until) {
+			until = throttle.Until
+		}
+		host := throttle.Host
+		if throttle.Scope != "" {
+			host = fmt.Sprintf("%s (%s)", host, throttle.Scope)
+		}
+		messages = append(messages, fmt.Sprintf("%s until %s: %s", host, throttle.Until.UTC().Format(time.RFC3339), throttle.Reason))
+	}
+	meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               string(promoterConditions.Throttled),
+		Status:             metav1.ConditionTrue,
+		Reason:             string(reason),
+		Message:            "Requests to " + strings.Join(messages, "; "),
+		ObservedGeneration: scmProvider.GetGeneration(),
+	})
+	return time.Until(until)
+}
+
+// throttledSecretSource returns a source that maps the secrets whose credentials start being throttled to reconcile
+// requests with mapFunc, so that SCM providers report throttling as soon as it starts.
+func throttledSecretSource(mapFunc handler.MapFunc) source.Source {
+	// We use a buffer of 1024 to match the default internal buffer size of source.Channel.
+	throttledSecrets := make(chan event.GenericEvent, 1024)
+	ratelimit.OnThrottle(func(secret types.NamespacedName) {
+		select {
+		case throttledSecrets <- event.GenericEvent{Object: &v1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: secret.Namespace, Name: secret.Name}}}:
+		default:
+			// The listener must not block the request that was throttled. If the buffer is full, the providers of this
+			// secret are already about to be reconciled.
+		}
+	})
+	return source.Channel(throttledSecrets, handler.EnqueueRequestsFromMapFunc(mapFunc))
+}
@@ -1299,11 +1299,23 @@ func allowedHostsForScmProvider(spec *promoterv1alpha1.ScmProviderSpec) string {
 		return spec.Gitea.Domain
 	case spec.BitbucketCloud != nil:
 		return "api.bitbucket.org"
+	case spec.BitbucketServer != nil:
+		host, _, _ := strings.Cut(spec.BitbucketServer.Domain, "/")
+		return host
 	case spec.AzureDevOps != nil:
 		if spec.AzureDevOps.Domain != "" {
 			return spec.AzureDevOps.Domain
 		}
 		return "dev.azure.com"
+	case spec.Gerrit != nil:
+		host, _, _ := strings.Cut(spec.Gerrit.Domain, "/")
+		return host
+	case spec.Generic != nil:
+		u, err := url.Parse(spec.Generic.URL)
+		if err != nil {
+			return ""
+		}
+		return u.Host
 	case spec.Fake != nil:
 		return spec.Fake.Domain
 	default:
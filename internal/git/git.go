@@ -13,9 +13,12 @@ import (
 	"context"
 	_ "embed"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"maps"
 	"os"
 	"os/exec"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -71,11 +74,16 @@ func (g *EnvironmentOperations) CloneRepo(ctx context.Context) error {
 	}
 	logger.V(4).Info("Created directory", "directory", path)
 
+	repoUrl, err := remoteUrl(g.gap, g.gitRepo)
+	if err != nil {
+		return err
+	}
+
 	start := time.Now()
-	stdout, stderr, err := g.runCmd(ctx, path, "clone", "--verbose", "--progress", "--filter=blob:none", g.gap.GetGitHttpsRepoUrl(*g.gitRepo), path)
+	stdout, stderr, err := g.runCmd(ctx, path, "clone", "--verbose", "--progress", "--filter=blob:none", repoUrl, path)
 	metrics.RecordGitOperation(g.gitRepo, metrics.GitOperationClone, metrics.GitOperationResultFromError(err), time.Since(start))
 	if err != nil {
-		logger.Error(err, "Cloned repo failed", "repo", g.gap.GetGitHttpsRepoUrl(*g.gitRepo), "stdout", stdout, "stderr", stderr)
+		logger.Error(err, "Cloned repo failed", "repo", repoUrl, "stdout", stdout, "stderr", stderr)
 		return err
 	}
 
@@ -111,25 +119,44 @@ type BranchShas struct {
 	Hydrated string
 }
 
-// GetBranchShas checks out the given branch, pulls the latest changes, and returns the hydrated and dry SHAs.
+// GetBranchShas fetches the given branch and returns the hydrated and dry SHAs.
 func (g *EnvironmentOperations) GetBranchShas(ctx context.Context, branch string) (BranchShas, error) {
+	if err := g.FetchBranches(ctx, branch); err != nil {
+		return BranchShas{}, err
+	}
+	return g.GetFetchedBranchShas(ctx, branch)
+}
+
+// FetchBranches fetches the given branches with a single git fetch, so that their remote refs are up to date. Git
+// commands that write to the clone, such as fetches, must not run concurrently in the same clone.
+func (g *EnvironmentOperations) FetchBranches(ctx context.Context, branches ...string) error {
 	logger := log.FromContext(ctx)
 	gitPath := gitpaths.Get(g.gap.GetGitHttpsRepoUrl(*g.gitRepo) + g.activeBranch)
 	if gitPath == "" {
-		return BranchShas{}, fmt.Errorf("no repo path found for repo %q", g.gitRepo.Name)
+		return fmt.Errorf("no repo path found for repo %q", g.gitRepo.Name)
 	}
 
 	logger.V(4).Info("git path", "path", gitPath)
 
-	// Fetch the branch to ensure we have the latest remote ref
 	start := time.Now()
-	_, stderr, err := g.runCmd(ctx, gitPath, "fetch", "origin", branch)
+	_, stderr, err := g.runCmd(ctx, gitPath, append([]string{"fetch", "origin"}, branches...)...)
 	metrics.RecordGitOperation(g.gitRepo, metrics.GitOperationFetch, metrics.GitOperationResultFromError(err), time.Since(start))
 	if err != nil {
-		logger.Error(err, "could not fetch branch", "gitError", stderr)
-		return BranchShas{}, fmt.Errorf("failed to fetch branch %q: %w", branch, err)
+		logger.Error(err, "could not fetch branches", "gitError", stderr)
+		return fmt.Errorf("failed to fetch branches %q: %w", branches, err)
+	}
+	logger.V(4).Info("Fetched branches", "branches", branches)
+	return nil
+}
+
+// GetFetchedBranchShas returns the hydrated and dry SHAs of a branch fetched earlier with FetchBranches. It only
+// reads from the clone, so it may run concurrently with other reads.
+func (g *EnvironmentOperations) GetFetchedBranchShas(ctx context.Context, branch string) (BranchShas, error) {
+	logger := log.FromContext(ctx)
+	gitPath := gitpaths.Get(g.gap.GetGitHttpsRepoUrl(*g.gitRepo) + g.activeBranch)
+	if gitPath == "" {
+		return BranchShas{}, fmt.Errorf("no repo path found for repo %q", g.gitRepo.Name)
 	}
-	logger.V(4).Info("Fetched branch", "branch", branch)
 
 	// Get the SHA of the remote branch
 	stdout, stderr, err := g.runCmd(ctx, gitPath, "rev-parse", "origin/"+branch)
@@ -327,9 +354,14 @@ func (g *EnvironmentOperations) GetShaTime(ctx context.Context, sha string) (v1.
 func LsRemote(ctx context.Context, gap scms.GitOperationsProvider, gitRepo *v1alpha1.GitRepository, branches ...string) (map[string]string, error) {
 	logger := log.FromContext(ctx)
 
+	repoUrl, err := remoteUrl(gap, gitRepo)
+	if err != nil {
+		return nil, err
+	}
+
 	start := time.Now()
 	args := make([]string, 0, 3+len(branches))
-	args = append(args, "ls-remote", "--heads", gap.GetGitHttpsRepoUrl(*gitRepo))
+	args = append(args, "ls-remote", "--heads", repoUrl)
 	args = append(args, branches...)
 	stdout, stderr, err := runCmd(ctx, gap, "", args...)
 	metrics.RecordGitOperation(gitRepo, metrics.GitOperationLsRemote, metrics.GitOperationResultFromError(err), time.Since(start))
@@ -370,47 +402,158 @@ func LsRemote(ctx context.Context, gap scms.GitOperationsProvider, gitRepo *v1al
 		shas[branch] = sha
 	}
 
-	logger.Info("ls-remote called", "repoUrl", gap.GetGitHttpsRepoUrl(*gitRepo), "branches", branches, "shas", shas)
+	logger.Info("ls-remote called", "repoUrl", repoUrl, "branches", branches, "shas", shas)
 
 	return shas, nil
 }
 
-// runCmd runs a git command in the given directory with the provided arguments and returns stdout, stderr, and error.
-func (g *EnvironmentOperations) runCmd(ctx context.Context, directory string, args ...string) (string, string, error) {
-	return runCmd(ctx, g.gap, directory, args...)
+// MergeBranch merges the source branch into the target branch with a merge commit and pushes the result. It is used by
+// SCM providers that have no pull request API to merge with. The merge happens in a temporary clone so that it never
+// touches the per-environment clones. If expectedSha is set and the source branch no longer points at it, no merge is
+// made. If the target branch moves while merging, the push is rejected and the merge can be retried.
+func MergeBranch(ctx context.Context, gap scms.GitOperationsProvider, gitRepo *v1alpha1.GitRepository, sourceBranch, targetBranch, expectedSha, message string) error {
+	_, err := pushMerge(ctx, gap, gitRepo, sourceBranch, targetBranch, expectedSha, message, "refs/heads/"+targetBranch)
+	return err
 }
 
-// runCmd runs a git command with the provided arguments and returns stdout, stderr, and error.
-func runCmd(ctx context.Context, gap scms.GitOperationsProvider, directory string, args ...string) (string, string, error) {
-	user, err := gap.GetUser(ctx)
+// PushMergeForReview creates the same merge commit as MergeBranch, but pushes it to refName instead of the target
+// branch. It is used by SCM providers such as Gerrit that review commits pushed to a special ref. It returns the SHA of
+// the merge commit.
+func PushMergeForReview(ctx context.Context, gap scms.GitOperationsProvider, gitRepo *v1alpha1.GitRepository, sourceBranch, targetBranch, expectedSha, message, refName string) (string, error) {
+	return pushMerge(ctx, gap, gitRepo, sourceBranch, targetBranch, expectedSha, message, refName)
+}
+
+// pushMerge merges the source branch into the target branch in a temporary clone and pushes the merge commit to
+// refName, returning the SHA of the merge commit.
+func pushMerge(ctx context.Context, gap scms.GitOperationsProvider, gitRepo *v1alpha1.GitRepository, sourceBranch, targetBranch, expectedSha, message, refName string) (string, error) {
+	logger := log.FromContext(ctx)
+
+	path, err := os.MkdirTemp("", "*")
 	if err != nil {
-		return "", "", fmt.Errorf("failed to get user: %w", err)
+		return "", fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(path); err != nil {
+			logger.Error(err, "failed to remove temp directory", "directory", path)
+		}
+	}()
+
+	repoUrl, err := remoteUrl(gap, gitRepo)
+	if err != nil {
+		return "", err
+	}
+
+	start := time.Now()
+	_, stderr, err := runCmd(ctx, gap, path, "clone", "--filter=blob:none", "--no-tags", "--branch", targetBranch, repoUrl, path)
+	metrics.RecordGitOperation(gitRepo, metrics.GitOperationClone, metrics.GitOperationResultFromError(err), time.Since(start))
+	if err != nil {
+		logger.Error(err, "could not clone repo to merge", "repo", repoUrl, "stderr", stderr)
+		return "", fmt.Errorf("failed to clone branch %q: %w", targetBranch, err)
 	}
 
-	token, err := gap.GetToken(ctx)
+	for _, kv := range [][2]string{{"user.name", "GitOps Promoter"}, {"user.email", "GitOpsPromoter@argoproj.io"}} {
+		if _, stderr, err := runCmd(ctx, gap, path, "config", kv[0], kv[1]); err != nil {
+			logger.Error(err, "could not set git config", "stderr", stderr)
+			return "", fmt.Errorf("failed to set git config %q: %w", kv[0], err)
+		}
+	}
+
+	stdout, _, err := runCmd(ctx, gap, path, "rev-parse", "origin/"+sourceBranch)
+	if err != nil {
+		return "", fmt.Errorf("failed to get SHA of branch %q: %w", sourceBranch, err)
+	}
+	if sha := strings.TrimSpace(stdout); expectedSha != "" && sha != expectedSha {
+		return "", fmt.Errorf("branch %q is at %q, not the expected SHA %q", sourceBranch, sha, expectedSha)
+	}
+
+	_, stderr, err = runCmd(ctx, gap, path, "merge", "--no-ff", "-m", message, "origin/"+sourceBranch)
 	if err != nil {
-		return "", "", fmt.Errorf("failed to get token: %w", err)
+		logger.Error(err, "could not merge branch", "sourceBranch", sourceBranch, "targetBranch", targetBranch, "stderr", stderr)
+		return "", fmt.Errorf("failed to merge branch %q into %q: %w", sourceBranch, targetBranch, err)
 	}
 
+	stdout, _, err = runCmd(ctx, gap, path, "rev-parse", "HEAD")
+	if err != nil {
+		return "", fmt.Errorf("failed to get SHA of merge commit: %w", err)
+	}
+	mergeSha := strings.TrimSpace(stdout)
+
+	start = time.Now()
+	_, stderr, err = runCmd(ctx, gap, path, "push", "origin", "HEAD:"+refName)
+	metrics.RecordGitOperation(gitRepo, metrics.GitOperationPush, metrics.GitOperationResultFromError(err), time.Since(start))
+	if err != nil {
+		logger.Error(err, "could not push merge", "ref", refName, "stderr", stderr)
+		return "", fmt.Errorf("failed to push merge to %q: %w", refName, err)
+	}
+
+	logger.Info("Pushed merge", "sourceBranch", sourceBranch, "targetBranch", targetBranch, "ref", refName, "sha", mergeSha)
+	return mergeSha, nil
+}
+
+// remoteUrl returns the URL git commands should use for the repository. This is the HTTPS URL unless the provider
+// uses another transport, such as SSH.
+func remoteUrl(gap scms.GitOperationsProvider, gitRepo *v1alpha1.GitRepository) (string, error) {
+	transport, ok := gap.(scms.GitTransportProvider)
+	if !ok {
+		return gap.GetGitHttpsRepoUrl(*gitRepo), nil
+	}
+	repoUrl, err := transport.GetGitRemoteUrl(*gitRepo)
+	if err != nil {
+		return "", fmt.Errorf("failed to get git remote URL for repo %q: %w", gitRepo.Name, err)
+	}
+	return repoUrl, nil
+}
+
+// runCmd runs a git command in the given directory with the provided arguments and returns stdout, stderr, and error.
+func (g *EnvironmentOperations) runCmd(ctx context.Context, directory string, args ...string) (string, string, error) {
+	return runCmd(ctx, g.gap, directory, args...)
+}
+
+// runCmd runs a git command with the provided arguments and returns stdout, stderr, and error.
+func runCmd(ctx context.Context, gap scms.GitOperationsProvider, directory string, args ...string) (string, string, error) {
 	cmd := exec.CommandContext(ctx, "git", args...)
 	cmd.Env = []string{
-		"GIT_ASKPASS=promoter_askpass.sh", // Needs to be on path
-		"GIT_USERNAME=" + user,
-		"GIT_PASSWORD=" + token,
 		"PATH=" + os.Getenv("PATH"),
 		"GIT_TERMINAL_PROMPT=0",
 	}
+
+	transport, ok := gap.(scms.GitTransportProvider)
+	if !ok || transport.UsesHTTPSCredentials() {
+		// Only look up the credentials when they are used, since getting a token may mint a new one.
+		user, err := gap.GetUser(ctx)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to get user: %w", err)
+		}
+
+		token, err := gap.GetToken(ctx)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to get token: %w", err)
+		}
+
+		cmd.Env = append(cmd.Env,
+			"GIT_ASKPASS=promoter_askpass.sh", // Needs to be on path
+			"GIT_USERNAME="+user,
+			"GIT_PASSWORD="+token,
+		)
+	}
+	if ok {
+		env, err := transport.GetGitEnv(ctx)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to get git transport environment: %w", err)
+		}
+		cmd.Env = append(cmd.Env, env...)
+	}
 	var stdoutBuf bytes.Buffer
 	var stderrBuf bytes.Buffer
 	cmd.Stdout = &stdoutBuf
 	cmd.Stderr = &stderrBuf
 	cmd.Dir = directory
 
-	if err = cmd.Start(); err != nil {
+	if err := cmd.Start(); err != nil {
 		return "", "failed to start", fmt.Errorf("failed to start git command: %w", err)
 	}
 
-	if err = cmd.Wait(); err != nil {
+	if err := cmd.Wait(); err != nil {
 		stdErr := stderrBuf.String()
 		if stdErr != "" {
 			return stdoutBuf.String(), stdErr, fmt.Errorf("%w: %s", err, stdErr)
@@ -425,32 +568,65 @@ func runCmd(ctx context.Context, gap scms.GitOperationsProvider, directory strin
 // This performs a stateless merge check without modifying the working directory. It assumes that origin/<branch> is
 // currently fetched and updated in the local repository. This should happen via GetBranchShas function earlier in the reconcile.
 func (g *EnvironmentOperations) HasConflict(ctx context.Context, proposedBranch, activeBranch string) (bool, error) {
+	paths, err := g.GetConflictingPaths(ctx, proposedBranch, activeBranch)
+	if err != nil {
+		return false, err
+	}
+	return len(paths) > 0, nil
+}
+
+// GetConflictingPaths returns the paths that conflict when merging the proposed branch into the active branch. An empty
+// slice means the branches merge cleanly. Like HasConflict, this is a stateless check that relies on origin/<branch>
+// having been fetched earlier in the reconcile.
+func (g *EnvironmentOperations) GetConflictingPaths(ctx context.Context, proposedBranch, activeBranch string) ([]string, error) {
 	logger := log.FromContext(ctx)
 	repoPath := gitpaths.Get(g.gap.GetGitHttpsRepoUrl(*g.gitRepo) + g.activeBranch)
 
-	// Use git merge-tree --write-tree to perform a stateless merge check
-	// With --write-tree, git exits with code 1 if conflicts exist, and writes conflict info to stdout
-	stdout, stderr, err := g.runCmd(ctx, repoPath, "merge-tree", "--write-tree", "origin/"+activeBranch, "origin/"+proposedBranch)
+	// Use git merge-tree --write-tree to perform a stateless merge check. With --write-tree, git exits with code 1 if
+	// conflicts exist. With --name-only and --no-messages, stdout is the tree SHA followed by one conflicting path per line.
+	stdout, stderr, err := g.runCmd(ctx, repoPath, "merge-tree", "--write-tree", "--name-only", "--no-messages", "origin/"+activeBranch, "origin/"+proposedBranch)
 	if err != nil {
-		// Exit code 1 with conflict info in stderr means conflicts were detected
-		if strings.Contains(stdout, "CONFLICT") {
-			logger.V(4).Info("Merge conflict detected via merge-tree --write-tree", "proposedBranch", proposedBranch, "activeBranch", activeBranch)
-			return true, nil
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) || exitErr.ExitCode() != 1 {
+			logger.Error(err, "could not run merge-tree --write-tree", "proposedBranch", proposedBranch, "activeBranch", activeBranch, "stdout", stdout, "stderr", stderr)
+			return nil, fmt.Errorf("failed to run merge-tree for branches %q and %q: %w", activeBranch, proposedBranch, err)
 		}
-		// Some other error occurred
-		logger.Error(err, "could not run merge-tree --write-tree", "proposedBranch", proposedBranch, "activeBranch", activeBranch, "stdout", stdout, "stderr", stderr)
-		return false, fmt.Errorf("failed to run merge-tree for branches %q and %q: %w", activeBranch, proposedBranch, err)
+
+		paths := parseConflictingPaths(stdout)
+		logger.V(4).Info("Merge conflict detected via merge-tree --write-tree", "proposedBranch", proposedBranch, "activeBranch", activeBranch, "paths", len(paths))
+		return paths, nil
 	}
 
 	// Exit code 0 means clean merge - stdout contains the resulting tree SHA
 	logger.V(4).Info("No merge conflicts detected via merge-tree --write-tree", "proposedBranch", proposedBranch, "activeBranch", activeBranch, "mergeTreeSHA", strings.TrimSpace(stdout))
-	return false, nil
+	return []string{}, nil
+}
+
+// parseConflictingPaths parses the output of git merge-tree --write-tree --name-only. The first line is the tree SHA;
+// the remaining lines are conflicting paths, which may repeat when a file has conflicts in several stages.
+func parseConflictingPaths(stdout string) []string {
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	paths := make([]string, 0, len(lines))
+	seen := make(map[string]struct{}, len(lines))
+	for _, line := range lines[1:] {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if _, ok := seen[line]; ok {
+			continue
+		}
+		seen[line] = struct{}{}
+		paths = append(paths, line)
+	}
+	return paths
 }
 
 // MergeWithOursStrategy merges the proposed branch into the active branch using the "ours" strategy.
 // This assumes that both branches have already been fetched via GetBranchShas earlier in the reconciliation,
-// ensuring we merge the exact same refs that were checked for conflicts.
-func (g *EnvironmentOperations) MergeWithOursStrategy(ctx context.Context, proposedBranch, activeBranch string) error {
+// ensuring we merge the exact same refs that were checked for conflicts. The conflicting paths, if provided, are
+// listed in the merge commit message so the overwritten changes can be traced later.
+func (g *EnvironmentOperations) MergeWithOursStrategy(ctx context.Context, proposedBranch, activeBranch string, conflictingPaths []string) error {
 	logger := log.FromContext(ctx)
 	gitPath := gitpaths.Get(g.gap.GetGitHttpsRepoUrl(*g.gitRepo) + g.activeBranch)
 
@@ -463,7 +639,7 @@ func (g *EnvironmentOperations) MergeWithOursStrategy(ctx context.Context, propo
 	}
 
 	// Perform the merge with "ours" strategy using the already-fetched origin ref
-	_, stderr, err = g.runCmd(ctx, gitPath, "merge", "-s", "ours", "origin/"+activeBranch)
+	_, stderr, err = g.runCmd(ctx, gitPath, "merge", "-s", "ours", "-m", oursMergeMessage(proposedBranch, activeBranch, conflictingPaths), "origin/"+activeBranch)
 	if err != nil {
 		logger.Error(err, "Failed to merge branch", "proposedBranch", proposedBranch, "activeBranch", activeBranch, "stderr", stderr)
 		return fmt.Errorf("failed to merge branch %q into %q with 'ours' strategy: %w", activeBranch, proposedBranch, err)
@@ -480,6 +656,19 @@ func (g *EnvironmentOperations) MergeWithOursStrategy(ctx context.Context, propo
 	return nil
 }
 
+// oursMergeMessage builds the commit message for an "ours" merge, listing the conflicting paths in the body.
+func oursMergeMessage(proposedBranch, activeBranch string, conflictingPaths []string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Merge branch '%s' into %s using the 'ours' strategy", activeBranch, proposedBranch)
+	if len(conflictingPaths) > 0 {
+		b.WriteString("\n\nConflicting paths resolved in favor of the proposed branch:\n")
+		for _, p := range conflictingPaths {
+			b.WriteString("\n- " + p)
+		}
+	}
+	return b.String()
+}
+
 // GetRevListFirstParent retrieves the first parent commit SHAs for the given branch using git rev-list.
 func (g *EnvironmentOperations) GetRevListFirstParent(ctx context.Context, branch string, maxCount int) ([]string, error) {
 	logger := log.FromContext(ctx)
@@ -504,6 +693,79 @@ func (g *EnvironmentOperations) GetRevListFirstParent(ctx context.Context, branc
 	return lines, nil
 }
 
+// maxChangeSummaryDirectories is the maximum number of directories listed in a ChangeSummary.
+const maxChangeSummaryDirectories = 20
+
+// GetChangeSummary returns a summary of the changes between two commits: the number of files changed, the lines added
+// and removed, and the directories containing changed files. Changes to hydrator.metadata are not counted. Since
+// clones are blobless, this may fetch the blobs of the changed files.
+func (g *EnvironmentOperations) GetChangeSummary(ctx context.Context, fromSha, toSha string) (*v1alpha1.ChangeSummary, error) {
+	logger := log.FromContext(ctx)
+
+	gitPath := gitpaths.Get(g.gap.GetGitHttpsRepoUrl(*g.gitRepo) + g.activeBranch)
+	if gitPath == "" {
+		return nil, fmt.Errorf("no repo path found for repo %q", g.gitRepo.Name)
+	}
+
+	stdout, stderr, err := g.runCmd(ctx, gitPath, "diff", "--numstat", "--no-renames", "-z", fromSha, toSha)
+	if err != nil {
+		logger.Error(err, "could not get diff numstat", "fromSha", fromSha, "toSha", toSha, "gitError", stderr)
+		return nil, fmt.Errorf("failed to get diff between %q and %q: %w", fromSha, toSha, err)
+	}
+
+	return parseChangeSummary(stdout)
+}
+
+// parseChangeSummary parses the output of git diff --numstat --no-renames -z. Each NUL-terminated record holds the
+// tab-separated added line count, deleted line count and path. The counts are "-" for binary files.
+func parseChangeSummary(stdout string) (*v1alpha1.ChangeSummary, error) {
+	summary := &v1alpha1.ChangeSummary{}
+	directories := map[string]struct{}{}
+	for record := range strings.SplitSeq(stdout, "\x00") {
+		if strings.TrimSpace(record) == "" {
+			continue
+		}
+		fields := strings.SplitN(record, "\t", 3)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("unexpected numstat record %q", record)
+		}
+
+		path := fields[2]
+		if path == "hydrator.metadata" {
+			continue
+		}
+
+		summary.FilesChanged++
+		if fields[0] != "-" {
+			added, err := strconv.Atoi(fields[0])
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse added line count in numstat record %q: %w", record, err)
+			}
+			summary.Additions += added
+		}
+		if fields[1] != "-" {
+			deleted, err := strconv.Atoi(fields[1])
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse deleted line count in numstat record %q: %w", record, err)
+			}
+			summary.Deletions += deleted
+		}
+
+		dir := "."
+		if i := strings.LastIndex(path, "/"); i >= 0 {
+			dir = path[:i]
+		}
+		directories[dir] = struct{}{}
+	}
+
+	summary.DirectoryCount = len(directories)
+	summary.Directories = slices.Sorted(maps.Keys(directories))
+	if len(summary.Directories) > maxChangeSummaryDirectories {
+		summary.Directories = summary.Directories[:maxChangeSummaryDirectories]
+	}
+	return summary, nil
+}
+
 // AddTrailerToCommitMessage adds a trailer to a commit message using git interpret-trailers.
 // This ensures we follow Git's exact trailer conventions and formatting rules.
 // The trailer will be appended at the end of the trailer block.
@@ -281,6 +281,327 @@ var _ = Describe("LsRemote", func() {
 	})
 })
 
+var _ = Describe("GetConflictingPaths", func() {
+	var tempRepoDir string
+
+	BeforeEach(func() {
+		var err error
+		tempRepoDir, err = os.MkdirTemp("", "git-test-*")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		if tempRepoDir != "" {
+			Expect(os.RemoveAll(tempRepoDir)).To(Succeed())
+		}
+	})
+
+	Context("When the proposed branch conflicts with the active branch", func() {
+		It("should list the conflicting paths and resolve them with the ours strategy", func() {
+			By("Setting up a bare git repository")
+			_, err := runGitCmd(tempRepoDir, "init", "--bare")
+			Expect(err).NotTo(HaveOccurred())
+
+			workDir, err := os.MkdirTemp("", "git-work-*")
+			Expect(err).NotTo(HaveOccurred())
+			defer func() {
+				Expect(os.RemoveAll(workDir)).To(Succeed())
+			}()
+
+			_, err = runGitCmd(workDir, "clone", tempRepoDir, ".")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = runGitCmd(workDir, "config", "user.name", "Test User")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = runGitCmd(workDir, "config", "user.email", "test@example.com")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = runGitCmd(workDir, "config", "commit.gpgsign", "false")
+			Expect(err).NotTo(HaveOccurred())
+
+			writeAndCommit := func(message string, files map[string]string) {
+				for name, content := range files {
+					Expect(os.WriteFile(filepath.Join(workDir, name), []byte(content), 0o644)).To(Succeed())
+					_, err := runGitCmd(workDir, "add", name)
+					Expect(err).NotTo(HaveOccurred())
+				}
+				_, err := runGitCmd(workDir, "commit", "-m", message)
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			By("Creating a common base on the active branch")
+			_, err = runGitCmd(workDir, "checkout", "-b", "environment/dev")
+			Expect(err).NotTo(HaveOccurred())
+			writeAndCommit("Initial commit", map[string]string{
+				"hydrator.metadata": `{"drySha": "abc123"}`,
+				"a.yaml":            "replicas: 1\n",
+				"b.yaml":            "image: v1\n",
+				"c.yaml":            "unchanged\n",
+			})
+			_, err = runGitCmd(workDir, "push", "origin", "environment/dev")
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Pushing a hydrated change to the proposed branch")
+			_, err = runGitCmd(workDir, "checkout", "-b", "environment/dev-next")
+			Expect(err).NotTo(HaveOccurred())
+			writeAndCommit("Hydrate", map[string]string{"a.yaml": "replicas: 2\n", "b.yaml": "image: v2\n"})
+			_, err = runGitCmd(workDir, "push", "origin", "environment/dev-next")
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Pushing a manual hotfix to the active branch")
+			_, err = runGitCmd(workDir, "checkout", "environment/dev")
+			Expect(err).NotTo(HaveOccurred())
+			writeAndCommit("Hotfix", map[string]string{"a.yaml": "replicas: 3\n", "b.yaml": "image: v1-hotfix\n", "c.yaml": "hotfix\n"})
+			_, err = runGitCmd(workDir, "push", "origin", "environment/dev")
+			Expect(err).NotTo(HaveOccurred())
+
+			repo := &v1alpha1.GitRepository{
+				Spec: v1alpha1.GitRepositorySpec{
+					GitHub: &v1alpha1.GitHubRepo{
+						Owner: "test-owner",
+						Name:  "conflictrepo",
+					},
+					ScmProviderRef: v1alpha1.ScmProviderObjectReference{
+						Kind: "ScmProvider",
+						Name: "testprovider",
+					},
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "conflictrepo",
+					Namespace: "default",
+				},
+			}
+			gap := &fakeGitProvider{tempDirPath: tempRepoDir}
+			g := git.NewEnvironmentOperations(repo, gap, "environment/dev")
+			ctx := GinkgoT().Context()
+			Expect(g.CloneRepo(ctx)).To(Succeed())
+			Expect(g.FetchBranches(ctx, "environment/dev-next", "environment/dev")).To(Succeed())
+			for _, branch := range []string{"environment/dev", "environment/dev-next"} {
+				shas, err := g.GetFetchedBranchShas(ctx, branch)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(shas.Dry).To(Equal("abc123"))
+			}
+
+			By("Listing the conflicting paths")
+			paths, err := g.GetConflictingPaths(ctx, "environment/dev-next", "environment/dev")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(paths).To(ConsistOf("a.yaml", "b.yaml"))
+
+			hasConflict, err := g.HasConflict(ctx, "environment/dev-next", "environment/dev")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hasConflict).To(BeTrue())
+
+			By("Merging with the ours strategy")
+			Expect(g.MergeWithOursStrategy(ctx, "environment/dev-next", "environment/dev", paths)).To(Succeed())
+
+			message, err := runGitCmd(tempRepoDir, "log", "-1", "--format=%B", "environment/dev-next")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(message).To(ContainSubstring("- a.yaml"))
+			Expect(message).To(ContainSubstring("- b.yaml"))
+
+			_, err = g.GetBranchShas(ctx, "environment/dev-next")
+			Expect(err).NotTo(HaveOccurred())
+			paths, err = g.GetConflictingPaths(ctx, "environment/dev-next", "environment/dev")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(paths).To(BeEmpty())
+		})
+	})
+})
+
+var _ = Describe("GetChangeSummary", func() {
+	var tempRepoDir string
+
+	BeforeEach(func() {
+		var err error
+		tempRepoDir, err = os.MkdirTemp("", "git-test-*")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		if tempRepoDir != "" {
+			Expect(os.RemoveAll(tempRepoDir)).To(Succeed())
+		}
+	})
+
+	Context("When a promotion changes several apps", func() {
+		It("should count the changed files and lines and list the changed directories", func() {
+			By("Setting up a bare git repository")
+			_, err := runGitCmd(tempRepoDir, "init", "--bare")
+			Expect(err).NotTo(HaveOccurred())
+
+			workDir, err := os.MkdirTemp("", "git-work-*")
+			Expect(err).NotTo(HaveOccurred())
+			defer func() {
+				Expect(os.RemoveAll(workDir)).To(Succeed())
+			}()
+
+			_, err = runGitCmd(workDir, "clone", tempRepoDir, ".")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = runGitCmd(workDir, "config", "user.name", "Test User")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = runGitCmd(workDir, "config", "user.email", "test@example.com")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = runGitCmd(workDir, "config", "commit.gpgsign", "false")
+			Expect(err).NotTo(HaveOccurred())
+
+			writeAndCommit := func(message string, files map[string]string) string {
+				for name, content := range files {
+					Expect(os.MkdirAll(filepath.Dir(filepath.Join(workDir, name)), 0o755)).To(Succeed())
+					Expect(os.WriteFile(filepath.Join(workDir, name), []byte(content), 0o644)).To(Succeed())
+					_, err := runGitCmd(workDir, "add", name)
+					Expect(err).NotTo(HaveOccurred())
+				}
+				_, err := runGitCmd(workDir, "commit", "-m", message)
+				Expect(err).NotTo(HaveOccurred())
+				sha, err := runGitCmd(workDir, "rev-parse", "HEAD")
+				Expect(err).NotTo(HaveOccurred())
+				return strings.TrimSpace(sha)
+			}
+
+			By("Creating two hydrated commits on the active branch")
+			_, err = runGitCmd(workDir, "checkout", "-b", "environment/dev")
+			Expect(err).NotTo(HaveOccurred())
+			fromSha := writeAndCommit("Initial commit", map[string]string{
+				"hydrator.metadata":         `{"drySha": "abc123"}`,
+				"apps/api/manifest.yaml":    "replicas: 1\nimage: v1\n",
+				"apps/web/manifest.yaml":    "replicas: 1\n",
+				"apps/worker/manifest.yaml": "replicas: 1\n",
+			})
+			toSha := writeAndCommit("Hydrate", map[string]string{
+				"hydrator.metadata":      `{"drySha": "def456"}`,
+				"apps/api/manifest.yaml": "replicas: 2\nimage: v2\nport: 80\n",
+				"apps/web/manifest.yaml": "replicas: 3\n",
+				"apps/new/manifest.yaml": "replicas: 1\n",
+			})
+			_, err = runGitCmd(workDir, "push", "origin", "environment/dev")
+			Expect(err).NotTo(HaveOccurred())
+
+			repo := &v1alpha1.GitRepository{
+				Spec: v1alpha1.GitRepositorySpec{
+					GitHub: &v1alpha1.GitHubRepo{
+						Owner: "test-owner",
+						Name:  "summaryrepo",
+					},
+					ScmProviderRef: v1alpha1.ScmProviderObjectReference{
+						Kind: "ScmProvider",
+						Name: "testprovider",
+					},
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "summaryrepo",
+					Namespace: "default",
+				},
+			}
+			gap := &fakeGitProvider{tempDirPath: tempRepoDir}
+			g := git.NewEnvironmentOperations(repo, gap, "environment/dev")
+			ctx := GinkgoT().Context()
+			Expect(g.CloneRepo(ctx)).To(Succeed())
+			_, err = g.GetBranchShas(ctx, "environment/dev")
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Summarizing the changes between the two commits")
+			summary, err := g.GetChangeSummary(ctx, fromSha, toSha)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(summary.FilesChanged).To(Equal(3))
+			Expect(summary.Additions).To(Equal(5))
+			Expect(summary.Deletions).To(Equal(3))
+			Expect(summary.Directories).To(Equal([]string{"apps/api", "apps/new", "apps/web"}))
+			Expect(summary.DirectoryCount).To(Equal(3))
+
+			By("Summarizing the history entry using the first parent")
+			summary, err = g.GetChangeSummary(ctx, toSha+"^1", toSha)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(summary.FilesChanged).To(Equal(3))
+		})
+	})
+})
+
+var _ = Describe("MergeBranch", func() {
+	var tempRepoDir string
+	var workDir string
+	var repo *v1alpha1.GitRepository
+
+	writeAndCommit := func(name, content, message string) string {
+		Expect(os.WriteFile(filepath.Join(workDir, name), []byte(content), 0o644)).To(Succeed())
+		_, err := runGitCmd(workDir, "add", name)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = runGitCmd(workDir, "commit", "-m", message)
+		Expect(err).NotTo(HaveOccurred())
+		sha, err := runGitCmd(workDir, "rev-parse", "HEAD")
+		Expect(err).NotTo(HaveOccurred())
+		return strings.TrimSpace(sha)
+	}
+
+	BeforeEach(func() {
+		var err error
+		tempRepoDir, err = os.MkdirTemp("", "git-test-*")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = runGitCmd(tempRepoDir, "init", "--bare")
+		Expect(err).NotTo(HaveOccurred())
+
+		workDir, err = os.MkdirTemp("", "git-work-*")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = runGitCmd(workDir, "clone", tempRepoDir, ".")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = runGitCmd(workDir, "config", "user.name", "Test User")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = runGitCmd(workDir, "config", "user.email", "test@example.com")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = runGitCmd(workDir, "config", "commit.gpgsign", "false")
+		Expect(err).NotTo(HaveOccurred())
+
+		By("Creating an active branch and a proposed branch with one more commit")
+		_, err = runGitCmd(workDir, "checkout", "-b", "environment/dev")
+		Expect(err).NotTo(HaveOccurred())
+		writeAndCommit("manifest.yaml", "replicas: 1\n", "Initial commit")
+		_, err = runGitCmd(workDir, "checkout", "-b", "environment/dev-next")
+		Expect(err).NotTo(HaveOccurred())
+		writeAndCommit("manifest.yaml", "replicas: 2\n", "Scale up")
+		_, err = runGitCmd(workDir, "push", "origin", "environment/dev", "environment/dev-next")
+		Expect(err).NotTo(HaveOccurred())
+
+		repo = &v1alpha1.GitRepository{
+			Spec: v1alpha1.GitRepositorySpec{
+				Generic: &v1alpha1.GenericRepo{Path: "mergerepo.git"},
+			},
+			ObjectMeta: metav1.ObjectMeta{Name: "mergerepo", Namespace: "default"},
+		}
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(tempRepoDir)).To(Succeed())
+		Expect(os.RemoveAll(workDir)).To(Succeed())
+	})
+
+	It("should push a merge commit of the source branch to the target branch", func(ctx SpecContext) {
+		sourceSha, err := runGitCmd(workDir, "rev-parse", "environment/dev-next")
+		Expect(err).NotTo(HaveOccurred())
+		sourceSha = strings.TrimSpace(sourceSha)
+
+		gap := &fakeGitProvider{tempDirPath: tempRepoDir}
+		Expect(git.MergeBranch(ctx, gap, repo, "environment/dev-next", "environment/dev", sourceSha, "Promote to dev")).To(Succeed())
+
+		parents, err := runGitCmd(tempRepoDir, "log", "-1", "--format=%P", "environment/dev")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(strings.Fields(parents)).To(HaveLen(2))
+		Expect(strings.Fields(parents)[1]).To(Equal(sourceSha))
+		subject, err := runGitCmd(tempRepoDir, "log", "-1", "--format=%s", "environment/dev")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(strings.TrimSpace(subject)).To(Equal("Promote to dev"))
+	})
+
+	It("should not merge if the source branch is not at the expected SHA", func(ctx SpecContext) {
+		before, err := runGitCmd(tempRepoDir, "rev-parse", "environment/dev")
+		Expect(err).NotTo(HaveOccurred())
+
+		gap := &fakeGitProvider{tempDirPath: tempRepoDir}
+		err = git.MergeBranch(ctx, gap, repo, "environment/dev-next", "environment/dev", strings.Repeat("0", 40), "Promote to dev")
+		Expect(err).To(MatchError(ContainSubstring("not the expected SHA")))
+
+		after, err := runGitCmd(tempRepoDir, "rev-parse", "environment/dev")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(after).To(Equal(before))
+	})
+})
+
 type fakeGitProvider struct {
 	tempDirPath string
 }
@@ -0,0 +1,203 @@
+package gitauth
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// CommitSigningKeySecretKey is the key in the ScmProvider secret that holds the private key used to sign commits.
+const CommitSigningKeySecretKey = "commitSigningKey"
+
+// CommitIdentityGitOperationsProvider wraps a GitOperationsProvider so that commits created by git commands use a
+// configured author and, optionally, are signed. Remote URLs and any environment of the wrapped provider (such as SSH
+// transport) are passed through.
+type CommitIdentityGitOperationsProvider struct {
+	scms.GitOperationsProvider
+	author     *v1alpha1.CommitAuthor
+	signing    *v1alpha1.CommitSigning
+	signingKey []byte
+}
+
+var (
+	_ scms.GitOperationsProvider = &CommitIdentityGitOperationsProvider{}
+	_ scms.GitTransportProvider  = &CommitIdentityGitOperationsProvider{}
+)
+
+// NewCommitIdentityGitOperationsProvider creates a new CommitIdentityGitOperationsProvider that wraps the given
+// provider. Either author or signing may be nil. If signing is set, the secret must contain the commitSigningKey key.
+func NewCommitIdentityGitOperationsProvider(provider scms.GitOperationsProvider, author *v1alpha1.CommitAuthor, signing *v1alpha1.CommitSigning, secret *corev1.Secret) (*CommitIdentityGitOperationsProvider, error) {
+	p := &CommitIdentityGitOperationsProvider{
+		GitOperationsProvider: provider,
+		author:                author,
+		signing:               signing,
+	}
+
+	if signing != nil {
+		if secret == nil {
+			return nil, errors.New("secret is required for commit signing")
+		}
+		p.signingKey = secret.Data[CommitSigningKeySecretKey]
+		if len(p.signingKey) == 0 {
+			return nil, fmt.Errorf("secret %q is missing required data key %q", secret.Name, CommitSigningKeySecretKey)
+		}
+	}
+
+	return p, nil
+}
+
+// GetGitRemoteUrl returns the wrapped provider's remote URL, which is the HTTPS URL unless it uses another transport.
+func (c *CommitIdentityGitOperationsProvider) GetGitRemoteUrl(gitRepo v1alpha1.GitRepository) (string, error) {
+	if transport, ok := c.GitOperationsProvider.(scms.GitTransportProvider); ok {
+		return transport.GetGitRemoteUrl(gitRepo) //nolint:wrapcheck // Pass through the wrapped provider's error.
+	}
+	return c.GetGitHttpsRepoUrl(gitRepo), nil
+}
+
+// UsesHTTPSCredentials returns whether the wrapped provider's transport authenticates with the user and token.
+func (c *CommitIdentityGitOperationsProvider) UsesHTTPSCredentials() bool {
+	if transport, ok := c.GitOperationsProvider.(scms.GitTransportProvider); ok {
+		return transport.UsesHTTPSCredentials()
+	}
+	return true
+}
+
+// GetGitEnv returns the wrapped provider's environment plus the variables that set the commit author and signing
+// configuration. Signing is configured through GIT_CONFIG_* variables so it applies to every git command without
+// touching the clone's config.
+func (c *CommitIdentityGitOperationsProvider) GetGitEnv(ctx context.Context) ([]string, error) {
+	var env []string
+	if transport, ok := c.GitOperationsProvider.(scms.GitTransportProvider); ok {
+		transportEnv, err := transport.GetGitEnv(ctx)
+		if err != nil {
+			return nil, err //nolint:wrapcheck // Pass through the wrapped provider's error.
+		}
+		env = append(env, transportEnv...)
+	}
+
+	if c.author != nil {
+		env = append(env,
+			"GIT_AUTHOR_NAME="+c.author.Name,
+			"GIT_AUTHOR_EMAIL="+c.author.Email,
+			"GIT_COMMITTER_NAME="+c.author.Name,
+			"GIT_COMMITTER_EMAIL="+c.author.Email,
+		)
+	}
+
+	if c.signing == nil {
+		return env, nil
+	}
+
+	var config [][2]string
+	switch c.signing.Format {
+	case v1alpha1.CommitSigningFormatSSH:
+		keyPath, err := c.writeSSHSigningKey()
+		if err != nil {
+			return nil, err
+		}
+		config = [][2]string{{"gpg.format", "ssh"}, {"user.signingkey", keyPath}}
+	case v1alpha1.CommitSigningFormatGPG:
+		gnupgHome, fingerprint, err := c.importGPGSigningKey(ctx)
+		if err != nil {
+			return nil, err
+		}
+		env = append(env, "GNUPGHOME="+gnupgHome)
+		config = [][2]string{{"gpg.format", "openpgp"}, {"user.signingkey", fingerprint}}
+	default:
+		return nil, fmt.Errorf("unsupported commit signing format %q", c.signing.Format)
+	}
+	config = append(config, [2]string{"commit.gpgsign", "true"})
+
+	env = append(env, "GIT_CONFIG_COUNT="+strconv.Itoa(len(config)))
+	for i, kv := range config {
+		env = append(env,
+			"GIT_CONFIG_KEY_"+strconv.Itoa(i)+"="+kv[0],
+			"GIT_CONFIG_VALUE_"+strconv.Itoa(i)+"="+kv[1],
+		)
+	}
+	return env, nil
+}
+
+// writeSSHSigningKey writes the SSH signing key to disk and returns its path.
+func (c *CommitIdentityGitOperationsProvider) writeSSHSigningKey() (string, error) {
+	dir := keyMaterialDir("ssh-signing", c.signingKey)
+	if err := ensureKeyMaterial(dir, []keyFile{{name: "signing_key", data: withTrailingNewline(c.signingKey)}}, nil); err != nil {
+		return "", fmt.Errorf("failed to write SSH signing key: %w", err)
+	}
+	return filepath.Join(dir, "signing_key"), nil
+}
+
+// importGPGSigningKey imports the GPG signing key into a dedicated GNUPGHOME and returns the directory and the key's
+// fingerprint.
+func (c *CommitIdentityGitOperationsProvider) importGPGSigningKey(ctx context.Context) (string, string, error) {
+	dir := keyMaterialDir("gpg", c.signingKey)
+	err := ensureKeyMaterial(dir, nil, func(dir string) error {
+		if _, err := runGPG(ctx, dir, c.signingKey, "--import"); err != nil {
+			return fmt.Errorf("failed to import GPG signing key: %w", err)
+		}
+		out, err := runGPG(ctx, dir, nil, "--with-colons", "--list-secret-keys")
+		if err != nil {
+			return fmt.Errorf("failed to list GPG signing keys: %w", err)
+		}
+		fingerprint := parseGPGFingerprint(out)
+		if fingerprint == "" {
+			return errors.New("no secret key found in GPG signing key")
+		}
+		if err := os.WriteFile(filepath.Join(dir, "fingerprint"), []byte(fingerprint), 0o600); err != nil {
+			return fmt.Errorf("failed to write GPG fingerprint: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	fingerprint, err := os.ReadFile(filepath.Join(dir, "fingerprint"))
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read GPG fingerprint: %w", err)
+	}
+	return dir, string(fingerprint), nil
+}
+
+// runGPG runs gpg in batch mode against the given GNUPGHOME.
+func runGPG(ctx context.Context, gnupgHome string, stdin []byte, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "gpg", append([]string{"--batch", "--no-tty"}, args...)...)
+	cmd.Env = []string{"GNUPGHOME=" + gnupgHome, "PATH=" + os.Getenv("PATH")}
+	if stdin != nil {
+		cmd.Stdin = bytes.NewReader(stdin)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
+
+// parseGPGFingerprint returns the fingerprint of the first secret key in gpg --with-colons output.
+func parseGPGFingerprint(out string) string {
+	inSecretKey := false
+	for line := range strings.SplitSeq(out, "\n") {
+		fields := strings.Split(line, ":")
+		switch fields[0] {
+		case "sec":
+			inSecretKey = true
+		case "fpr":
+			if inSecretKey && len(fields) > 9 {
+				return fields[9]
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,100 @@
+package gitauth_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/gitauth"
+)
+
+var _ = Describe("CommitIdentityGitOperationsProvider", func() {
+	var repoDir string
+	author := &v1alpha1.CommitAuthor{Name: "Release Bot", Email: "release-bot@example.com"}
+	inner := httpsGitProvider{url: "https://git.example.com/org/repo.git"}
+
+	// commitWithEnv creates an empty commit in repoDir with the provider's environment and returns the raw commit object.
+	commitWithEnv := func(provider *gitauth.CommitIdentityGitOperationsProvider) string {
+		env, err := provider.GetGitEnv(GinkgoT().Context())
+		Expect(err).NotTo(HaveOccurred())
+
+		cmd := exec.CommandContext(GinkgoT().Context(), "git", "commit", "--allow-empty", "-m", "Merge")
+		cmd.Dir = repoDir
+		cmd.Env = append([]string{"PATH=" + os.Getenv("PATH"), "HOME=" + repoDir}, env...)
+		out, err := cmd.CombinedOutput()
+		Expect(err).NotTo(HaveOccurred(), string(out))
+
+		out, err = exec.CommandContext(GinkgoT().Context(), "git", "-C", repoDir, "cat-file", "commit", "HEAD").CombinedOutput()
+		Expect(err).NotTo(HaveOccurred(), string(out))
+		return string(out)
+	}
+
+	BeforeEach(func() {
+		repoDir = GinkgoT().TempDir()
+		out, err := exec.CommandContext(GinkgoT().Context(), "git", "init", repoDir).CombinedOutput()
+		Expect(err).NotTo(HaveOccurred(), string(out))
+	})
+
+	It("sets the author and committer without signing", func() {
+		provider, err := gitauth.NewCommitIdentityGitOperationsProvider(inner, author, nil, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		commit := commitWithEnv(provider)
+		Expect(commit).To(ContainSubstring("author Release Bot <release-bot@example.com>"))
+		Expect(commit).To(ContainSubstring("committer Release Bot <release-bot@example.com>"))
+		Expect(commit).NotTo(ContainSubstring("gpgsig"))
+	})
+
+	It("requires the signing key in the secret", func() {
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "scm-secret"}}
+		_, err := gitauth.NewCommitIdentityGitOperationsProvider(inner, author, &v1alpha1.CommitSigning{Format: v1alpha1.CommitSigningFormatSSH}, secret)
+		Expect(err).To(MatchError(ContainSubstring(gitauth.CommitSigningKeySecretKey)))
+	})
+
+	It("signs commits with an SSH key", func() {
+		if _, err := exec.LookPath("ssh-keygen"); err != nil {
+			Skip("ssh-keygen is not installed")
+		}
+		keyPath := filepath.Join(GinkgoT().TempDir(), "id_ed25519")
+		out, err := exec.CommandContext(GinkgoT().Context(), "ssh-keygen", "-q", "-t", "ed25519", "-N", "", "-f", keyPath).CombinedOutput()
+		Expect(err).NotTo(HaveOccurred(), string(out))
+		key, err := os.ReadFile(keyPath)
+		Expect(err).NotTo(HaveOccurred())
+
+		secret := &corev1.Secret{Data: map[string][]byte{gitauth.CommitSigningKeySecretKey: key}}
+		provider, err := gitauth.NewCommitIdentityGitOperationsProvider(inner, author, &v1alpha1.CommitSigning{Format: v1alpha1.CommitSigningFormatSSH}, secret)
+		Expect(err).NotTo(HaveOccurred())
+
+		commit := commitWithEnv(provider)
+		Expect(commit).To(ContainSubstring("gpgsig -----BEGIN SSH SIGNATURE-----"))
+	})
+
+	It("signs commits with a GPG key", func() {
+		if _, err := exec.LookPath("gpg"); err != nil {
+			Skip("gpg is not installed")
+		}
+		gnupgHome := GinkgoT().TempDir()
+		runGPG := func(args ...string) []byte {
+			cmd := exec.CommandContext(GinkgoT().Context(), "gpg", append([]string{"--batch", "--no-tty"}, args...)...)
+			cmd.Env = []string{"GNUPGHOME=" + gnupgHome, "PATH=" + os.Getenv("PATH")}
+			out, err := cmd.Output()
+			Expect(err).NotTo(HaveOccurred())
+			return out
+		}
+		runGPG("--passphrase", "", "--quick-gen-key", "Release Bot <release-bot@example.com>", "ed25519", "sign", "never")
+		key := runGPG("--armor", "--export-secret-keys")
+
+		secret := &corev1.Secret{Data: map[string][]byte{gitauth.CommitSigningKeySecretKey: key}}
+		provider, err := gitauth.NewCommitIdentityGitOperationsProvider(inner, author, &v1alpha1.CommitSigning{Format: v1alpha1.CommitSigningFormatGPG}, secret)
+		Expect(err).NotTo(HaveOccurred())
+
+		commit := commitWithEnv(provider)
+		Expect(commit).To(ContainSubstring("gpgsig -----BEGIN PGP SIGNATURE-----"))
+	})
+})
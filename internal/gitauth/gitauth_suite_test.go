@@ -0,0 +1,18 @@
+package gitauth_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestGitAuth(t *testing.T) {
+	t.Parallel()
+
+	RegisterFailHandler(Fail)
+
+	c, _ := GinkgoConfiguration()
+
+	RunSpecs(t, "GitAuth Suite", c)
+}
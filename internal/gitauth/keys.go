@@ -0,0 +1,92 @@
+package gitauth
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+var (
+	// keyMaterialMutex serializes writes of key material to disk, since several reconciles may share the same secret.
+	keyMaterialMutex sync.Mutex
+	// keyMaterialDirs holds the key material directories created by this process, so they can be removed on shutdown.
+	keyMaterialDirs = map[string]struct{}{}
+)
+
+// keyMaterialCompleteFile marks a key material directory as fully written.
+const keyMaterialCompleteFile = ".complete"
+
+// keyFile is a file of key material to write with owner-only permissions.
+type keyFile struct {
+	name string
+	data []byte
+}
+
+// keyMaterialDir returns a directory for key material of the given kind, keyed by its content. Reconciles sharing a
+// secret reuse the same directory, and a rotated key gets a new one.
+func keyMaterialDir(kind string, contents ...[]byte) string {
+	hash := sha256.New()
+	for _, content := range contents {
+		hash.Write(content)
+	}
+	return filepath.Join(os.TempDir(), "promoter-"+kind, hex.EncodeToString(hash.Sum(nil))[:16])
+}
+
+// ensureKeyMaterial writes the files to dir and runs setup, if provided, unless a previous call already completed.
+func ensureKeyMaterial(dir string, files []keyFile, setup func(dir string) error) error {
+	keyMaterialMutex.Lock()
+	defer keyMaterialMutex.Unlock()
+
+	// The directory may be left over from a previous run of the process, in which case it is reused and removed too.
+	keyMaterialDirs[dir] = struct{}{}
+	if _, err := os.Stat(filepath.Join(dir, keyMaterialCompleteFile)); err == nil {
+		return nil
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create key directory: %w", err)
+	}
+	for _, file := range files {
+		if err := os.WriteFile(filepath.Join(dir, file.name), file.data, 0o600); err != nil {
+			return fmt.Errorf("failed to write %s: %w", file.name, err)
+		}
+	}
+	if setup != nil {
+		if err := setup(dir); err != nil {
+			return err
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, keyMaterialCompleteFile), nil, 0o600); err != nil {
+		return fmt.Errorf("failed to mark key directory as complete: %w", err)
+	}
+	return nil
+}
+
+// RemoveKeyMaterial removes the private keys and other key material written to disk by this process. It is meant to be
+// called on shutdown, after git commands have stopped.
+func RemoveKeyMaterial() error {
+	keyMaterialMutex.Lock()
+	defer keyMaterialMutex.Unlock()
+
+	var errs []error
+	for dir := range keyMaterialDirs {
+		if err := os.RemoveAll(dir); err != nil {
+			errs = append(errs, fmt.Errorf("failed to remove key directory %q: %w", dir, err))
+			continue
+		}
+		delete(keyMaterialDirs, dir)
+	}
+	return errors.Join(errs...)
+}
+
+// withTrailingNewline returns key with a trailing newline. ssh and ssh-keygen reject private keys without one.
+func withTrailingNewline(key []byte) []byte {
+	if len(key) > 0 && key[len(key)-1] == '\n' {
+		return key
+	}
+	return append(append([]byte{}, key...), '\n')
+}
@@ -9,8 +9,11 @@ import (
 	"github.com/argoproj-labs/gitops-promoter/internal/scms"
 	"github.com/argoproj-labs/gitops-promoter/internal/scms/azuredevops"
 	bitbucket_cloud "github.com/argoproj-labs/gitops-promoter/internal/scms/bitbucket_cloud"
+	bitbucket_server "github.com/argoproj-labs/gitops-promoter/internal/scms/bitbucket_server"
 	"github.com/argoproj-labs/gitops-promoter/internal/scms/fake"
 	"github.com/argoproj-labs/gitops-promoter/internal/scms/forgejo"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms/generic"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms/gerrit"
 	"github.com/argoproj-labs/gitops-promoter/internal/scms/gitea"
 	"github.com/argoproj-labs/gitops-promoter/internal/scms/github"
 	"github.com/argoproj-labs/gitops-promoter/internal/scms/gitlab"
@@ -25,11 +28,13 @@ import (
 // Parameters:
 //   - ctx: Context for logging and cancellation
 //   - k8sClient: Kubernetes client for fetching resources (needed by GitHub provider)
-//   - scmProvider: The SCM provider configuration (GitHub, GitLab, Forgejo, Bitbucket Cloud, or Fake)
+//   - scmProvider: The SCM provider configuration (GitHub, GitLab, Forgejo, Bitbucket, Azure DevOps, Gerrit, generic, or Fake)
 //   - secret: The secret containing authentication credentials
 //   - repoObjectKey: The namespace/name reference to the GitRepository resource
 //
-// Returns the GitOperationsProvider interface implementation and any error encountered.
+// Returns the GitOperationsProvider interface implementation and any error encountered. If the SCM provider configures
+// SSH, the returned provider runs git commands over SSH with the deploy key from the secret. If it configures a commit
+// author or commit signing, commits created by git commands use that author and are signed.
 func CreateGitOperationsProvider(
 	ctx context.Context,
 	k8sClient client.Client,
@@ -38,6 +43,41 @@ func CreateGitOperationsProvider(
 	repoObjectKey client.ObjectKey,
 ) (scms.GitOperationsProvider, error) {
 	logger := log.FromContext(ctx)
+	spec := scmProvider.GetSpec()
+
+	provider, err := createGitOperationsProvider(ctx, k8sClient, scmProvider, secret, repoObjectKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if spec.SSH != nil {
+		logger.V(4).Info("Using SSH for git operations")
+		provider, err = NewSSHGitOperationsProvider(provider, *spec.SSH, secret)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create SSH git operations provider: %w", err)
+		}
+	}
+
+	if spec.CommitAuthor != nil || spec.CommitSigning != nil {
+		logger.V(4).Info("Using configured commit identity for git operations", "signed", spec.CommitSigning != nil)
+		provider, err = NewCommitIdentityGitOperationsProvider(provider, spec.CommitAuthor, spec.CommitSigning, secret)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create commit identity git operations provider: %w", err)
+		}
+	}
+
+	return provider, nil
+}
+
+// createGitOperationsProvider creates the HTTPS git operations provider for the SCM provider type.
+func createGitOperationsProvider(
+	ctx context.Context,
+	k8sClient client.Client,
+	scmProvider v1alpha1.GenericScmProvider,
+	secret *corev1.Secret,
+	repoObjectKey client.ObjectKey,
+) (scms.GitOperationsProvider, error) {
+	logger := log.FromContext(ctx)
 
 	switch {
 	case scmProvider.GetSpec().Fake != nil:
@@ -84,9 +124,34 @@ func CreateGitOperationsProvider(
 		}
 		return provider, nil
 
+	case scmProvider.GetSpec().BitbucketServer != nil:
+		logger.V(4).Info("Creating Bitbucket Data Center git authentication provider")
+		provider, err := bitbucket_server.NewBitbucketServerGitAuthenticationProvider(scmProvider, secret)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Bitbucket Data Center Auth Provider: %w", err)
+		}
+		return provider, nil
+
 	case scmProvider.GetSpec().AzureDevOps != nil:
 		logger.V(4).Info("Creating Azure DevOps git authentication provider")
 		return azuredevops.NewAzdoGitAuthenticationProvider(scmProvider, secret), nil
+
+	case scmProvider.GetSpec().Gerrit != nil:
+		logger.V(4).Info("Creating Gerrit git authentication provider")
+		provider, err := gerrit.NewGerritGitAuthenticationProvider(scmProvider, secret)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Gerrit Auth Provider: %w", err)
+		}
+		return provider, nil
+
+	case scmProvider.GetSpec().Generic != nil:
+		logger.V(4).Info("Creating generic git authentication provider")
+		provider, err := generic.NewGenericGitAuthenticationProvider(scmProvider, secret)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create generic Auth Provider: %w", err)
+		}
+		return provider, nil
+
 	default:
 		return nil, errors.New("no supported git authentication provider found")
 	}
@@ -0,0 +1,133 @@
+package gitauth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strconv"
+
+	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms"
+	"github.com/argoproj-labs/gitops-promoter/internal/utils"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// SSHPrivateKeySecretKey is the key in the ScmProvider secret that holds the SSH private key (deploy key).
+	SSHPrivateKeySecretKey = "sshPrivateKey"
+	// SSHKnownHostsSecretKey is the key in the ScmProvider secret that holds the known_hosts entries for the SSH server.
+	SSHKnownHostsSecretKey = "sshKnownHosts"
+
+	defaultSSHUser = "git"
+)
+
+// SSHGitOperationsProvider wraps a GitOperationsProvider so that git commands use SSH with a deploy key instead of
+// HTTPS. Everything else, including the HTTPS URL used for web links, is delegated to the wrapped provider.
+type SSHGitOperationsProvider struct {
+	scms.GitOperationsProvider
+	config     v1alpha1.GitSSH
+	privateKey []byte
+	knownHosts []byte
+}
+
+var (
+	_ scms.GitOperationsProvider = &SSHGitOperationsProvider{}
+	_ scms.GitTransportProvider  = &SSHGitOperationsProvider{}
+)
+
+// NewSSHGitOperationsProvider creates a new SSHGitOperationsProvider that wraps the given provider. The secret must
+// contain both the sshPrivateKey and sshKnownHosts keys.
+func NewSSHGitOperationsProvider(provider scms.GitOperationsProvider, config v1alpha1.GitSSH, secret *corev1.Secret) (*SSHGitOperationsProvider, error) {
+	if secret == nil {
+		return nil, errors.New("secret is required for SSH git operations")
+	}
+	privateKey := secret.Data[SSHPrivateKeySecretKey]
+	if len(privateKey) == 0 {
+		return nil, fmt.Errorf("secret %q is missing required data key %q", secret.Name, SSHPrivateKeySecretKey)
+	}
+	knownHosts := secret.Data[SSHKnownHostsSecretKey]
+	if len(knownHosts) == 0 {
+		return nil, fmt.Errorf("secret %q is missing required data key %q", secret.Name, SSHKnownHostsSecretKey)
+	}
+
+	return &SSHGitOperationsProvider{
+		GitOperationsProvider: provider,
+		config:                config,
+		privateKey:            privateKey,
+		knownHosts:            knownHosts,
+	}, nil
+}
+
+// GetGitRemoteUrl returns the SSH URL for the repository. By default, it is built from the host and path of the
+// wrapped provider's HTTPS URL.
+func (s *SSHGitOperationsProvider) GetGitRemoteUrl(gitRepo v1alpha1.GitRepository) (string, error) {
+	httpsUrl, err := url.Parse(s.GetGitHttpsRepoUrl(gitRepo))
+	if err != nil || httpsUrl.Host == "" {
+		return "", fmt.Errorf("failed to parse HTTPS URL for repo %q", gitRepo.Name)
+	}
+
+	host := s.config.Host
+	if host == "" {
+		host = httpsUrl.Hostname()
+	}
+	user := s.config.User
+	if user == "" {
+		user = defaultSSHUser
+	}
+	path := httpsUrl.EscapedPath()
+	if len(path) > 0 && path[0] == '/' {
+		path = path[1:]
+	}
+
+	if s.config.RepoURLTemplate != "" {
+		remoteUrl, err := utils.RenderStringTemplate(s.config.RepoURLTemplate, map[string]any{
+			"Host":          host,
+			"Port":          s.config.Port,
+			"User":          user,
+			"Path":          path,
+			"GitRepository": gitRepo,
+		}, "missingkey=error")
+		if err != nil {
+			return "", fmt.Errorf("failed to render SSH repo URL template: %w", err)
+		}
+		return remoteUrl, nil
+	}
+
+	hostPort := host
+	if s.config.Port != 0 {
+		hostPort = host + ":" + strconv.Itoa(s.config.Port)
+	}
+	return fmt.Sprintf("ssh://%s@%s/%s", user, hostPort, path), nil
+}
+
+// GetGitEnv returns a GIT_SSH_COMMAND that authenticates with the deploy key and verifies the server against the
+// configured known_hosts entries.
+func (s *SSHGitOperationsProvider) GetGitEnv(ctx context.Context) ([]string, error) {
+	keyPath, knownHostsPath, err := s.writeKeyFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	sshCommand := fmt.Sprintf("ssh -i %q -o IdentitiesOnly=yes -o UserKnownHostsFile=%q -o StrictHostKeyChecking=yes -o BatchMode=yes", keyPath, knownHostsPath)
+	return []string{"GIT_SSH_COMMAND=" + sshCommand}, nil
+}
+
+// UsesHTTPSCredentials returns false, since git commands authenticate with the deploy key.
+func (s *SSHGitOperationsProvider) UsesHTTPSCredentials() bool {
+	return false
+}
+
+// writeKeyFiles writes the private key and known_hosts to disk and returns their paths.
+func (s *SSHGitOperationsProvider) writeKeyFiles() (string, string, error) {
+	dir := keyMaterialDir("ssh", s.privateKey, s.knownHosts)
+	err := ensureKeyMaterial(dir, []keyFile{
+		{name: "id", data: withTrailingNewline(s.privateKey)},
+		{name: "known_hosts", data: s.knownHosts},
+	}, nil)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to write SSH key material: %w", err)
+	}
+	return filepath.Join(dir, "id"), filepath.Join(dir, "known_hosts"), nil
+}
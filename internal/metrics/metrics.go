@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
 
 	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
@@ -74,6 +75,55 @@ const (
 	SCMOperationGet SCMOperation = "get"
 )
 
+// SCMThrottleAction represents what happened to an SCM API call that was held back by the rate limiter.
+type SCMThrottleAction string
+
+const (
+	// SCMThrottleActionDelayed is used when a call waited for rate limit quota before it was sent.
+	SCMThrottleActionDelayed SCMThrottleAction = "delayed"
+	// SCMThrottleActionDeferred is used when a call that could wait was not sent because the quota was low.
+	SCMThrottleActionDeferred SCMThrottleAction = "deferred"
+	// SCMThrottleActionRejected is used when a call was not sent because the credential was throttled for too long.
+	SCMThrottleActionRejected SCMThrottleAction = "rejected"
+)
+
+// SCMCacheResult represents whether a cached SCM API response could be reused.
+type SCMCacheResult string
+
+const (
+	// SCMCacheResultHit is used when the SCM confirmed that the cached response is still current.
+	SCMCacheResultHit SCMCacheResult = "hit"
+	// SCMCacheResultMiss is used when the SCM sent a full response.
+	SCMCacheResultMiss SCMCacheResult = "miss"
+)
+
+// ChangeTransferPolicyStatusPhase represents a phase of calculating a ChangeTransferPolicy's status.
+type ChangeTransferPolicyStatusPhase string
+
+const (
+	// ChangeTransferPolicyStatusPhaseFetch is the phase that fetches the proposed and active branches.
+	ChangeTransferPolicyStatusPhaseFetch ChangeTransferPolicyStatusPhase = "fetch"
+	// ChangeTransferPolicyStatusPhaseBranchShas is the phase that reads the SHAs of the fetched branches.
+	ChangeTransferPolicyStatusPhaseBranchShas ChangeTransferPolicyStatusPhase = "branch-shas"
+	// ChangeTransferPolicyStatusPhaseMetadata is the phase that reads commit metadata and hydrator notes.
+	ChangeTransferPolicyStatusPhaseMetadata ChangeTransferPolicyStatusPhase = "metadata"
+	// ChangeTransferPolicyStatusPhaseCommitStatuses is the phase that looks up commit statuses and the pull request.
+	ChangeTransferPolicyStatusPhaseCommitStatuses ChangeTransferPolicyStatusPhase = "commit-statuses"
+)
+
+// WebhookDropReason represents why the webhook receiver did not process a delivery.
+type WebhookDropReason string
+
+const (
+	// WebhookDropReasonPayloadTooLarge is used when the delivery's body is larger than the configured maximum.
+	WebhookDropReasonPayloadTooLarge WebhookDropReason = "payload_too_large"
+	// WebhookDropReasonRateLimited is used when the delivery's source exceeded the configured rate limit.
+	WebhookDropReasonRateLimited WebhookDropReason = "rate_limited"
+	// WebhookDropReasonDuplicate is used when a delivery with the same delivery ID was accepted within the
+	// deduplication window.
+	WebhookDropReasonDuplicate WebhookDropReason = "duplicate"
+)
+
 // RateLimit represents the rate limit information for SCM API calls.
 type RateLimit struct {
 	// Limit is the maximum number of requests allowed in the current rate limit window.
@@ -153,12 +203,36 @@ var (
 		scmCallRateLimitLabels,
 	)
 
+	scmCallsThrottledTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "scm_calls_throttled_total",
+			Help: "A counter of SCM API calls held back by the rate limiter.",
+		},
+		[]string{"host", "action"},
+	)
+
+	scmCacheRequestsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "scm_cache_requests_total",
+			Help: "A counter of cacheable SCM API reads by whether the cached response was reused.",
+		},
+		[]string{"host", "result"},
+	)
+
 	webhookCallsTotal = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "webhook_calls_total",
 			Help: "A counter of webhook calls.",
 		},
-		[]string{"ctp_found", "response_code"},
+		[]string{"ctp_found", "signature", "response_code"},
+	)
+
+	webhookDeliveriesDroppedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "webhook_deliveries_dropped_total",
+			Help: "A counter of webhook deliveries that were not processed because of the webhook receiver's limits.",
+		},
+		[]string{"reason"},
 	)
 
 	webhookProcessingDurationSeconds = prometheus.NewHistogramVec(
@@ -166,7 +240,16 @@ var (
 			Name: "webhook_processing_duration_seconds",
 			Help: "A histogram of the duration of webhook processing.",
 		},
-		[]string{"ctp_found", "response_code"},
+		[]string{"ctp_found", "signature", "response_code"},
+	)
+
+	changeTransferPolicyStatusPhaseDurationSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "change_transfer_policy_status_phase_duration_seconds",
+			Help:    "A histogram of the duration of each phase of calculating a ChangeTransferPolicy's status.",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"git_repository", "phase", "result"},
 	)
 
 	// FinalizerDependentCount tracks the current number of dependent resources blocking deletion
@@ -199,7 +282,11 @@ func init() {
 		scmCallsRateLimitLimit,
 		scmCallsRateLimitRemaining,
 		scmCallsRateLimitResetRemainingSeconds,
+		scmCallsThrottledTotal,
+		scmCacheRequestsTotal,
 		webhookProcessingDurationSeconds,
+		webhookDeliveriesDroppedTotal,
+		changeTransferPolicyStatusPhaseDurationSeconds,
 		FinalizerDependentCount,
 		ApplicationWatchEventsHandled,
 	)
@@ -240,12 +327,48 @@ func RecordSCMCall(gitRepo *v1alpha1.GitRepository, api SCMAPI, operation SCMOpe
 	}
 }
 
-// RecordWebhookCall records the duration of webhook processing.
-func RecordWebhookCall(ctpFound bool, responseCode int, duration time.Duration) {
+// RecordSCMCallThrottled records an SCM API call that was held back by the rate limiter.
+func RecordSCMCallThrottled(host string, action SCMThrottleAction) {
+	scmCallsThrottledTotal.With(prometheus.Labels{
+		"host":   host,
+		"action": string(action),
+	}).Inc()
+}
+
+// RecordSCMCacheRequest records a cacheable SCM API read and whether the cached response was reused.
+func RecordSCMCacheRequest(host string, result SCMCacheResult) {
+	scmCacheRequestsTotal.With(prometheus.Labels{
+		"host":   host,
+		"result": string(result),
+	}).Inc()
+}
+
+// RecordChangeTransferPolicyStatusPhase records the duration of a phase of calculating a ChangeTransferPolicy's status.
+// The GitRepository is labeled with its namespace, since GitRepository names are only unique within a namespace.
+func RecordChangeTransferPolicyStatusPhase(gitRepository types.NamespacedName, phase ChangeTransferPolicyStatusPhase, err error, duration time.Duration) {
+	changeTransferPolicyStatusPhaseDurationSeconds.With(prometheus.Labels{
+		"git_repository": gitRepository.String(),
+		"phase":          string(phase),
+		"result":         string(GitOperationResultFromError(err)),
+	}).Observe(duration.Seconds())
+}
+
+// RecordWebhookCall records the duration of webhook processing. The signature is the result of verifying the
+// delivery's signature, or empty if the delivery was rejected before it was verified.
+func RecordWebhookCall(ctpFound bool, signature string, responseCode int, duration time.Duration) {
 	labels := prometheus.Labels{
 		"ctp_found":     strconv.FormatBool(ctpFound),
+		"signature":     signature,
 		"response_code": strconv.Itoa(responseCode),
 	}
 	webhookCallsTotal.With(labels).Inc()
 	webhookProcessingDurationSeconds.With(labels).Observe(duration.Seconds())
 }
+
+// RecordWebhookDeliveryDropped records a webhook delivery that was not processed because of the webhook receiver's
+// limits.
+func RecordWebhookDeliveryDropped(reason WebhookDropReason) {
+	webhookDeliveriesDroppedTotal.With(prometheus.Labels{
+		"reason": string(reason),
+	}).Inc()
+}
@@ -56,6 +56,7 @@ var promoterResources = []promoterResource{
 	{kind: "PromotionStrategy", obj: &promoterv1alpha1.PromotionStrategy{}},
 	{kind: "PullRequest", obj: &promoterv1alpha1.PullRequest{}},
 	{kind: "RevertCommit", obj: &promoterv1alpha1.RevertCommit{}},
+	{kind: "ScmCheckCommitStatus", obj: &promoterv1alpha1.ScmCheckCommitStatus{}},
 	{kind: "ScmProvider", obj: &promoterv1alpha1.ScmProvider{}},
 	{kind: "TimedCommitStatus", obj: &promoterv1alpha1.TimedCommitStatus{}},
 	{kind: "WebRequestCommitStatus", obj: &promoterv1alpha1.WebRequestCommitStatus{}},
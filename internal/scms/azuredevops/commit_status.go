@@ -15,6 +15,7 @@ import (
 	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
 	"github.com/argoproj-labs/gitops-promoter/internal/metrics"
 	"github.com/argoproj-labs/gitops-promoter/internal/scms"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms/ratelimit"
 	"github.com/argoproj-labs/gitops-promoter/internal/utils"
 )
 
@@ -24,6 +25,7 @@ const azureDevopsDomain = "dev.azure.com"
 type CommitStatus struct {
 	client    *azuredevops.Connection
 	k8sClient client.Client
+	limiter   *ratelimit.Limiter
 }
 
 var _ scms.CommitStatusProvider = &CommitStatus{}
@@ -38,6 +40,7 @@ func NewAzureDevopsCommitStatusProvider(ctx context.Context, k8sClient client.Cl
 	return &CommitStatus{
 		client:    azureClient,
 		k8sClient: k8sClient,
+		limiter:   newLimiter(scmProvider, secret),
 	}, nil
 }
 
@@ -63,7 +66,7 @@ func (cs CommitStatus) Set(ctx context.Context, commitStatus *v1alpha1.CommitSta
 	}
 
 	// Get Git client from Azure DevOps connection
-	gitClient, err := git.NewClient(ctx, cs.client)
+	gitClient, err := newGitClient(ctx, cs.client, cs.limiter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Git client: %w", err)
 	}
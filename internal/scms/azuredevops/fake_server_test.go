@@ -0,0 +1,372 @@
+package azuredevops
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/core"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/git"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/webapi"
+	. "github.com/onsi/gomega"
+)
+
+const (
+	fakeOrganization = "myorg"
+	fakeProject      = "myproject"
+	fakeRepository   = "myrepo"
+	fakeCreatorID    = "3b5f0c5e-8d0e-4c1a-9f1e-7a0f2d6b9c11"
+	fakeProjectID    = "6a0d4f2e-1b3c-4d5e-8f9a-0b1c2d3e4f50"
+)
+
+// fakeLocations are the API resource locations served by the fake server. The Azure DevOps client discovers the
+// route template of each API it calls from them.
+var fakeLocations = []map[string]any{
+	{"id": "e81700f7-3be2-46de-8624-2eb35882fcaa", "area": "Location", "resourceName": "ResourceAreas", "routeTemplate": "_apis/{resource}/{areaId}"},
+	{"id": "9946fd70-0d40-406e-b686-b4744cbbcc37", "area": "git", "resourceName": "pullRequests", "routeTemplate": "{project}/_apis/git/repositories/{repositoryId}/pullRequests/{pullRequestId}"},
+	{"id": "4b6702c7-aa35-4b89-9c96-b9abf6d3e540", "area": "git", "resourceName": "pullRequestReviewers", "routeTemplate": "{project}/_apis/git/repositories/{repositoryId}/pullRequests/{pullRequestId}/reviewers/{reviewerId}"},
+	{"id": "ab6e2e5d-a0b7-4153-b64a-a4efe0d49449", "area": "git", "resourceName": "pullRequestThreads", "routeTemplate": "{project}/_apis/git/repositories/{repositoryId}/pullRequests/{pullRequestId}/threads/{threadId}"},
+	{"id": "965a3ec7-5ed8-455a-bdcb-835a5ea7fe7b", "area": "git", "resourceName": "pullRequestThreadComments", "routeTemplate": "{project}/_apis/git/repositories/{repositoryId}/pullRequests/{pullRequestId}/threads/{threadId}/comments/{commentId}"},
+	{"id": "28010c54-d0c0-4c89-a5b0-1c9e188b9fb7", "area": "IMS", "resourceName": "Identities", "routeTemplate": "_apis/{resource}/{identityId}"},
+	{"id": "72c7ddf8-2cdc-4f60-90cd-ab71c14a399b", "area": "wit", "resourceName": "workItems", "routeTemplate": "{project}/_apis/{area}/{resource}/{id}"},
+	{"id": "c23ddff5-229c-4d04-a80b-0fdce9f360c8", "area": "policy", "resourceName": "evaluations", "routeTemplate": "{project}/_apis/policy/{resource}"},
+}
+
+// fakeAzureDevOpsServer is an in-memory implementation of the parts of the Azure DevOps REST API used by the pull
+// request provider.
+type fakeAzureDevOpsServer struct {
+	*httptest.Server
+
+	mu sync.Mutex
+	// identities maps the names reviewers are looked up by to identity IDs.
+	identities map[string]string
+	// sourceSha is the commit the source branch of new pull requests is at.
+	sourceSha    string
+	pullRequests map[int]*git.GitPullRequest
+	// identityLookups counts the identity searches by name.
+	identityLookups map[string]int
+	// workItemLinks maps work item IDs to the artifact URLs linked to them.
+	workItemLinks map[int][]string
+	// threads holds the comment threads on all pull requests, by thread ID minus one.
+	threads []*git.GitPullRequestCommentThread
+	// policyEvaluations holds the policy evaluation records of each pull request, by pull request ID.
+	policyEvaluations map[int][]map[string]any
+}
+
+func newFakeAzureDevOpsServer() *fakeAzureDevOpsServer {
+	f := &fakeAzureDevOpsServer{
+		identities:        map[string]string{},
+		pullRequests:      map[int]*git.GitPullRequest{},
+		identityLookups:   map[string]int{},
+		workItemLinks:     map[int][]string{},
+		policyEvaluations: map[int][]map[string]any{},
+	}
+
+	prefix := "/" + fakeOrganization
+	pullRequestsPath := prefix + "/" + fakeProject + "/_apis/git/repositories/" + fakeRepository + "/pullRequests"
+	mux := http.NewServeMux()
+	mux.HandleFunc("OPTIONS "+prefix+"/_apis", f.options)
+	mux.HandleFunc("GET "+prefix+"/_apis/ResourceAreas", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]any{"count": 0, "value": []any{}})
+	})
+	mux.HandleFunc("GET "+prefix+"/_apis/Identities", f.readIdentities)
+	mux.HandleFunc("POST "+pullRequestsPath, f.createPullRequest)
+	mux.HandleFunc("GET "+pullRequestsPath+"/{id}", f.getPullRequest)
+	mux.HandleFunc("PATCH "+pullRequestsPath+"/{id}", f.updatePullRequest)
+	mux.HandleFunc("PUT "+pullRequestsPath+"/{id}/reviewers/{reviewerId}", f.createReviewer)
+	mux.HandleFunc("POST "+pullRequestsPath+"/{id}/threads", f.createThread)
+	mux.HandleFunc("PATCH "+pullRequestsPath+"/{id}/threads/{threadId}/comments/{commentId}", f.updateComment)
+	mux.HandleFunc("PATCH "+prefix+"/_apis/wit/workItems/{id}", f.updateWorkItem)
+	mux.HandleFunc("GET "+prefix+"/"+fakeProject+"/_apis/policy/evaluations", f.getPolicyEvaluations)
+
+	f.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		mux.ServeHTTP(w, r)
+	}))
+	return f
+}
+
+// update runs fn while holding the server's lock, so that tests can change its state.
+func (f *fakeAzureDevOpsServer) update(fn func()) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	fn()
+}
+
+// pullRequest returns a copy of the stored pull request with the given ID.
+func (f *fakeAzureDevOpsServer) pullRequest(id string) git.GitPullRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	n, _ := strconv.Atoi(id)
+	return *f.pullRequests[n]
+}
+
+func (f *fakeAzureDevOpsServer) options(w http.ResponseWriter, _ *http.Request) {
+	locations := make([]map[string]any, 0, len(fakeLocations))
+	for _, location := range fakeLocations {
+		entry := map[string]any{"minVersion": "1.0", "maxVersion": "7.1", "releasedVersion": "7.0", "resourceVersion": 3}
+		for k, v := range location {
+			entry[k] = v
+		}
+		locations = append(locations, entry)
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"count": len(locations), "value": locations})
+}
+
+func (f *fakeAzureDevOpsServer) readIdentities(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("filterValue")
+	Expect(r.URL.Query().Get("searchFilter")).To(Equal("General"))
+	f.identityLookups[name]++
+
+	identities := []map[string]any{}
+	if id, ok := f.identities[name]; ok {
+		identities = append(identities, map[string]any{"id": id, "providerDisplayName": name})
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"count": len(identities), "value": identities})
+}
+
+func (f *fakeAzureDevOpsServer) createPullRequest(w http.ResponseWriter, r *http.Request) {
+	var input git.GitPullRequest
+	Expect(json.NewDecoder(r.Body).Decode(&input)).To(Succeed())
+
+	id := len(f.pullRequests) + 1
+	status := git.PullRequestStatusValues.Active
+	reviewers := []git.IdentityRefWithVote{}
+	if input.Reviewers != nil {
+		for _, reviewer := range *input.Reviewers {
+			reviewers = append(reviewers, f.reviewer(*reviewer.Id, reviewer.IsRequired))
+		}
+	}
+	sourceSha := f.sourceSha
+	f.pullRequests[id] = &git.GitPullRequest{
+		PullRequestId: &id,
+		Title:         input.Title,
+		Description:   input.Description,
+		SourceRefName: input.SourceRefName,
+		TargetRefName: input.TargetRefName,
+		Repository: &git.GitRepository{
+			Name:    &[]string{fakeRepository}[0],
+			Project: &core.TeamProjectReference{Id: &[]uuid.UUID{uuid.MustParse(fakeProjectID)}[0], Name: &[]string{fakeProject}[0]},
+		},
+		Status:                &status,
+		CreatedBy:             &webapi.IdentityRef{Id: &[]string{fakeCreatorID}[0]},
+		ArtifactId:            &[]string{fmt.Sprintf("vstfs:///Git/PullRequestId/%s%%2F%s%%2F%d", fakeProject, fakeRepository, id)}[0],
+		LastMergeSourceCommit: &git.GitCommitRef{CommitId: &sourceSha},
+		Reviewers:             &reviewers,
+		WorkItemRefs:          input.WorkItemRefs,
+		Labels:                input.Labels,
+		IsDraft:               input.IsDraft,
+	}
+	writeJSON(w, http.StatusCreated, f.pullRequests[id])
+}
+
+func (f *fakeAzureDevOpsServer) getPullRequest(w http.ResponseWriter, r *http.Request) {
+	pr := f.lookup(w, r)
+	if pr == nil {
+		return
+	}
+	result := *pr
+	if r.URL.Query().Get("includeWorkItemRefs") != "true" {
+		result.WorkItemRefs = nil
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (f *fakeAzureDevOpsServer) updatePullRequest(w http.ResponseWriter, r *http.Request) {
+	pr := f.lookup(w, r)
+	if pr == nil {
+		return
+	}
+	var input git.GitPullRequest
+	Expect(json.NewDecoder(r.Body).Decode(&input)).To(Succeed())
+
+	if input.Title != nil {
+		pr.Title = input.Title
+	}
+	if input.Description != nil {
+		pr.Description = input.Description
+	}
+	if input.IsDraft != nil {
+		pr.IsDraft = input.IsDraft
+	}
+	if input.CompletionOptions != nil {
+		pr.CompletionOptions = input.CompletionOptions
+	}
+	if input.AutoCompleteSetBy != nil {
+		pr.AutoCompleteSetBy = input.AutoCompleteSetBy
+	}
+	if input.Status != nil {
+		if *input.Status == git.PullRequestStatusValues.Completed &&
+			(input.LastMergeSourceCommit == nil || *input.LastMergeSourceCommit.CommitId != *pr.LastMergeSourceCommit.CommitId) {
+			writeJSON(w, http.StatusConflict, map[string]any{"message": "The last merge source commit does not match the pull request."})
+			return
+		}
+		pr.Status = input.Status
+	}
+	result := *pr
+	result.WorkItemRefs = nil
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (f *fakeAzureDevOpsServer) createReviewer(w http.ResponseWriter, r *http.Request) {
+	pr := f.lookup(w, r)
+	if pr == nil {
+		return
+	}
+	var input git.IdentityRefWithVote
+	Expect(json.NewDecoder(r.Body).Decode(&input)).To(Succeed())
+
+	reviewer := f.reviewer(r.PathValue("reviewerId"), input.IsRequired)
+	reviewers := []git.IdentityRefWithVote{}
+	for _, existing := range *pr.Reviewers {
+		if *existing.Id != *reviewer.Id {
+			reviewers = append(reviewers, existing)
+		}
+	}
+	reviewers = append(reviewers, reviewer)
+	pr.Reviewers = &reviewers
+	writeJSON(w, http.StatusOK, reviewer)
+}
+
+func (f *fakeAzureDevOpsServer) createThread(w http.ResponseWriter, r *http.Request) {
+	if f.lookup(w, r) == nil {
+		return
+	}
+	var input git.GitPullRequestCommentThread
+	Expect(json.NewDecoder(r.Body).Decode(&input)).To(Succeed())
+	Expect(input.Comments).NotTo(BeNil())
+
+	id := len(f.threads) + 1
+	comments := *input.Comments
+	for i := range comments {
+		comments[i].Id = &[]int{i + 1}[0]
+	}
+	f.threads = append(f.threads, &git.GitPullRequestCommentThread{Id: &id, Comments: &comments, Status: input.Status})
+	writeJSON(w, http.StatusOK, f.threads[id-1])
+}
+
+func (f *fakeAzureDevOpsServer) updateComment(w http.ResponseWriter, r *http.Request) {
+	if f.lookup(w, r) == nil {
+		return
+	}
+	threadID, err := strconv.Atoi(r.PathValue("threadId"))
+	if err != nil || threadID < 1 || threadID > len(f.threads) {
+		writeJSON(w, http.StatusNotFound, map[string]any{"message": "The thread does not exist."})
+		return
+	}
+	commentID, err := strconv.Atoi(r.PathValue("commentId"))
+	comments := *f.threads[threadID-1].Comments
+	if err != nil || commentID < 1 || commentID > len(comments) {
+		writeJSON(w, http.StatusNotFound, map[string]any{"message": "The comment does not exist."})
+		return
+	}
+	var input git.Comment
+	Expect(json.NewDecoder(r.Body).Decode(&input)).To(Succeed())
+	comments[commentID-1].Content = input.Content
+	writeJSON(w, http.StatusOK, comments[commentID-1])
+}
+
+// thread returns a copy of the stored comment thread with the given ID.
+func (f *fakeAzureDevOpsServer) thread(id string) git.GitPullRequestCommentThread {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	n, _ := strconv.Atoi(id)
+	return *f.threads[n-1]
+}
+
+// reviewer returns the reviewer with the given identity ID, named after the identity.
+func (f *fakeAzureDevOpsServer) reviewer(id string, isRequired *bool) git.IdentityRefWithVote {
+	reviewer := git.IdentityRefWithVote{Id: &id, IsRequired: isRequired}
+	for name, identityID := range f.identities {
+		if identityID == id {
+			reviewer.UniqueName = &name
+		}
+	}
+	return reviewer
+}
+
+// addPolicyEvaluation adds the evaluation of a branch policy to the pull request with the given ID.
+func (f *fakeAzureDevOpsServer) addPolicyEvaluation(id int, typeName string, settings map[string]any, blocking bool, status string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.policyEvaluations[id] = append(f.policyEvaluations[id], map[string]any{
+		"configuration": map[string]any{
+			"id":         len(f.policyEvaluations[id]) + 1,
+			"isEnabled":  true,
+			"isBlocking": blocking,
+			"type":       map[string]any{"displayName": typeName},
+			"settings":   settings,
+		},
+		"status": status,
+	})
+}
+
+func (f *fakeAzureDevOpsServer) getPolicyEvaluations(w http.ResponseWriter, r *http.Request) {
+	var id int
+	_, err := fmt.Sscanf(r.URL.Query().Get("artifactId"), "vstfs:///CodeReview/CodeReviewId/"+fakeProjectID+"/%d", &id)
+	Expect(err).NotTo(HaveOccurred())
+
+	evaluations := f.policyEvaluations[id]
+	if evaluations == nil {
+		evaluations = []map[string]any{}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"count": len(evaluations), "value": evaluations})
+}
+
+func (f *fakeAzureDevOpsServer) updateWorkItem(w http.ResponseWriter, r *http.Request) {
+	Expect(r.Header.Get("Content-Type")).To(HavePrefix("application/json-patch+json"))
+	id, err := strconv.Atoi(r.PathValue("id"))
+	Expect(err).NotTo(HaveOccurred())
+
+	var operations []struct {
+		Op    string `json:"op"`
+		Path  string `json:"path"`
+		Value struct {
+			Rel string `json:"rel"`
+			URL string `json:"url"`
+		} `json:"value"`
+	}
+	Expect(json.NewDecoder(r.Body).Decode(&operations)).To(Succeed())
+	for _, op := range operations {
+		Expect(op.Op).To(Equal("add"))
+		Expect(op.Path).To(Equal("/relations/-"))
+		Expect(op.Value.Rel).To(Equal("ArtifactLink"))
+		f.workItemLinks[id] = append(f.workItemLinks[id], op.Value.URL)
+
+		// Azure DevOps reports the work items linked to a pull request through its artifact link.
+		artifactID := op.Value.URL
+		for _, pr := range f.pullRequests {
+			if *pr.ArtifactId != artifactID {
+				continue
+			}
+			refs := []webapi.ResourceRef{}
+			if pr.WorkItemRefs != nil {
+				refs = *pr.WorkItemRefs
+			}
+			refs = append(refs, webapi.ResourceRef{Id: &[]string{strconv.Itoa(id)}[0]})
+			pr.WorkItemRefs = &refs
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"id": id})
+}
+
+// lookup returns the pull request for the request's {id}, or writes a 404 and returns nil.
+func (f *fakeAzureDevOpsServer) lookup(w http.ResponseWriter, r *http.Request) *git.GitPullRequest {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil || f.pullRequests[id] == nil {
+		writeJSON(w, http.StatusNotFound, map[string]any{"message": "TF401180: The requested pull request was not found."})
+		return nil
+	}
+	return f.pullRequests[id]
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	Expect(json.NewEncoder(w).Encode(v)).To(Succeed())
+}
@@ -4,29 +4,54 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/core"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/git"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/identity"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/policy"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/webapi"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/workitemtracking"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
 	"github.com/argoproj-labs/gitops-promoter/internal/metrics"
 	"github.com/argoproj-labs/gitops-promoter/internal/scms"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms/ratelimit"
 	"github.com/argoproj-labs/gitops-promoter/internal/utils"
 )
 
+// noIdentity is the ID Azure DevOps uses for the absence of an identity. Setting a pull request's auto-complete
+// identity to it cancels auto-complete.
+const noIdentity = "00000000-0000-0000-0000-000000000000"
+
+// workItemMention matches the AB#<id> syntax used to mention Azure Boards work items.
+var workItemMention = regexp.MustCompile(`\bAB#(\d+)\b`)
+
 // PullRequest implements the scms.PullRequestProvider interface for Azure DevOps.
 type PullRequest struct {
-	client    *azuredevops.Connection
-	k8sClient client.Client
+	client        *azuredevops.Connection
+	k8sClient     client.Client
+	autoComplete  bool
+	mergeStrategy git.GitPullRequestMergeStrategy
+	linkWorkItems bool
+	limiter       *ratelimit.Limiter
 }
 
-var _ scms.PullRequestProvider = &PullRequest{}
+var (
+	_ scms.PullRequestProvider                 = &PullRequest{}
+	_ scms.PullRequestBranchProtectionProvider = &PullRequest{}
+)
 
 // NewAzdoPullRequestProvider creates a new instance of PullRequest for Azure DevOps.
 func NewAzdoPullRequestProvider(k8sClient client.Client, secret v1.Secret, scmProvider v1alpha1.GenericScmProvider, org string) (*PullRequest, error) {
@@ -35,9 +60,14 @@ func NewAzdoPullRequestProvider(k8sClient client.Client, secret v1.Secret, scmPr
 		return nil, err
 	}
 
+	azureDevOps := scmProvider.GetSpec().AzureDevOps
 	return &PullRequest{
-		client:    prClient,
-		k8sClient: k8sClient,
+		client:        prClient,
+		k8sClient:     k8sClient,
+		autoComplete:  azureDevOps.AutoComplete,
+		mergeStrategy: git.GitPullRequestMergeStrategy(azureDevOps.GetMergeStrategy()),
+		linkWorkItems: azureDevOps.LinkWorkItems,
+		limiter:       newLimiter(scmProvider, secret),
 	}, nil
 }
 
@@ -56,7 +86,7 @@ func (pr *PullRequest) Create(ctx context.Context, title, head, base, descriptio
 	}
 
 	// Get Git client
-	gitClient, err := git.NewClient(ctx, pr.client)
+	gitClient, err := newGitClient(ctx, pr.client, pr.limiter)
 	if err != nil {
 		return "", fmt.Errorf("failed to create Git client: %w", err)
 	}
@@ -66,12 +96,49 @@ func (pr *PullRequest) Create(ctx context.Context, title, head, base, descriptio
 		return "", fmt.Errorf("failed to validate branch reference: %w", err)
 	}
 
+	reviewers := make([]git.IdentityRefWithVote, 0, len(pullRequest.Spec.RequiredReviewers))
+	for _, name := range pullRequest.Spec.RequiredReviewers {
+		id, err := pr.resolveIdentity(ctx, gitRepo, name)
+		if err != nil {
+			return "", err
+		}
+		reviewers = append(reviewers, git.IdentityRefWithVote{Id: &id, IsRequired: &[]bool{true}[0]})
+	}
+	for _, name := range pullRequest.Spec.Reviewers {
+		if slices.Contains(pullRequest.Spec.RequiredReviewers, name) {
+			continue
+		}
+		id, err := pr.resolveIdentity(ctx, gitRepo, name)
+		if err != nil {
+			return "", err
+		}
+		reviewers = append(reviewers, git.IdentityRefWithVote{Id: &id, IsRequired: &[]bool{false}[0]})
+	}
+
+	labels := make([]core.WebApiTagDefinition, 0, len(pullRequest.Spec.Labels))
+	for _, name := range pullRequest.Spec.Labels {
+		labels = append(labels, core.WebApiTagDefinition{Name: &name})
+	}
+
+	workItemIDs, err := pr.workItemIDs(ctx, pullRequest)
+	if err != nil {
+		return "", err
+	}
+	workItemRefs := make([]webapi.ResourceRef, 0, len(workItemIDs))
+	for _, id := range workItemIDs {
+		workItemRefs = append(workItemRefs, webapi.ResourceRef{Id: &id})
+	}
+
 	// Create Git pull request
 	gitPullRequest := git.GitPullRequest{
 		Title:         &title,
 		Description:   &description,
 		SourceRefName: &sourceRef,
 		TargetRefName: &targetRef,
+		Reviewers:     &reviewers,
+		WorkItemRefs:  &workItemRefs,
+		Labels:        &labels,
+		IsDraft:       &pullRequest.Spec.Draft,
 	}
 
 	start := time.Now()
@@ -112,7 +179,7 @@ func (pr *PullRequest) Update(ctx context.Context, title, description string, pu
 	}
 
 	// Get Git client
-	gitClient, err := git.NewClient(ctx, pr.client)
+	gitClient, err := newGitClient(ctx, pr.client, pr.limiter)
 	if err != nil {
 		return fmt.Errorf("failed to create Git client: %w", err)
 	}
@@ -121,10 +188,11 @@ func (pr *PullRequest) Update(ctx context.Context, title, description string, pu
 	gitPullRequest := git.GitPullRequest{
 		Title:       &title,
 		Description: &description,
+		IsDraft:     &pullRequest.Spec.Draft,
 	}
 
 	start := time.Now()
-	_, err = gitClient.UpdatePullRequest(ctx, git.UpdatePullRequestArgs{
+	updatedPR, err := gitClient.UpdatePullRequest(ctx, git.UpdatePullRequestArgs{
 		GitPullRequestToUpdate: &gitPullRequest,
 		RepositoryId:           &gitRepo.Spec.AzureDevOps.Name,
 		PullRequestId:          &prId,
@@ -141,6 +209,23 @@ func (pr *PullRequest) Update(ctx context.Context, title, description string, pu
 
 	metrics.RecordSCMCall(gitRepo, metrics.SCMAPIPullRequest, metrics.SCMOperationUpdate, statusCode, time.Since(start), nil)
 
+	// The pull request is only updated while it should stay open. If auto-complete was set when it was last asked to
+	// merge, cancel it so that Azure DevOps does not complete it past the promotion gates.
+	if pr.autoComplete && hasAutoComplete(updatedPR) {
+		if err := pr.cancelAutoComplete(ctx, gitClient, gitRepo, prId); err != nil {
+			return err
+		}
+		logger.Info("Canceled Azure DevOps pull request auto-complete", "prId", prId)
+	}
+
+	if err := pr.addRequiredReviewers(ctx, gitClient, gitRepo, updatedPR, pullRequest.Spec.RequiredReviewers); err != nil {
+		return err
+	}
+
+	if err := pr.linkNewWorkItems(ctx, gitClient, gitRepo, pullRequest); err != nil {
+		return err
+	}
+
 	logger.V(4).Info("Azure DevOps pull request updated successfully", "prId", prId)
 
 	return nil
@@ -162,7 +247,7 @@ func (pr *PullRequest) Close(ctx context.Context, pullRequest v1alpha1.PullReque
 	}
 
 	// Get Git client
-	gitClient, err := git.NewClient(ctx, pr.client)
+	gitClient, err := newGitClient(ctx, pr.client, pr.limiter)
 	if err != nil {
 		return fmt.Errorf("failed to create Git client: %w", err)
 	}
@@ -196,7 +281,8 @@ func (pr *PullRequest) Close(ctx context.Context, pullRequest v1alpha1.PullReque
 	return nil
 }
 
-// Merge merges an existing pull request with the specified commit message.
+// Merge merges an existing pull request with the specified commit message. If the provider auto-completes pull
+// requests, auto-complete is set instead and scms.ErrMergeScheduled is returned.
 func (pr *PullRequest) Merge(ctx context.Context, pullRequest v1alpha1.PullRequest) error {
 	logger := log.FromContext(ctx)
 	logger.Info("Merging Pull Request in Azure DevOps")
@@ -212,7 +298,7 @@ func (pr *PullRequest) Merge(ctx context.Context, pullRequest v1alpha1.PullReque
 	}
 
 	// Get Git client
-	gitClient, err := git.NewClient(ctx, pr.client)
+	gitClient, err := newGitClient(ctx, pr.client, pr.limiter)
 	if err != nil {
 		return fmt.Errorf("failed to create Git client: %w", err)
 	}
@@ -221,6 +307,11 @@ func (pr *PullRequest) Merge(ctx context.Context, pullRequest v1alpha1.PullReque
 	completionOptions := git.GitPullRequestCompletionOptions{
 		MergeCommitMessage: &pullRequest.Spec.Commit.Message,
 		DeleteSourceBranch: &[]bool{false}[0], // Keep source branch by default
+		MergeStrategy:      &pr.mergeStrategy,
+	}
+
+	if pr.autoComplete {
+		return pr.setAutoComplete(ctx, gitClient, gitRepo, pullRequest, prId, completionOptions)
 	}
 
 	// Set merge status to completed
@@ -265,7 +356,7 @@ func (pr *PullRequest) FindOpen(ctx context.Context, pullRequest v1alpha1.PullRe
 	}
 
 	// Get Git client
-	gitClient, err := git.NewClient(ctx, pr.client)
+	gitClient, err := newGitClient(ctx, pr.client, pr.limiter)
 	if err != nil {
 		return false, "", time.Time{}, fmt.Errorf("failed to create Git client: %w", err)
 	}
@@ -361,6 +452,74 @@ func (pr *PullRequest) generatePullRequestUrl(ctx context.Context, prObj v1alpha
 	return baseUrl, nil
 }
 
+// UpsertComment posts a comment on the pull request in a new thread, or updates the first comment of the thread with
+// the given ID. The thread is created closed so that it never blocks completion on a comment resolution policy.
+func (pr *PullRequest) UpsertComment(ctx context.Context, pullRequest v1alpha1.PullRequest, commentID, body string) (string, error) {
+	gitRepo, err := utils.GetGitRepositoryFromObjectKey(ctx, pr.k8sClient, client.ObjectKey{Namespace: pullRequest.Namespace, Name: pullRequest.Spec.RepositoryReference.Name})
+	if err != nil {
+		return "", fmt.Errorf("failed to get GitRepository: %w", err)
+	}
+
+	prId, err := strconv.Atoi(pullRequest.Status.ID)
+	if err != nil {
+		return "", fmt.Errorf("failed to convert PR ID to int: %w", err)
+	}
+
+	gitClient, err := newGitClient(ctx, pr.client, pr.limiter)
+	if err != nil {
+		return "", fmt.Errorf("failed to create Git client: %w", err)
+	}
+
+	if commentID != "" {
+		threadId, err := strconv.Atoi(commentID)
+		if err != nil {
+			return "", fmt.Errorf("failed to convert thread ID to int: %w", err)
+		}
+
+		// The comment is the first one in its thread.
+		commentId := 1
+		start := time.Now()
+		_, err = gitClient.UpdateComment(ctx, git.UpdateCommentArgs{
+			Comment:       &git.Comment{Content: &body},
+			RepositoryId:  &gitRepo.Spec.AzureDevOps.Name,
+			PullRequestId: &prId,
+			ThreadId:      &threadId,
+			CommentId:     &commentId,
+			Project:       &gitRepo.Spec.AzureDevOps.Project,
+		})
+		if err != nil {
+			if statusCode, ok := errorStatusCode(err); ok && statusCode == http.StatusNotFound {
+				metrics.RecordSCMCall(gitRepo, metrics.SCMAPIPullRequest, metrics.SCMOperationUpdate, statusCode, time.Since(start), nil)
+				return "", fmt.Errorf("failed to update comment in thread %d: %w: %w", threadId, scms.ErrCommentNotFound, err)
+			}
+			metrics.RecordSCMCall(gitRepo, metrics.SCMAPIPullRequest, metrics.SCMOperationUpdate, 500, time.Since(start), nil)
+			return "", fmt.Errorf("failed to update comment in thread %d: %w", threadId, err)
+		}
+		metrics.RecordSCMCall(gitRepo, metrics.SCMAPIPullRequest, metrics.SCMOperationUpdate, 200, time.Since(start), nil)
+		return commentID, nil
+	}
+
+	start := time.Now()
+	thread, err := gitClient.CreateThread(ctx, git.CreateThreadArgs{
+		CommentThread: &git.GitPullRequestCommentThread{
+			Comments: &[]git.Comment{{Content: &body, CommentType: &git.CommentTypeValues.Text}},
+			Status:   &git.CommentThreadStatusValues.Closed,
+		},
+		RepositoryId:  &gitRepo.Spec.AzureDevOps.Name,
+		PullRequestId: &prId,
+		Project:       &gitRepo.Spec.AzureDevOps.Project,
+	})
+	if err != nil {
+		metrics.RecordSCMCall(gitRepo, metrics.SCMAPIPullRequest, metrics.SCMOperationCreate, 500, time.Since(start), nil)
+		return "", fmt.Errorf("failed to create comment thread: %w", err)
+	}
+	metrics.RecordSCMCall(gitRepo, metrics.SCMAPIPullRequest, metrics.SCMOperationCreate, 201, time.Since(start), nil)
+	if thread.Id == nil {
+		return "", errors.New("comment thread ID not found in Azure DevOps API response")
+	}
+	return strconv.Itoa(*thread.Id), nil
+}
+
 // mapAzureDevOpsPRStatusToState maps Azure DevOps PullRequestStatus to GitOps Promoter PullRequestState
 func mapAzureDevOpsPRStatusToState(status git.PullRequestStatus) v1alpha1.PullRequestState {
 	switch status { //revive:disable
@@ -399,3 +558,364 @@ func getFormattedRefs(head, base string) (string, string, error) {
 
 	return sourceRef, targetRef, nil
 }
+
+// setAutoComplete sets the pull request to complete once its branch policies pass and returns scms.ErrMergeScheduled.
+// Auto-complete is only set while the source branch is at the merge SHA; if the source branch moved since it was set,
+// it is canceled and an error is returned.
+func (pr *PullRequest) setAutoComplete(ctx context.Context, gitClient git.Client, gitRepo *v1alpha1.GitRepository, pullRequest v1alpha1.PullRequest, prId int, completionOptions git.GitPullRequestCompletionOptions) error {
+	logger := log.FromContext(ctx)
+
+	current, err := pr.getPullRequest(ctx, gitClient, gitRepo, prId, false)
+	if err != nil {
+		return err
+	}
+
+	var sourceSha string
+	if current.LastMergeSourceCommit != nil && current.LastMergeSourceCommit.CommitId != nil {
+		sourceSha = *current.LastMergeSourceCommit.CommitId
+	}
+	if sourceSha != pullRequest.Spec.MergeSha {
+		// Auto-complete would complete the pull request with commits that were not promoted.
+		if hasAutoComplete(current) {
+			if err := pr.cancelAutoComplete(ctx, gitClient, gitRepo, prId); err != nil {
+				return err
+			}
+		}
+		return fmt.Errorf("pull request %d cannot be set to auto-complete: source branch is at %q, not the merge SHA %q", prId, sourceSha, pullRequest.Spec.MergeSha)
+	}
+	if hasAutoComplete(current) {
+		logger.V(4).Info("Azure DevOps pull request is already set to auto-complete", "prId", prId, "sha", sourceSha)
+		return scms.ErrMergeScheduled
+	}
+	if current.CreatedBy == nil || current.CreatedBy.Id == nil {
+		return fmt.Errorf("pull request %d has no creator to set auto-complete for", prId)
+	}
+
+	start := time.Now()
+	_, err = gitClient.UpdatePullRequest(ctx, git.UpdatePullRequestArgs{
+		GitPullRequestToUpdate: &git.GitPullRequest{
+			// Auto-complete is set on behalf of the identity that created the pull request, which is the token's.
+			AutoCompleteSetBy: &webapi.IdentityRef{Id: current.CreatedBy.Id},
+			CompletionOptions: &completionOptions,
+		},
+		RepositoryId:  &gitRepo.Spec.AzureDevOps.Name,
+		PullRequestId: &prId,
+		Project:       &gitRepo.Spec.AzureDevOps.Project,
+	})
+	statusCode := 200
+	if err != nil {
+		statusCode = 500
+	}
+	metrics.RecordSCMCall(gitRepo, metrics.SCMAPIPullRequest, metrics.SCMOperationMerge, statusCode, time.Since(start), nil)
+	if err != nil {
+		return fmt.Errorf("failed to set pull request %d to auto-complete: %w", prId, err)
+	}
+
+	logger.Info("Azure DevOps pull request set to auto-complete", "prId", prId)
+	return scms.ErrMergeScheduled
+}
+
+// cancelAutoComplete cancels auto-complete of the pull request.
+func (pr *PullRequest) cancelAutoComplete(ctx context.Context, gitClient git.Client, gitRepo *v1alpha1.GitRepository, prId int) error {
+	start := time.Now()
+	_, err := gitClient.UpdatePullRequest(ctx, git.UpdatePullRequestArgs{
+		GitPullRequestToUpdate: &git.GitPullRequest{AutoCompleteSetBy: &webapi.IdentityRef{Id: &[]string{noIdentity}[0]}},
+		RepositoryId:           &gitRepo.Spec.AzureDevOps.Name,
+		PullRequestId:          &prId,
+		Project:                &gitRepo.Spec.AzureDevOps.Project,
+	})
+	statusCode := 200
+	if err != nil {
+		statusCode = 500
+	}
+	metrics.RecordSCMCall(gitRepo, metrics.SCMAPIPullRequest, metrics.SCMOperationUpdate, statusCode, time.Since(start), nil)
+	if err != nil {
+		return fmt.Errorf("failed to cancel auto-complete of pull request %d: %w", prId, err)
+	}
+	return nil
+}
+
+// hasAutoComplete returns whether the pull request is set to auto-complete.
+func hasAutoComplete(gitPullRequest *git.GitPullRequest) bool {
+	setBy := gitPullRequest.AutoCompleteSetBy
+	return setBy != nil && setBy.Id != nil && *setBy.Id != "" && *setBy.Id != noIdentity
+}
+
+// getPullRequest gets the pull request, optionally with the work items it is linked to.
+func (pr *PullRequest) getPullRequest(ctx context.Context, gitClient git.Client, gitRepo *v1alpha1.GitRepository, prId int, includeWorkItemRefs bool) (*git.GitPullRequest, error) {
+	start := time.Now()
+	gitPullRequest, err := gitClient.GetPullRequest(ctx, git.GetPullRequestArgs{
+		RepositoryId:        &gitRepo.Spec.AzureDevOps.Name,
+		PullRequestId:       &prId,
+		Project:             &gitRepo.Spec.AzureDevOps.Project,
+		IncludeWorkItemRefs: &includeWorkItemRefs,
+	})
+	statusCode := 200
+	if err != nil {
+		statusCode = 500
+	}
+	metrics.RecordSCMCall(gitRepo, metrics.SCMAPIPullRequest, metrics.SCMOperationGet, statusCode, time.Since(start), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request %d: %w", prId, err)
+	}
+	return gitPullRequest, nil
+}
+
+// addRequiredReviewers adds the reviewers that are not yet required reviewers of the pull request. Reviewers already
+// on the pull request are matched by their unique or display name, so that their identities are not looked up on
+// every update.
+func (pr *PullRequest) addRequiredReviewers(ctx context.Context, gitClient git.Client, gitRepo *v1alpha1.GitRepository, gitPullRequest *git.GitPullRequest, names []string) error {
+	var existing []git.IdentityRefWithVote
+	if gitPullRequest.Reviewers != nil {
+		existing = *gitPullRequest.Reviewers
+	}
+
+	for _, name := range names {
+		if slices.ContainsFunc(existing, func(reviewer git.IdentityRefWithVote) bool {
+			return reviewer.IsRequired != nil && *reviewer.IsRequired &&
+				(reviewer.UniqueName != nil && strings.EqualFold(*reviewer.UniqueName, name) ||
+					reviewer.DisplayName != nil && strings.EqualFold(*reviewer.DisplayName, name))
+		}) {
+			continue
+		}
+
+		id, err := pr.resolveIdentity(ctx, gitRepo, name)
+		if err != nil {
+			return err
+		}
+
+		start := time.Now()
+		_, err = gitClient.CreatePullRequestReviewer(ctx, git.CreatePullRequestReviewerArgs{
+			Reviewer:      &git.IdentityRefWithVote{IsRequired: &[]bool{true}[0]},
+			RepositoryId:  &gitRepo.Spec.AzureDevOps.Name,
+			PullRequestId: gitPullRequest.PullRequestId,
+			ReviewerId:    &id,
+			Project:       &gitRepo.Spec.AzureDevOps.Project,
+		})
+		statusCode := 200
+		if err != nil {
+			statusCode = 500
+		}
+		metrics.RecordSCMCall(gitRepo, metrics.SCMAPIPullRequest, metrics.SCMOperationUpdate, statusCode, time.Since(start), nil)
+		if err != nil {
+			return fmt.Errorf("failed to add required reviewer %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// resolveIdentity returns the ID of the Azure DevOps user or group with the given account, display or unique name.
+func (pr *PullRequest) resolveIdentity(ctx context.Context, gitRepo *v1alpha1.GitRepository, name string) (string, error) {
+	identityClient, err := identity.NewClient(ctx, pr.client)
+	if err != nil {
+		return "", fmt.Errorf("failed to create Identity client: %w", err)
+	}
+
+	start := time.Now()
+	identities, err := rateLimited(ctx, pr.limiter, func() (*[]identity.Identity, error) {
+		return identityClient.ReadIdentities(ctx, identity.ReadIdentitiesArgs{
+			SearchFilter: &[]string{"General"}[0],
+			FilterValue:  &name,
+		})
+	})
+	statusCode := 200
+	if err != nil {
+		statusCode = 500
+	}
+	metrics.RecordSCMCall(gitRepo, metrics.SCMAPIPullRequest, metrics.SCMOperationGet, statusCode, time.Since(start), nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to look up reviewer %q: %w", name, err)
+	}
+
+	switch {
+	case identities == nil || len(*identities) == 0:
+		return "", fmt.Errorf("reviewer %q does not match an Azure DevOps identity", name)
+	case len(*identities) > 1:
+		return "", fmt.Errorf("reviewer %q matches %d Azure DevOps identities", name, len(*identities))
+	}
+	return (*identities)[0].Id.String(), nil
+}
+
+// workItemIDs returns the IDs of the work items mentioned as AB#<id> in the message of the proposed dry commit of the
+// ChangeTransferPolicy that owns the pull request. It returns nil if the provider does not link work items.
+func (pr *PullRequest) workItemIDs(ctx context.Context, pullRequest v1alpha1.PullRequest) ([]string, error) {
+	if !pr.linkWorkItems {
+		return nil, nil
+	}
+
+	owner := metav1.GetControllerOf(&pullRequest)
+	if owner == nil || owner.Kind != "ChangeTransferPolicy" {
+		return nil, nil
+	}
+	var ctp v1alpha1.ChangeTransferPolicy
+	if err := pr.k8sClient.Get(ctx, client.ObjectKey{Namespace: pullRequest.Namespace, Name: owner.Name}, &ctp); err != nil {
+		return nil, fmt.Errorf("failed to get ChangeTransferPolicy %q for work items: %w", owner.Name, err)
+	}
+
+	return parseWorkItemIDs(ctp.Status.Proposed.Dry.Subject + "\n\n" + ctp.Status.Proposed.Dry.Body), nil
+}
+
+// parseWorkItemIDs returns the IDs of the work items mentioned as AB#<id> in the message, in order and without
+// duplicates.
+func parseWorkItemIDs(message string) []string {
+	var ids []string
+	for _, match := range workItemMention.FindAllStringSubmatch(message, -1) {
+		if !slices.Contains(ids, match[1]) {
+			ids = append(ids, match[1])
+		}
+	}
+	return ids
+}
+
+// linkNewWorkItems links the pull request to the mentioned work items that it is not linked to yet. Work items are
+// only linked to a pull request through its artifact link once it exists.
+func (pr *PullRequest) linkNewWorkItems(ctx context.Context, gitClient git.Client, gitRepo *v1alpha1.GitRepository, pullRequest v1alpha1.PullRequest) error {
+	ids, err := pr.workItemIDs(ctx, pullRequest)
+	if err != nil || len(ids) == 0 {
+		return err
+	}
+
+	prId, err := strconv.Atoi(pullRequest.Status.ID)
+	if err != nil {
+		return fmt.Errorf("failed to convert PR ID to int: %w", err)
+	}
+	current, err := pr.getPullRequest(ctx, gitClient, gitRepo, prId, true)
+	if err != nil {
+		return err
+	}
+	if current.ArtifactId == nil {
+		return fmt.Errorf("pull request %d has no artifact ID to link work items to", prId)
+	}
+
+	var workItemClient workitemtracking.Client
+	for _, id := range ids {
+		if current.WorkItemRefs != nil && slices.ContainsFunc(*current.WorkItemRefs, func(ref webapi.ResourceRef) bool {
+			return ref.Id != nil && *ref.Id == id
+		}) {
+			continue
+		}
+
+		if workItemClient == nil {
+			workItemClient, err = workitemtracking.NewClient(ctx, pr.client)
+			if err != nil {
+				return fmt.Errorf("failed to create Work Item Tracking client: %w", err)
+			}
+		}
+
+		workItemID, err := strconv.Atoi(id)
+		if err != nil {
+			return fmt.Errorf("failed to convert work item ID %q to int: %w", id, err)
+		}
+		start := time.Now()
+		_, err = rateLimited(ctx, pr.limiter, func() (*workitemtracking.WorkItem, error) {
+			return workItemClient.UpdateWorkItem(ctx, workitemtracking.UpdateWorkItemArgs{
+				Id: &workItemID,
+				Document: &[]webapi.JsonPatchOperation{{
+					Op:   &webapi.OperationValues.Add,
+					Path: &[]string{"/relations/-"}[0],
+					Value: map[string]any{
+						"rel":        "ArtifactLink",
+						"url":        *current.ArtifactId,
+						"attributes": map[string]string{"name": "Pull Request"},
+					},
+				}},
+			})
+		})
+		statusCode := 200
+		if err != nil {
+			statusCode = 500
+		}
+		metrics.RecordSCMCall(gitRepo, metrics.SCMAPIPullRequest, metrics.SCMOperationUpdate, statusCode, time.Since(start), nil)
+		if err != nil {
+			return fmt.Errorf("failed to link work item %d to pull request %d: %w", workItemID, prId, err)
+		}
+	}
+	return nil
+}
+
+// GetBranchProtection returns the blocking branch policies that apply to the pull request, such as build validation
+// and the minimum number of reviewers, and which of them it does not fulfill yet. Each policy is reported as a required
+// check named after the policy, since Azure DevOps evaluates reviewer policies like any other policy.
+func (pr *PullRequest) GetBranchProtection(ctx context.Context, pullRequest v1alpha1.PullRequest) (*scms.BranchProtection, error) {
+	gitRepo, err := utils.GetGitRepositoryFromObjectKey(ctx, pr.k8sClient, client.ObjectKey{Namespace: pullRequest.Namespace, Name: pullRequest.Spec.RepositoryReference.Name})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GitRepository: %w", err)
+	}
+
+	prId, err := strconv.Atoi(pullRequest.Status.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert PR ID to int: %w", err)
+	}
+
+	gitClient, err := newGitClient(ctx, pr.client, pr.limiter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Git client: %w", err)
+	}
+	gitPullRequest, err := pr.getPullRequest(ctx, gitClient, gitRepo, prId, false)
+	if err != nil {
+		return nil, err
+	}
+	if gitPullRequest.Repository == nil || gitPullRequest.Repository.Project == nil || gitPullRequest.Repository.Project.Id == nil {
+		return nil, fmt.Errorf("pull request %d has no project ID to read its policy evaluations with", prId)
+	}
+
+	policyClient, err := policy.NewClient(ctx, pr.client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Policy client: %w", err)
+	}
+
+	// Policy evaluations are reported for the code review artifact of the pull request, which is identified by the ID,
+	// not the name, of its project.
+	artifactID := fmt.Sprintf("vstfs:///CodeReview/CodeReviewId/%s/%d", gitPullRequest.Repository.Project.Id.String(), prId)
+	start := time.Now()
+	evaluations, err := rateLimited(ctx, pr.limiter, func() (*[]policy.PolicyEvaluationRecord, error) {
+		return policyClient.GetPolicyEvaluations(ctx, policy.GetPolicyEvaluationsArgs{
+			Project:    &gitRepo.Spec.AzureDevOps.Project,
+			ArtifactId: &artifactID,
+		})
+	})
+	statusCode := 200
+	if err != nil {
+		statusCode = 500
+	}
+	metrics.RecordSCMCall(gitRepo, metrics.SCMAPIPullRequest, metrics.SCMOperationList, statusCode, time.Since(start), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the policy evaluations of pull request %d: %w", prId, err)
+	}
+
+	protection := &scms.BranchProtection{}
+	if evaluations != nil {
+		for _, evaluation := range *evaluations {
+			configuration := evaluation.Configuration
+			if configuration == nil || !ptr.Deref(configuration.IsEnabled, false) || !ptr.Deref(configuration.IsBlocking, false) {
+				continue
+			}
+			name := policyName(*configuration)
+			protection.RequiredChecks = append(protection.RequiredChecks, name)
+			if status := ptr.Deref(evaluation.Status, ""); status != policy.PolicyEvaluationStatusValues.Approved && status != policy.PolicyEvaluationStatusValues.NotApplicable {
+				protection.UnmetChecks = append(protection.UnmetChecks, name)
+			}
+		}
+	}
+	if len(protection.RequiredChecks) == 0 {
+		return nil, nil
+	}
+	return protection, nil
+}
+
+// policyName returns the name a branch policy is shown with: the display name of a build validation or status check
+// policy, or else the name of its type.
+func policyName(configuration policy.PolicyConfiguration) string {
+	if settings, ok := configuration.Settings.(map[string]any); ok {
+		if name, ok := settings["displayName"].(string); ok && name != "" {
+			return name
+		}
+		if name, ok := settings["statusName"].(string); ok && name != "" {
+			return name
+		}
+	}
+	if configuration.Type != nil && configuration.Type.DisplayName != nil {
+		return *configuration.Type.DisplayName
+	}
+	return "Policy " + strconv.Itoa(ptr.Deref(configuration.Id, 0))
+}
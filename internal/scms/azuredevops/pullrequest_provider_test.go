@@ -0,0 +1,282 @@
+package azuredevops
+
+import (
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/git"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/webapi"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms"
+)
+
+const (
+	mergeSha    = "0123456789abcdef0123456789abcdef01234567"
+	approverID  = "5c2e7d1a-4f3b-4a8e-9d6c-2b1f0e9a8d77"
+	releasersID = "8e4a1b2c-6d5f-4e3a-8b7c-9d0e1f2a3b44"
+)
+
+var _ = Describe("PullRequest", func() {
+	var server *fakeAzureDevOpsServer
+	var ctp *v1alpha1.ChangeTransferPolicy
+	var pullRequests *PullRequest
+	var prObj v1alpha1.PullRequest
+
+	BeforeEach(func() {
+		server = newFakeAzureDevOpsServer()
+		DeferCleanup(server.Close)
+		server.update(func() {
+			server.sourceSha = mergeSha
+			server.identities["jane@example.com"] = approverID
+			server.identities[`[myproject]\Release Approvers`] = releasersID
+		})
+
+		gitRepo := &v1alpha1.GitRepository{
+			ObjectMeta: metav1.ObjectMeta{Name: "ado-repo", Namespace: "default"},
+			Spec: v1alpha1.GitRepositorySpec{
+				AzureDevOps:    &v1alpha1.AzureDevOpsRepo{Project: fakeProject, Name: fakeRepository},
+				ScmProviderRef: v1alpha1.ScmProviderObjectReference{Kind: v1alpha1.ScmProviderKind, Name: "ado"},
+			},
+		}
+		ctp = &v1alpha1.ChangeTransferPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "promote-dev", Namespace: "default", UID: "8f3e2d1c"},
+			Status: v1alpha1.ChangeTransferPolicyStatus{
+				Proposed: v1alpha1.CommitBranchState{Dry: v1alpha1.CommitShaState{
+					Subject: "Bump image to v1.2.3 for AB#101",
+					Body:    "Fixes the rollout timeout.\n\nAB#102\nRelated: AB#101",
+				}},
+			},
+		}
+
+		scheme := runtime.NewScheme()
+		Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+		k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(gitRepo, ctp).Build()
+
+		pullRequests = &PullRequest{
+			client:        azuredevops.NewPatConnection(server.URL+"/"+fakeOrganization, "token"),
+			k8sClient:     k8sClient,
+			mergeStrategy: git.GitPullRequestMergeStrategyValues.NoFastForward,
+		}
+
+		prObj = v1alpha1.PullRequest{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "promote-dev",
+				Namespace: "default",
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: v1alpha1.GroupVersion.String(),
+					Kind:       "ChangeTransferPolicy",
+					Name:       ctp.Name,
+					UID:        ctp.UID,
+					Controller: &[]bool{true}[0],
+				}},
+			},
+			Spec: v1alpha1.PullRequestSpec{
+				RepositoryReference: v1alpha1.ObjectReference{Name: "ado-repo"},
+				Title:               "Promote to dev",
+				SourceBranch:        "environment/dev-next",
+				TargetBranch:        "environment/dev",
+				MergeSha:            mergeSha,
+				Commit:              v1alpha1.CommitConfiguration{Message: "Promote to dev"},
+				RequiredReviewers:   []string{"jane@example.com"},
+			},
+		}
+	})
+
+	create := func(ctx SpecContext) {
+		GinkgoHelper()
+		id, err := pullRequests.Create(ctx, prObj.Spec.Title, prObj.Spec.SourceBranch, prObj.Spec.TargetBranch, "", prObj)
+		Expect(err).NotTo(HaveOccurred())
+		prObj.Status = v1alpha1.PullRequestStatus{ID: id, State: v1alpha1.PullRequestOpen}
+	}
+
+	workItemIDs := func(pr git.GitPullRequest) []string {
+		GinkgoHelper()
+		var ids []string
+		if pr.WorkItemRefs != nil {
+			for _, ref := range *pr.WorkItemRefs {
+				ids = append(ids, *ref.Id)
+			}
+		}
+		return ids
+	}
+
+	Describe("required reviewers", func() {
+		It("should add required reviewers on create and only look up new reviewers on update", func(ctx SpecContext) {
+			create(ctx)
+			reviewers := *server.pullRequest(prObj.Status.ID).Reviewers
+			Expect(reviewers).To(HaveLen(1))
+			Expect(*reviewers[0].Id).To(Equal(approverID))
+			Expect(*reviewers[0].IsRequired).To(BeTrue())
+
+			By("Adding a reviewer that was added to the PromotionStrategy")
+			prObj.Spec.RequiredReviewers = append(prObj.Spec.RequiredReviewers, `[myproject]\Release Approvers`)
+			Expect(pullRequests.Update(ctx, prObj.Spec.Title, "", prObj)).To(Succeed())
+			Expect(pullRequests.Update(ctx, prObj.Spec.Title, "", prObj)).To(Succeed())
+
+			reviewers = *server.pullRequest(prObj.Status.ID).Reviewers
+			Expect(reviewers).To(HaveLen(2))
+			Expect(*reviewers[1].Id).To(Equal(releasersID))
+			Expect(*reviewers[1].IsRequired).To(BeTrue())
+			server.update(func() {
+				Expect(server.identityLookups).To(Equal(map[string]int{"jane@example.com": 1, `[myproject]\Release Approvers`: 1}))
+			})
+		})
+
+		It("should fail for a reviewer without an Azure DevOps identity", func(ctx SpecContext) {
+			prObj.Spec.RequiredReviewers = []string{"nobody@example.com"}
+			_, err := pullRequests.Create(ctx, prObj.Spec.Title, prObj.Spec.SourceBranch, prObj.Spec.TargetBranch, "", prObj)
+			Expect(err).To(MatchError(`reviewer "nobody@example.com" does not match an Azure DevOps identity`))
+		})
+	})
+
+	It("should add optional reviewers and labels when the pull request is opened", func(ctx SpecContext) {
+		prObj.Spec.Reviewers = []string{`[myproject]\Release Approvers`, "jane@example.com"}
+		prObj.Spec.Labels = []string{"promotion"}
+		create(ctx)
+
+		pr := server.pullRequest(prObj.Status.ID)
+		reviewers := *pr.Reviewers
+		Expect(reviewers).To(HaveLen(2))
+		Expect(*reviewers[0].Id).To(Equal(approverID))
+		Expect(*reviewers[0].IsRequired).To(BeTrue())
+		Expect(*reviewers[1].Id).To(Equal(releasersID))
+		Expect(*reviewers[1].IsRequired).To(BeFalse())
+		Expect(*pr.Labels).To(HaveLen(1))
+		Expect(*(*pr.Labels)[0].Name).To(Equal("promotion"))
+	})
+
+	It("should open a draft and publish it once it is no longer a draft", func(ctx SpecContext) {
+		prObj.Spec.Draft = true
+		create(ctx)
+		Expect(*server.pullRequest(prObj.Status.ID).IsDraft).To(BeTrue())
+
+		prObj.Spec.Draft = false
+		Expect(pullRequests.Update(ctx, prObj.Spec.Title, "", prObj)).To(Succeed())
+		Expect(*server.pullRequest(prObj.Status.ID).IsDraft).To(BeFalse())
+	})
+
+	It("should post a comment in a closed thread and update it in place", func(ctx SpecContext) {
+		create(ctx)
+
+		threadID, err := pullRequests.UpsertComment(ctx, prObj, "", "pending")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(threadID).To(Equal("1"))
+		Expect(*server.thread(threadID).Status).To(Equal(git.CommentThreadStatusValues.Closed))
+
+		threadID, err = pullRequests.UpsertComment(ctx, prObj, threadID, "success")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(threadID).To(Equal("1"))
+		comments := *server.thread(threadID).Comments
+		Expect(comments).To(HaveLen(1))
+		Expect(*comments[0].Content).To(Equal("success"))
+
+		By("Reporting a deleted thread as not found")
+		_, err = pullRequests.UpsertComment(ctx, prObj, "42", "failure")
+		Expect(err).To(MatchError(scms.ErrCommentNotFound))
+	})
+
+	Describe("work items", func() {
+		It("should not link work items unless enabled", func(ctx SpecContext) {
+			create(ctx)
+			Expect(workItemIDs(server.pullRequest(prObj.Status.ID))).To(BeEmpty())
+		})
+
+		It("should link the work items mentioned by the proposed dry commit", func(ctx SpecContext) {
+			pullRequests.linkWorkItems = true
+			create(ctx)
+			Expect(workItemIDs(server.pullRequest(prObj.Status.ID))).To(Equal([]string{"101", "102"}))
+
+			By("Linking work items mentioned by a new dry commit once")
+			ctp.Status.Proposed.Dry.Body = "AB#103"
+			Expect(pullRequests.k8sClient.Update(ctx, ctp)).To(Succeed())
+			Expect(pullRequests.Update(ctx, prObj.Spec.Title, "", prObj)).To(Succeed())
+			Expect(pullRequests.Update(ctx, prObj.Spec.Title, "", prObj)).To(Succeed())
+
+			pr := server.pullRequest(prObj.Status.ID)
+			Expect(workItemIDs(pr)).To(Equal([]string{"101", "102", "103"}))
+			server.update(func() {
+				Expect(server.workItemLinks).To(Equal(map[int][]string{103: {*pr.ArtifactId}}))
+			})
+		})
+	})
+
+	Describe("Merge", func() {
+		It("should complete the pull request with the merge strategy", func(ctx SpecContext) {
+			pullRequests.mergeStrategy = git.GitPullRequestMergeStrategyValues.Squash
+			create(ctx)
+
+			Expect(pullRequests.Merge(ctx, prObj)).To(Succeed())
+			pr := server.pullRequest(prObj.Status.ID)
+			Expect(*pr.Status).To(Equal(git.PullRequestStatusValues.Completed))
+			Expect(*pr.CompletionOptions.MergeStrategy).To(Equal(git.GitPullRequestMergeStrategyValues.Squash))
+			Expect(*pr.CompletionOptions.MergeCommitMessage).To(Equal("Promote to dev"))
+		})
+
+		It("should set auto-complete once and cancel it when the pull request is updated", func(ctx SpecContext) {
+			pullRequests.autoComplete = true
+			create(ctx)
+
+			Expect(pullRequests.Merge(ctx, prObj)).To(MatchError(scms.ErrMergeScheduled))
+			pr := server.pullRequest(prObj.Status.ID)
+			Expect(*pr.Status).To(Equal(git.PullRequestStatusValues.Active))
+			Expect(*pr.AutoCompleteSetBy.Id).To(Equal(fakeCreatorID))
+			Expect(*pr.CompletionOptions.MergeStrategy).To(Equal(git.GitPullRequestMergeStrategyValues.NoFastForward))
+
+			By("Leaving auto-complete as it is on the next merge")
+			server.update(func() { server.pullRequests[1].CompletionOptions = nil })
+			Expect(pullRequests.Merge(ctx, prObj)).To(MatchError(scms.ErrMergeScheduled))
+			Expect(server.pullRequest(prObj.Status.ID).CompletionOptions).To(BeNil())
+
+			By("Canceling auto-complete when the pull request should stay open")
+			Expect(pullRequests.Update(ctx, prObj.Spec.Title, "", prObj)).To(Succeed())
+			Expect(*server.pullRequest(prObj.Status.ID).AutoCompleteSetBy.Id).To(Equal(noIdentity))
+		})
+
+		It("should cancel auto-complete and refuse to merge when the source branch moved", func(ctx SpecContext) {
+			pullRequests.autoComplete = true
+			create(ctx)
+			server.update(func() {
+				server.pullRequests[1].AutoCompleteSetBy = &webapi.IdentityRef{Id: &[]string{fakeCreatorID}[0]}
+				server.pullRequests[1].LastMergeSourceCommit.CommitId = &[]string{"fedcba9876543210fedcba9876543210fedcba98"}[0]
+			})
+
+			Expect(pullRequests.Merge(ctx, prObj)).To(MatchError(ContainSubstring("not the merge SHA")))
+			Expect(*server.pullRequest(prObj.Status.ID).AutoCompleteSetBy.Id).To(Equal(noIdentity))
+		})
+	})
+
+	Describe("GetBranchProtection", func() {
+		It("should return nil without blocking policies", func(ctx SpecContext) {
+			create(ctx)
+			server.addPolicyEvaluation(1, "Comment requirements", nil, false, "rejected")
+
+			protection, err := pullRequests.GetBranchProtection(ctx, prObj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(protection).To(BeNil())
+		})
+
+		It("should report the blocking policies that are not fulfilled as unmet checks", func(ctx SpecContext) {
+			create(ctx)
+			server.addPolicyEvaluation(1, "Minimum number of reviewers", map[string]any{"minimumApproverCount": 2}, true, "rejected")
+			server.addPolicyEvaluation(1, "Build", map[string]any{"displayName": "CI", "buildDefinitionId": 7}, true, "running")
+			server.addPolicyEvaluation(1, "Status", map[string]any{"statusGenre": "security", "statusName": "scan"}, true, "approved")
+			server.addPolicyEvaluation(1, "Work item linking", nil, true, "notApplicable")
+			server.addPolicyEvaluation(1, "Build", map[string]any{"displayName": "Optional build"}, false, "rejected")
+
+			protection, err := pullRequests.GetBranchProtection(ctx, prObj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(protection).NotTo(BeNil())
+			Expect(protection.RequiredChecks).To(Equal([]string{"Minimum number of reviewers", "CI", "scan", "Work item linking"}))
+			Expect(protection.UnmetChecks).To(Equal([]string{"Minimum number of reviewers", "CI"}))
+			Expect(protection.Satisfied()).To(BeFalse())
+		})
+	})
+
+	It("should parse work item mentions in order without duplicates", func() {
+		Expect(parseWorkItemIDs("Fix AB#12 and AB#7\n\nAB#12\nSee xAB#99 and AB#\nWork-item: AB#5")).To(Equal([]string{"12", "7", "5"}))
+	})
+})
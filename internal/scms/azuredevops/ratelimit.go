@@ -0,0 +1,109 @@
+package azuredevops
+
+import (
+	"context"
+	"errors"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/git"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms/ratelimit"
+)
+
+// rateLimitedGitClient sends the Git API calls the provider uses through the rate limiter of its credential. The Azure
+// DevOps SDK creates its own HTTP clients, so the limiter cannot read the rate limit headers and only learns that the
+// credential is throttled from the status code of failed calls.
+type rateLimitedGitClient struct {
+	git.Client
+	limiter *ratelimit.Limiter
+}
+
+// newGitClient returns a Git client for the connection whose calls go through limiter, if it is not nil.
+func newGitClient(ctx context.Context, connection *azuredevops.Connection, limiter *ratelimit.Limiter) (git.Client, error) {
+	gitClient, err := git.NewClient(ctx, connection)
+	if err != nil || limiter == nil {
+		return gitClient, err //nolint:wrapcheck // Callers wrap the error.
+	}
+	return rateLimitedGitClient{Client: gitClient, limiter: limiter}, nil
+}
+
+// newLimiter returns the rate limiter of the credential in secret for the Azure DevOps host of scmProvider.
+func newLimiter(scmProvider v1alpha1.GenericScmProvider, secret v1.Secret) *ratelimit.Limiter {
+	host := scmProvider.GetSpec().AzureDevOps.Domain
+	if host == "" {
+		host = azureDevopsDomain
+	}
+	return ratelimit.For(types.NamespacedName{Namespace: secret.Namespace, Name: secret.Name}, "", host)
+}
+
+// rateLimited waits for limiter, if it is not nil, before making call, and reports a throttled response to it.
+func rateLimited[T any](ctx context.Context, limiter *ratelimit.Limiter, call func() (T, error)) (T, error) {
+	if limiter == nil {
+		return call()
+	}
+	if err := limiter.Wait(ctx); err != nil {
+		var zero T
+		return zero, err //nolint:wrapcheck // The ThrottledError is returned as is so that callers can inspect it.
+	}
+	result, err := call()
+	if statusCode, ok := errorStatusCode(err); ok {
+		limiter.ObserveStatus(statusCode)
+	}
+	return result, err
+}
+
+// errorStatusCode returns the HTTP status code of an Azure DevOps API error.
+func errorStatusCode(err error) (int, bool) {
+	var wrappedErr azuredevops.WrappedError
+	if errors.As(err, &wrappedErr) && wrappedErr.StatusCode != nil {
+		return *wrappedErr.StatusCode, true
+	}
+	var wrappedErrPtr *azuredevops.WrappedError
+	if errors.As(err, &wrappedErrPtr) && wrappedErrPtr.StatusCode != nil {
+		return *wrappedErrPtr.StatusCode, true
+	}
+	return 0, false
+}
+
+// CreateCommitStatus implements git.Client.
+func (c rateLimitedGitClient) CreateCommitStatus(ctx context.Context, args git.CreateCommitStatusArgs) (*git.GitStatus, error) {
+	return rateLimited(ctx, c.limiter, func() (*git.GitStatus, error) { return c.Client.CreateCommitStatus(ctx, args) })
+}
+
+// CreatePullRequest implements git.Client.
+func (c rateLimitedGitClient) CreatePullRequest(ctx context.Context, args git.CreatePullRequestArgs) (*git.GitPullRequest, error) {
+	return rateLimited(ctx, c.limiter, func() (*git.GitPullRequest, error) { return c.Client.CreatePullRequest(ctx, args) })
+}
+
+// CreatePullRequestReviewer implements git.Client.
+func (c rateLimitedGitClient) CreatePullRequestReviewer(ctx context.Context, args git.CreatePullRequestReviewerArgs) (*git.IdentityRefWithVote, error) {
+	return rateLimited(ctx, c.limiter, func() (*git.IdentityRefWithVote, error) { return c.Client.CreatePullRequestReviewer(ctx, args) })
+}
+
+// CreateThread implements git.Client.
+func (c rateLimitedGitClient) CreateThread(ctx context.Context, args git.CreateThreadArgs) (*git.GitPullRequestCommentThread, error) {
+	return rateLimited(ctx, c.limiter, func() (*git.GitPullRequestCommentThread, error) { return c.Client.CreateThread(ctx, args) })
+}
+
+// GetPullRequest implements git.Client.
+func (c rateLimitedGitClient) GetPullRequest(ctx context.Context, args git.GetPullRequestArgs) (*git.GitPullRequest, error) {
+	return rateLimited(ctx, c.limiter, func() (*git.GitPullRequest, error) { return c.Client.GetPullRequest(ctx, args) })
+}
+
+// GetPullRequests implements git.Client.
+func (c rateLimitedGitClient) GetPullRequests(ctx context.Context, args git.GetPullRequestsArgs) (*[]git.GitPullRequest, error) {
+	return rateLimited(ctx, c.limiter, func() (*[]git.GitPullRequest, error) { return c.Client.GetPullRequests(ctx, args) })
+}
+
+// UpdateComment implements git.Client.
+func (c rateLimitedGitClient) UpdateComment(ctx context.Context, args git.UpdateCommentArgs) (*git.Comment, error) {
+	return rateLimited(ctx, c.limiter, func() (*git.Comment, error) { return c.Client.UpdateComment(ctx, args) })
+}
+
+// UpdatePullRequest implements git.Client.
+func (c rateLimitedGitClient) UpdatePullRequest(ctx context.Context, args git.UpdatePullRequestArgs) (*git.GitPullRequest, error) {
+	return rateLimited(ctx, c.limiter, func() (*git.GitPullRequest, error) { return c.Client.UpdatePullRequest(ctx, args) })
+}
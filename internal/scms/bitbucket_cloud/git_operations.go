@@ -3,6 +3,7 @@ package bitbucket_cloud
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"net/url"
 
 	"github.com/ktrysmt/go-bitbucket"
@@ -10,6 +11,8 @@ import (
 
 	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
 	"github.com/argoproj-labs/gitops-promoter/internal/scms"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms/httpcache"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms/ratelimit"
 )
 
 // GitAuthenticationProvider implements the scms.GitOperationsProvider interface for Bitbucket Cloud.
@@ -65,6 +68,7 @@ func GetClient(secret v1.Secret) (*bitbucket.Client, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Bitbucket client: %w", err)
 	}
+	client.HttpClient = &http.Client{Transport: httpcache.NewTransport(secret, "", ratelimit.NewTransport(secret, "", nil))}
 
 	return client, nil
 }
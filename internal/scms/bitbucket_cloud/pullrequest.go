@@ -4,8 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/ktrysmt/go-bitbucket"
@@ -23,6 +27,8 @@ import (
 type PullRequest struct {
 	client    *bitbucket.Client
 	k8sClient client.Client
+	// token authenticates the requests the Bitbucket client cannot make.
+	token string
 }
 
 var _ scms.PullRequestProvider = &PullRequest{}
@@ -37,6 +43,7 @@ func NewBitbucketCloudPullRequestProvider(k8sClient client.Client, secret v1.Sec
 	return &PullRequest{
 		client:    client,
 		k8sClient: k8sClient,
+		token:     string(secret.Data["token"]),
 	}, nil
 }
 
@@ -60,6 +67,8 @@ func (pr *PullRequest) Create(ctx context.Context, title, head, base, desc strin
 		Title:             title,
 		Description:       desc,
 		CloseSourceBranch: false,
+		Reviewers:         prObj.Spec.Reviewers,
+		Draft:             prObj.Spec.Draft,
 	}
 
 	start := time.Now()
@@ -109,16 +118,30 @@ func (pr *PullRequest) Update(ctx context.Context, title, description string, pr
 		return fmt.Errorf("failed to get repo: %w", err)
 	}
 
+	// The client always sends the reviewers, which replace those of the pull request, so the reviewers it already has
+	// are sent along with the configured ones to keep reviewers added by people.
+	reviewers, err := pr.getReviewers(repo, prObj.Status.ID)
+	if err != nil {
+		return err
+	}
+	for _, reviewer := range prObj.Spec.Reviewers {
+		if !slices.ContainsFunc(reviewers, func(uuid string) bool { return strings.EqualFold(uuid, reviewer) }) {
+			reviewers = append(reviewers, reviewer)
+		}
+	}
+
 	options := &bitbucket.PullRequestsOptions{
 		Owner:       repo.Spec.BitbucketCloud.Owner,
 		RepoSlug:    repo.Spec.BitbucketCloud.Name,
 		ID:          prObj.Status.ID,
 		Title:       title,
 		Description: description,
+		Reviewers:   reviewers,
+		Draft:       prObj.Spec.Draft,
 	}
 
 	start := time.Now()
-	_, err = pr.client.Repositories.PullRequests.Update(options)
+	resp, err := pr.client.Repositories.PullRequests.Update(options)
 	statusCode := parseErrorStatusCode(err, http.StatusOK)
 	metrics.RecordSCMCall(repo, metrics.SCMAPIPullRequest, metrics.SCMOperationUpdate, statusCode, time.Since(start), nil)
 
@@ -133,6 +156,77 @@ func (pr *PullRequest) Update(ctx context.Context, title, description string, pr
 	logger.V(4).Info("bitbucket response status", "status", statusCode)
 	logger.V(4).Info("updated pull request", "id", prObj.Status.ID)
 
+	// The client only sends the draft state when it is true, so a draft is marked ready for review separately.
+	if respMap, ok := resp.(map[string]any); ok && respMap["draft"] == true && !prObj.Spec.Draft {
+		if err := pr.markReady(ctx, repo, prObj.Status.ID); err != nil {
+			return err
+		}
+		logger.Info("marked pull request ready for review", "id", prObj.Status.ID)
+	}
+
+	return nil
+}
+
+// getReviewers returns the account UUIDs of the reviewers of the pull request.
+func (pr *PullRequest) getReviewers(repo *v1alpha1.GitRepository, id string) ([]string, error) {
+	options := &bitbucket.PullRequestsOptions{
+		Owner:    repo.Spec.BitbucketCloud.Owner,
+		RepoSlug: repo.Spec.BitbucketCloud.Name,
+		ID:       id,
+	}
+
+	start := time.Now()
+	resp, err := pr.client.Repositories.PullRequests.Get(options)
+	statusCode := parseErrorStatusCode(err, http.StatusOK)
+	metrics.RecordSCMCall(repo, metrics.SCMAPIPullRequest, metrics.SCMOperationGet, statusCode, time.Since(start), nil)
+
+	if err != nil {
+		var unexpectedErr *bitbucket.UnexpectedResponseStatusError
+		if errors.As(err, &unexpectedErr) {
+			return nil, fmt.Errorf("failed to get pull request: %w", unexpectedErr.ErrorWithBody())
+		}
+		return nil, fmt.Errorf("failed to get pull request: %w", err)
+	}
+
+	respMap, ok := resp.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("unexpected response type from Bitbucket API: %T", resp)
+	}
+	entries, _ := respMap["reviewers"].([]any)
+	var reviewers []string
+	for _, entry := range entries {
+		if reviewer, ok := entry.(map[string]any); ok {
+			if uuid, ok := reviewer["uuid"].(string); ok && uuid != "" {
+				reviewers = append(reviewers, uuid)
+			}
+		}
+	}
+	return reviewers, nil
+}
+
+// markReady marks a draft pull request ready for review.
+func (pr *PullRequest) markReady(ctx context.Context, repo *v1alpha1.GitRepository, id string) error {
+	endpoint := fmt.Sprintf("%s/repositories/%s/%s/pullrequests/%s", pr.client.GetApiBaseURL(),
+		url.PathEscape(repo.Spec.BitbucketCloud.Owner), url.PathEscape(repo.Spec.BitbucketCloud.Name), url.PathEscape(id))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, endpoint, strings.NewReader(`{"draft":false}`))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+pr.token)
+
+	start := time.Now()
+	resp, err := pr.client.HttpClient.Do(req)
+	if err != nil {
+		metrics.RecordSCMCall(repo, metrics.SCMAPIPullRequest, metrics.SCMOperationUpdate, http.StatusInternalServerError, time.Since(start), nil)
+		return fmt.Errorf("failed to mark pull request ready for review: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	metrics.RecordSCMCall(repo, metrics.SCMAPIPullRequest, metrics.SCMOperationUpdate, resp.StatusCode, time.Since(start), nil)
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("failed to mark pull request ready for review: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
 	return nil
 }
 
@@ -316,3 +410,61 @@ func (pr *PullRequest) GetUrl(ctx context.Context, prObj v1alpha1.PullRequest) (
 		repo.Spec.BitbucketCloud.Name,
 		prObj.Status.ID), nil
 }
+
+// UpsertComment posts a comment on the pull request, or updates the comment with the given ID.
+func (pr *PullRequest) UpsertComment(ctx context.Context, prObj v1alpha1.PullRequest, commentID, body string) (string, error) {
+	repo, err := utils.GetGitRepositoryFromObjectKey(ctx, pr.k8sClient, client.ObjectKey{
+		Namespace: prObj.Namespace,
+		Name:      prObj.Spec.RepositoryReference.Name,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get repo: %w", err)
+	}
+
+	options := (&bitbucket.PullRequestCommentOptions{
+		Owner:         repo.Spec.BitbucketCloud.Owner,
+		RepoSlug:      repo.Spec.BitbucketCloud.Name,
+		PullRequestID: prObj.Status.ID,
+		Content:       body,
+		CommentId:     commentID,
+	}).WithContext(ctx)
+
+	if commentID != "" {
+		start := time.Now()
+		_, err = pr.client.Repositories.PullRequests.UpdateComment(options)
+		statusCode := parseErrorStatusCode(err, http.StatusOK)
+		metrics.RecordSCMCall(repo, metrics.SCMAPIPullRequest, metrics.SCMOperationUpdate, statusCode, time.Since(start), nil)
+		if statusCode == http.StatusNotFound {
+			return "", fmt.Errorf("failed to update comment %s: %w: %w", commentID, scms.ErrCommentNotFound, err)
+		}
+		if err != nil {
+			var unexpectedErr *bitbucket.UnexpectedResponseStatusError
+			if errors.As(err, &unexpectedErr) {
+				return "", fmt.Errorf("failed to update comment %s: %w", commentID, unexpectedErr.ErrorWithBody())
+			}
+			return "", fmt.Errorf("failed to update comment %s: %w", commentID, err)
+		}
+		return commentID, nil
+	}
+
+	start := time.Now()
+	resp, err := pr.client.Repositories.PullRequests.AddComment(options)
+	metrics.RecordSCMCall(repo, metrics.SCMAPIPullRequest, metrics.SCMOperationCreate, parseErrorStatusCode(err, http.StatusCreated), time.Since(start), nil)
+	if err != nil {
+		var unexpectedErr *bitbucket.UnexpectedResponseStatusError
+		if errors.As(err, &unexpectedErr) {
+			return "", fmt.Errorf("failed to comment on pull request: %w", unexpectedErr.ErrorWithBody())
+		}
+		return "", fmt.Errorf("failed to comment on pull request: %w", err)
+	}
+
+	respMap, ok := resp.(map[string]any)
+	if !ok {
+		return "", fmt.Errorf("unexpected response type from Bitbucket API: %T", resp)
+	}
+	idFloat, ok := respMap["id"].(float64)
+	if !ok {
+		return "", fmt.Errorf("comment ID has unexpected type: %T (expected float64)", respMap["id"])
+	}
+	return strconv.Itoa(int(idFloat)), nil
+}
@@ -0,0 +1,16 @@
+package bitbucket_server
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestBitbucketServer(t *testing.T) {
+	t.Parallel()
+
+	RegisterFailHandler(Fail)
+	c, _ := GinkgoConfiguration()
+	RunSpecs(t, "Bitbucket Server Suite", c)
+}
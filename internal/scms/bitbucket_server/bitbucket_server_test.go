@@ -0,0 +1,317 @@
+package bitbucket_server
+
+import (
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms"
+)
+
+const namespace = "default"
+
+func newSecret(token string) corev1.Secret {
+	return corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "bitbucket-secret", Namespace: namespace},
+		Data:       map[string][]byte{"token": []byte(token)},
+	}
+}
+
+func newK8sClient() client.Client {
+	scheme := runtime.NewScheme()
+	Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+	Expect(corev1.AddToScheme(scheme)).To(Succeed())
+
+	gitRepo := &v1alpha1.GitRepository{
+		ObjectMeta: metav1.ObjectMeta{Name: "bitbucket-repo", Namespace: namespace},
+		Spec: v1alpha1.GitRepositorySpec{
+			BitbucketServer: &v1alpha1.BitbucketServerRepo{Project: fakeProject, Name: fakeRepo},
+			ScmProviderRef:  v1alpha1.ScmProviderObjectReference{Kind: v1alpha1.ScmProviderKind, Name: "bitbucket"},
+		},
+	}
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(gitRepo).Build()
+}
+
+func newPullRequestObject(source, target, id string) v1alpha1.PullRequest {
+	return v1alpha1.PullRequest{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pr", Namespace: namespace},
+		Spec: v1alpha1.PullRequestSpec{
+			RepositoryReference: v1alpha1.ObjectReference{Name: "bitbucket-repo"},
+			SourceBranch:        source,
+			TargetBranch:        target,
+		},
+		Status: v1alpha1.PullRequestStatus{ID: id},
+	}
+}
+
+var _ = Describe("Bitbucket Data Center", func() {
+	var server *fakeBitbucketServer
+
+	BeforeEach(func() {
+		server = newFakeBitbucketServer()
+		DeferCleanup(server.Close)
+	})
+
+	newPullRequestProvider := func(token string) *PullRequest {
+		provider, err := NewBitbucketServerPullRequestProvider(newK8sClient(), newSecret(token), server.domain())
+		Expect(err).NotTo(HaveOccurred())
+		provider.client.httpClient = server.Client()
+		return provider
+	}
+
+	Describe("PullRequest", func() {
+		It("should create, find, update and merge a pull request", func(ctx SpecContext) {
+			provider := newPullRequestProvider(fakeToken)
+
+			By("Creating a pull request from the same source branch to another target first, so FindOpen has to page")
+			_, err := provider.Create(ctx, "Other", "environment/dev-next", "other", "", newPullRequestObject("environment/dev-next", "other", ""))
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Creating the pull request")
+			prObj := newPullRequestObject("environment/dev-next", "environment/dev", "")
+			id, err := provider.Create(ctx, "Promote abc1234", "environment/dev-next", "environment/dev", "description", prObj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(id).To(Equal("2"))
+			created := server.pullRequest(id)
+			Expect(created.FromRef.ID).To(Equal("refs/heads/environment/dev-next"))
+			Expect(created.ToRef.ID).To(Equal("refs/heads/environment/dev"))
+
+			By("Finding the open pull request")
+			found, foundID, createdAt, err := provider.FindOpen(ctx, prObj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(foundID).To(Equal(id))
+			Expect(createdAt).To(BeTemporally("==", time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)))
+
+			By("Updating the pull request twice, which requires the current version each time")
+			prObj.Status.ID = id
+			Expect(provider.Update(ctx, "Promote def5678", "new description", prObj)).To(Succeed())
+			Expect(provider.Update(ctx, "Promote 0123abc", "newer description", prObj)).To(Succeed())
+			updated := server.pullRequest(id)
+			Expect(updated.Title).To(Equal("Promote 0123abc"))
+			Expect(updated.Description).To(Equal("newer description"))
+			Expect(updated.Version).To(Equal(2))
+
+			By("Refusing to merge a source branch that moved past the merge SHA")
+			server.setLatestCommit(id, "def5678")
+			prObj.Spec.MergeSha = "abc1234"
+			prObj.Spec.Commit.Message = "Promote abc1234\n\nGitops-Promoter-Dry-Sha: abc1234"
+			err = provider.Merge(ctx, prObj)
+			Expect(err).To(MatchError(ContainSubstring(`source branch is at "def5678", not the merge SHA "abc1234"`)))
+			Expect(server.pullRequest(id).State).To(Equal("OPEN"))
+
+			By("Merging the pull request with the commit message")
+			server.setLatestCommit(id, "abc1234")
+			Expect(provider.Merge(ctx, prObj)).To(Succeed())
+			Expect(server.pullRequest(id).State).To(Equal("MERGED"))
+			Expect(server.mergeMessage(id)).To(Equal(prObj.Spec.Commit.Message))
+
+			found, _, _, err = provider.FindOpen(ctx, prObj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("should add reviewers when opening a pull request and keep them on update", func(ctx SpecContext) {
+			provider := newPullRequestProvider(fakeToken)
+			prObj := newPullRequestObject("environment/prod-next", "environment/prod", "")
+			prObj.Spec.Reviewers = []string{"jane", "release-manager"}
+
+			id, err := provider.Create(ctx, "Promote", "environment/prod-next", "environment/prod", "", prObj)
+			Expect(err).NotTo(HaveOccurred())
+			reviewers := []participant{{User: participantUser{Name: "jane"}}, {User: participantUser{Name: "release-manager"}}}
+			Expect(server.pullRequest(id).Reviewers).To(Equal(reviewers))
+
+			prObj.Status.ID = id
+			Expect(provider.Update(ctx, "Promote again", "", prObj)).To(Succeed())
+			Expect(server.pullRequest(id).Reviewers).To(Equal(reviewers))
+		})
+
+		It("should open a draft and mark it ready for review", func(ctx SpecContext) {
+			provider := newPullRequestProvider(fakeToken)
+			prObj := newPullRequestObject("environment/prod-next", "environment/prod", "")
+			prObj.Spec.Draft = true
+
+			id, err := provider.Create(ctx, "Promote", "environment/prod-next", "environment/prod", "", prObj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(server.pullRequest(id).Draft).To(Equal(ptr.To(true)))
+
+			prObj.Status.ID = id
+			prObj.Spec.Draft = false
+			Expect(provider.Update(ctx, "Promote", "", prObj)).To(Succeed())
+			Expect(server.pullRequest(id).Draft).To(Equal(ptr.To(false)))
+		})
+
+		It("should post a comment and update it at its current version", func(ctx SpecContext) {
+			provider := newPullRequestProvider(fakeToken)
+			prObj := newPullRequestObject("environment/prod-next", "environment/prod", "")
+			id, err := provider.Create(ctx, "Promote", "environment/prod-next", "environment/prod", "", prObj)
+			Expect(err).NotTo(HaveOccurred())
+			prObj.Status.ID = id
+
+			commentID, err := provider.UpsertComment(ctx, prObj, "", "pending")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(commentID).To(Equal("1"))
+
+			for _, body := range []string{"failure", "success"} {
+				commentID, err = provider.UpsertComment(ctx, prObj, commentID, body)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(commentID).To(Equal("1"))
+			}
+			Expect(server.storedComment(commentID)).To(Equal(comment{ID: 1, Version: 2, Text: "success"}))
+
+			By("Reporting a deleted comment as not found")
+			_, err = provider.UpsertComment(ctx, prObj, "42", "failure")
+			Expect(err).To(MatchError(scms.ErrCommentNotFound))
+		})
+
+		It("should decline a pull request when closing it", func(ctx SpecContext) {
+			provider := newPullRequestProvider(fakeToken)
+			prObj := newPullRequestObject("environment/dev-next", "environment/dev", "")
+			id, err := provider.Create(ctx, "Promote abc1234", "environment/dev-next", "environment/dev", "", prObj)
+			Expect(err).NotTo(HaveOccurred())
+
+			prObj.Status.ID = id
+			Expect(provider.Close(ctx, prObj)).To(Succeed())
+			Expect(server.pullRequest(id).State).To(Equal("DECLINED"))
+
+			By("Failing to merge the declined pull request")
+			err = provider.Merge(ctx, prObj)
+			Expect(err).To(MatchError(ContainSubstring("The pull request is not open.")))
+		})
+
+		It("should report the merge checks that veto the merge as unmet branch protection", func(ctx SpecContext) {
+			provider := newPullRequestProvider(fakeToken)
+			prObj := newPullRequestObject("environment/dev-next", "environment/dev", "")
+			id, err := provider.Create(ctx, "Promote abc1234", "environment/dev-next", "environment/dev", "", prObj)
+			Expect(err).NotTo(HaveOccurred())
+			prObj.Status.ID = id
+
+			By("Reporting no branch protection while no merge check vetoes the merge")
+			protection, err := provider.GetBranchProtection(ctx, prObj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(protection).To(BeNil())
+
+			server.setVetoes(id,
+				mergeVeto{SummaryMessage: "Not all required builds are successful yet", DetailedMessage: "You need 1 more successful build before this pull request can be merged."},
+				mergeVeto{SummaryMessage: "Requires approvals"},
+			)
+			protection, err = provider.GetBranchProtection(ctx, prObj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(protection).NotTo(BeNil())
+			Expect(protection.Satisfied()).To(BeFalse())
+			Expect(protection.Message()).To(Equal("Branch protection is not satisfied: You need 1 more successful build before this pull request can be merged; Requires approvals"))
+		})
+
+		It("should return the web URL of the pull request", func(ctx SpecContext) {
+			provider := newPullRequestProvider(fakeToken)
+			url, err := provider.GetUrl(ctx, newPullRequestObject("environment/dev-next", "environment/dev", "42"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(url).To(Equal(server.URL + "/projects/PROJ/repos/repo/pull-requests/42"))
+		})
+
+		It("should surface the error messages returned by the server", func(ctx SpecContext) {
+			provider := newPullRequestProvider("wrong-token")
+			_, err := provider.Create(ctx, "Promote abc1234", "environment/dev-next", "environment/dev", "", newPullRequestObject("environment/dev-next", "environment/dev", ""))
+			Expect(err).To(MatchError(ContainSubstring("unexpected response status 401 from Bitbucket Data Center: Authentication failed.")))
+
+			var apiErr *APIError
+			Expect(errors.As(err, &apiErr)).To(BeTrue())
+			Expect(apiErr.StatusCode).To(Equal(401))
+		})
+
+		It("should require a token in the secret", func() {
+			_, err := NewBitbucketServerPullRequestProvider(newK8sClient(), newSecret(""), server.domain())
+			Expect(err).To(MatchError(ContainSubstring("missing required data key 'token'")))
+		})
+	})
+
+	Describe("CommitStatus", func() {
+		It("should post a build status with the commit URL as the default", func(ctx SpecContext) {
+			provider, err := NewBitbucketServerCommitStatusProvider(newK8sClient(), newSecret(fakeToken), server.domain())
+			Expect(err).NotTo(HaveOccurred())
+			provider.client.httpClient = server.Client()
+
+			sha := "0123456789abcdef0123456789abcdef01234567"
+			commitStatus := &v1alpha1.CommitStatus{
+				ObjectMeta: metav1.ObjectMeta{Name: "health", Namespace: namespace},
+				Spec: v1alpha1.CommitStatusSpec{
+					RepositoryReference: v1alpha1.ObjectReference{Name: "bitbucket-repo"},
+					Sha:                 sha,
+					Name:                "argocd-health",
+					Description:         "Apps are healthy",
+					Phase:               v1alpha1.CommitPhaseSuccess,
+				},
+			}
+
+			result, err := provider.Set(ctx, commitStatus)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Status.Phase).To(Equal(v1alpha1.CommitPhaseSuccess))
+			Expect(result.Status.Sha).To(Equal(sha))
+
+			Expect(server.buildStatuses(sha)).To(ConsistOf(buildStatus{
+				State:       "SUCCESSFUL",
+				Key:         "argocd-health",
+				Name:        "argocd-health",
+				Url:         server.URL + "/projects/PROJ/repos/repo/commits/" + sha,
+				Description: "Apps are healthy",
+			}))
+
+			By("Replacing the status when the phase changes")
+			commitStatus.Spec.Phase = v1alpha1.CommitPhasePending
+			commitStatus.Spec.Url = "https://ci.example.com/build/1"
+			_, err = provider.Set(ctx, commitStatus)
+			Expect(err).NotTo(HaveOccurred())
+			statuses := server.buildStatuses(sha)
+			Expect(statuses).To(HaveLen(2))
+			Expect(statuses[1].State).To(Equal("INPROGRESS"))
+			Expect(statuses[1].Url).To(Equal("https://ci.example.com/build/1"))
+		})
+	})
+
+	Describe("GitAuthenticationProvider", func() {
+		newScmProvider := func(domain string) *v1alpha1.ScmProvider {
+			return &v1alpha1.ScmProvider{
+				Spec: v1alpha1.ScmProviderSpec{BitbucketServer: &v1alpha1.BitbucketServer{Domain: domain}},
+			}
+		}
+		gitRepo := v1alpha1.GitRepository{
+			Spec: v1alpha1.GitRepositorySpec{
+				BitbucketServer: &v1alpha1.BitbucketServerRepo{Project: "PROJ", Name: "repo"},
+			},
+		}
+
+		It("should build the clone URL, including any context path", func() {
+			secret := newSecret(fakeToken)
+			provider, err := NewBitbucketServerGitAuthenticationProvider(newScmProvider("git.example.com:7990/bitbucket"), &secret)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(provider.GetGitHttpsRepoUrl(gitRepo)).To(Equal("https://git.example.com:7990/bitbucket/scm/proj/repo.git"))
+		})
+
+		It("should use the username from the secret when it is set", func(ctx SpecContext) {
+			secret := newSecret(fakeToken)
+			provider, err := NewBitbucketServerGitAuthenticationProvider(newScmProvider("bitbucket.example.com"), &secret)
+			Expect(err).NotTo(HaveOccurred())
+			user, err := provider.GetUser(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(user).To(Equal("x-token-auth"))
+
+			secret.Data["username"] = []byte("jdoe")
+			user, err = provider.GetUser(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(user).To(Equal("jdoe"))
+
+			token, err := provider.GetToken(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(token).To(Equal(fakeToken))
+		})
+	})
+})
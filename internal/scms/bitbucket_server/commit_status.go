@@ -0,0 +1,90 @@
+package bitbucket_server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/metrics"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms"
+	"github.com/argoproj-labs/gitops-promoter/internal/utils"
+)
+
+// Bitbucket Data Center key and name fields for build statuses have a max length of 255
+const maxKeyFieldLength = 255
+
+// CommitStatus implements the scms.CommitStatusProvider interface for Bitbucket Data Center using build statuses.
+type CommitStatus struct {
+	client    *Client
+	k8sClient client.Client
+}
+
+var _ scms.CommitStatusProvider = &CommitStatus{}
+
+// buildStatus is the request body for creating a build status.
+type buildStatus struct {
+	State       string `json:"state"`
+	Key         string `json:"key"`
+	Name        string `json:"name,omitempty"`
+	Url         string `json:"url"`
+	Description string `json:"description,omitempty"`
+}
+
+// NewBitbucketServerCommitStatusProvider creates a new instance of CommitStatus for Bitbucket Data Center.
+func NewBitbucketServerCommitStatusProvider(k8sClient client.Client, secret v1.Secret, domain string) (*CommitStatus, error) {
+	client, err := GetClient(domain, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CommitStatus{client: client, k8sClient: k8sClient}, nil
+}
+
+// Set sets the build status for a given commit SHA in the specified repository. Posting a status with the same key
+// replaces the previous one.
+func (cs *CommitStatus) Set(ctx context.Context, commitStatus *v1alpha1.CommitStatus) (*v1alpha1.CommitStatus, error) {
+	logger := log.FromContext(ctx)
+	logger.Info("Setting Commit Phase")
+
+	repo, err := utils.GetGitRepositoryFromObjectKey(ctx, cs.k8sClient, client.ObjectKey{
+		Namespace: commitStatus.Namespace,
+		Name:      commitStatus.Spec.RepositoryReference.Name,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repo: %w", err)
+	}
+
+	// The URL is required by Bitbucket Data Center, so fall back to the commit's page.
+	commitUrl := commitStatus.Spec.Url
+	if commitUrl == "" {
+		commitUrl = cs.client.webRepoURL(repo) + "/commits/" + commitStatus.Spec.Sha
+	}
+	status := buildStatus{
+		State:       phaseToBuildState(commitStatus.Spec.Phase),
+		Key:         utils.TruncateString(commitStatus.Spec.Name, maxKeyFieldLength),
+		Name:        utils.TruncateString(commitStatus.Spec.Name, maxKeyFieldLength),
+		Url:         commitUrl,
+		Description: commitStatus.Spec.Description,
+	}
+
+	start := time.Now()
+	statusCode, err := cs.client.do(ctx, http.MethodPost, repoPath(repo)+"/commits/"+commitStatus.Spec.Sha+"/builds", nil, status, nil)
+	metrics.RecordSCMCall(repo, metrics.SCMAPICommitStatus, metrics.SCMOperationCreate, statusCode, time.Since(start), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create build status: %w", err)
+	}
+
+	logger.V(4).Info("bitbucket response status", "status", statusCode)
+
+	// The build status API does not return the created status, so report the phase that was sent.
+	commitStatus.Status.Phase = commitStatus.Spec.Phase
+	commitStatus.Status.Sha = commitStatus.Spec.Sha
+
+	return commitStatus, nil
+}
@@ -0,0 +1,385 @@
+package bitbucket_server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	fakeToken   = "fake-token"
+	fakeProject = "PROJ"
+	fakeRepo    = "repo"
+)
+
+// fakeBitbucketServer is an in-memory implementation of the parts of the Bitbucket Data Center REST API used by the
+// providers. It enforces bearer token auth and pull request versions like the real server.
+type fakeBitbucketServer struct {
+	*httptest.Server
+
+	mu           sync.Mutex
+	nextID       int
+	pullRequests map[int]*pullRequest
+	builds       map[string][]buildStatus
+	// comments holds the comments on all pull requests, by comment ID minus one.
+	comments []*comment
+	// mergeMessages holds the commit messages pull requests were merged with, by pull request ID.
+	mergeMessages map[int]string
+	// vetoes holds the merge checks that veto the merge of each pull request, by pull request ID.
+	vetoes map[int][]mergeVeto
+	// pageSize is the number of pull requests returned per page when listing.
+	pageSize int
+}
+
+func newFakeBitbucketServer() *fakeBitbucketServer {
+	f := &fakeBitbucketServer{
+		nextID:        1,
+		pullRequests:  map[int]*pullRequest{},
+		builds:        map[string][]buildStatus{},
+		mergeMessages: map[int]string{},
+		vetoes:        map[int][]mergeVeto{},
+		pageSize:      1,
+	}
+
+	repoPrefix := "/rest/api/1.0/projects/" + fakeProject + "/repos/" + fakeRepo
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST "+repoPrefix+"/pull-requests", f.createPullRequest)
+	mux.HandleFunc("GET "+repoPrefix+"/pull-requests", f.listPullRequests)
+	mux.HandleFunc("GET "+repoPrefix+"/pull-requests/{id}", f.getPullRequest)
+	mux.HandleFunc("PUT "+repoPrefix+"/pull-requests/{id}", f.updatePullRequest)
+	mux.HandleFunc("POST "+repoPrefix+"/pull-requests/{id}/decline", f.transitionPullRequest("DECLINED"))
+	mux.HandleFunc("GET "+repoPrefix+"/pull-requests/{id}/merge", f.getMergeStatus)
+	mux.HandleFunc("POST "+repoPrefix+"/pull-requests/{id}/merge", f.mergePullRequest)
+	mux.HandleFunc("POST "+repoPrefix+"/pull-requests/{id}/comments", f.createComment)
+	mux.HandleFunc("GET "+repoPrefix+"/pull-requests/{id}/comments/{commentID}", f.getComment)
+	mux.HandleFunc("PUT "+repoPrefix+"/pull-requests/{id}/comments/{commentID}", f.updateComment)
+	mux.HandleFunc("POST "+repoPrefix+"/commits/{sha}/builds", f.createBuildStatus)
+
+	f.Server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+fakeToken {
+			writeErrors(w, http.StatusUnauthorized, "Authentication failed. Please check your credentials and try again.")
+			return
+		}
+		mux.ServeHTTP(w, r)
+	}))
+	return f
+}
+
+// domain returns the host and port of the server, for use as the ScmProvider domain.
+func (f *fakeBitbucketServer) domain() string {
+	return strings.TrimPrefix(f.URL, "https://")
+}
+
+func (f *fakeBitbucketServer) createPullRequest(w http.ResponseWriter, r *http.Request) {
+	var req pullRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrors(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if req.Title == "" || req.FromRef == nil || req.ToRef == nil {
+		writeErrors(w, http.StatusBadRequest, "title, fromRef and toRef are required")
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, existing := range f.pullRequests {
+		if existing.State == "OPEN" && existing.FromRef.ID == req.FromRef.ID && existing.ToRef.ID == req.ToRef.ID {
+			writeErrors(w, http.StatusConflict, "Only one pull request may be open for a given source and target branch")
+			return
+		}
+	}
+
+	created := &pullRequest{
+		ID:          f.nextID,
+		Title:       req.Title,
+		Description: req.Description,
+		State:       "OPEN",
+		FromRef:     req.FromRef,
+		ToRef:       req.ToRef,
+		CreatedDate: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC).UnixMilli(),
+		Reviewers:   req.Reviewers,
+		Draft:       req.Draft,
+	}
+	f.pullRequests[created.ID] = created
+	f.nextID++
+	writeJSON(w, http.StatusCreated, created)
+}
+
+func (f *fakeBitbucketServer) listPullRequests(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	start, _ := strconv.Atoi(query.Get("start"))
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var matching []pullRequest
+	for id := 1; id < f.nextID; id++ {
+		p, ok := f.pullRequests[id]
+		if !ok {
+			continue
+		}
+		if state := query.Get("state"); state != "" && p.State != state {
+			continue
+		}
+		// With direction=OUTGOING, "at" filters on the source branch.
+		if at := query.Get("at"); at != "" && p.FromRef.ID != at {
+			continue
+		}
+		matching = append(matching, *p)
+	}
+
+	page := pullRequestPage{IsLastPage: true, Values: []pullRequest{}}
+	if start < len(matching) {
+		end := min(start+f.pageSize, len(matching))
+		page.Values = matching[start:end]
+		if end < len(matching) {
+			page.IsLastPage = false
+			page.NextPageStart = end
+		}
+	}
+	writeJSON(w, http.StatusOK, page)
+}
+
+func (f *fakeBitbucketServer) getPullRequest(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	p := f.lookup(w, r)
+	if p == nil {
+		return
+	}
+	writeJSON(w, http.StatusOK, p)
+}
+
+func (f *fakeBitbucketServer) updatePullRequest(w http.ResponseWriter, r *http.Request) {
+	var req pullRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrors(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	p := f.lookup(w, r)
+	if p == nil {
+		return
+	}
+	if req.Version != p.Version {
+		writeErrors(w, http.StatusConflict, "You are attempting to modify a pull request based on out-of-date information.")
+		return
+	}
+	p.Title = req.Title
+	p.Description = req.Description
+	p.Reviewers = req.Reviewers
+	if req.Draft != nil {
+		p.Draft = req.Draft
+	}
+	p.Version++
+	writeJSON(w, http.StatusOK, p)
+}
+
+func (f *fakeBitbucketServer) transitionPullRequest(state string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		p := f.lookup(w, r)
+		if p == nil {
+			return
+		}
+		if r.URL.Query().Get("version") != strconv.Itoa(p.Version) {
+			writeErrors(w, http.StatusConflict, "You are attempting to modify a pull request based on out-of-date information.")
+			return
+		}
+		if p.State != "OPEN" {
+			writeErrors(w, http.StatusConflict, "The pull request is not open.")
+			return
+		}
+		p.State = state
+		p.Version++
+		writeJSON(w, http.StatusOK, p)
+	}
+}
+
+func (f *fakeBitbucketServer) mergePullRequest(w http.ResponseWriter, r *http.Request) {
+	var req mergeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrors(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	f.mu.Lock()
+	id, _ := strconv.Atoi(r.PathValue("id"))
+	f.mergeMessages[id] = req.Message
+	f.mu.Unlock()
+
+	f.transitionPullRequest("MERGED")(w, r)
+}
+
+func (f *fakeBitbucketServer) getMergeStatus(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	p := f.lookup(w, r)
+	if p == nil {
+		return
+	}
+	vetoes := f.vetoes[p.ID]
+	writeJSON(w, http.StatusOK, map[string]any{"canMerge": len(vetoes) == 0, "conflicted": false, "vetoes": append([]mergeVeto{}, vetoes...)})
+}
+
+func (f *fakeBitbucketServer) createComment(w http.ResponseWriter, r *http.Request) {
+	var req comment
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrors(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.lookup(w, r) == nil {
+		return
+	}
+	c := &comment{ID: len(f.comments) + 1, Text: req.Text}
+	f.comments = append(f.comments, c)
+	writeJSON(w, http.StatusCreated, c)
+}
+
+func (f *fakeBitbucketServer) getComment(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if c := f.lookupComment(w, r); c != nil {
+		writeJSON(w, http.StatusOK, c)
+	}
+}
+
+func (f *fakeBitbucketServer) updateComment(w http.ResponseWriter, r *http.Request) {
+	var req comment
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrors(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	c := f.lookupComment(w, r)
+	if c == nil {
+		return
+	}
+	if req.Version != c.Version {
+		writeErrors(w, http.StatusConflict, "You are attempting to modify a comment based on out-of-date information.")
+		return
+	}
+	c.Text = req.Text
+	c.Version++
+	writeJSON(w, http.StatusOK, c)
+}
+
+// lookupComment returns the comment for the request's {commentID}, or writes a 404 and returns nil. The caller must
+// hold f.mu.
+func (f *fakeBitbucketServer) lookupComment(w http.ResponseWriter, r *http.Request) *comment {
+	if f.lookup(w, r) == nil {
+		return nil
+	}
+	id, err := strconv.Atoi(r.PathValue("commentID"))
+	if err != nil || id < 1 || id > len(f.comments) {
+		writeErrors(w, http.StatusNotFound, "Comment "+r.PathValue("commentID")+" does not exist.")
+		return nil
+	}
+	return f.comments[id-1]
+}
+
+func (f *fakeBitbucketServer) createBuildStatus(w http.ResponseWriter, r *http.Request) {
+	var req buildStatus
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrors(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if req.Key == "" || req.Url == "" {
+		writeErrors(w, http.StatusBadRequest, "key and url are required")
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	sha := r.PathValue("sha")
+	f.builds[sha] = append(f.builds[sha], req)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// lookup returns the pull request for the request's {id}, or writes a 404 and returns nil. The caller must hold f.mu.
+func (f *fakeBitbucketServer) lookup(w http.ResponseWriter, r *http.Request) *pullRequest {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeErrors(w, http.StatusNotFound, "Pull request "+r.PathValue("id")+" does not exist.")
+		return nil
+	}
+	p, ok := f.pullRequests[id]
+	if !ok {
+		writeErrors(w, http.StatusNotFound, "Pull request "+r.PathValue("id")+" does not exist.")
+		return nil
+	}
+	return p
+}
+
+// pullRequest returns a copy of the stored pull request with the given ID.
+func (f *fakeBitbucketServer) pullRequest(id string) pullRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	n, _ := strconv.Atoi(id)
+	return *f.pullRequests[n]
+}
+
+// setLatestCommit sets the SHA that the source branch of the pull request with the given ID points to.
+func (f *fakeBitbucketServer) setLatestCommit(id string, sha string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	n, _ := strconv.Atoi(id)
+	fromRef := *f.pullRequests[n].FromRef
+	fromRef.LatestCommit = sha
+	f.pullRequests[n].FromRef = &fromRef
+}
+
+// setVetoes sets the merge checks that veto the merge of the pull request with the given ID.
+func (f *fakeBitbucketServer) setVetoes(id string, vetoes ...mergeVeto) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	n, _ := strconv.Atoi(id)
+	f.vetoes[n] = vetoes
+}
+
+// mergeMessage returns the commit message the pull request with the given ID was merged with.
+func (f *fakeBitbucketServer) mergeMessage(id string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	n, _ := strconv.Atoi(id)
+	return f.mergeMessages[n]
+}
+
+// storedComment returns a copy of the stored comment with the given ID.
+func (f *fakeBitbucketServer) storedComment(id string) comment {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	n, _ := strconv.Atoi(id)
+	return *f.comments[n-1]
+}
+
+// buildStatuses returns the build statuses posted for the given commit.
+func (f *fakeBitbucketServer) buildStatuses(sha string) []buildStatus {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]buildStatus(nil), f.builds[sha]...)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeErrors(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]any{
+		"errors": []map[string]string{{"message": message}},
+	})
+}
@@ -0,0 +1,66 @@
+package bitbucket_server
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms"
+)
+
+// defaultGitUser is the user sent with the HTTP access token for git operations. Bitbucket Data Center identifies
+// project and repository tokens by the token alone, so the user only matters for personal tokens.
+const defaultGitUser = "x-token-auth"
+
+// GitAuthenticationProvider implements the scms.GitOperationsProvider interface for Bitbucket Data Center.
+type GitAuthenticationProvider struct {
+	scmProvider v1alpha1.GenericScmProvider
+	secret      *v1.Secret
+}
+
+var _ scms.GitOperationsProvider = &GitAuthenticationProvider{}
+
+// NewBitbucketServerGitAuthenticationProvider creates a new instance of GitAuthenticationProvider for Bitbucket Data
+// Center.
+func NewBitbucketServerGitAuthenticationProvider(scmProvider v1alpha1.GenericScmProvider, secret *v1.Secret) (*GitAuthenticationProvider, error) {
+	if _, err := GetClient(scmProvider.GetSpec().BitbucketServer.Domain, *secret); err != nil {
+		return nil, fmt.Errorf("failed to create Bitbucket Data Center client: %w", err)
+	}
+
+	return &GitAuthenticationProvider{
+		scmProvider: scmProvider,
+		secret:      secret,
+	}, nil
+}
+
+// GetGitHttpsRepoUrl constructs the HTTPS URL for a Bitbucket Data Center repository based on the provided
+// GitRepository object.
+func (gap GitAuthenticationProvider) GetGitHttpsRepoUrl(repo v1alpha1.GitRepository) string {
+	repoUrl := fmt.Sprintf("https://%s/scm/%s/%s.git",
+		strings.TrimSuffix(gap.scmProvider.GetSpec().BitbucketServer.Domain, "/"),
+		strings.ToLower(repo.Spec.BitbucketServer.Project),
+		repo.Spec.BitbucketServer.Name,
+	)
+	if _, err := url.Parse(repoUrl); err != nil {
+		return ""
+	}
+	return repoUrl
+}
+
+// GetToken retrieves the Bitbucket Data Center HTTP access token from the secret.
+func (gap GitAuthenticationProvider) GetToken(ctx context.Context) (string, error) {
+	return string(gap.secret.Data["token"]), nil
+}
+
+// GetUser returns the user from the secret's optional "username" key, or a placeholder user if it is not set.
+// Personal HTTP access tokens must be used with the slug of the user that owns them.
+func (gap GitAuthenticationProvider) GetUser(ctx context.Context) (string, error) {
+	if username := string(gap.secret.Data["username"]); username != "" {
+		return username, nil
+	}
+	return defaultGitUser, nil
+}
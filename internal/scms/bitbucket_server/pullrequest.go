@@ -0,0 +1,413 @@
+package bitbucket_server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/metrics"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms"
+	"github.com/argoproj-labs/gitops-promoter/internal/utils"
+)
+
+// PullRequest implements the scms.PullRequestProvider interface for Bitbucket Data Center.
+type PullRequest struct {
+	client    *Client
+	k8sClient client.Client
+}
+
+var (
+	_ scms.PullRequestProvider                 = &PullRequest{}
+	_ scms.PullRequestBranchProtectionProvider = &PullRequest{}
+)
+
+// pullRequestRef is a source or target ref of a pull request.
+type pullRequestRef struct {
+	ID string `json:"id"`
+	// LatestCommit is the SHA the ref points to. It is only read from the server.
+	LatestCommit string `json:"latestCommit,omitempty"`
+}
+
+// pullRequest is the subset of the Bitbucket Data Center pull request resource used by the provider.
+type pullRequest struct {
+	ID          int             `json:"id,omitempty"`
+	Version     int             `json:"version"`
+	Title       string          `json:"title,omitempty"`
+	Description string          `json:"description"`
+	State       string          `json:"state,omitempty"`
+	FromRef     *pullRequestRef `json:"fromRef,omitempty"`
+	ToRef       *pullRequestRef `json:"toRef,omitempty"`
+	CreatedDate int64           `json:"createdDate,omitempty"`
+	// Reviewers replace the reviewers of the pull request when it is updated.
+	Reviewers []participant `json:"reviewers,omitempty"`
+	// Draft is only sent to change the draft state, since servers older than 8.18 do not support drafts.
+	Draft *bool `json:"draft,omitempty"`
+}
+
+// mergeRequest is the body of a request to merge a pull request.
+type mergeRequest struct {
+	Message string `json:"message,omitempty"`
+}
+
+// mergeStatus is the subset of the result of the merge checks of a pull request used by the provider.
+type mergeStatus struct {
+	Vetoes []mergeVeto `json:"vetoes"`
+}
+
+// mergeVeto is a merge check that the pull request does not pass.
+type mergeVeto struct {
+	SummaryMessage  string `json:"summaryMessage"`
+	DetailedMessage string `json:"detailedMessage"`
+}
+
+// participant is a reviewer of a pull request.
+type participant struct {
+	User participantUser `json:"user"`
+}
+
+// participantUser identifies a user by their slug.
+type participantUser struct {
+	Name string `json:"name"`
+}
+
+// comment is the subset of the Bitbucket Data Center pull request comment resource used by the provider.
+type comment struct {
+	ID      int    `json:"id,omitempty"`
+	Version int    `json:"version"`
+	Text    string `json:"text"`
+}
+
+// pullRequestPage is a page of pull requests.
+type pullRequestPage struct {
+	Values        []pullRequest `json:"values"`
+	IsLastPage    bool          `json:"isLastPage"`
+	NextPageStart int           `json:"nextPageStart"`
+}
+
+// NewBitbucketServerPullRequestProvider creates a new instance of PullRequest for Bitbucket Data Center.
+func NewBitbucketServerPullRequestProvider(k8sClient client.Client, secret v1.Secret, domain string) (*PullRequest, error) {
+	client, err := GetClient(domain, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PullRequest{
+		client:    client,
+		k8sClient: k8sClient,
+	}, nil
+}
+
+// Create creates a new pull request with the specified title, head, base, and description.
+func (pr *PullRequest) Create(ctx context.Context, title, head, base, desc string, prObj v1alpha1.PullRequest) (string, error) {
+	logger := log.FromContext(ctx)
+
+	repo, err := utils.GetGitRepositoryFromObjectKey(ctx, pr.k8sClient, client.ObjectKey{
+		Namespace: prObj.Namespace,
+		Name:      prObj.Spec.RepositoryReference.Name,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get GitRepository: %w", err)
+	}
+
+	body := pullRequest{
+		Title:       title,
+		Description: desc,
+		FromRef:     &pullRequestRef{ID: branchRef(head)},
+		ToRef:       &pullRequestRef{ID: branchRef(base)},
+	}
+	for _, reviewer := range prObj.Spec.Reviewers {
+		body.Reviewers = append(body.Reviewers, participant{User: participantUser{Name: reviewer}})
+	}
+	if prObj.Spec.Draft {
+		body.Draft = ptr.To(true)
+	}
+
+	var created pullRequest
+	start := time.Now()
+	statusCode, err := pr.client.do(ctx, http.MethodPost, repoPath(repo)+"/pull-requests", nil, body, &created)
+	metrics.RecordSCMCall(repo, metrics.SCMAPIPullRequest, metrics.SCMOperationCreate, statusCode, time.Since(start), nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create pull request: %w", err)
+	}
+	if created.ID == 0 {
+		return "", errors.New("pull request ID not found in Bitbucket Data Center API response")
+	}
+
+	logger.V(4).Info("bitbucket response status", "status", statusCode)
+	logger.V(4).Info("created pull request", "id", created.ID)
+
+	return strconv.Itoa(created.ID), nil
+}
+
+// Update updates an existing pull request with the specified title and description.
+func (pr *PullRequest) Update(ctx context.Context, title, description string, prObj v1alpha1.PullRequest) error {
+	logger := log.FromContext(ctx)
+
+	repo, err := utils.GetGitRepositoryFromObjectKey(ctx, pr.k8sClient, client.ObjectKey{
+		Namespace: prObj.Namespace,
+		Name:      prObj.Spec.RepositoryReference.Name,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get repo: %w", err)
+	}
+
+	current, err := pr.get(ctx, repo, prObj.Status.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update pull request: %w", err)
+	}
+
+	body := pullRequest{
+		Version:     current.Version,
+		Title:       title,
+		Description: description,
+		ToRef:       current.ToRef,
+		Reviewers:   current.Reviewers,
+	}
+	if ptr.Deref(current.Draft, false) != prObj.Spec.Draft {
+		body.Draft = ptr.To(prObj.Spec.Draft)
+	}
+
+	start := time.Now()
+	statusCode, err := pr.client.do(ctx, http.MethodPut, repoPath(repo)+"/pull-requests/"+url.PathEscape(prObj.Status.ID), nil, body, nil)
+	metrics.RecordSCMCall(repo, metrics.SCMAPIPullRequest, metrics.SCMOperationUpdate, statusCode, time.Since(start), nil)
+	if err != nil {
+		return fmt.Errorf("failed to update pull request: %w", err)
+	}
+
+	logger.V(4).Info("bitbucket response status", "status", statusCode)
+	logger.V(4).Info("updated pull request", "id", prObj.Status.ID)
+
+	return nil
+}
+
+// Close declines an existing pull request.
+func (pr *PullRequest) Close(ctx context.Context, prObj v1alpha1.PullRequest) error {
+	logger := log.FromContext(ctx)
+
+	repo, err := utils.GetGitRepositoryFromObjectKey(ctx, pr.k8sClient, client.ObjectKey{
+		Namespace: prObj.Namespace,
+		Name:      prObj.Spec.RepositoryReference.Name,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get repo: %w", err)
+	}
+
+	current, err := pr.get(ctx, repo, prObj.Status.ID)
+	if err != nil {
+		return fmt.Errorf("failed to close pull request: %w", err)
+	}
+
+	start := time.Now()
+	statusCode, err := pr.client.do(ctx, http.MethodPost, repoPath(repo)+"/pull-requests/"+url.PathEscape(prObj.Status.ID)+"/decline", versionQuery(current.Version), nil, nil)
+	metrics.RecordSCMCall(repo, metrics.SCMAPIPullRequest, metrics.SCMOperationClose, statusCode, time.Since(start), nil)
+	if err != nil {
+		return fmt.Errorf("failed to close pull request: %w", err)
+	}
+
+	logger.V(4).Info("bitbucket response status", "status", statusCode)
+	logger.V(4).Info("closed pull request", "id", prObj.Status.ID)
+
+	return nil
+}
+
+// Merge merges an existing pull request.
+func (pr *PullRequest) Merge(ctx context.Context, prObj v1alpha1.PullRequest) error {
+	logger := log.FromContext(ctx)
+
+	repo, err := utils.GetGitRepositoryFromObjectKey(ctx, pr.k8sClient, client.ObjectKey{
+		Namespace: prObj.Namespace,
+		Name:      prObj.Spec.RepositoryReference.Name,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get repo: %w", err)
+	}
+
+	current, err := pr.get(ctx, repo, prObj.Status.ID)
+	if err != nil {
+		return fmt.Errorf("failed to merge request: %w", err)
+	}
+	// The merge API has no parameter for the expected source commit, so refuse to merge commits that were pushed to
+	// the source branch after the merge was decided.
+	if current.FromRef != nil && prObj.Spec.MergeSha != "" && current.FromRef.LatestCommit != prObj.Spec.MergeSha {
+		return fmt.Errorf("failed to merge request: source branch is at %q, not the merge SHA %q", current.FromRef.LatestCommit, prObj.Spec.MergeSha)
+	}
+
+	// The commit message carries the trailers that the ChangeTransferPolicy history is built from, so it must replace
+	// the server's default merge message.
+	body := mergeRequest{Message: prObj.Spec.Commit.Message}
+
+	start := time.Now()
+	statusCode, err := pr.client.do(ctx, http.MethodPost, repoPath(repo)+"/pull-requests/"+url.PathEscape(prObj.Status.ID)+"/merge", versionQuery(current.Version), body, nil)
+	metrics.RecordSCMCall(repo, metrics.SCMAPIPullRequest, metrics.SCMOperationMerge, statusCode, time.Since(start), nil)
+	if err != nil {
+		return fmt.Errorf("failed to merge request: %w", err)
+	}
+
+	logger.V(4).Info("bitbucket response status", "status", statusCode)
+	logger.V(4).Info("merged pull request", "id", prObj.Status.ID)
+
+	return nil
+}
+
+// FindOpen checks if a pull request is open and returns its status.
+func (pr *PullRequest) FindOpen(ctx context.Context, pullRequest v1alpha1.PullRequest) (bool, string, time.Time, error) {
+	logger := log.FromContext(ctx)
+	logger.V(4).Info("Finding Open Pull Request")
+
+	repo, err := utils.GetGitRepositoryFromObjectKey(ctx, pr.k8sClient, client.ObjectKey{
+		Namespace: pullRequest.Namespace,
+		Name:      pullRequest.Spec.RepositoryReference.Name,
+	})
+	if err != nil {
+		return false, "", time.Time{}, fmt.Errorf("failed to get repo: %w", err)
+	}
+
+	// The API can filter by the source branch but not the target branch, so page through the open pull requests from
+	// the source branch and match the target branch locally.
+	targetRef := branchRef(pullRequest.Spec.TargetBranch)
+	query := url.Values{
+		"state":     {"OPEN"},
+		"direction": {"OUTGOING"},
+		"at":        {branchRef(pullRequest.Spec.SourceBranch)},
+	}
+	for {
+		var page pullRequestPage
+		start := time.Now()
+		statusCode, err := pr.client.do(ctx, http.MethodGet, repoPath(repo)+"/pull-requests", query, nil, &page)
+		metrics.RecordSCMCall(repo, metrics.SCMAPIPullRequest, metrics.SCMOperationList, statusCode, time.Since(start), nil)
+		if err != nil {
+			return false, "", time.Time{}, fmt.Errorf("failed to list pull requests: %w", err)
+		}
+
+		logger.V(4).Info("bitbucket response status", "status", statusCode)
+
+		for _, p := range page.Values {
+			if p.ToRef != nil && p.ToRef.ID == targetRef {
+				return true, strconv.Itoa(p.ID), time.UnixMilli(p.CreatedDate), nil
+			}
+		}
+
+		if page.IsLastPage || page.NextPageStart == 0 {
+			return false, "", time.Time{}, nil
+		}
+		query.Set("start", strconv.Itoa(page.NextPageStart))
+	}
+}
+
+// GetUrl retrieves the URL of the pull request.
+func (pr *PullRequest) GetUrl(ctx context.Context, prObj v1alpha1.PullRequest) (string, error) {
+	repo, err := utils.GetGitRepositoryFromObjectKey(ctx, pr.k8sClient, client.ObjectKey{
+		Namespace: prObj.Namespace,
+		Name:      prObj.Spec.RepositoryReference.Name,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get repo: %w", err)
+	}
+
+	return pr.client.webRepoURL(repo) + "/pull-requests/" + prObj.Status.ID, nil
+}
+
+// UpsertComment posts a comment on the pull request, or updates the comment with the given ID. Updating a comment
+// requires its current version, so the comment is retrieved first.
+func (pr *PullRequest) UpsertComment(ctx context.Context, prObj v1alpha1.PullRequest, commentID, body string) (string, error) {
+	repo, err := utils.GetGitRepositoryFromObjectKey(ctx, pr.k8sClient, client.ObjectKey{
+		Namespace: prObj.Namespace,
+		Name:      prObj.Spec.RepositoryReference.Name,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get repo: %w", err)
+	}
+
+	commentsPath := repoPath(repo) + "/pull-requests/" + url.PathEscape(prObj.Status.ID) + "/comments"
+	if commentID != "" {
+		var current comment
+		start := time.Now()
+		statusCode, err := pr.client.do(ctx, http.MethodGet, commentsPath+"/"+url.PathEscape(commentID), nil, nil, &current)
+		metrics.RecordSCMCall(repo, metrics.SCMAPIPullRequest, metrics.SCMOperationGet, statusCode, time.Since(start), nil)
+		if statusCode == http.StatusNotFound {
+			return "", fmt.Errorf("failed to get comment %q: %w: %w", commentID, scms.ErrCommentNotFound, err)
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to get comment %q: %w", commentID, err)
+		}
+
+		start = time.Now()
+		statusCode, err = pr.client.do(ctx, http.MethodPut, commentsPath+"/"+url.PathEscape(commentID), nil, comment{Version: current.Version, Text: body}, nil)
+		metrics.RecordSCMCall(repo, metrics.SCMAPIPullRequest, metrics.SCMOperationUpdate, statusCode, time.Since(start), nil)
+		if err != nil {
+			return "", fmt.Errorf("failed to update comment %q: %w", commentID, err)
+		}
+		return commentID, nil
+	}
+
+	var created comment
+	start := time.Now()
+	statusCode, err := pr.client.do(ctx, http.MethodPost, commentsPath, nil, comment{Text: body}, &created)
+	metrics.RecordSCMCall(repo, metrics.SCMAPIPullRequest, metrics.SCMOperationCreate, statusCode, time.Since(start), nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to comment on pull request: %w", err)
+	}
+	return strconv.Itoa(created.ID), nil
+}
+
+// GetBranchProtection returns the merge checks of the pull request that veto its merge, such as required builds and
+// approvals, or nil if none does. Bitbucket Data Center only describes the checks that fail, so they are reported as
+// unmet requirements rather than as checks and approvals.
+func (pr *PullRequest) GetBranchProtection(ctx context.Context, pullRequest v1alpha1.PullRequest) (*scms.BranchProtection, error) {
+	repo, err := utils.GetGitRepositoryFromObjectKey(ctx, pr.k8sClient, client.ObjectKey{
+		Namespace: pullRequest.Namespace,
+		Name:      pullRequest.Spec.RepositoryReference.Name,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repo: %w", err)
+	}
+
+	var status mergeStatus
+	start := time.Now()
+	statusCode, err := pr.client.do(ctx, http.MethodGet, repoPath(repo)+"/pull-requests/"+url.PathEscape(pullRequest.Status.ID)+"/merge", nil, nil, &status)
+	metrics.RecordSCMCall(repo, metrics.SCMAPIPullRequest, metrics.SCMOperationGet, statusCode, time.Since(start), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the merge status of pull request %q: %w", pullRequest.Status.ID, err)
+	}
+	if len(status.Vetoes) == 0 {
+		return nil, nil
+	}
+
+	protection := &scms.BranchProtection{}
+	for _, veto := range status.Vetoes {
+		message := veto.DetailedMessage
+		if message == "" {
+			message = veto.SummaryMessage
+		}
+		protection.UnmetRequirements = append(protection.UnmetRequirements, strings.TrimSuffix(message, "."))
+	}
+	return protection, nil
+}
+
+// get retrieves a pull request. Updating, declining and merging a pull request require its current version.
+func (pr *PullRequest) get(ctx context.Context, repo *v1alpha1.GitRepository, id string) (*pullRequest, error) {
+	var current pullRequest
+	start := time.Now()
+	statusCode, err := pr.client.do(ctx, http.MethodGet, repoPath(repo)+"/pull-requests/"+url.PathEscape(id), nil, nil, &current)
+	metrics.RecordSCMCall(repo, metrics.SCMAPIPullRequest, metrics.SCMOperationGet, statusCode, time.Since(start), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request %q: %w", id, err)
+	}
+	return &current, nil
+}
+
+// versionQuery returns the query parameters that carry a pull request's version.
+func versionQuery(version int) url.Values {
+	return url.Values{"version": {strconv.Itoa(version)}}
+}
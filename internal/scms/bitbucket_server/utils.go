@@ -0,0 +1,178 @@
+package bitbucket_server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	v1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms/httpcache"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms/ratelimit"
+)
+
+// Client is a minimal client for the Bitbucket Data Center REST API. It authenticates with an HTTP access token.
+type Client struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+}
+
+// APIError is returned when the Bitbucket Data Center API responds with an unexpected status code.
+type APIError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+	// Messages are the error messages from the response body, if any.
+	Messages []string
+}
+
+func (e *APIError) Error() string {
+	if len(e.Messages) == 0 {
+		return fmt.Sprintf("unexpected response status %d from Bitbucket Data Center", e.StatusCode)
+	}
+	return fmt.Sprintf("unexpected response status %d from Bitbucket Data Center: %s", e.StatusCode, strings.Join(e.Messages, "; "))
+}
+
+// GetClient creates a new Bitbucket Data Center client for the given domain using the token in the provided secret.
+func GetClient(domain string, secret corev1.Secret) (*Client, error) {
+	token := string(secret.Data["token"])
+	if token == "" {
+		return nil, fmt.Errorf("secret %q is missing required data key 'token'", secret.Name)
+	}
+	if domain == "" {
+		return nil, errors.New("domain is required for Bitbucket Data Center")
+	}
+
+	return &Client{
+		baseURL:    "https://" + strings.TrimSuffix(domain, "/"),
+		token:      token,
+		httpClient: &http.Client{Transport: httpcache.NewTransport(secret, "", ratelimit.NewTransport(secret, "", nil))},
+	}, nil
+}
+
+// do sends a request to the Bitbucket Data Center API and decodes the JSON response into out, if out is not nil. It
+// returns the response status code for metrics, which is http.StatusInternalServerError if no response was received.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out any) (int, error) {
+	reqURL := c.baseURL + path
+	if len(query) > 0 {
+		reqURL += "?" + query.Encode()
+	}
+
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		reqBody = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, reqBody)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, parseAPIError(resp.StatusCode, respBody)
+	}
+
+	if out != nil && len(respBody) > 0 {
+		if err := json.Unmarshal(respBody, out); err != nil {
+			return resp.StatusCode, fmt.Errorf("failed to parse response body: %w", err)
+		}
+	}
+	return resp.StatusCode, nil
+}
+
+// parseAPIError builds an APIError from an error response. Bitbucket Data Center returns errors as
+// {"errors": [{"message": "..."}]}.
+func parseAPIError(statusCode int, body []byte) *APIError {
+	apiErr := &APIError{StatusCode: statusCode}
+	var errResp struct {
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &errResp); err == nil {
+		for _, e := range errResp.Errors {
+			if e.Message != "" {
+				apiErr.Messages = append(apiErr.Messages, e.Message)
+			}
+		}
+	}
+	return apiErr
+}
+
+// repoPath returns the REST API path for the repository.
+func repoPath(repo *v1alpha1.GitRepository) string {
+	return fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s",
+		url.PathEscape(repo.Spec.BitbucketServer.Project),
+		url.PathEscape(repo.Spec.BitbucketServer.Name),
+	)
+}
+
+// webRepoURL returns the URL of the repository in the Bitbucket Data Center web UI.
+func (c *Client) webRepoURL(repo *v1alpha1.GitRepository) string {
+	return fmt.Sprintf("%s/projects/%s/repos/%s",
+		c.baseURL,
+		url.PathEscape(repo.Spec.BitbucketServer.Project),
+		url.PathEscape(repo.Spec.BitbucketServer.Name),
+	)
+}
+
+// branchRef returns the fully qualified ref for a branch name.
+func branchRef(branch string) string {
+	if strings.HasPrefix(branch, "refs/") {
+		return branch
+	}
+	return "refs/heads/" + branch
+}
+
+// phaseToBuildState converts a CommitStatusPhase to a Bitbucket Data Center build state.
+// Bitbucket Data Center states: SUCCESSFUL, FAILED, INPROGRESS
+// https://developer.atlassian.com/server/bitbucket/rest/v906/api-group-builds-and-deployments/#api-api-latest-projects-projectkey-repos-repositoryslug-commits-commitid-builds-post
+func phaseToBuildState(phase v1alpha1.CommitStatusPhase) string {
+	switch phase {
+	case v1alpha1.CommitPhaseSuccess:
+		return "SUCCESSFUL"
+	case v1alpha1.CommitPhasePending:
+		return "INPROGRESS"
+	default:
+		return "FAILED"
+	}
+}
+
+// ApplyHTTPAuth applies Bitbucket Data Center authentication to the HTTP request using a Bearer token header.
+func ApplyHTTPAuth(secret corev1.Secret, req *http.Request) error {
+	token := string(secret.Data["token"])
+	if token == "" {
+		return errors.New("non-empty token required in secret for Bitbucket Data Center SCM auth")
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	return nil
+}
@@ -11,3 +11,22 @@ type CommitStatusProvider interface {
 	// Set sets the commit status for a given commit SHA in the specified repository.
 	Set(ctx context.Context, commitStatus *v1alpha1.CommitStatus) (*v1alpha1.CommitStatus, error)
 }
+
+// CommitCheck is a check run or commit status that the SCM reports on a commit, usually from a CI system.
+type CommitCheck struct {
+	// Name is the name of the check run, or the context of the commit status.
+	Name string
+	// Phase is the phase of the check. Checks that are queued or running are pending.
+	Phase v1alpha1.CommitStatusPhase
+	// Description is the description or summary of the check.
+	Description string
+	// Url is the URL of the check's details page.
+	Url string
+}
+
+// CommitCheckProvider is implemented by commit status providers that can read the checks reported on a commit.
+type CommitCheckProvider interface {
+	// GetCommitChecks returns the latest checks reported on sha in gitRepo. If both a check run and a commit
+	// status have the same name, both are returned.
+	GetCommitChecks(ctx context.Context, gitRepo *v1alpha1.GitRepository, sha string) ([]CommitCheck, error)
+}
@@ -3,16 +3,35 @@ package fake
 import (
 	"context"
 	"errors"
+	"sync"
 
 	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
 	"github.com/argoproj-labs/gitops-promoter/internal/scms"
 	v1 "k8s.io/api/core/v1"
 )
 
+var (
+	commitChecks      map[string][]scms.CommitCheck
+	mutexCommitChecks sync.RWMutex
+)
+
 // CommitStatus implements the scms.CommitStatusProvider interface for testing purposes.
 type CommitStatus struct{}
 
-var _ scms.CommitStatusProvider = &CommitStatus{}
+var (
+	_ scms.CommitStatusProvider = &CommitStatus{}
+	_ scms.CommitCheckProvider  = &CommitStatus{}
+)
+
+// SetCommitChecks sets the checks that the fake SCM reports on sha, replacing any previous ones.
+func SetCommitChecks(sha string, checks ...scms.CommitCheck) {
+	mutexCommitChecks.Lock()
+	defer mutexCommitChecks.Unlock()
+	if commitChecks == nil {
+		commitChecks = make(map[string][]scms.CommitCheck)
+	}
+	commitChecks[sha] = checks
+}
 
 // NewFakeCommitStatusProvider creates a new instance of CommitStatus for testing purposes.
 func NewFakeCommitStatusProvider(secret v1.Secret) (*CommitStatus, error) {
@@ -31,3 +50,13 @@ func (cs CommitStatus) Set(ctx context.Context, commitStatus *promoterv1alpha1.C
 	commitStatus.Status.Sha = commitStatus.Spec.Sha
 	return commitStatus, nil
 }
+
+// GetCommitChecks returns the checks set on sha with SetCommitChecks.
+func (cs CommitStatus) GetCommitChecks(ctx context.Context, gitRepo *promoterv1alpha1.GitRepository, sha string) ([]scms.CommitCheck, error) {
+	if sha == "" {
+		return nil, errors.New("sha is required")
+	}
+	mutexCommitChecks.RLock()
+	defer mutexCommitChecks.RUnlock()
+	return append([]scms.CommitCheck(nil), commitChecks[sha]...), nil
+}
@@ -28,11 +28,18 @@ import (
 var (
 	pullRequests map[string]pullRequestProviderState
 	mutexPR      sync.RWMutex
+
+	branchProtections      map[string]scms.BranchProtection
+	mutexBranchProtections sync.RWMutex
 )
 
 type pullRequestProviderState struct {
 	id    string
 	state v1alpha1.PullRequestState
+	// comment is the body of the pull request's comment.
+	comment string
+	// commentDeleted is whether the comment was deleted, so that it cannot be updated.
+	commentDeleted bool
 }
 
 // PullRequest implements the scms.PullRequestProvider interface for testing purposes.
@@ -40,7 +47,26 @@ type PullRequest struct {
 	k8sClient client.Client
 }
 
-var _ scms.PullRequestProvider = &PullRequest{}
+var (
+	_ scms.PullRequestProvider                 = &PullRequest{}
+	_ scms.PullRequestBranchProtectionProvider = &PullRequest{}
+)
+
+// SetBranchProtection sets the branch protection that the fake SCM reports for pull requests into branch of the
+// GitRepository with the given name. A nil protection leaves the branch unprotected.
+func SetBranchProtection(gitRepositoryName, branch string, protection *scms.BranchProtection) {
+	mutexBranchProtections.Lock()
+	defer mutexBranchProtections.Unlock()
+	if branchProtections == nil {
+		branchProtections = make(map[string]scms.BranchProtection)
+	}
+	key := gitRepositoryName + "/" + branch
+	if protection == nil {
+		delete(branchProtections, key)
+		return
+	}
+	branchProtections[key] = *protection
+}
 
 // NewFakePullRequestProvider creates a new instance of PullRequest for testing purposes.
 func NewFakePullRequestProvider(k8sClient client.Client) *PullRequest {
@@ -235,6 +261,62 @@ func (pr *PullRequest) findOpen(ctx context.Context, pullRequest v1alpha1.PullRe
 	return ok && pullRequestState.state == v1alpha1.PullRequestOpen, pullRequestState.id
 }
 
+// UpsertComment stores the body as the pull request's only comment, whose ID is the ID of the pull request. A deleted
+// comment can only be posted again, not updated.
+func (pr *PullRequest) UpsertComment(ctx context.Context, pullRequest v1alpha1.PullRequest, commentID, body string) (string, error) {
+	gitRepo, err := utils.GetGitRepositoryFromObjectKey(ctx, pr.k8sClient, client.ObjectKey{Namespace: pullRequest.Namespace, Name: pullRequest.Spec.RepositoryReference.Name})
+	if err != nil {
+		return "", fmt.Errorf("failed to get GitRepository: %w", err)
+	}
+
+	mutexPR.Lock()
+	defer mutexPR.Unlock()
+	prKey := pr.getMapKey(pullRequest, gitRepo.Spec.Fake.Owner, gitRepo.Spec.Fake.Name)
+	state, ok := pullRequests[prKey]
+	if !ok {
+		return "", errors.New("pull request not found")
+	}
+	if commentID != "" && state.commentDeleted {
+		return "", fmt.Errorf("failed to update comment %s: %w", commentID, scms.ErrCommentNotFound)
+	}
+	state.comment = body
+	state.commentDeleted = false
+	pullRequests[prKey] = state
+	return state.id, nil
+}
+
+// DeleteComment deletes the pull request's comment, like a user deleting it on the SCM. This is used for testing.
+func (pr *PullRequest) DeleteComment(ctx context.Context, pullRequest v1alpha1.PullRequest) error {
+	gitRepo, err := utils.GetGitRepositoryFromObjectKey(ctx, pr.k8sClient, client.ObjectKey{Namespace: pullRequest.Namespace, Name: pullRequest.Spec.RepositoryReference.Name})
+	if err != nil {
+		return fmt.Errorf("failed to get GitRepository: %w", err)
+	}
+
+	mutexPR.Lock()
+	defer mutexPR.Unlock()
+	prKey := pr.getMapKey(pullRequest, gitRepo.Spec.Fake.Owner, gitRepo.Spec.Fake.Name)
+	state, ok := pullRequests[prKey]
+	if !ok {
+		return errors.New("pull request not found")
+	}
+	state.comment = ""
+	state.commentDeleted = true
+	pullRequests[prKey] = state
+	return nil
+}
+
+// GetComment returns the body of the pull request's comment. This is used for testing.
+func (pr *PullRequest) GetComment(ctx context.Context, pullRequest v1alpha1.PullRequest) (string, error) {
+	gitRepo, err := utils.GetGitRepositoryFromObjectKey(ctx, pr.k8sClient, client.ObjectKey{Namespace: pullRequest.Namespace, Name: pullRequest.Spec.RepositoryReference.Name})
+	if err != nil {
+		return "", fmt.Errorf("failed to get GitRepository: %w", err)
+	}
+
+	mutexPR.RLock()
+	defer mutexPR.RUnlock()
+	return pullRequests[pr.getMapKey(pullRequest, gitRepo.Spec.Fake.Owner, gitRepo.Spec.Fake.Name)].comment, nil
+}
+
 func (pr *PullRequest) getMapKey(pullRequest v1alpha1.PullRequest, owner, name string) string {
 	return fmt.Sprintf("%s/%s/%s/%s", owner, name, pullRequest.Spec.SourceBranch, pullRequest.Spec.TargetBranch)
 }
@@ -363,3 +445,14 @@ func (pr *PullRequest) GetUrl(ctx context.Context, pullRequest v1alpha1.PullRequ
 	logger.Info("Pull request not found", "pullRequest", pullRequest)
 	return "", errors.New("pull request not found")
 }
+
+// GetBranchProtection returns the branch protection set for the pull request's target branch with SetBranchProtection.
+func (pr *PullRequest) GetBranchProtection(ctx context.Context, pullRequest v1alpha1.PullRequest) (*scms.BranchProtection, error) {
+	mutexBranchProtections.RLock()
+	defer mutexBranchProtections.RUnlock()
+	protection, ok := branchProtections[pullRequest.Spec.RepositoryReference.Name+"/"+pullRequest.Spec.TargetBranch]
+	if !ok {
+		return nil, nil
+	}
+	return &protection, nil
+}
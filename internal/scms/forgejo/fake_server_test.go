@@ -0,0 +1,205 @@
+package forgejo
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+)
+
+const (
+	fakeToken = "fake-token"
+	fakeOwner = "owner"
+	fakeRepo  = "repo"
+	// fakeHeadSha is the head commit of every pull request.
+	fakeHeadSha = "0123456789abcdef0123456789abcdef01234567"
+)
+
+// fakeForgejoServer is an in-memory implementation of the parts of the Forgejo REST API used to create pull requests and
+// read the branch protection of their target branch.
+type fakeForgejoServer struct {
+	*httptest.Server
+
+	mu sync.Mutex
+	// labels are the labels of the repository, by ID.
+	labels map[int64]string
+	// pullRequestLabels holds the label IDs each created pull request was opened with, by pull request number.
+	pullRequestLabels map[int64][]int64
+	// branches holds the protection settings of the protected branches, by name.
+	branches map[string]map[string]any
+	// statuses holds the commit statuses of each commit, by SHA.
+	statuses map[string][]map[string]any
+	// reviews holds the reviews of each pull request, by pull request number.
+	reviews map[int64][]map[string]any
+}
+
+func newFakeForgejoServer() *fakeForgejoServer {
+	f := &fakeForgejoServer{
+		labels:            map[int64]string{},
+		pullRequestLabels: map[int64][]int64{},
+		branches:          map[string]map[string]any{},
+		statuses:          map[string][]map[string]any{},
+		reviews:           map[int64][]map[string]any{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/version", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]string{"version": "1.22.0"})
+	})
+	mux.HandleFunc("GET /api/v1/repos/"+fakeOwner+"/"+fakeRepo+"/labels", f.listLabels)
+	mux.HandleFunc("POST /api/v1/repos/"+fakeOwner+"/"+fakeRepo+"/pulls", f.createPullRequest)
+	mux.HandleFunc("GET /api/v1/repos/"+fakeOwner+"/"+fakeRepo+"/pulls/{number}", f.getPullRequest)
+	mux.HandleFunc("GET /api/v1/repos/"+fakeOwner+"/"+fakeRepo+"/pulls/{number}/reviews", f.listReviews)
+	mux.HandleFunc("GET /api/v1/repos/"+fakeOwner+"/"+fakeRepo+"/branches/{branch...}", f.getBranch)
+	mux.HandleFunc("GET /api/v1/repos/"+fakeOwner+"/"+fakeRepo+"/commits/{sha}/status", f.getCombinedStatus)
+
+	f.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "token "+fakeToken {
+			writeJSON(w, http.StatusUnauthorized, map[string]string{"message": "unauthorized"})
+			return
+		}
+		mux.ServeHTTP(w, r)
+	}))
+	return f
+}
+
+// addLabel adds a label to the repository.
+func (f *fakeForgejoServer) addLabel(id int64, name string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.labels[id] = name
+}
+
+// labelsOf returns the label IDs the pull request was opened with.
+func (f *fakeForgejoServer) labelsOf(number int64) []int64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.pullRequestLabels[number]
+}
+
+// protectBranch protects a branch with the required status check contexts and number of approvals. Status checks are
+// only enabled if contexts is not nil.
+func (f *fakeForgejoServer) protectBranch(branch string, contexts []string, approvals int64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.branches[branch] = map[string]any{
+		"enable_status_check":   contexts != nil,
+		"status_check_contexts": contexts,
+		"required_approvals":    approvals,
+	}
+}
+
+// setStatus reports a commit status on the commit.
+func (f *fakeForgejoServer) setStatus(sha, context, state string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.statuses[sha] = append(f.statuses[sha], map[string]any{"context": context, "status": state})
+}
+
+// addReview adds a review of the pull request by the user.
+func (f *fakeForgejoServer) addReview(number, userID int64, state string, official, dismissed bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.reviews[number] = append(f.reviews[number], map[string]any{
+		"user":      map[string]any{"id": userID},
+		"state":     state,
+		"official":  official,
+		"dismissed": dismissed,
+	})
+}
+
+func (f *fakeForgejoServer) getPullRequest(w http.ResponseWriter, r *http.Request) {
+	number, err := strconv.ParseInt(r.PathValue("number"), 10, 64)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"message": err.Error()})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"number": number,
+		"head":   map[string]any{"sha": fakeHeadSha},
+	})
+}
+
+func (f *fakeForgejoServer) listReviews(w http.ResponseWriter, r *http.Request) {
+	number, err := strconv.ParseInt(r.PathValue("number"), 10, 64)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"message": err.Error()})
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	reviews := f.reviews[number]
+	if reviews == nil {
+		reviews = []map[string]any{}
+	}
+	writeJSON(w, http.StatusOK, reviews)
+}
+
+func (f *fakeForgejoServer) getBranch(w http.ResponseWriter, r *http.Request) {
+	branch := r.PathValue("branch")
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	body := map[string]any{"name": branch, "protected": false}
+	if protection, ok := f.branches[branch]; ok {
+		body["protected"] = true
+		body["effective_branch_protection_name"] = branch
+		for key, value := range protection {
+			body[key] = value
+		}
+	}
+	writeJSON(w, http.StatusOK, body)
+}
+
+func (f *fakeForgejoServer) getCombinedStatus(w http.ResponseWriter, r *http.Request) {
+	sha := r.PathValue("sha")
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	statuses := f.statuses[sha]
+	if statuses == nil {
+		statuses = []map[string]any{}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"sha": sha, "statuses": statuses})
+}
+
+func (f *fakeForgejoServer) listLabels(w http.ResponseWriter, _ *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	labels := []map[string]any{}
+	for id, name := range f.labels {
+		labels = append(labels, map[string]any{"id": id, "name": name})
+	}
+	writeJSON(w, http.StatusOK, labels)
+}
+
+func (f *fakeForgejoServer) createPullRequest(w http.ResponseWriter, r *http.Request) {
+	var options struct {
+		Labels []int64 `json:"labels"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&options); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"message": err.Error()})
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, id := range options.Labels {
+		if _, ok := f.labels[id]; !ok {
+			writeJSON(w, http.StatusUnprocessableEntity, map[string]string{"message": "label does not exist"})
+			return
+		}
+	}
+	number := int64(len(f.pullRequestLabels) + 1)
+	f.pullRequestLabels[number] = options.Labels
+	writeJSON(w, http.StatusCreated, map[string]any{"number": number})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
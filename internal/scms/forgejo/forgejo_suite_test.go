@@ -0,0 +1,16 @@
+package forgejo
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestForgejo(t *testing.T) {
+	t.Parallel()
+
+	RegisterFailHandler(Fail)
+	c, _ := GinkgoConfiguration()
+	RunSpecs(t, "Forgejo Suite", c)
+}
@@ -0,0 +1,35 @@
+package generic
+
+import (
+	"context"
+	"errors"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms"
+)
+
+// CommitStatus implements the scms.CommitStatusProvider interface for plain git HTTP servers. Since there is no API to
+// report statuses to, the status is only recorded on the CommitStatus resource.
+type CommitStatus struct{}
+
+var _ scms.CommitStatusProvider = &CommitStatus{}
+
+// NewGenericCommitStatusProvider creates a new instance of CommitStatus for a plain git HTTP server.
+func NewGenericCommitStatusProvider() *CommitStatus {
+	return &CommitStatus{}
+}
+
+// Set records the commit status on the CommitStatus resource without reporting it anywhere else.
+func (cs *CommitStatus) Set(ctx context.Context, commitStatus *v1alpha1.CommitStatus) (*v1alpha1.CommitStatus, error) {
+	if commitStatus.Spec.Sha == "" {
+		return nil, errors.New("sha is required")
+	}
+
+	log.FromContext(ctx).V(4).Info("Recording commit status without an SCM API", "sha", commitStatus.Spec.Sha, "phase", commitStatus.Spec.Phase)
+
+	commitStatus.Status.Phase = commitStatus.Spec.Phase
+	commitStatus.Status.Sha = commitStatus.Spec.Sha
+	return commitStatus, nil
+}
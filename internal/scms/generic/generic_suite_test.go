@@ -0,0 +1,16 @@
+package generic
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestGeneric(t *testing.T) {
+	t.Parallel()
+
+	RegisterFailHandler(Fail)
+	c, _ := GinkgoConfiguration()
+	RunSpecs(t, "Generic Suite", c)
+}
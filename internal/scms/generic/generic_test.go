@@ -0,0 +1,175 @@
+package generic
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+)
+
+const namespace = "default"
+
+func newSecret(data map[string]string) *corev1.Secret {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "git-secret", Namespace: namespace},
+		Data:       map[string][]byte{},
+	}
+	for k, v := range data {
+		secret.Data[k] = []byte(v)
+	}
+	return secret
+}
+
+func newScmProvider(url string) *v1alpha1.ScmProvider {
+	return &v1alpha1.ScmProvider{
+		Spec: v1alpha1.ScmProviderSpec{Generic: &v1alpha1.Generic{URL: url}},
+	}
+}
+
+func newGitRepository(path string) *v1alpha1.GitRepository {
+	return &v1alpha1.GitRepository{
+		ObjectMeta: metav1.ObjectMeta{Name: "generic-repo", Namespace: namespace},
+		Spec: v1alpha1.GitRepositorySpec{
+			Generic:        &v1alpha1.GenericRepo{Path: path},
+			ScmProviderRef: v1alpha1.ScmProviderObjectReference{Kind: v1alpha1.ScmProviderKind, Name: "generic"},
+		},
+	}
+}
+
+func runGit(dir string, args ...string) string {
+	cmd := exec.CommandContext(context.Background(), "git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	out, err := cmd.CombinedOutput()
+	Expect(err).NotTo(HaveOccurred(), string(out))
+	return strings.TrimSpace(string(out))
+}
+
+// localGitProvider points git operations at a local repository.
+type localGitProvider struct {
+	path string
+}
+
+func (l localGitProvider) GetGitHttpsRepoUrl(v1alpha1.GitRepository) string { return l.path }
+func (l localGitProvider) GetToken(context.Context) (string, error)         { return "", nil }
+func (l localGitProvider) GetUser(context.Context) (string, error)          { return "", nil }
+
+var _ = Describe("Generic", func() {
+	Describe("GitAuthenticationProvider", func() {
+		It("should join the provider URL and the repository path", func() {
+			secret := newSecret(map[string]string{"username": "promoter", "password": "secret"})
+			provider, err := NewGenericGitAuthenticationProvider(newScmProvider("http://git.example.com:8080/git/"), secret)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(provider.GetGitHttpsRepoUrl(*newGitRepository("team/app.git"))).To(Equal("http://git.example.com:8080/git/team/app.git"))
+
+			user, err := provider.GetUser(GinkgoT().Context())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(user).To(Equal("promoter"))
+			password, err := provider.GetToken(GinkgoT().Context())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(password).To(Equal("secret"))
+		})
+
+		It("should require a username and password in the secret", func() {
+			_, err := NewGenericGitAuthenticationProvider(newScmProvider("https://git.example.com"), newSecret(map[string]string{"username": "promoter"}))
+			Expect(err).To(MatchError(`secret "git-secret" is missing required data key "password"`))
+		})
+	})
+
+	Describe("ApplyHTTPAuth", func() {
+		It("should set basic auth on the request", func() {
+			req, err := http.NewRequestWithContext(GinkgoT().Context(), http.MethodGet, "https://example.com", nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ApplyHTTPAuth(*newSecret(map[string]string{"username": "promoter", "password": "secret"}), req)).To(Succeed())
+			user, password, ok := req.BasicAuth()
+			Expect(ok).To(BeTrue())
+			Expect(user).To(Equal("promoter"))
+			Expect(password).To(Equal("secret"))
+		})
+	})
+
+	Describe("CommitStatus", func() {
+		It("should only record the status on the resource", func(ctx SpecContext) {
+			commitStatus := &v1alpha1.CommitStatus{
+				Spec: v1alpha1.CommitStatusSpec{Sha: "abc123", Phase: v1alpha1.CommitPhaseSuccess},
+			}
+			result, err := NewGenericCommitStatusProvider().Set(ctx, commitStatus)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Status.Sha).To(Equal("abc123"))
+			Expect(result.Status.Phase).To(Equal(v1alpha1.CommitPhaseSuccess))
+		})
+	})
+
+	Describe("PullRequest", func() {
+		var remoteDir string
+		var provider *PullRequest
+
+		BeforeEach(func() {
+			remoteDir = GinkgoT().TempDir()
+			runGit(remoteDir, "init", "--bare")
+
+			workDir := GinkgoT().TempDir()
+			runGit(workDir, "clone", remoteDir, ".")
+			runGit(workDir, "config", "user.name", "Test User")
+			runGit(workDir, "config", "user.email", "test@example.com")
+			runGit(workDir, "config", "commit.gpgsign", "false")
+			runGit(workDir, "checkout", "-b", "environment/dev")
+			Expect(os.WriteFile(filepath.Join(workDir, "manifest.yaml"), []byte("replicas: 1\n"), 0o644)).To(Succeed())
+			runGit(workDir, "add", ".")
+			runGit(workDir, "commit", "-m", "Initial commit")
+			runGit(workDir, "checkout", "-b", "environment/dev-next")
+			Expect(os.WriteFile(filepath.Join(workDir, "manifest.yaml"), []byte("replicas: 2\n"), 0o644)).To(Succeed())
+			runGit(workDir, "commit", "-am", "Scale up")
+			runGit(workDir, "push", "origin", "environment/dev", "environment/dev-next")
+
+			scheme := runtime.NewScheme()
+			Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+			k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(newGitRepository("repo.git")).Build()
+			provider = NewGenericPullRequestProvider(k8sClient, localGitProvider{path: remoteDir})
+		})
+
+		It("should track the pull request on the resource and merge it with git", func(ctx SpecContext) {
+			prObj := v1alpha1.PullRequest{
+				ObjectMeta: metav1.ObjectMeta{Name: "promote-dev", Namespace: namespace},
+				Spec: v1alpha1.PullRequestSpec{
+					RepositoryReference: v1alpha1.ObjectReference{Name: "generic-repo"},
+					SourceBranch:        "environment/dev-next",
+					TargetBranch:        "environment/dev",
+					MergeSha:            runGit(remoteDir, "rev-parse", "environment/dev-next"),
+					Commit:              v1alpha1.CommitConfiguration{Message: "Promote to dev"},
+				},
+			}
+
+			By("Not finding a pull request that was never created")
+			found, _, _, err := provider.FindOpen(ctx, prObj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+
+			By("Finding the pull request once its status records it as open")
+			id, err := provider.Create(ctx, "Promote", prObj.Spec.SourceBranch, prObj.Spec.TargetBranch, "", prObj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(id).To(Equal("promote-dev"))
+			prObj.Status = v1alpha1.PullRequestStatus{ID: id, State: v1alpha1.PullRequestOpen, PRCreationTime: metav1.Now()}
+			found, foundID, _, err := provider.FindOpen(ctx, prObj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(foundID).To(Equal(id))
+
+			By("Merging the source branch into the target branch")
+			Expect(provider.Merge(ctx, prObj)).To(Succeed())
+			Expect(runGit(remoteDir, "show", "environment/dev:manifest.yaml")).To(Equal("replicas: 2"))
+			Expect(runGit(remoteDir, "log", "-1", "--format=%s", "environment/dev")).To(Equal("Promote to dev"))
+		})
+	})
+})
@@ -0,0 +1,52 @@
+package generic
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms"
+)
+
+// GitAuthenticationProvider implements the scms.GitOperationsProvider interface for plain git HTTP servers.
+type GitAuthenticationProvider struct {
+	scmProvider v1alpha1.GenericScmProvider
+	secret      *v1.Secret
+}
+
+var _ scms.GitOperationsProvider = &GitAuthenticationProvider{}
+
+// NewGenericGitAuthenticationProvider creates a new instance of GitAuthenticationProvider for a plain git HTTP server.
+func NewGenericGitAuthenticationProvider(scmProvider v1alpha1.GenericScmProvider, secret *v1.Secret) (*GitAuthenticationProvider, error) {
+	if _, _, err := getCredentials(*secret); err != nil {
+		return nil, err
+	}
+
+	return &GitAuthenticationProvider{
+		scmProvider: scmProvider,
+		secret:      secret,
+	}, nil
+}
+
+// GetGitHttpsRepoUrl constructs the repository URL by joining the provider's URL and the repository's path. Despite
+// the name, the URL uses plain HTTP if the provider's URL does.
+func (gap GitAuthenticationProvider) GetGitHttpsRepoUrl(repo v1alpha1.GitRepository) string {
+	repoUrl := strings.TrimSuffix(gap.scmProvider.GetSpec().Generic.URL, "/") + "/" + strings.TrimPrefix(repo.Spec.Generic.Path, "/")
+	if _, err := url.Parse(repoUrl); err != nil {
+		return ""
+	}
+	return repoUrl
+}
+
+// GetToken retrieves the basic auth password from the secret.
+func (gap GitAuthenticationProvider) GetToken(ctx context.Context) (string, error) {
+	return string(gap.secret.Data[passwordSecretKey]), nil
+}
+
+// GetUser retrieves the basic auth user from the secret.
+func (gap GitAuthenticationProvider) GetUser(ctx context.Context) (string, error) {
+	return string(gap.secret.Data[usernameSecretKey]), nil
+}
@@ -0,0 +1,94 @@
+package generic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/git"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms"
+	"github.com/argoproj-labs/gitops-promoter/internal/utils"
+)
+
+// PullRequest implements the scms.PullRequestProvider interface for plain git HTTP servers. There is no pull request
+// on the server: the PullRequest resource is the only record of it, and merging it pushes a merge commit directly to
+// the target branch.
+type PullRequest struct {
+	k8sClient     client.Client
+	gitOperations scms.GitOperationsProvider
+}
+
+var _ scms.PullRequestProvider = &PullRequest{}
+
+// NewGenericPullRequestProvider creates a new instance of PullRequest for a plain git HTTP server. The git operations
+// provider is used to push merges.
+func NewGenericPullRequestProvider(k8sClient client.Client, gitOperations scms.GitOperationsProvider) *PullRequest {
+	return &PullRequest{
+		k8sClient:     k8sClient,
+		gitOperations: gitOperations,
+	}
+}
+
+// Create records a new pull request. Its ID is the name of the PullRequest resource.
+func (pr *PullRequest) Create(ctx context.Context, title, head, base, desc string, prObj v1alpha1.PullRequest) (string, error) {
+	if prObj.Name == "" {
+		return "", errors.New("pull request name is empty")
+	}
+	log.FromContext(ctx).V(4).Info("created pull request without an SCM API", "id", prObj.Name)
+	return prObj.Name, nil
+}
+
+// Update does nothing, since the title and description only exist on the PullRequest resource.
+func (pr *PullRequest) Update(ctx context.Context, title, description string, prObj v1alpha1.PullRequest) error {
+	return nil
+}
+
+// Close does nothing, since there is nothing on the server to close.
+func (pr *PullRequest) Close(ctx context.Context, prObj v1alpha1.PullRequest) error {
+	return nil
+}
+
+// Merge merges the source branch into the target branch and pushes the merge commit. The merge is refused if the
+// source branch has moved past the pull request's merge SHA.
+func (pr *PullRequest) Merge(ctx context.Context, prObj v1alpha1.PullRequest) error {
+	if prObj.Status.ID == "" {
+		return errors.New("pull request ID is empty, cannot merge")
+	}
+
+	repo, err := utils.GetGitRepositoryFromObjectKey(ctx, pr.k8sClient, client.ObjectKey{
+		Namespace: prObj.Namespace,
+		Name:      prObj.Spec.RepositoryReference.Name,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get repo: %w", err)
+	}
+
+	if err := git.MergeBranch(ctx, pr.gitOperations, repo, prObj.Spec.SourceBranch, prObj.Spec.TargetBranch, prObj.Spec.MergeSha, prObj.Spec.Commit.Message); err != nil {
+		return fmt.Errorf("failed to merge pull request: %w", err)
+	}
+	return nil
+}
+
+// FindOpen reports the pull request as open if its PullRequest resource was created and has not been merged or
+// closed, since the resource is the only record of it.
+func (pr *PullRequest) FindOpen(ctx context.Context, prObj v1alpha1.PullRequest) (bool, string, time.Time, error) {
+	if prObj.Status.ID == "" || prObj.Status.State != v1alpha1.PullRequestOpen {
+		return false, "", time.Time{}, nil
+	}
+	return true, prObj.Status.ID, prObj.Status.PRCreationTime.Time, nil
+}
+
+// GetUrl returns an empty URL, since there is no web page for the pull request.
+func (pr *PullRequest) GetUrl(ctx context.Context, prObj v1alpha1.PullRequest) (string, error) {
+	return "", nil
+}
+
+// UpsertComment does nothing, since there is no pull request on the server to comment on.
+func (pr *PullRequest) UpsertComment(ctx context.Context, prObj v1alpha1.PullRequest, commentID, body string) (string, error) {
+	return "", nil
+}
@@ -0,0 +1,38 @@
+package generic
+
+import (
+	"fmt"
+	"net/http"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// usernameSecretKey is the key in the ScmProvider secret that holds the basic auth user.
+	usernameSecretKey = "username"
+	// passwordSecretKey is the key in the ScmProvider secret that holds the basic auth password.
+	passwordSecretKey = "password"
+)
+
+// getCredentials returns the basic auth username and password from the secret. Both are required.
+func getCredentials(secret corev1.Secret) (string, string, error) {
+	username := string(secret.Data[usernameSecretKey])
+	if username == "" {
+		return "", "", fmt.Errorf("secret %q is missing required data key %q", secret.Name, usernameSecretKey)
+	}
+	password := string(secret.Data[passwordSecretKey])
+	if password == "" {
+		return "", "", fmt.Errorf("secret %q is missing required data key %q", secret.Name, passwordSecretKey)
+	}
+	return username, password, nil
+}
+
+// ApplyHTTPAuth sets basic auth on the request using the username and password from the secret.
+func ApplyHTTPAuth(secret corev1.Secret, req *http.Request) error {
+	username, password, err := getCredentials(secret)
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(username, password)
+	return nil
+}
@@ -0,0 +1,129 @@
+package gerrit
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/metrics"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms"
+	"github.com/argoproj-labs/gitops-promoter/internal/utils"
+)
+
+// reviewTag marks the promoter's reviews as automated, so Gerrit can hide them from the change log by default.
+const reviewTag = "autogenerated:gitops-promoter"
+
+// CommitStatus implements the scms.CommitStatusProvider interface for Gerrit by voting on labels. Gerrit has no commit
+// statuses, so a status is reported as a vote on the open change that promotes the commit, on the label that the
+// status's key is mapped to.
+type CommitStatus struct {
+	client    *Client
+	k8sClient client.Client
+	labels    map[string]string
+}
+
+var _ scms.CommitStatusProvider = &CommitStatus{}
+
+// reviewInput is the request body for setting a review on a revision.
+type reviewInput struct {
+	Message string         `json:"message,omitempty"`
+	Labels  map[string]int `json:"labels"`
+	Tag     string         `json:"tag,omitempty"`
+}
+
+// NewGerritCommitStatusProvider creates a new instance of CommitStatus for Gerrit. The labels map commit status keys
+// to the Gerrit labels they vote on.
+func NewGerritCommitStatusProvider(k8sClient client.Client, secret v1.Secret, domain string, labels map[string]string) (*CommitStatus, error) {
+	client, err := GetClient(domain, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CommitStatus{client: client, k8sClient: k8sClient, labels: labels}, nil
+}
+
+// Set votes on the label mapped to the commit status's key, on the current patch set of the open change that merges
+// the commit. If the key is not mapped, or no open change merges the commit, the status is only recorded on the
+// CommitStatus resource.
+func (cs *CommitStatus) Set(ctx context.Context, commitStatus *v1alpha1.CommitStatus) (*v1alpha1.CommitStatus, error) {
+	logger := log.FromContext(ctx)
+	logger.Info("Setting Commit Phase")
+
+	commitStatus.Status.Phase = commitStatus.Spec.Phase
+	commitStatus.Status.Sha = commitStatus.Spec.Sha
+
+	label, ok := cs.labels[commitStatus.Labels[v1alpha1.CommitStatusLabel]]
+	if !ok {
+		logger.V(4).Info("No Gerrit label mapped to commit status, not voting", "key", commitStatus.Labels[v1alpha1.CommitStatusLabel])
+		return commitStatus, nil
+	}
+
+	repo, err := utils.GetGitRepositoryFromObjectKey(ctx, cs.k8sClient, client.ObjectKey{
+		Namespace: commitStatus.Namespace,
+		Name:      commitStatus.Spec.RepositoryReference.Name,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repo: %w", err)
+	}
+
+	change, err := cs.findChangeMerging(ctx, repo, commitStatus.Spec.Sha)
+	if err != nil {
+		return nil, err
+	}
+	if change == nil {
+		logger.V(4).Info("No open change merges the commit, not voting", "sha", commitStatus.Spec.Sha)
+		return commitStatus, nil
+	}
+
+	message := commitStatus.Spec.Name + ": " + string(commitStatus.Spec.Phase)
+	if commitStatus.Spec.Description != "" {
+		message += " - " + commitStatus.Spec.Description
+	}
+	if commitStatus.Spec.Url != "" {
+		message += "\n\n" + commitStatus.Spec.Url
+	}
+	review := reviewInput{
+		Message: message,
+		Labels:  map[string]int{label: phaseToLabelValue(commitStatus.Spec.Phase)},
+		Tag:     reviewTag,
+	}
+
+	number := strconv.Itoa(change.Number)
+	start := time.Now()
+	statusCode, err := cs.client.do(ctx, http.MethodPost, changePath(repo, number)+"/revisions/"+url.PathEscape(change.CurrentRevision)+"/review", nil, review, nil)
+	metrics.RecordSCMCall(repo, metrics.SCMAPICommitStatus, metrics.SCMOperationCreate, statusCode, time.Since(start), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to vote on change %s: %w", number, err)
+	}
+
+	logger.V(4).Info("gerrit response status", "status", statusCode)
+
+	return commitStatus, nil
+}
+
+// findChangeMerging returns the open change in the repository whose current patch set merges the commit, or nil if
+// there is none. Gerrit cannot search changes by the parents of their commits, so the query is narrowed to the changes
+// uploaded by the promoter's own account, and all pages of results are searched.
+func (cs *CommitStatus) findChangeMerging(ctx context.Context, repo *v1alpha1.GitRepository, sha string) (*changeInfo, error) {
+	start := time.Now()
+	changes, statusCode, err := cs.client.queryChanges(ctx, fmt.Sprintf("project:%q status:open owner:self", repo.Spec.Gerrit.Project), currentRevisionOptions...)
+	metrics.RecordSCMCall(repo, metrics.SCMAPIPullRequest, metrics.SCMOperationList, statusCode, time.Since(start), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query changes: %w", err)
+	}
+
+	for i := range changes {
+		if changes[i].mergedSha() == sha {
+			return &changes[i], nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,327 @@
+package gerrit
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+const (
+	fakeUsername = "promoter"
+	fakePassword = "http-password"
+	fakeProject  = "platform/deployments"
+)
+
+var changeIDPattern = regexp.MustCompile(`(?m)^Change-Id: (I[0-9a-f]{40})$`)
+
+// fakeChange is a change stored by the fake Gerrit server.
+type fakeChange struct {
+	number   int
+	changeID string
+	branch   string
+	status   string
+	wip      bool
+	// revisions are the commit SHAs of the change's patch sets, oldest first.
+	revisions []string
+	votes     map[string]int
+	messages  []string
+}
+
+// fakeGerritServer is an in-memory implementation of the parts of the Gerrit REST API used by the providers. Like
+// Gerrit's receive-pack, it turns commits pushed to refs/for/<branch> of a local bare repository into changes and patch
+// sets, matched by their Change-Id trailer.
+type fakeGerritServer struct {
+	*httptest.Server
+
+	repoDir string
+
+	mu      sync.Mutex
+	nextID  int
+	changes map[int]*fakeChange
+	// pageSize is the number of changes returned per page of query results, or zero for all of them.
+	pageSize int
+}
+
+func newFakeGerritServer(repoDir string) *fakeGerritServer {
+	f := &fakeGerritServer{
+		repoDir: repoDir,
+		nextID:  1,
+		changes: map[int]*fakeChange{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /a/changes/", f.queryChanges)
+	mux.HandleFunc("GET /a/changes/{id}", f.getChange)
+	mux.HandleFunc("POST /a/changes/{id}/abandon", f.abandon)
+	mux.HandleFunc("PUT /a/changes/{id}/message", f.editMessage)
+	mux.HandleFunc("POST /a/changes/{id}/submit", f.submit)
+	mux.HandleFunc("POST /a/changes/{id}/wip", f.setWorkInProgress(true))
+	mux.HandleFunc("POST /a/changes/{id}/ready", f.setWorkInProgress(false))
+	mux.HandleFunc("POST /a/changes/{id}/revisions/{revision}/review", f.review)
+
+	f.Server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, password, ok := r.BasicAuth(); !ok || user != fakeUsername || password != fakePassword {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.receivePushes()
+		mux.ServeHTTP(w, r)
+	}))
+	return f
+}
+
+// domain returns the host and port of the server, for use as the ScmProvider domain.
+func (f *fakeGerritServer) domain() string {
+	return strings.TrimPrefix(f.URL, "https://")
+}
+
+// git runs a git command in the bare repository.
+func (f *fakeGerritServer) git(args ...string) string {
+	return runGit(f.repoDir, args...)
+}
+
+// receivePushes turns the commits pushed to refs/for/<branch> into changes or new patch sets. The caller must hold
+// f.mu.
+func (f *fakeGerritServer) receivePushes() {
+	refs := f.git("for-each-ref", "--format=%(refname) %(objectname)", "refs/for/")
+	if refs == "" {
+		return
+	}
+	for line := range strings.SplitSeq(refs, "\n") {
+		ref, sha, _ := strings.Cut(line, " ")
+		branch := strings.TrimPrefix(ref, "refs/for/")
+		match := changeIDPattern.FindStringSubmatch(f.git("log", "-1", "--format=%B", sha))
+		Expect(match).NotTo(BeNil(), "commit pushed for review without a Change-Id")
+
+		if change := f.findChange(match[1], branch); change != nil {
+			change.revisions = append(change.revisions, sha)
+		} else {
+			f.changes[f.nextID] = &fakeChange{
+				number:    f.nextID,
+				changeID:  match[1],
+				branch:    branch,
+				status:    "NEW",
+				revisions: []string{sha},
+				votes:     map[string]int{},
+			}
+			f.nextID++
+		}
+		f.git("update-ref", "-d", ref)
+	}
+}
+
+// addChange adds an open change to the branch whose only patch set is the given commit.
+func (f *fakeGerritServer) addChange(changeID, branch, sha string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.changes[f.nextID] = &fakeChange{
+		number:    f.nextID,
+		changeID:  changeID,
+		branch:    branch,
+		status:    "NEW",
+		revisions: []string{sha},
+		votes:     map[string]int{},
+	}
+	f.nextID++
+}
+
+func (f *fakeGerritServer) findChange(changeID, branch string) *fakeChange {
+	for _, c := range f.changes {
+		if c.changeID == changeID && c.branch == branch && c.status == "NEW" {
+			return c
+		}
+	}
+	return nil
+}
+
+// info renders a change as a ChangeInfo with its current revision and commit.
+func (f *fakeGerritServer) info(c *fakeChange) changeInfo {
+	current := c.revisions[len(c.revisions)-1]
+	commit := &commitInfo{Message: f.git("log", "-1", "--format=%B", current) + "\n"}
+	for parent := range strings.FieldsSeq(f.git("log", "-1", "--format=%P", current)) {
+		commit.Parents = append(commit.Parents, struct {
+			Commit string `json:"commit"`
+		}{Commit: parent})
+	}
+	return changeInfo{
+		ID:              fakeProject + "~" + c.branch + "~" + c.changeID,
+		Project:         fakeProject,
+		Branch:          c.branch,
+		ChangeID:        c.changeID,
+		Status:          c.status,
+		WorkInProgress:  c.wip,
+		Created:         time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC).Format(timestampLayout),
+		Number:          c.number,
+		CurrentRevision: current,
+		Revisions:       map[string]revisionInfo{current: {Number: len(c.revisions), Commit: commit}},
+	}
+}
+
+func (f *fakeGerritServer) queryChanges(w http.ResponseWriter, r *http.Request) {
+	terms := map[string]string{}
+	for _, term := range strings.Fields(r.URL.Query().Get("q")) {
+		key, value, _ := strings.Cut(term, ":")
+		if unquoted, err := strconv.Unquote(value); err == nil {
+			value = unquoted
+		}
+		terms[key] = value
+	}
+
+	result := []changeInfo{}
+	for id := 1; id < f.nextID; id++ {
+		c := f.changes[id]
+		if (terms["change"] != "" && c.changeID != terms["change"]) ||
+			(terms["branch"] != "" && c.branch != terms["branch"]) ||
+			(terms["project"] != "" && terms["project"] != fakeProject) ||
+			(terms["status"] == "open" && c.status != "NEW") ||
+			(terms["owner"] != "" && terms["owner"] != "self") {
+			continue
+		}
+		result = append(result, f.info(c))
+	}
+
+	start, _ := strconv.Atoi(r.URL.Query().Get("S"))
+	result = result[min(start, len(result)):]
+	if f.pageSize > 0 && len(result) > f.pageSize {
+		result = result[:f.pageSize]
+		result[len(result)-1].MoreChanges = true
+	}
+	writeJSON(w, result)
+}
+
+func (f *fakeGerritServer) getChange(w http.ResponseWriter, r *http.Request) {
+	if c := f.lookup(w, r); c != nil {
+		writeJSON(w, f.info(c))
+	}
+}
+
+func (f *fakeGerritServer) abandon(w http.ResponseWriter, r *http.Request) {
+	c := f.lookup(w, r)
+	if c == nil {
+		return
+	}
+	if c.status != "NEW" {
+		http.Error(w, "change is "+strings.ToLower(c.status), http.StatusConflict)
+		return
+	}
+	c.status = "ABANDONED"
+	writeJSON(w, f.info(c))
+}
+
+// editMessage creates a new patch set with the same tree and parents and the new commit message.
+func (f *fakeGerritServer) editMessage(w http.ResponseWriter, r *http.Request) {
+	c := f.lookup(w, r)
+	if c == nil {
+		return
+	}
+	var input struct {
+		Message string `json:"message"`
+	}
+	Expect(json.NewDecoder(r.Body).Decode(&input)).To(Succeed())
+	if match := changeIDPattern.FindStringSubmatch(input.Message); match == nil || match[1] != c.changeID {
+		http.Error(w, "wrong Change-Id footer", http.StatusBadRequest)
+		return
+	}
+
+	current := c.revisions[len(c.revisions)-1]
+	args := []string{"-c", "user.name=Gerrit", "-c", "user.email=gerrit@example.com", "commit-tree", current + "^{tree}", "-m", input.Message}
+	for parent := range strings.FieldsSeq(f.git("log", "-1", "--format=%P", current)) {
+		args = append(args, "-p", parent)
+	}
+	c.revisions = append(c.revisions, f.git(args...))
+	// Like a label without a copy condition, votes are not copied to the new patch set.
+	c.votes = map[string]int{}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// submit fast-forwards the branch to the change's current patch set.
+func (f *fakeGerritServer) submit(w http.ResponseWriter, r *http.Request) {
+	c := f.lookup(w, r)
+	if c == nil {
+		return
+	}
+	if c.status != "NEW" {
+		http.Error(w, "change is "+strings.ToLower(c.status), http.StatusConflict)
+		return
+	}
+	current := c.revisions[len(c.revisions)-1]
+	parents := strings.Fields(f.git("log", "-1", "--format=%P", current))
+	if parents[0] != f.git("rev-parse", "refs/heads/"+c.branch) {
+		http.Error(w, "change is not based on the branch head", http.StatusConflict)
+		return
+	}
+	f.git("update-ref", "refs/heads/"+c.branch, current)
+	c.status = "MERGED"
+	writeJSON(w, f.info(c))
+}
+
+// setWorkInProgress returns a handler that marks a change as work in progress or ready for review.
+func (f *fakeGerritServer) setWorkInProgress(wip bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		c := f.lookup(w, r)
+		if c == nil {
+			return
+		}
+		if c.wip == wip {
+			http.Error(w, "change is already in this state", http.StatusConflict)
+			return
+		}
+		c.wip = wip
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+func (f *fakeGerritServer) review(w http.ResponseWriter, r *http.Request) {
+	c := f.lookup(w, r)
+	if c == nil {
+		return
+	}
+	if r.PathValue("revision") != c.revisions[len(c.revisions)-1] {
+		http.Error(w, "can only vote on the current patch set", http.StatusConflict)
+		return
+	}
+	var input reviewInput
+	Expect(json.NewDecoder(r.Body).Decode(&input)).To(Succeed())
+	for label, value := range input.Labels {
+		c.votes[label] = value
+	}
+	c.messages = append(c.messages, input.Message)
+	writeJSON(w, map[string]any{"labels": input.Labels})
+}
+
+// lookup returns the change for the request's {id}, in the form <project>~<number>, or writes a 404 and returns nil.
+func (f *fakeGerritServer) lookup(w http.ResponseWriter, r *http.Request) *fakeChange {
+	project, number, ok := strings.Cut(r.PathValue("id"), "~")
+	n, err := strconv.Atoi(number)
+	if !ok || err != nil || project != fakeProject || f.changes[n] == nil {
+		http.Error(w, "Not found: "+r.PathValue("id"), http.StatusNotFound)
+		return nil
+	}
+	return f.changes[n]
+}
+
+// change returns a copy of the stored change with the given number.
+func (f *fakeGerritServer) change(number string) fakeChange {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.receivePushes()
+	n, _ := strconv.Atoi(number)
+	return *f.changes[n]
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	b, _ := json.Marshal(v)
+	_, _ = fmt.Fprintf(w, "%s\n%s", xssiPrefix, b)
+}
@@ -0,0 +1,16 @@
+package gerrit
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestGerrit(t *testing.T) {
+	t.Parallel()
+
+	RegisterFailHandler(Fail)
+	c, _ := GinkgoConfiguration()
+	RunSpecs(t, "Gerrit Suite", c)
+}
@@ -0,0 +1,270 @@
+package gerrit
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+)
+
+const namespace = "default"
+
+func newSecret(data map[string]string) *corev1.Secret {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "gerrit-secret", Namespace: namespace},
+		Data:       map[string][]byte{},
+	}
+	for k, v := range data {
+		secret.Data[k] = []byte(v)
+	}
+	return secret
+}
+
+func newGitRepository() *v1alpha1.GitRepository {
+	return &v1alpha1.GitRepository{
+		ObjectMeta: metav1.ObjectMeta{Name: "gerrit-repo", Namespace: namespace},
+		Spec: v1alpha1.GitRepositorySpec{
+			Gerrit:         &v1alpha1.GerritRepo{Project: fakeProject},
+			ScmProviderRef: v1alpha1.ScmProviderObjectReference{Kind: v1alpha1.ScmProviderKind, Name: "gerrit"},
+		},
+	}
+}
+
+func runGit(dir string, args ...string) string {
+	cmd := exec.CommandContext(context.Background(), "git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	out, err := cmd.CombinedOutput()
+	Expect(err).NotTo(HaveOccurred(), string(out))
+	return strings.TrimSpace(string(out))
+}
+
+// localGitProvider points git operations at a local repository.
+type localGitProvider struct {
+	path string
+}
+
+func (l localGitProvider) GetGitHttpsRepoUrl(v1alpha1.GitRepository) string { return l.path }
+func (l localGitProvider) GetToken(context.Context) (string, error)         { return "", nil }
+func (l localGitProvider) GetUser(context.Context) (string, error)          { return "", nil }
+
+var _ = Describe("Gerrit", func() {
+	Describe("GitAuthenticationProvider", func() {
+		It("should use the authenticated URL of the project", func() {
+			scmProvider := &v1alpha1.ScmProvider{
+				Spec: v1alpha1.ScmProviderSpec{Gerrit: &v1alpha1.Gerrit{Domain: "review.example.com/"}},
+			}
+			provider, err := NewGerritGitAuthenticationProvider(scmProvider, newSecret(map[string]string{"username": fakeUsername, "password": fakePassword}))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(provider.GetGitHttpsRepoUrl(*newGitRepository())).To(Equal("https://review.example.com/a/platform/deployments"))
+		})
+
+		It("should require a username and HTTP password in the secret", func() {
+			scmProvider := &v1alpha1.ScmProvider{
+				Spec: v1alpha1.ScmProviderSpec{Gerrit: &v1alpha1.Gerrit{Domain: "review.example.com"}},
+			}
+			_, err := NewGerritGitAuthenticationProvider(scmProvider, newSecret(map[string]string{"password": fakePassword}))
+			Expect(err).To(MatchError(`secret "gerrit-secret" is missing required data key "username"`))
+		})
+	})
+
+	Describe("Providers", func() {
+		var server *fakeGerritServer
+		var k8sClient client.Client
+		var pullRequests *PullRequest
+		var prObj v1alpha1.PullRequest
+
+		BeforeEach(func() {
+			remoteDir := GinkgoT().TempDir()
+			runGit(remoteDir, "init", "--bare")
+
+			workDir := GinkgoT().TempDir()
+			runGit(workDir, "clone", remoteDir, ".")
+			runGit(workDir, "config", "user.name", "Test User")
+			runGit(workDir, "config", "user.email", "test@example.com")
+			runGit(workDir, "config", "commit.gpgsign", "false")
+			runGit(workDir, "checkout", "-b", "environment/dev")
+			Expect(os.WriteFile(filepath.Join(workDir, "manifest.yaml"), []byte("replicas: 1\n"), 0o644)).To(Succeed())
+			runGit(workDir, "add", ".")
+			runGit(workDir, "commit", "-m", "Initial commit")
+			runGit(workDir, "checkout", "-b", "environment/dev-next")
+			Expect(os.WriteFile(filepath.Join(workDir, "manifest.yaml"), []byte("replicas: 2\n"), 0o644)).To(Succeed())
+			runGit(workDir, "commit", "-am", "Scale up")
+			runGit(workDir, "push", "origin", "environment/dev", "environment/dev-next")
+
+			server = newFakeGerritServer(remoteDir)
+			DeferCleanup(server.Close)
+
+			scheme := runtime.NewScheme()
+			Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+			k8sClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(newGitRepository()).Build()
+
+			var err error
+			pullRequests, err = NewGerritPullRequestProvider(k8sClient, *newSecret(map[string]string{"username": fakeUsername, "password": fakePassword}), server.domain(), localGitProvider{path: remoteDir})
+			Expect(err).NotTo(HaveOccurred())
+			pullRequests.client.httpClient = server.Client()
+
+			prObj = v1alpha1.PullRequest{
+				ObjectMeta: metav1.ObjectMeta{Name: "promote-dev", Namespace: namespace, UID: types.UID("7d6e0f1c")},
+				Spec: v1alpha1.PullRequestSpec{
+					RepositoryReference: v1alpha1.ObjectReference{Name: "gerrit-repo"},
+					Title:               "Promote to dev",
+					SourceBranch:        "environment/dev-next",
+					TargetBranch:        "environment/dev",
+					MergeSha:            runGit(remoteDir, "rev-parse", "environment/dev-next"),
+					Commit:              v1alpha1.CommitConfiguration{Message: "Promote to dev\n\nTarget-Sha: abc123"},
+				},
+			}
+		})
+
+		It("should create, find and submit a change", func(ctx SpecContext) {
+			By("Not finding a change that was never pushed")
+			found, _, _, err := pullRequests.FindOpen(ctx, prObj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+
+			By("Pushing a merge commit for review")
+			id, err := pullRequests.Create(ctx, prObj.Spec.Title, prObj.Spec.SourceBranch, prObj.Spec.TargetBranch, "", prObj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(id).To(Equal("1"))
+			prObj.Status = v1alpha1.PullRequestStatus{ID: id, State: v1alpha1.PullRequestOpen}
+
+			found, foundID, created, err := pullRequests.FindOpen(ctx, prObj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(foundID).To(Equal(id))
+			Expect(created).To(Equal(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)))
+
+			url, err := pullRequests.GetUrl(ctx, prObj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(url).To(Equal(server.URL + "/c/platform/deployments/+/1"))
+
+			By("Not pushing a new patch set while the source branch has not moved")
+			Expect(pullRequests.Update(ctx, prObj.Spec.Title, "", prObj)).To(Succeed())
+			Expect(server.change(id).revisions).To(HaveLen(1))
+
+			By("Updating the commit message and submitting the change")
+			prObj.Spec.Commit.Message = "Promote to dev\n\nTarget-Sha: def456"
+			Expect(pullRequests.Merge(ctx, prObj)).To(Succeed())
+			change := server.change(id)
+			Expect(change.status).To(Equal("MERGED"))
+			Expect(change.revisions).To(HaveLen(2))
+			Expect(runGit(server.repoDir, "show", "environment/dev:manifest.yaml")).To(Equal("replicas: 2"))
+			Expect(runGit(server.repoDir, "log", "-1", "--format=%B", "environment/dev")).To(Equal(
+				"Promote to dev\n\nTarget-Sha: def456\nChange-Id: " + changeID(prObj)))
+		})
+
+		It("should refuse to submit a change that merges a different SHA", func(ctx SpecContext) {
+			id, err := pullRequests.Create(ctx, prObj.Spec.Title, prObj.Spec.SourceBranch, prObj.Spec.TargetBranch, "", prObj)
+			Expect(err).NotTo(HaveOccurred())
+			prObj.Status = v1alpha1.PullRequestStatus{ID: id, State: v1alpha1.PullRequestOpen}
+
+			prObj.Spec.MergeSha = "0000000000000000000000000000000000000000"
+			Expect(pullRequests.Merge(ctx, prObj)).To(MatchError(ContainSubstring("not the expected SHA")))
+			Expect(server.change(id).status).To(Equal("NEW"))
+		})
+
+		It("should abandon a closed change", func(ctx SpecContext) {
+			id, err := pullRequests.Create(ctx, prObj.Spec.Title, prObj.Spec.SourceBranch, prObj.Spec.TargetBranch, "", prObj)
+			Expect(err).NotTo(HaveOccurred())
+			prObj.Status = v1alpha1.PullRequestStatus{ID: id, State: v1alpha1.PullRequestOpen}
+
+			Expect(pullRequests.Close(ctx, prObj)).To(Succeed())
+			Expect(server.change(id).status).To(Equal("ABANDONED"))
+
+			found, _, _, err := pullRequests.FindOpen(ctx, prObj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("should mark a draft change as work in progress until it is ready for review", func(ctx SpecContext) {
+			prObj.Spec.Draft = true
+			id, err := pullRequests.Create(ctx, prObj.Spec.Title, prObj.Spec.SourceBranch, prObj.Spec.TargetBranch, "", prObj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(server.change(id).wip).To(BeTrue())
+			prObj.Status = v1alpha1.PullRequestStatus{ID: id, State: v1alpha1.PullRequestOpen}
+
+			prObj.Spec.Draft = false
+			Expect(pullRequests.Update(ctx, prObj.Spec.Title, "", prObj)).To(Succeed())
+			Expect(server.change(id).wip).To(BeFalse())
+			Expect(server.change(id).revisions).To(HaveLen(1))
+		})
+
+		It("should post a new review message for each comment", func(ctx SpecContext) {
+			id, err := pullRequests.Create(ctx, prObj.Spec.Title, prObj.Spec.SourceBranch, prObj.Spec.TargetBranch, "", prObj)
+			Expect(err).NotTo(HaveOccurred())
+			prObj.Status = v1alpha1.PullRequestStatus{ID: id, State: v1alpha1.PullRequestOpen}
+
+			for _, body := range []string{"pending", "success"} {
+				commentID, err := pullRequests.UpsertComment(ctx, prObj, "", body)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(commentID).To(BeEmpty())
+			}
+			Expect(server.change(id).messages).To(Equal([]string{"pending", "success"}))
+			Expect(server.change(id).votes).To(BeEmpty())
+		})
+
+		It("should vote on the label mapped to a commit status key", func(ctx SpecContext) {
+			By("Adding another open change first, so that finding the change has to page through the results")
+			server.addChange("I"+strings.Repeat("0", 40), "environment/dev", server.git("rev-parse", "environment/dev"))
+			server.mu.Lock()
+			server.pageSize = 1
+			server.mu.Unlock()
+
+			id, err := pullRequests.Create(ctx, prObj.Spec.Title, prObj.Spec.SourceBranch, prObj.Spec.TargetBranch, "", prObj)
+			Expect(err).NotTo(HaveOccurred())
+
+			commitStatuses, err := NewGerritCommitStatusProvider(k8sClient, *newSecret(map[string]string{"username": fakeUsername, "password": fakePassword}), server.domain(), map[string]string{"argocd-health": "Verified"})
+			Expect(err).NotTo(HaveOccurred())
+			commitStatuses.client.httpClient = server.Client()
+
+			newCommitStatus := func(key string, phase v1alpha1.CommitStatusPhase) *v1alpha1.CommitStatus {
+				return &v1alpha1.CommitStatus{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "health",
+						Namespace: namespace,
+						Labels:    map[string]string{v1alpha1.CommitStatusLabel: key},
+					},
+					Spec: v1alpha1.CommitStatusSpec{
+						RepositoryReference: v1alpha1.ObjectReference{Name: "gerrit-repo"},
+						Sha:                 prObj.Spec.MergeSha,
+						Name:                "argocd-health",
+						Description:         "Application is healthy",
+						Phase:               phase,
+					},
+				}
+			}
+
+			By("Voting on the change that merges the commit")
+			result, err := commitStatuses.Set(ctx, newCommitStatus("argocd-health", v1alpha1.CommitPhaseFailure))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Status.Phase).To(Equal(v1alpha1.CommitPhaseFailure))
+			Expect(server.change(id).votes).To(Equal(map[string]int{"Verified": -1}))
+
+			_, err = commitStatuses.Set(ctx, newCommitStatus("argocd-health", v1alpha1.CommitPhaseSuccess))
+			Expect(err).NotTo(HaveOccurred())
+			change := server.change(id)
+			Expect(change.votes).To(Equal(map[string]int{"Verified": 1}))
+			Expect(change.messages).To(ContainElement("argocd-health: success - Application is healthy"))
+
+			By("Only recording statuses whose key is not mapped to a label")
+			result, err = commitStatuses.Set(ctx, newCommitStatus("timer", v1alpha1.CommitPhasePending))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Status.Phase).To(Equal(v1alpha1.CommitPhasePending))
+			Expect(server.change(id).messages).To(HaveLen(2))
+		})
+	})
+})
@@ -0,0 +1,52 @@
+package gerrit
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms"
+)
+
+// GitAuthenticationProvider implements the scms.GitOperationsProvider interface for Gerrit.
+type GitAuthenticationProvider struct {
+	scmProvider v1alpha1.GenericScmProvider
+	secret      *v1.Secret
+}
+
+var _ scms.GitOperationsProvider = &GitAuthenticationProvider{}
+
+// NewGerritGitAuthenticationProvider creates a new instance of GitAuthenticationProvider for Gerrit.
+func NewGerritGitAuthenticationProvider(scmProvider v1alpha1.GenericScmProvider, secret *v1.Secret) (*GitAuthenticationProvider, error) {
+	if _, _, err := getCredentials(*secret); err != nil {
+		return nil, err
+	}
+
+	return &GitAuthenticationProvider{
+		scmProvider: scmProvider,
+		secret:      secret,
+	}, nil
+}
+
+// GetGitHttpsRepoUrl constructs the HTTPS URL for a Gerrit project. The "/a/" prefix makes Gerrit authenticate the
+// request, which is required to push.
+func (gap GitAuthenticationProvider) GetGitHttpsRepoUrl(repo v1alpha1.GitRepository) string {
+	repoUrl := "https://" + strings.TrimSuffix(gap.scmProvider.GetSpec().Gerrit.Domain, "/") + "/a/" + repo.Spec.Gerrit.Project
+	if _, err := url.Parse(repoUrl); err != nil {
+		return ""
+	}
+	return repoUrl
+}
+
+// GetToken retrieves the Gerrit HTTP password from the secret.
+func (gap GitAuthenticationProvider) GetToken(ctx context.Context) (string, error) {
+	return string(gap.secret.Data[passwordSecretKey]), nil
+}
+
+// GetUser retrieves the Gerrit user name from the secret.
+func (gap GitAuthenticationProvider) GetUser(ctx context.Context) (string, error) {
+	return string(gap.secret.Data[usernameSecretKey]), nil
+}
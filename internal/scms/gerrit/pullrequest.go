@@ -0,0 +1,354 @@
+package gerrit
+
+import (
+	"context"
+	"crypto/sha1" //nolint:gosec // Change-Ids are SHA-1 shaped identifiers, not a security feature.
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/git"
+	"github.com/argoproj-labs/gitops-promoter/internal/metrics"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms"
+	"github.com/argoproj-labs/gitops-promoter/internal/utils"
+)
+
+// changeIDTrailer is the commit message trailer Gerrit uses to identify the change a commit belongs to.
+const changeIDTrailer = "Change-Id"
+
+// PullRequest implements the scms.PullRequestProvider interface for Gerrit. A pull request is a Gerrit change whose
+// commit is a merge of the source branch into the target branch, pushed to refs/for/<target branch>. Each time the
+// source branch moves, a new patch set is pushed to the change, and merging the pull request submits the change.
+type PullRequest struct {
+	client        *Client
+	k8sClient     client.Client
+	gitOperations scms.GitOperationsProvider
+}
+
+var _ scms.PullRequestProvider = &PullRequest{}
+
+// NewGerritPullRequestProvider creates a new instance of PullRequest for Gerrit. The git operations provider is used
+// to push changes for review.
+func NewGerritPullRequestProvider(k8sClient client.Client, secret v1.Secret, domain string, gitOperations scms.GitOperationsProvider) (*PullRequest, error) {
+	client, err := GetClient(domain, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PullRequest{
+		client:        client,
+		k8sClient:     k8sClient,
+		gitOperations: gitOperations,
+	}, nil
+}
+
+// Create pushes a merge of the source branch into the target branch for review and returns the number of the change
+// Gerrit creates for it.
+func (pr *PullRequest) Create(ctx context.Context, title, head, base, desc string, prObj v1alpha1.PullRequest) (string, error) {
+	logger := log.FromContext(ctx)
+
+	repo, err := utils.GetGitRepositoryFromObjectKey(ctx, pr.k8sClient, client.ObjectKey{
+		Namespace: prObj.Namespace,
+		Name:      prObj.Spec.RepositoryReference.Name,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get GitRepository: %w", err)
+	}
+
+	if err := pr.pushPatchSet(ctx, repo, title, desc, prObj); err != nil {
+		return "", fmt.Errorf("failed to create change: %w", err)
+	}
+
+	change, err := pr.findOpenChange(ctx, repo, prObj)
+	if err != nil {
+		return "", fmt.Errorf("failed to find created change: %w", err)
+	}
+	if change == nil {
+		return "", fmt.Errorf("change %s not found after pushing it for review", changeID(prObj))
+	}
+	if prObj.Spec.Draft {
+		if err := pr.setWorkInProgress(ctx, repo, strconv.Itoa(change.Number), true); err != nil {
+			return "", err
+		}
+	}
+
+	logger.V(4).Info("created change", "number", change.Number, "changeId", change.ChangeID)
+
+	return strconv.Itoa(change.Number), nil
+}
+
+// Update pushes a new patch set to the change if the source branch has moved since the current patch set was pushed,
+// and marks the change as work in progress or ready for review to match the pull request's draft state. The commit
+// message is only updated when merging, since every new patch set may reset the votes on the change.
+func (pr *PullRequest) Update(ctx context.Context, title, description string, prObj v1alpha1.PullRequest) error {
+	logger := log.FromContext(ctx)
+
+	repo, err := utils.GetGitRepositoryFromObjectKey(ctx, pr.k8sClient, client.ObjectKey{
+		Namespace: prObj.Namespace,
+		Name:      prObj.Spec.RepositoryReference.Name,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get repo: %w", err)
+	}
+
+	change, err := pr.getChange(ctx, repo, prObj.Status.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update change: %w", err)
+	}
+	if change.WorkInProgress != prObj.Spec.Draft {
+		if err := pr.setWorkInProgress(ctx, repo, prObj.Status.ID, prObj.Spec.Draft); err != nil {
+			return err
+		}
+	}
+	if change.mergedSha() == prObj.Spec.MergeSha {
+		return nil
+	}
+
+	if err := pr.pushPatchSet(ctx, repo, title, description, prObj); err != nil {
+		return fmt.Errorf("failed to update change: %w", err)
+	}
+
+	logger.V(4).Info("pushed new patch set", "number", prObj.Status.ID, "mergeSha", prObj.Spec.MergeSha)
+
+	return nil
+}
+
+// Close abandons the change.
+func (pr *PullRequest) Close(ctx context.Context, prObj v1alpha1.PullRequest) error {
+	logger := log.FromContext(ctx)
+
+	repo, err := utils.GetGitRepositoryFromObjectKey(ctx, pr.k8sClient, client.ObjectKey{
+		Namespace: prObj.Namespace,
+		Name:      prObj.Spec.RepositoryReference.Name,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get repo: %w", err)
+	}
+
+	start := time.Now()
+	statusCode, err := pr.client.do(ctx, http.MethodPost, changePath(repo, prObj.Status.ID)+"/abandon", nil, struct{}{}, nil)
+	metrics.RecordSCMCall(repo, metrics.SCMAPIPullRequest, metrics.SCMOperationClose, statusCode, time.Since(start), nil)
+	if err != nil {
+		return fmt.Errorf("failed to abandon change: %w", err)
+	}
+
+	logger.V(4).Info("gerrit response status", "status", statusCode)
+	logger.V(4).Info("abandoned change", "number", prObj.Status.ID)
+
+	return nil
+}
+
+// Merge submits the change. If the commit message of the current patch set differs from the pull request's merge
+// commit message, the message is updated first so that the merge commit carries the promoter's trailers.
+func (pr *PullRequest) Merge(ctx context.Context, prObj v1alpha1.PullRequest) error {
+	logger := log.FromContext(ctx)
+
+	repo, err := utils.GetGitRepositoryFromObjectKey(ctx, pr.k8sClient, client.ObjectKey{
+		Namespace: prObj.Namespace,
+		Name:      prObj.Spec.RepositoryReference.Name,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get repo: %w", err)
+	}
+
+	change, err := pr.getChange(ctx, repo, prObj.Status.ID)
+	if err != nil {
+		return fmt.Errorf("failed to merge change: %w", err)
+	}
+	if mergedSha := change.mergedSha(); mergedSha != prObj.Spec.MergeSha {
+		return fmt.Errorf("change %s merges %q, not the expected SHA %q", prObj.Status.ID, mergedSha, prObj.Spec.MergeSha)
+	}
+
+	message, err := commitMessage(ctx, prObj.Spec.Title, prObj.Spec.Description, prObj)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(change.currentCommit().Message) != message {
+		start := time.Now()
+		statusCode, err := pr.client.do(ctx, http.MethodPut, changePath(repo, prObj.Status.ID)+"/message", nil, map[string]string{"message": message}, nil)
+		metrics.RecordSCMCall(repo, metrics.SCMAPIPullRequest, metrics.SCMOperationUpdate, statusCode, time.Since(start), nil)
+		if err != nil {
+			return fmt.Errorf("failed to update commit message of change: %w", err)
+		}
+		logger.V(4).Info("gerrit response status", "status", statusCode)
+	}
+
+	start := time.Now()
+	statusCode, err := pr.client.do(ctx, http.MethodPost, changePath(repo, prObj.Status.ID)+"/submit", nil, struct{}{}, nil)
+	metrics.RecordSCMCall(repo, metrics.SCMAPIPullRequest, metrics.SCMOperationMerge, statusCode, time.Since(start), nil)
+	if err != nil {
+		return fmt.Errorf("failed to submit change: %w", err)
+	}
+
+	logger.V(4).Info("gerrit response status", "status", statusCode)
+	logger.V(4).Info("submitted change", "number", prObj.Status.ID)
+
+	return nil
+}
+
+// FindOpen checks if the change for the pull request is open and returns its number and creation time.
+func (pr *PullRequest) FindOpen(ctx context.Context, pullRequest v1alpha1.PullRequest) (bool, string, time.Time, error) {
+	logger := log.FromContext(ctx)
+	logger.V(4).Info("Finding Open Pull Request")
+
+	repo, err := utils.GetGitRepositoryFromObjectKey(ctx, pr.k8sClient, client.ObjectKey{
+		Namespace: pullRequest.Namespace,
+		Name:      pullRequest.Spec.RepositoryReference.Name,
+	})
+	if err != nil {
+		return false, "", time.Time{}, fmt.Errorf("failed to get repo: %w", err)
+	}
+
+	change, err := pr.findOpenChange(ctx, repo, pullRequest)
+	if err != nil {
+		return false, "", time.Time{}, err
+	}
+	if change == nil {
+		return false, "", time.Time{}, nil
+	}
+
+	created, err := change.createdTime()
+	if err != nil {
+		return false, "", time.Time{}, err
+	}
+	return true, strconv.Itoa(change.Number), created, nil
+}
+
+// GetUrl retrieves the URL of the change in the Gerrit web UI.
+func (pr *PullRequest) GetUrl(ctx context.Context, prObj v1alpha1.PullRequest) (string, error) {
+	repo, err := utils.GetGitRepositoryFromObjectKey(ctx, pr.k8sClient, client.ObjectKey{
+		Namespace: prObj.Namespace,
+		Name:      prObj.Spec.RepositoryReference.Name,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get repo: %w", err)
+	}
+
+	return pr.client.webChangeURL(repo, prObj.Status.ID), nil
+}
+
+// pushPatchSet pushes a merge of the source branch at the pull request's merge SHA into the target branch for review.
+// Gerrit creates a change for the commit, or adds it as a new patch set if a change with its Change-Id exists.
+func (pr *PullRequest) pushPatchSet(ctx context.Context, repo *v1alpha1.GitRepository, title, description string, prObj v1alpha1.PullRequest) error {
+	message, err := commitMessage(ctx, title, description, prObj)
+	if err != nil {
+		return err
+	}
+
+	_, err = git.PushMergeForReview(ctx, pr.gitOperations, repo, prObj.Spec.SourceBranch, prObj.Spec.TargetBranch, prObj.Spec.MergeSha, message, "refs/for/"+prObj.Spec.TargetBranch)
+	if err != nil {
+		return fmt.Errorf("failed to push for review: %w", err)
+	}
+	return nil
+}
+
+// UpsertComment posts the body as a review message on the current patch set of the change. Gerrit's change messages
+// cannot be edited, so a new message is posted each time and the returned ID is empty. The message is not tagged as
+// autogenerated, so that Gerrit shows it in the change log.
+func (pr *PullRequest) UpsertComment(ctx context.Context, prObj v1alpha1.PullRequest, commentID, body string) (string, error) {
+	repo, err := utils.GetGitRepositoryFromObjectKey(ctx, pr.k8sClient, client.ObjectKey{
+		Namespace: prObj.Namespace,
+		Name:      prObj.Spec.RepositoryReference.Name,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get repo: %w", err)
+	}
+
+	change, err := pr.getChange(ctx, repo, prObj.Status.ID)
+	if err != nil {
+		return "", fmt.Errorf("failed to comment on change: %w", err)
+	}
+
+	start := time.Now()
+	statusCode, err := pr.client.do(ctx, http.MethodPost, changePath(repo, prObj.Status.ID)+"/revisions/"+url.PathEscape(change.CurrentRevision)+"/review", nil, reviewInput{Message: body, Labels: map[string]int{}}, nil)
+	metrics.RecordSCMCall(repo, metrics.SCMAPIPullRequest, metrics.SCMOperationCreate, statusCode, time.Since(start), nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to comment on change %s: %w", prObj.Status.ID, err)
+	}
+	return "", nil
+}
+
+// setWorkInProgress marks the change as work in progress, Gerrit's counterpart of a draft pull request, or as
+// ready for review.
+func (pr *PullRequest) setWorkInProgress(ctx context.Context, repo *v1alpha1.GitRepository, number string, wip bool) error {
+	action := "/ready"
+	if wip {
+		action = "/wip"
+	}
+
+	start := time.Now()
+	statusCode, err := pr.client.do(ctx, http.MethodPost, changePath(repo, number)+action, nil, struct{}{}, nil)
+	metrics.RecordSCMCall(repo, metrics.SCMAPIPullRequest, metrics.SCMOperationUpdate, statusCode, time.Since(start), nil)
+	if err != nil {
+		return fmt.Errorf("failed to set work in progress state of change %q: %w", number, err)
+	}
+
+	log.FromContext(ctx).V(4).Info("set work in progress state of change", "number", number, "workInProgress", wip)
+	return nil
+}
+
+// getChange retrieves a change with its current revision and commit.
+func (pr *PullRequest) getChange(ctx context.Context, repo *v1alpha1.GitRepository, number string) (*changeInfo, error) {
+	if number == "" {
+		return nil, errors.New("change number is empty")
+	}
+
+	var change changeInfo
+	start := time.Now()
+	statusCode, err := pr.client.do(ctx, http.MethodGet, changePath(repo, number), url.Values{"o": currentRevisionOptions}, nil, &change)
+	metrics.RecordSCMCall(repo, metrics.SCMAPIPullRequest, metrics.SCMOperationGet, statusCode, time.Since(start), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get change %q: %w", number, err)
+	}
+	return &change, nil
+}
+
+// findOpenChange returns the open change for the pull request, or nil if there is none.
+func (pr *PullRequest) findOpenChange(ctx context.Context, repo *v1alpha1.GitRepository, prObj v1alpha1.PullRequest) (*changeInfo, error) {
+	query := fmt.Sprintf("change:%s project:%q branch:%q status:open", changeID(prObj), repo.Spec.Gerrit.Project, prObj.Spec.TargetBranch)
+
+	start := time.Now()
+	changes, statusCode, err := pr.client.queryChanges(ctx, query, currentRevisionOptions...)
+	metrics.RecordSCMCall(repo, metrics.SCMAPIPullRequest, metrics.SCMOperationList, statusCode, time.Since(start), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query changes: %w", err)
+	}
+
+	log.FromContext(ctx).V(4).Info("gerrit response status", "status", statusCode)
+
+	if len(changes) == 0 {
+		return nil, nil
+	}
+	return &changes[0], nil
+}
+
+// changeID returns the Change-Id of the pull request's change. It is derived from the PullRequest resource so that
+// every patch set pushed for the resource lands on the same change, and a recreated resource gets a new change.
+func changeID(prObj v1alpha1.PullRequest) string {
+	sum := sha1.Sum([]byte(prObj.Namespace + "/" + prObj.Name + "/" + string(prObj.UID))) //nolint:gosec // See import.
+	return "I" + hex.EncodeToString(sum[:])
+}
+
+// commitMessage returns the commit message for the pull request's change: the merge commit message, or the title and
+// description if it is not set, with the Change-Id trailer.
+func commitMessage(ctx context.Context, title, description string, prObj v1alpha1.PullRequest) (string, error) {
+	message := prObj.Spec.Commit.Message
+	if message == "" {
+		message = title + "\n\n" + description
+	}
+	message, err := git.AddTrailerToCommitMessage(ctx, message, changeIDTrailer, changeID(prObj))
+	if err != nil {
+		return "", fmt.Errorf("failed to add Change-Id to commit message: %w", err)
+	}
+	return message, nil
+}
@@ -0,0 +1,247 @@
+package gerrit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms/httpcache"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms/ratelimit"
+)
+
+const (
+	// usernameSecretKey is the key in the ScmProvider secret that holds the Gerrit user name.
+	usernameSecretKey = "username"
+	// passwordSecretKey is the key in the ScmProvider secret that holds the user's Gerrit HTTP password.
+	passwordSecretKey = "password"
+
+	// xssiPrefix is prepended to every JSON response by Gerrit to prevent cross-site script inclusion.
+	xssiPrefix = ")]}'"
+
+	// timestampLayout is the layout of timestamps in Gerrit REST API responses, which are always in UTC.
+	timestampLayout = "2006-01-02 15:04:05.000000000"
+)
+
+// currentRevisionOptions are the query options that include the current revision and its commit in ChangeInfo.
+var currentRevisionOptions = []string{"CURRENT_REVISION", "CURRENT_COMMIT"}
+
+// Client is a minimal client for the Gerrit REST API.
+type Client struct {
+	baseURL    string
+	username   string
+	password   string
+	httpClient *http.Client
+}
+
+// APIError is returned when the Gerrit REST API responds with an unexpected status code.
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("unexpected response status %d from Gerrit", e.StatusCode)
+	}
+	return fmt.Sprintf("unexpected response status %d from Gerrit: %s", e.StatusCode, e.Message)
+}
+
+// changeInfo is the subset of the Gerrit ChangeInfo entity used by the providers.
+type changeInfo struct {
+	ID              string                  `json:"id"`
+	Project         string                  `json:"project"`
+	Branch          string                  `json:"branch"`
+	ChangeID        string                  `json:"change_id"`
+	Status          string                  `json:"status"`
+	WorkInProgress  bool                    `json:"work_in_progress,omitempty"`
+	Created         string                  `json:"created"`
+	Number          int                     `json:"_number"`
+	CurrentRevision string                  `json:"current_revision"`
+	Revisions       map[string]revisionInfo `json:"revisions"`
+	MoreChanges     bool                    `json:"_more_changes"`
+}
+
+// revisionInfo is the subset of the Gerrit RevisionInfo entity used by the providers.
+type revisionInfo struct {
+	Number int         `json:"_number"`
+	Commit *commitInfo `json:"commit"`
+}
+
+// commitInfo is the subset of the Gerrit CommitInfo entity used by the providers.
+type commitInfo struct {
+	Parents []struct {
+		Commit string `json:"commit"`
+	} `json:"parents"`
+	Message string `json:"message"`
+}
+
+// currentCommit returns the commit of the change's current revision, or nil if the change was fetched without it.
+func (c *changeInfo) currentCommit() *commitInfo {
+	return c.Revisions[c.CurrentRevision].Commit
+}
+
+// mergedSha returns the SHA of the second parent of the change's current revision, which is the head of the proposed
+// branch for a promotion change, or an empty string if the revision is not a merge commit.
+func (c *changeInfo) mergedSha() string {
+	commit := c.currentCommit()
+	if commit == nil || len(commit.Parents) < 2 {
+		return ""
+	}
+	return commit.Parents[1].Commit
+}
+
+// createdTime returns the time the change was created.
+func (c *changeInfo) createdTime() (time.Time, error) {
+	created, err := time.ParseInLocation(timestampLayout, c.Created, time.UTC)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse creation time %q of change %d: %w", c.Created, c.Number, err)
+	}
+	return created, nil
+}
+
+// GetClient creates a Gerrit REST API client for the given domain using the username and HTTP password from the
+// secret.
+func GetClient(domain string, secret corev1.Secret) (*Client, error) {
+	username, password, err := getCredentials(secret)
+	if err != nil {
+		return nil, err
+	}
+	if domain == "" {
+		return nil, errors.New("domain is required for Gerrit")
+	}
+
+	return &Client{
+		baseURL:    "https://" + strings.TrimSuffix(domain, "/"),
+		username:   username,
+		password:   password,
+		httpClient: &http.Client{Transport: httpcache.NewTransport(secret, "", ratelimit.NewTransport(secret, "", nil))},
+	}, nil
+}
+
+// getCredentials returns the username and HTTP password from the secret. Both are required.
+func getCredentials(secret corev1.Secret) (string, string, error) {
+	username := string(secret.Data[usernameSecretKey])
+	if username == "" {
+		return "", "", fmt.Errorf("secret %q is missing required data key %q", secret.Name, usernameSecretKey)
+	}
+	password := string(secret.Data[passwordSecretKey])
+	if password == "" {
+		return "", "", fmt.Errorf("secret %q is missing required data key %q", secret.Name, passwordSecretKey)
+	}
+	return username, password, nil
+}
+
+// do sends an authenticated request to the Gerrit REST API and decodes the JSON response into out, if out is not nil.
+// The path must not include the "/a" prefix for authenticated requests. It returns the response status code for
+// metrics, which is http.StatusInternalServerError if no response was received.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out any) (int, error) {
+	reqURL := c.baseURL + "/a" + path
+	if len(query) > 0 {
+		reqURL += "?" + query.Encode()
+	}
+
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		reqBody = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, reqBody)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.SetBasicAuth(c.username, c.password)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		// Gerrit returns errors as plain text.
+		return resp.StatusCode, &APIError{StatusCode: resp.StatusCode, Message: strings.TrimSpace(string(respBody))}
+	}
+
+	if out != nil {
+		respBody = bytes.TrimPrefix(respBody, []byte(xssiPrefix))
+		if err := json.Unmarshal(respBody, out); err != nil {
+			return resp.StatusCode, fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+
+	return resp.StatusCode, nil
+}
+
+// queryChanges returns all changes matching the Gerrit search query, following pagination.
+func (c *Client) queryChanges(ctx context.Context, query string, options ...string) ([]changeInfo, int, error) {
+	var all []changeInfo
+	params := url.Values{"q": {query}, "o": options}
+	for {
+		var page []changeInfo
+		statusCode, err := c.do(ctx, http.MethodGet, "/changes/", params, nil, &page)
+		if err != nil {
+			return nil, statusCode, err
+		}
+		all = append(all, page...)
+		if len(page) == 0 || !page[len(page)-1].MoreChanges {
+			return all, statusCode, nil
+		}
+		params.Set("S", strconv.Itoa(len(all)))
+	}
+}
+
+// changePath returns the REST API path of a change, identified by project and change number.
+func changePath(repo *v1alpha1.GitRepository, number string) string {
+	return "/changes/" + url.PathEscape(repo.Spec.Gerrit.Project+"~"+number)
+}
+
+// webChangeURL returns the URL of the change's page in the Gerrit web UI.
+func (c *Client) webChangeURL(repo *v1alpha1.GitRepository, number string) string {
+	return c.baseURL + "/c/" + repo.Spec.Gerrit.Project + "/+/" + number
+}
+
+// phaseToLabelValue maps a commit status phase to a label vote.
+func phaseToLabelValue(phase v1alpha1.CommitStatusPhase) int {
+	switch phase {
+	case v1alpha1.CommitPhaseSuccess:
+		return 1
+	case v1alpha1.CommitPhaseFailure:
+		return -1
+	default:
+		return 0
+	}
+}
+
+// ApplyHTTPAuth sets basic auth on the request using the username and HTTP password from the secret.
+func ApplyHTTPAuth(secret corev1.Secret, req *http.Request) error {
+	username, password, err := getCredentials(secret)
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(username, password)
+	return nil
+}
@@ -15,3 +15,16 @@ type GitOperationsProvider interface {
 	// GetUser returns the user name for authentication.
 	GetUser(ctx context.Context) (string, error)
 }
+
+// GitTransportProvider is an optional interface for GitOperationsProviders that change how git commands are run, for
+// example to use SSH instead of HTTPS with askpass credentials, or to sign commits. GetGitHttpsRepoUrl is still used to
+// build web links and to key local clones.
+type GitTransportProvider interface {
+	// GetGitRemoteUrl returns the URL git commands should use to reach the repository.
+	GetGitRemoteUrl(gitRepo v1alpha1.GitRepository) (string, error)
+	// GetGitEnv returns additional environment variables for git commands, such as GIT_SSH_COMMAND.
+	GetGitEnv(ctx context.Context) ([]string, error)
+	// UsesHTTPSCredentials returns true if git commands authenticate with the user and token of the
+	// GitOperationsProvider. If false, the credentials are not looked up.
+	UsesHTTPSCredentials() bool
+}
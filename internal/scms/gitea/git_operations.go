@@ -3,11 +3,14 @@ package gitea
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"net/url"
 
 	"code.gitea.io/sdk/gitea"
 	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
 	"github.com/argoproj-labs/gitops-promoter/internal/scms"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms/httpcache"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms/ratelimit"
 	k8sV1 "k8s.io/api/core/v1"
 )
 
@@ -82,7 +85,7 @@ func GetClient(domain string, secret k8sV1.Secret) (*gitea.Client, error) {
 
 	client, err := gitea.NewClient(
 		"https://"+domain,
-		options...,
+		append(options, gitea.SetHTTPClient(&http.Client{Transport: httpcache.NewTransport(secret, "", ratelimit.NewTransport(secret, "", nil))}))...,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Gitea client: %w", err)
@@ -3,7 +3,11 @@ package gitea
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"code.gitea.io/sdk/gitea"
@@ -24,7 +28,10 @@ type PullRequest struct {
 	domain      string
 }
 
-var _ scms.PullRequestProvider = &PullRequest{}
+var (
+	_ scms.PullRequestProvider                 = &PullRequest{}
+	_ scms.PullRequestBranchProtectionProvider = &PullRequest{}
+)
 
 // NewGiteaPullRequestProvider creates a new instance of PullRequest for Gitea.
 func NewGiteaPullRequestProvider(k8sClient k8sClient.Client, secret k8sV1.Secret, domain string) (*PullRequest, error) {
@@ -55,9 +62,18 @@ func (pr *PullRequest) Create(ctx context.Context, title, head, base, descriptio
 	options := gitea.CreatePullRequestOption{
 		Head:  head,
 		Base:  base,
-		Title: title,
+		Title: draftTitle(title, prObj.Spec.Draft),
 		Body:  description,
 	}
+	options.Reviewers, options.TeamReviewers = scms.SplitReviewers(prObj.Spec.Reviewers)
+	options.Assignees = prObj.Spec.Assignees
+	if len(prObj.Spec.Labels) > 0 {
+		// Labels are cosmetic, so failing to look them up does not keep the pull request from being created.
+		options.Labels, err = pr.labelIDs(ctx, repo, prObj.Spec.Labels)
+		if err != nil {
+			logger.Error(err, "failed to look up labels, creating pull request without them")
+		}
+	}
 
 	start := time.Now()
 	pullRequest, resp, err := pr.giteaClient.CreatePullRequest(repo.Spec.Gitea.Owner, repo.Spec.Gitea.Name, options)
@@ -72,6 +88,40 @@ func (pr *PullRequest) Create(ctx context.Context, title, head, base, descriptio
 	return strconv.FormatInt(pullRequest.Index, 10), nil
 }
 
+// draftTitle returns the title of a pull request, prefixed with "WIP: " if it is a draft. Gitea marks pull requests as
+// work in progress by their title, so setting the title without the prefix marks it ready.
+func draftTitle(title string, draft bool) string {
+	if draft {
+		return "WIP: " + title
+	}
+	return title
+}
+
+// labelIDs returns the IDs of the repository's labels with the given names. Names of labels that do not exist in the
+// repository are logged and skipped.
+func (pr *PullRequest) labelIDs(ctx context.Context, repo *promoterv1alpha1.GitRepository, names []string) ([]int64, error) {
+	start := time.Now()
+	labels, resp, err := pr.giteaClient.ListRepoLabels(repo.Spec.Gitea.Owner, repo.Spec.Gitea.Name, gitea.ListLabelsOptions{ListOptions: gitea.ListOptions{Page: -1}})
+	if resp != nil {
+		metrics.RecordSCMCall(repo, metrics.SCMAPIPullRequest, metrics.SCMOperationList, resp.StatusCode, time.Since(start), nil)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repository labels: %w", err)
+	}
+
+	ids := make([]int64, 0, len(names))
+	for _, name := range names {
+		index := slices.IndexFunc(labels, func(label *gitea.Label) bool { return label.Name == name })
+		if index < 0 {
+			log.FromContext(ctx).Info("skipping label that does not exist in the repository", "label", name,
+				"repository", repo.Spec.Gitea.Owner+"/"+repo.Spec.Gitea.Name)
+			continue
+		}
+		ids = append(ids, labels[index].ID)
+	}
+	return ids, nil
+}
+
 // Update updates the title and description of an existing pull request.
 func (pr *PullRequest) Update(ctx context.Context, title, description string, prObj promoterv1alpha1.PullRequest) error {
 	logger := log.FromContext(ctx)
@@ -90,7 +140,7 @@ func (pr *PullRequest) Update(ctx context.Context, title, description string, pr
 	}
 
 	options := gitea.EditPullRequestOption{
-		Title: title,
+		Title: draftTitle(title, prObj.Spec.Draft),
 		Body:  &description,
 	}
 
@@ -249,3 +299,170 @@ func (pr *PullRequest) GetUrl(ctx context.Context, pullRequest promoterv1alpha1.
 
 	return fmt.Sprintf("https://%s/%s/%s/pulls/%s", pr.domain, gitRepo.Spec.Gitea.Owner, gitRepo.Spec.Gitea.Name, pullRequest.Status.ID), nil
 }
+
+// UpsertComment posts a comment on the pull request, or edits the comment with the given ID.
+func (pr *PullRequest) UpsertComment(ctx context.Context, prObj promoterv1alpha1.PullRequest, commentID, body string) (string, error) {
+	repo, err := utils.GetGitRepositoryFromObjectKey(ctx, pr.k8sClient, k8sClient.ObjectKey{
+		Namespace: prObj.Namespace,
+		Name:      prObj.Spec.RepositoryReference.Name,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get git repository from object: %w", err)
+	}
+
+	if commentID != "" {
+		id, err := strconv.ParseInt(commentID, 10, 64)
+		if err != nil {
+			return "", fmt.Errorf("failed to convert comment ID %q to int: %w", commentID, err)
+		}
+
+		start := time.Now()
+		_, resp, err := pr.giteaClient.EditIssueComment(repo.Spec.Gitea.Owner, repo.Spec.Gitea.Name, id, gitea.EditIssueCommentOption{Body: body})
+		if resp != nil {
+			metrics.RecordSCMCall(repo, metrics.SCMAPIPullRequest, metrics.SCMOperationUpdate, resp.StatusCode, time.Since(start), nil)
+		}
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return "", fmt.Errorf("failed to edit comment %d: %w: %w", id, scms.ErrCommentNotFound, err)
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to edit comment %d: %w", id, err)
+		}
+		return commentID, nil
+	}
+
+	prID, err := strconv.ParseInt(prObj.Status.ID, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("failed to convert PR ID %q to int: %w", prObj.Status.ID, err)
+	}
+
+	start := time.Now()
+	comment, resp, err := pr.giteaClient.CreateIssueComment(repo.Spec.Gitea.Owner, repo.Spec.Gitea.Name, prID, gitea.CreateIssueCommentOption{Body: body})
+	if resp != nil {
+		metrics.RecordSCMCall(repo, metrics.SCMAPIPullRequest, metrics.SCMOperationCreate, resp.StatusCode, time.Since(start), nil)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to comment on pull request %d: %w", prID, err)
+	}
+	return strconv.FormatInt(comment.ID, 10), nil
+}
+
+// GetBranchProtection returns the required status checks and approvals of the branch protection rule that applies to
+// the pull request's target branch, and which of them the pull request meets.
+func (pr *PullRequest) GetBranchProtection(ctx context.Context, pullRequest promoterv1alpha1.PullRequest) (*scms.BranchProtection, error) {
+	prID, err := strconv.ParseInt(pullRequest.Status.ID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert PR ID %q to int: %w", pullRequest.Status.ID, err)
+	}
+	repo, err := utils.GetGitRepositoryFromObjectKey(ctx, pr.k8sClient, k8sClient.ObjectKey{
+		Namespace: pullRequest.Namespace,
+		Name:      pullRequest.Spec.RepositoryReference.Name,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get git repository from object: %w", err)
+	}
+
+	// The branch reports the settings of the protection rule that applies to it, which, unlike the rule itself, only
+	// needs read access to the repository.
+	start := time.Now()
+	branch, resp, err := pr.giteaClient.GetRepoBranch(repo.Spec.Gitea.Owner, repo.Spec.Gitea.Name, pullRequest.Spec.TargetBranch)
+	if resp != nil {
+		metrics.RecordSCMCall(repo, metrics.SCMAPIPullRequest, metrics.SCMOperationGet, resp.StatusCode, time.Since(start), nil)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get branch %q: %w", pullRequest.Spec.TargetBranch, err)
+	}
+	if !branch.Protected || (!branch.EnableStatusCheck && branch.RequiredApprovals == 0) {
+		return nil, nil
+	}
+
+	protection := &scms.BranchProtection{RequiredApprovals: int(branch.RequiredApprovals)}
+	if branch.EnableStatusCheck {
+		sha := pullRequest.Spec.MergeSha
+		if sha == "" {
+			start = time.Now()
+			giteaPullRequest, resp, err := pr.giteaClient.GetPullRequest(repo.Spec.Gitea.Owner, repo.Spec.Gitea.Name, prID)
+			if resp != nil {
+				metrics.RecordSCMCall(repo, metrics.SCMAPIPullRequest, metrics.SCMOperationGet, resp.StatusCode, time.Since(start), nil)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to get pull request %d: %w", prID, err)
+			}
+			sha = giteaPullRequest.Head.Sha
+		}
+
+		start = time.Now()
+		status, resp, err := pr.giteaClient.GetCombinedStatus(repo.Spec.Gitea.Owner, repo.Spec.Gitea.Name, sha)
+		if resp != nil {
+			metrics.RecordSCMCall(repo, metrics.SCMAPICommitStatus, metrics.SCMOperationGet, resp.StatusCode, time.Since(start), nil)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the commit statuses of %q: %w", sha, err)
+		}
+		protection.RequiredChecks, protection.UnmetChecks = requiredChecks(branch.StatusCheckContexts, status.Statuses)
+	}
+
+	if protection.RequiredApprovals > 0 {
+		start = time.Now()
+		reviews, resp, err := pr.giteaClient.ListPullReviews(repo.Spec.Gitea.Owner, repo.Spec.Gitea.Name, prID, gitea.ListPullReviewsOptions{ListOptions: gitea.ListOptions{Page: -1}})
+		if resp != nil {
+			metrics.RecordSCMCall(repo, metrics.SCMAPIPullRequest, metrics.SCMOperationList, resp.StatusCode, time.Since(start), nil)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list the reviews of pull request %d: %w", prID, err)
+		}
+		protection.Approvals = countApprovals(reviews)
+	}
+	return protection, nil
+}
+
+// requiredChecks returns the status check contexts that the branch protection requires and those of them that have not
+// succeeded. Required contexts are glob patterns, each of which must match at least one status, and every status it
+// matches must have succeeded. Without required contexts, Gitea requires every status reported on the commit.
+func requiredChecks(contexts []string, statuses []*gitea.Status) (required, unmet []string) {
+	if len(contexts) == 0 {
+		for _, status := range statuses {
+			required = append(required, status.Context)
+			if status.State != gitea.StatusSuccess {
+				unmet = append(unmet, status.Context)
+			}
+		}
+		return required, unmet
+	}
+
+	for _, pattern := range contexts {
+		matched, succeeded := false, true
+		for _, status := range statuses {
+			if !matchContext(pattern, status.Context) {
+				continue
+			}
+			matched = true
+			succeeded = succeeded && status.State == gitea.StatusSuccess
+		}
+		if !matched || !succeeded {
+			unmet = append(unmet, pattern)
+		}
+	}
+	return contexts, unmet
+}
+
+// matchContext returns whether a status check context matches a required context pattern, in which "*" matches any
+// sequence of characters, including "/", and "?" matches a single character.
+func matchContext(pattern, context string) bool {
+	expression := regexp.QuoteMeta(pattern)
+	expression = strings.NewReplacer(`\*`, ".*", `\?`, ".").Replace(expression)
+	matched, err := regexp.MatchString("^"+expression+"$", context)
+	return err == nil && matched
+}
+
+// countApprovals returns the number of reviewers whose approval counts towards the required approvals: official
+// approving reviews that were not dismissed, counting each reviewer once.
+func countApprovals(reviews []*gitea.PullReview) int {
+	approvers := map[int64]bool{}
+	for _, review := range reviews {
+		if review.State != gitea.ReviewStateApproved || !review.Official || review.Dismissed || review.Reviewer == nil {
+			continue
+		}
+		approvers[review.Reviewer.ID] = true
+	}
+	return len(approvers)
+}
@@ -0,0 +1,114 @@
+package gitea
+
+import (
+	"code.gitea.io/sdk/gitea"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+)
+
+var _ = Describe("PullRequest", func() {
+	var server *fakeGiteaServer
+	var provider *PullRequest
+
+	BeforeEach(func() {
+		server = newFakeGiteaServer()
+		DeferCleanup(server.Close)
+
+		scheme := runtime.NewScheme()
+		Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		gitRepo := &v1alpha1.GitRepository{
+			ObjectMeta: metav1.ObjectMeta{Name: "gitea-repo", Namespace: "default"},
+			Spec: v1alpha1.GitRepositorySpec{
+				Gitea:          &v1alpha1.GiteaRepo{Owner: fakeOwner, Name: fakeRepo},
+				ScmProviderRef: v1alpha1.ScmProviderObjectReference{Kind: v1alpha1.ScmProviderKind, Name: "gitea"},
+			},
+		}
+
+		giteaClient, err := gitea.NewClient(server.URL, gitea.SetToken(fakeToken))
+		Expect(err).NotTo(HaveOccurred())
+		provider = &PullRequest{
+			giteaClient: giteaClient,
+			k8sClient:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(gitRepo).Build(),
+		}
+	})
+
+	It("should create a pull request without the labels that do not exist in the repository", func(ctx SpecContext) {
+		server.addLabel(7, "promotion")
+
+		prObj := v1alpha1.PullRequest{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-pr", Namespace: "default"},
+			Spec: v1alpha1.PullRequestSpec{
+				RepositoryReference: v1alpha1.ObjectReference{Name: "gitea-repo"},
+				SourceBranch:        "environment/dev-next",
+				TargetBranch:        "environment/dev",
+				Labels:              []string{"promotion", "missing"},
+			},
+		}
+		id, err := provider.Create(ctx, "Promote", "environment/dev-next", "environment/dev", "", prObj)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(id).To(Equal("1"))
+		Expect(server.labelsOf(1)).To(Equal([]int64{7}))
+	})
+
+	Describe("GetBranchProtection", func() {
+		prObj := v1alpha1.PullRequest{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-pr", Namespace: "default"},
+			Spec: v1alpha1.PullRequestSpec{
+				RepositoryReference: v1alpha1.ObjectReference{Name: "gitea-repo"},
+				SourceBranch:        "environment/dev-next",
+				TargetBranch:        "environment/dev",
+			},
+			Status: v1alpha1.PullRequestStatus{ID: "1"},
+		}
+
+		It("should return nil for an unprotected target branch", func(ctx SpecContext) {
+			protection, err := provider.GetBranchProtection(ctx, prObj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(protection).To(BeNil())
+		})
+
+		It("should report the required status checks and the approvals that are missing", func(ctx SpecContext) {
+			server.protectBranch("environment/dev", []string{"ci/*", "lint"}, 2)
+			server.setStatus(fakeHeadSha, "ci/build", "success")
+			server.setStatus(fakeHeadSha, "ci/test", "failure")
+			server.addReview(1, 1, "APPROVED", true, false)
+			server.addReview(1, 2, "APPROVED", false, false)
+			server.addReview(1, 3, "APPROVED", true, true)
+			server.addReview(1, 4, "REQUEST_CHANGES", true, false)
+
+			protection, err := provider.GetBranchProtection(ctx, prObj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(protection).NotTo(BeNil())
+			Expect(protection.RequiredChecks).To(Equal([]string{"ci/*", "lint"}))
+			Expect(protection.UnmetChecks).To(Equal([]string{"ci/*", "lint"}))
+			Expect(protection.RequiredApprovals).To(Equal(2))
+			Expect(protection.Approvals).To(Equal(1))
+			Expect(protection.Satisfied()).To(BeFalse())
+		})
+
+		It("should require every status of the merge commit when no contexts are configured", func(ctx SpecContext) {
+			server.protectBranch("environment/dev", []string{}, 1)
+			server.setStatus("merge-sha", "ci/build", "success")
+			server.setStatus("merge-sha", "deploy/preview", "success")
+			server.setStatus(fakeHeadSha, "ci/build", "failure")
+			server.addReview(1, 1, "APPROVED", true, false)
+
+			pullRequest := *prObj.DeepCopy()
+			pullRequest.Spec.MergeSha = "merge-sha"
+			protection, err := provider.GetBranchProtection(ctx, pullRequest)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(protection).NotTo(BeNil())
+			Expect(protection.RequiredChecks).To(Equal([]string{"ci/build", "deploy/preview"}))
+			Expect(protection.UnmetChecks).To(BeEmpty())
+			Expect(protection.Approvals).To(Equal(1))
+			Expect(protection.Satisfied()).To(BeTrue())
+		})
+	})
+})
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"strconv"
 	"time"
+	"unicode/utf8"
 
 	"github.com/google/go-github/v71/github"
 	v1 "k8s.io/api/core/v1"
@@ -19,14 +20,19 @@ import (
 	"github.com/argoproj-labs/gitops-promoter/internal/utils"
 )
 
-// Compile-time check to ensure CommitStatus implements scms.CommitStatusProvider
-var _ scms.CommitStatusProvider = &CommitStatus{}
+// Compile-time checks to ensure CommitStatus implements scms.CommitStatusProvider and scms.CommitCheckProvider
+var (
+	_ scms.CommitStatusProvider = &CommitStatus{}
+	_ scms.CommitCheckProvider  = &CommitStatus{}
+)
 
 // CommitStatus implements the scms.CommitStatusProvider interface for GitHub.
-// It uses GitHub's CheckRun API.
+// It uses GitHub's CheckRun API, the classic commit status API, or both, depending on the ScmProvider's commit status
+// mode.
 type CommitStatus struct {
 	client    *github.Client
 	k8sClient client.Client
+	mode      promoterv1alpha1.GitHubCommitStatusMode
 }
 
 const (
@@ -35,6 +41,12 @@ const (
 	checkRunStatusQueued     = "queued"      // Check is queued
 	checkRunStatusInProgress = "in_progress" // Check is running
 	checkRunStatusCompleted  = "completed"   // Check is finished (use conclusion for success/failure)
+
+	// listPageSize is the page size used when listing the check runs and commit statuses of a commit.
+	listPageSize = 100
+
+	// maxStatusDescriptionLength is the maximum length of a classic commit status description accepted by GitHub.
+	maxStatusDescriptionLength = 140
 )
 
 // NewGithubCommitStatusProvider creates a new instance of CommitStatus for GitHub.
@@ -48,14 +60,34 @@ func NewGithubCommitStatusProvider(ctx context.Context, k8sClient client.Client,
 	return &CommitStatus{
 		client:    githubClient,
 		k8sClient: k8sClient,
+		mode:      scmProvider.GetSpec().GitHub.GetCommitStatusMode(),
 	}, nil
 }
 
-// Set sets the commit status for a given commit SHA in the specified repository using GitHub Checks API.
+// Set sets the commit status for a given commit SHA in the specified repository. Depending on the commit status mode,
+// it sets a check run, a classic commit status, or both. In "both" mode, the check run ID is recorded in the status.
+func (cs *CommitStatus) Set(ctx context.Context, commitStatus *promoterv1alpha1.CommitStatus) (*promoterv1alpha1.CommitStatus, error) {
+	switch cs.mode {
+	case promoterv1alpha1.GitHubCommitStatusModeStatuses:
+		return cs.createStatus(ctx, commitStatus, true)
+	case promoterv1alpha1.GitHubCommitStatusModeBoth:
+		// The check run goes first, since it compares the recorded status with the spec to decide whether to update
+		// the existing check run.
+		updated, err := cs.setCheckRun(ctx, commitStatus)
+		if err != nil {
+			return nil, err
+		}
+		return cs.createStatus(ctx, updated, false)
+	default:
+		return cs.setCheckRun(ctx, commitStatus)
+	}
+}
+
+// setCheckRun sets the commit status for a given commit SHA in the specified repository using GitHub Checks API.
 // If the SHA hasn't changed and we have an existing check run ID, it will update the existing check run.
 // However, if we're transitioning between different completed states or from completed to pending,
 // we create a new check run.
-func (cs *CommitStatus) Set(ctx context.Context, commitStatus *promoterv1alpha1.CommitStatus) (*promoterv1alpha1.CommitStatus, error) {
+func (cs *CommitStatus) setCheckRun(ctx context.Context, commitStatus *promoterv1alpha1.CommitStatus) (*promoterv1alpha1.CommitStatus, error) {
 	logger := log.FromContext(ctx)
 
 	// Check if we should create a new check run instead of updating
@@ -205,6 +237,96 @@ func (cs *CommitStatus) updateCheckRun(ctx context.Context, commitStatus *promot
 	return cs.handleCheckRunResponse(ctx, metrics.SCMOperationUpdate, start, commitStatus, gitRepo, checkRun, response)
 }
 
+// createStatus creates a classic commit status, with the CommitStatus name as its context. Classic statuses cannot be
+// updated, so GitHub shows the latest status created for each context. No status is created if the latest one already
+// has the same state, description and URL, since GitHub limits the number of statuses per context and commit. If
+// recordID is true, the ID of the status is recorded in the CommitStatus.
+func (cs *CommitStatus) createStatus(ctx context.Context, commitStatus *promoterv1alpha1.CommitStatus, recordID bool) (*promoterv1alpha1.CommitStatus, error) {
+	logger := log.FromContext(ctx)
+
+	gitRepo, err := cs.getGitRepository(ctx, commitStatus)
+	if err != nil {
+		return nil, err
+	}
+
+	repoStatus := &github.RepoStatus{
+		State:       github.Ptr(string(commitStatus.Spec.Phase)), // pending, success and failure are valid states
+		Context:     github.Ptr(commitStatus.Spec.Name),
+		Description: github.Ptr(truncateStatusDescription(commitStatus.Spec.Description)),
+		TargetURL:   buildDetailsURL(commitStatus.Spec.Url),
+	}
+
+	latest, err := cs.getLatestStatus(ctx, gitRepo, commitStatus.Spec.Sha, commitStatus.Spec.Name)
+	if err != nil {
+		return nil, err
+	}
+	if latest != nil && latest.GetState() == repoStatus.GetState() && latest.GetDescription() == repoStatus.GetDescription() && latest.GetTargetURL() == repoStatus.GetTargetURL() {
+		logger.V(4).Info("Commit status is up to date, not creating a new one", "context", commitStatus.Spec.Name)
+		commitStatus.Status.Phase = commitStatus.Spec.Phase
+		commitStatus.Status.Sha = commitStatus.Spec.Sha
+		if recordID {
+			commitStatus.Status.Id = strconv.FormatInt(latest.GetID(), 10)
+		}
+		return commitStatus, nil
+	}
+
+	logger.Info("Creating commit status via Statuses API")
+	start := time.Now()
+	status, response, err := cs.client.Repositories.CreateStatus(ctx, gitRepo.Spec.GitHub.Owner, gitRepo.Spec.GitHub.Name, commitStatus.Spec.Sha, repoStatus)
+	if response != nil {
+		metrics.RecordSCMCall(gitRepo, metrics.SCMAPICommitStatus, metrics.SCMOperationCreate, response.StatusCode, time.Since(start), getRateLimitMetrics(response.Rate))
+		logger.V(4).Info("github response status", "status", response.Status)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create commit status: %w", err)
+	}
+
+	commitStatus.Status.Phase = commitStatus.Spec.Phase
+	commitStatus.Status.Sha = commitStatus.Spec.Sha
+	if recordID {
+		commitStatus.Status.Id = strconv.FormatInt(status.GetID(), 10)
+	}
+
+	return commitStatus, nil
+}
+
+// getLatestStatus returns the latest classic commit status with the given context on sha, or nil if there is none.
+func (cs *CommitStatus) getLatestStatus(ctx context.Context, gitRepo *promoterv1alpha1.GitRepository, sha string, statusContext string) (*github.RepoStatus, error) {
+	logger := log.FromContext(ctx)
+
+	opts := &github.ListOptions{PerPage: listPageSize}
+	for {
+		start := time.Now()
+		combined, response, err := cs.client.Repositories.GetCombinedStatus(ctx, gitRepo.Spec.GitHub.Owner, gitRepo.Spec.GitHub.Name, sha, opts)
+		if response != nil {
+			metrics.RecordSCMCall(gitRepo, metrics.SCMAPICommitStatus, metrics.SCMOperationGet, response.StatusCode, time.Since(start), getRateLimitMetrics(response.Rate))
+			logger.V(4).Info("github response status", "status", response.Status)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get combined status for %q: %w", sha, err)
+		}
+		for _, status := range combined.Statuses {
+			if status.GetContext() == statusContext {
+				return status, nil
+			}
+		}
+		if response == nil || response.NextPage == 0 {
+			return nil, nil
+		}
+		opts.Page = response.NextPage
+	}
+}
+
+// truncateStatusDescription shortens a description that is longer than GitHub accepts for classic commit statuses,
+// without splitting a multi-byte character.
+func truncateStatusDescription(description string) string {
+	if utf8.RuneCountInString(description) <= maxStatusDescriptionLength {
+		return description
+	}
+	runes := []rune(description)
+	return string(runes[:maxStatusDescriptionLength-3]) + "..."
+}
+
 // handleCheckRunResponse handles the response from GitHub check run API calls
 // It records metrics, logs rate limit information, and updates the commit status
 func (cs *CommitStatus) handleCheckRunResponse(
@@ -260,3 +382,96 @@ func phaseToCheckRunStatus(phase promoterv1alpha1.CommitStatusPhase) string {
 		return checkRunStatusQueued
 	}
 }
+
+// GetCommitChecks returns the latest check runs and classic commit statuses reported on sha. Check runs that are not
+// completed are pending, and completed check runs are successful if their conclusion is success, neutral or skipped.
+func (cs *CommitStatus) GetCommitChecks(ctx context.Context, gitRepo *promoterv1alpha1.GitRepository, sha string) ([]scms.CommitCheck, error) {
+	return listCommitChecks(ctx, cs.client, gitRepo, sha)
+}
+
+// listCommitChecks returns the latest check runs and classic commit statuses reported on sha.
+func listCommitChecks(ctx context.Context, client *github.Client, gitRepo *promoterv1alpha1.GitRepository, sha string) ([]scms.CommitCheck, error) {
+	logger := log.FromContext(ctx)
+	var checks []scms.CommitCheck
+
+	checkRunOpts := &github.ListCheckRunsOptions{
+		Filter:      github.Ptr("latest"),
+		ListOptions: github.ListOptions{PerPage: listPageSize},
+	}
+	for {
+		start := time.Now()
+		result, response, err := client.Checks.ListCheckRunsForRef(ctx, gitRepo.Spec.GitHub.Owner, gitRepo.Spec.GitHub.Name, sha, checkRunOpts)
+		if response != nil {
+			metrics.RecordSCMCall(gitRepo, metrics.SCMAPICommitStatus, metrics.SCMOperationList, response.StatusCode, time.Since(start), getRateLimitMetrics(response.Rate))
+			logger.V(4).Info("github response status", "status", response.Status)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list check runs for %q: %w", sha, err)
+		}
+		for _, checkRun := range result.CheckRuns {
+			checks = append(checks, scms.CommitCheck{
+				Name:        checkRun.GetName(),
+				Phase:       checkRunToPhase(checkRun.GetStatus(), checkRun.GetConclusion()),
+				Description: checkRun.GetOutput().GetTitle(),
+				Url:         checkRun.GetHTMLURL(),
+			})
+		}
+		if response == nil || response.NextPage == 0 {
+			break
+		}
+		checkRunOpts.Page = response.NextPage
+	}
+
+	statusOpts := &github.ListOptions{PerPage: listPageSize}
+	for {
+		start := time.Now()
+		combined, response, err := client.Repositories.GetCombinedStatus(ctx, gitRepo.Spec.GitHub.Owner, gitRepo.Spec.GitHub.Name, sha, statusOpts)
+		if response != nil {
+			metrics.RecordSCMCall(gitRepo, metrics.SCMAPICommitStatus, metrics.SCMOperationGet, response.StatusCode, time.Since(start), getRateLimitMetrics(response.Rate))
+			logger.V(4).Info("github response status", "status", response.Status)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get combined status for %q: %w", sha, err)
+		}
+		for _, status := range combined.Statuses {
+			checks = append(checks, scms.CommitCheck{
+				Name:        status.GetContext(),
+				Phase:       statusStateToPhase(status.GetState()),
+				Description: status.GetDescription(),
+				Url:         status.GetTargetURL(),
+			})
+		}
+		if response == nil || response.NextPage == 0 {
+			break
+		}
+		statusOpts.Page = response.NextPage
+	}
+
+	return checks, nil
+}
+
+// checkRunToPhase maps the status and conclusion of a GitHub check run to a CommitStatusPhase.
+func checkRunToPhase(status, conclusion string) promoterv1alpha1.CommitStatusPhase {
+	if status != checkRunStatusCompleted {
+		return promoterv1alpha1.CommitPhasePending
+	}
+	switch conclusion {
+	case "success", "neutral", "skipped":
+		return promoterv1alpha1.CommitPhaseSuccess
+	default:
+		return promoterv1alpha1.CommitPhaseFailure
+	}
+}
+
+// statusStateToPhase maps the state of a GitHub classic commit status to a CommitStatusPhase. Both the failure and
+// error states are failures.
+func statusStateToPhase(state string) promoterv1alpha1.CommitStatusPhase {
+	switch state {
+	case "success":
+		return promoterv1alpha1.CommitPhaseSuccess
+	case "pending":
+		return promoterv1alpha1.CommitPhasePending
+	default:
+		return promoterv1alpha1.CommitPhaseFailure
+	}
+}
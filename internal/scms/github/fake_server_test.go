@@ -0,0 +1,506 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+)
+
+// fakeGitHubServer serves the GitHub App endpoints used to discover installations and create installation tokens, and
+// records the check runs and classic commit statuses created on it.
+type fakeGitHubServer struct {
+	*httptest.Server
+
+	mu sync.Mutex
+	// installations maps account logins to installation IDs.
+	installations map[string]int64
+	listCalls     int
+	tokenCalls    map[int64]int
+	checkRuns     []map[string]any
+	statuses      []map[string]any
+	pullRequests  map[int]*fakePullRequest
+	// comments maps comment IDs to their bodies.
+	comments map[int64]string
+	// graphQLMutations counts the GraphQL mutations that changed the draft state of a pull request.
+	graphQLMutations int
+	// rejectReviewRequests makes requesting reviewers fail, like it does for users without access to the repository.
+	rejectReviewRequests bool
+	// mergeQueueBranches are the branches that require a merge queue.
+	mergeQueueBranches map[string]bool
+	// reportedCheckRuns and reportedStatuses are the check runs and classic commit statuses that CI reported on each
+	// commit SHA.
+	reportedCheckRuns map[string][]map[string]any
+	reportedStatuses  map[string][]map[string]any
+	// branchRules and branchProtections are the repository rules and the classic branch protection of each branch.
+	branchRules       map[string][]map[string]any
+	branchProtections map[string]map[string]any
+	// rulesets are the rulesets of the repository, by ID.
+	rulesets map[string]map[string]any
+	// repositoryAdmin is whether the credentials have admin permission on the repository.
+	repositoryAdmin bool
+	// hideBranchProtection makes reading classic branch protection fail, like it does without the Administration
+	// permission.
+	hideBranchProtection bool
+}
+
+// fakePullRequest is a pull request stored on the fake GitHub server.
+type fakePullRequest struct {
+	Number             int      `json:"number"`
+	Title              string   `json:"title"`
+	Draft              bool     `json:"draft"`
+	Base               string   `json:"-"`
+	RequestedReviewers []string `json:"-"`
+	RequestedTeams     []string `json:"-"`
+	Assignees          []string `json:"-"`
+	Labels             []string `json:"-"`
+	Merged             bool     `json:"-"`
+	// Queued is whether the pull request is in the merge queue, and QueuedSha the head it was queued for.
+	Queued    bool   `json:"-"`
+	QueuedSha string `json:"-"`
+	HeadSha   string `json:"-"`
+	// Reviews are the reviews submitted on the pull request, in chronological order.
+	Reviews []map[string]any `json:"-"`
+}
+
+// MarshalJSON renders the pull request the way the GitHub API does.
+func (p fakePullRequest) MarshalJSON() ([]byte, error) {
+	assignees := []map[string]any{}
+	for _, login := range p.Assignees {
+		assignees = append(assignees, map[string]any{"login": login})
+	}
+	requestedReviewers := []map[string]any{}
+	for _, login := range p.RequestedReviewers {
+		requestedReviewers = append(requestedReviewers, map[string]any{"login": login})
+	}
+	requestedTeams := []map[string]any{}
+	for _, slug := range p.RequestedTeams {
+		requestedTeams = append(requestedTeams, map[string]any{"slug": slug})
+	}
+	return json.Marshal(map[string]any{
+		"number":              p.Number,
+		"node_id":             fmt.Sprintf("PR_%d", p.Number),
+		"title":               p.Title,
+		"draft":               p.Draft,
+		"assignees":           assignees,
+		"labels":              p.labels(),
+		"requested_reviewers": requestedReviewers,
+		"requested_teams":     requestedTeams,
+		"head":                map[string]any{"sha": p.HeadSha},
+	})
+}
+
+func (p fakePullRequest) labels() []map[string]any {
+	labels := []map[string]any{}
+	for _, name := range p.Labels {
+		labels = append(labels, map[string]any{"name": name})
+	}
+	return labels
+}
+
+func newFakeGitHubServer(installations map[string]int64) *fakeGitHubServer {
+	f := &fakeGitHubServer{
+		installations:      installations,
+		tokenCalls:         map[int64]int{},
+		pullRequests:       map[int]*fakePullRequest{},
+		comments:           map[int64]string{},
+		mergeQueueBranches: map[string]bool{},
+		reportedCheckRuns:  map[string][]map[string]any{},
+		reportedStatuses:   map[string][]map[string]any{},
+		branchRules:        map[string][]map[string]any{},
+		branchProtections:  map[string]map[string]any{},
+		rulesets:           map[string]map[string]any{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v3/app/installations", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.listCalls++
+		result := []map[string]any{}
+		for login, id := range f.installations {
+			result = append(result, map[string]any{"id": id, "account": map[string]any{"login": login}})
+		}
+		_ = json.NewEncoder(w).Encode(result)
+	})
+	mux.HandleFunc("POST /api/v3/app/installations/{id}/access_tokens", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		var id int64
+		_, _ = fmt.Sscan(r.PathValue("id"), &id)
+		found := false
+		for _, installed := range f.installations {
+			found = found || installed == id
+		}
+		if !found {
+			http.Error(w, `{"message":"Not Found"}`, http.StatusNotFound)
+			return
+		}
+		f.tokenCalls[id]++
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"token":      fmt.Sprintf("token-%d-%d", id, f.tokenCalls[id]),
+			"expires_at": time.Now().Add(time.Hour),
+		})
+	})
+
+	mux.HandleFunc("POST /api/v3/repos/{owner}/{repo}/check-runs", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		var checkRun map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&checkRun)
+		f.checkRuns = append(f.checkRuns, checkRun)
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(map[string]any{"id": 100 + len(f.checkRuns)})
+	})
+	mux.HandleFunc("POST /api/v3/repos/{owner}/{repo}/statuses/{sha}", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		var status map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&status)
+		status["sha"] = r.PathValue("sha")
+		f.statuses = append(f.statuses, status)
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(map[string]any{"id": 200 + len(f.statuses)})
+	})
+	mux.HandleFunc("GET /api/v3/repos/{owner}/{repo}/commits/{sha}/check-runs", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		checkRuns := append([]map[string]any{}, f.reportedCheckRuns[r.PathValue("sha")]...)
+		_ = json.NewEncoder(w).Encode(map[string]any{"total_count": len(checkRuns), "check_runs": checkRuns})
+	})
+	mux.HandleFunc("GET /api/v3/repos/{owner}/{repo}/commits/{sha}/status", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		statuses := append([]map[string]any{}, f.reportedStatuses[r.PathValue("sha")]...)
+		// Like GitHub, only the latest status created for each context is returned.
+		latest := map[string]map[string]any{}
+		for i, status := range f.statuses {
+			if status["sha"] == r.PathValue("sha") {
+				latest[status["context"].(string)] = map[string]any{
+					"id": 201 + i, "context": status["context"], "state": status["state"],
+					"description": status["description"], "target_url": status["target_url"],
+				}
+			}
+		}
+		for _, status := range latest {
+			statuses = append(statuses, status)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"sha": r.PathValue("sha"), "total_count": len(statuses), "statuses": statuses})
+	})
+
+	mux.HandleFunc("GET /api/v3/repos/{owner}/{repo}/rules/branches/{branch...}", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		_ = json.NewEncoder(w).Encode(append([]map[string]any{}, f.branchRules[r.PathValue("branch")]...))
+	})
+	mux.HandleFunc("GET /api/v3/repos/{owner}/{repo}/rulesets/{id}", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		ruleset, ok := f.rulesets[r.PathValue("id")]
+		if !ok {
+			http.Error(w, `{"message":"Not Found"}`, http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(ruleset)
+	})
+	mux.HandleFunc("GET /api/v3/repos/{owner}/{repo}", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"name":        r.PathValue("repo"),
+			"permissions": map[string]bool{"admin": f.repositoryAdmin, "push": true, "pull": true},
+		})
+	})
+	mux.HandleFunc("GET /api/v3/repos/{owner}/{repo}/branches/{branch}", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		// The branch reports the required checks of its classic protection, but not the required reviews.
+		branch := map[string]any{"name": r.PathValue("branch"), "protected": false}
+		if protection, ok := f.branchProtections[r.PathValue("branch")]; ok {
+			branch["protected"] = true
+			branch["protection"] = map[string]any{"enabled": true, "required_status_checks": protection["required_status_checks"]}
+		}
+		_ = json.NewEncoder(w).Encode(branch)
+	})
+	mux.HandleFunc("GET /api/v3/repos/{owner}/{repo}/branches/{branch}/protection", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		if f.hideBranchProtection {
+			http.Error(w, `{"message":"Resource not accessible by integration"}`, http.StatusForbidden)
+			return
+		}
+		protection, ok := f.branchProtections[r.PathValue("branch")]
+		if !ok {
+			http.Error(w, `{"message":"Branch not protected"}`, http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(protection)
+	})
+
+	mux.HandleFunc("POST /api/v3/repos/{owner}/{repo}/pulls", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		var input struct {
+			Title string `json:"title"`
+			Base  string `json:"base"`
+			Draft bool   `json:"draft"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&input)
+		pr := &fakePullRequest{Number: len(f.pullRequests) + 1, Title: input.Title, Base: input.Base, Draft: input.Draft}
+		f.pullRequests[pr.Number] = pr
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(pr)
+	})
+	mux.HandleFunc("PATCH /api/v3/repos/{owner}/{repo}/pulls/{number}", f.withPullRequest(func(w http.ResponseWriter, r *http.Request, pr *fakePullRequest) {
+		var input struct {
+			Title string `json:"title"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&input)
+		pr.Title = input.Title
+		_ = json.NewEncoder(w).Encode(pr)
+	}))
+	mux.HandleFunc("GET /api/v3/repos/{owner}/{repo}/pulls/{number}", f.withPullRequest(func(w http.ResponseWriter, r *http.Request, pr *fakePullRequest) {
+		_ = json.NewEncoder(w).Encode(pr)
+	}))
+	mux.HandleFunc("GET /api/v3/repos/{owner}/{repo}/pulls/{number}/reviews", f.withPullRequest(func(w http.ResponseWriter, r *http.Request, pr *fakePullRequest) {
+		_ = json.NewEncoder(w).Encode(append([]map[string]any{}, pr.Reviews...))
+	}))
+	mux.HandleFunc("POST /api/v3/repos/{owner}/{repo}/pulls/{number}/requested_reviewers", f.withPullRequest(func(w http.ResponseWriter, r *http.Request, pr *fakePullRequest) {
+		var input struct {
+			Reviewers     []string `json:"reviewers"`
+			TeamReviewers []string `json:"team_reviewers"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&input)
+		if f.rejectReviewRequests {
+			http.Error(w, `{"message":"Reviews may only be requested from collaborators."}`, http.StatusUnprocessableEntity)
+			return
+		}
+		pr.RequestedReviewers = append(pr.RequestedReviewers, input.Reviewers...)
+		pr.RequestedTeams = append(pr.RequestedTeams, input.TeamReviewers...)
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(pr)
+	}))
+	mux.HandleFunc("POST /api/v3/repos/{owner}/{repo}/issues/{number}/assignees", f.withPullRequest(func(w http.ResponseWriter, r *http.Request, pr *fakePullRequest) {
+		var input struct {
+			Assignees []string `json:"assignees"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&input)
+		pr.Assignees = append(pr.Assignees, input.Assignees...)
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(pr)
+	}))
+	mux.HandleFunc("POST /api/v3/repos/{owner}/{repo}/issues/{number}/labels", f.withPullRequest(func(w http.ResponseWriter, r *http.Request, pr *fakePullRequest) {
+		var labels []string
+		_ = json.NewDecoder(r.Body).Decode(&labels)
+		pr.Labels = append(pr.Labels, labels...)
+		_ = json.NewEncoder(w).Encode(pr.labels())
+	}))
+	mux.HandleFunc("POST /api/v3/repos/{owner}/{repo}/issues/{number}/comments", f.withPullRequest(func(w http.ResponseWriter, r *http.Request, pr *fakePullRequest) {
+		var input struct {
+			Body string `json:"body"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&input)
+		id := int64(len(f.comments) + 1)
+		f.comments[id] = input.Body
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(map[string]any{"id": id, "body": input.Body})
+	}))
+	mux.HandleFunc("PATCH /api/v3/repos/{owner}/{repo}/issues/comments/{id}", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		var id int64
+		_, _ = fmt.Sscan(r.PathValue("id"), &id)
+		if _, ok := f.comments[id]; !ok {
+			http.Error(w, `{"message":"Not Found"}`, http.StatusNotFound)
+			return
+		}
+		var input struct {
+			Body string `json:"body"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&input)
+		f.comments[id] = input.Body
+		_ = json.NewEncoder(w).Encode(map[string]any{"id": id, "body": input.Body})
+	})
+
+	mux.HandleFunc("PUT /api/v3/repos/{owner}/{repo}/pulls/{number}/merge", f.withPullRequest(func(w http.ResponseWriter, r *http.Request, pr *fakePullRequest) {
+		if f.mergeQueueBranches[pr.Base] {
+			http.Error(w, `{"message":"Changes must be made through the merge queue"}`, http.StatusMethodNotAllowed)
+			return
+		}
+		pr.Merged = true
+		_ = json.NewEncoder(w).Encode(map[string]any{"merged": true})
+	}))
+
+	mux.HandleFunc("POST /api/graphql", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		var input struct {
+			Query     string `json:"query"`
+			Variables struct {
+				ID     string `json:"id"`
+				SHA    string `json:"sha"`
+				Number int    `json:"number"`
+				Branch string `json:"branch"`
+			} `json:"variables"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&input)
+		number := input.Variables.Number
+		if number == 0 {
+			_, _ = fmt.Sscanf(input.Variables.ID, "PR_%d", &number)
+		}
+		pr, ok := f.pullRequests[number]
+		if !ok {
+			_ = json.NewEncoder(w).Encode(map[string]any{"errors": []map[string]any{{"message": "Could not resolve to a node"}}})
+			return
+		}
+		switch {
+		case strings.Contains(input.Query, "mergeQueue(branch:"):
+			var mergeQueue, entry any
+			if f.mergeQueueBranches[input.Variables.Branch] {
+				mergeQueue = map[string]any{"id": "MQ_1"}
+			}
+			if pr.Queued {
+				entry = map[string]any{"state": "AWAITING_CHECKS", "position": 1, "enqueuedAt": "2024-01-01T00:00:00Z"}
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"repository": map[string]any{
+				"mergeQueue":  mergeQueue,
+				"pullRequest": map[string]any{"id": fmt.Sprintf("PR_%d", pr.Number), "mergeQueueEntry": entry},
+			}}})
+		case strings.Contains(input.Query, "enqueuePullRequest"):
+			pr.Queued = true
+			pr.QueuedSha = input.Variables.SHA
+			_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"enqueuePullRequest": map[string]any{"mergeQueueEntry": map[string]any{"position": 1}}}})
+		default:
+			f.graphQLMutations++
+			pr.Draft = strings.Contains(input.Query, "convertPullRequestToDraft")
+			_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{}})
+		}
+	})
+
+	f.Server = httptest.NewTLSServer(mux)
+	return f
+}
+
+func (f *fakeGitHubServer) setInstallations(installations map[string]int64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.installations = installations
+}
+
+func (f *fakeGitHubServer) counts() (int, map[int64]int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	tokenCalls := map[int64]int{}
+	for id, n := range f.tokenCalls {
+		tokenCalls[id] = n
+	}
+	return f.listCalls, tokenCalls
+}
+
+// published returns the check runs and classic commit statuses created on the server.
+func (f *fakeGitHubServer) published() ([]map[string]any, []map[string]any) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.checkRuns, f.statuses
+}
+
+// reportChecks records check runs and classic commit statuses reported by CI on sha.
+func (f *fakeGitHubServer) reportChecks(sha string, checkRuns []map[string]any, statuses []map[string]any) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.reportedCheckRuns[sha] = checkRuns
+	f.reportedStatuses[sha] = statuses
+}
+
+// withPullRequest wraps a handler for the pull request or issue {number} of the request, holding the server's lock.
+func (f *fakeGitHubServer) withPullRequest(handler func(http.ResponseWriter, *http.Request, *fakePullRequest)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		var number int
+		_, _ = fmt.Sscan(r.PathValue("number"), &number)
+		pr, ok := f.pullRequests[number]
+		if !ok {
+			http.Error(w, `{"message":"Not Found"}`, http.StatusNotFound)
+			return
+		}
+		handler(w, r, pr)
+	}
+}
+
+// requireMergeQueue makes the branch require a merge queue.
+func (f *fakeGitHubServer) requireMergeQueue(branch string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.mergeQueueBranches[branch] = true
+}
+
+// protectBranch sets the repository rules and the classic branch protection of the branch. A nil protection leaves the
+// branch without classic branch protection.
+func (f *fakeGitHubServer) protectBranch(branch string, rules []map[string]any, protection map[string]any) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.branchRules[branch] = rules
+	if protection != nil {
+		f.branchProtections[branch] = protection
+	}
+}
+
+// setRepositoryAccess sets whether the credentials have admin permission on the repository, and whether they can read
+// classic branch protection.
+func (f *fakeGitHubServer) setRepositoryAccess(admin, readBranchProtection bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.repositoryAdmin = admin
+	f.hideBranchProtection = !readBranchProtection
+}
+
+// addRuleset adds a repository ruleset that the credentials can bypass with the given mode.
+func (f *fakeGitHubServer) addRuleset(id int64, bypassMode string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.rulesets[fmt.Sprint(id)] = map[string]any{"id": id, "name": fmt.Sprintf("ruleset-%d", id), "current_user_can_bypass": bypassMode}
+}
+
+// updatePullRequest changes the pull request with the given number.
+func (f *fakeGitHubServer) updatePullRequest(number int, change func(pr *fakePullRequest)) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	change(f.pullRequests[number])
+}
+
+// setRejectReviewRequests makes requesting reviewers fail or succeed again.
+func (f *fakeGitHubServer) setRejectReviewRequests(reject bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.rejectReviewRequests = reject
+}
+
+// mutations returns the number of GraphQL mutations that changed the draft state of a pull request.
+func (f *fakeGitHubServer) mutations() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.graphQLMutations
+}
+
+// commentBodies returns a copy of the comments posted on the server, by ID.
+func (f *fakeGitHubServer) commentBodies() map[int64]string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	comments := map[int64]string{}
+	for id, body := range f.comments {
+		comments[id] = body
+	}
+	return comments
+}
+
+// pullRequest returns a copy of the pull request with the given number.
+func (f *fakeGitHubServer) pullRequest(number int) fakePullRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return *f.pullRequests[number]
+}
@@ -2,11 +2,17 @@ package github
 
 import (
 	"context"
+	"crypto/sha256"
+	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/argoproj-labs/gitops-promoter/internal/scms/httpcache"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms/ratelimit"
 	"github.com/argoproj-labs/gitops-promoter/internal/utils"
 	"github.com/bradleyfalzon/ghinstallation/v2"
 	"github.com/google/go-github/v71/github"
@@ -40,10 +46,6 @@ func NewGithubGitAuthenticationProvider(ctx context.Context, k8sClient client.Cl
 		return GitAuthenticationProvider{}, fmt.Errorf("failed to create GitHub client: %w", err)
 	}
 
-	if scmProvider.GetSpec().GitHub != nil && scmProvider.GetSpec().GitHub.Domain != "" {
-		itr.BaseURL = fmt.Sprintf("https://%s/api/v3", scmProvider.GetSpec().GitHub.Domain)
-	}
-
 	return GitAuthenticationProvider{
 		scmProvider: scmProvider,
 		transport:   itr,
@@ -62,6 +64,7 @@ func (gh GitAuthenticationProvider) GetGitHttpsRepoUrl(gitRepository v1alpha1.Gi
 func (gh GitAuthenticationProvider) GetToken(ctx context.Context) (string, error) {
 	token, err := gh.transport.Token(ctx)
 	if err != nil {
+		forgetInstallation(gh.scmProvider.GetSpec().GitHub.Domain, err)
 		return "", fmt.Errorf("failed to get GitHub token for provider %q: %w", gh.scmProvider.GetName(), err)
 	}
 	return token, nil
@@ -72,28 +75,34 @@ func (gh GitAuthenticationProvider) GetUser(ctx context.Context) (string, error)
 	return "git", nil
 }
 
+// baseTransport is the transport that GitHub App transports send requests with. It is a variable so that tests can
+// trust a TLS test server.
+var baseTransport = http.DefaultTransport
+
 // getInstallationClient creates a new GitHub client with the specified installation ID.
-// It also returns a ghinstallation.Transport, which can be used for git requests.
+// It also returns a ghinstallation.Transport, which can be used for git requests. The transport is shared by every
+// client for the same installation, so that installation tokens are reused across controllers until they expire.
 func getInstallationClient(scmProvider v1alpha1.GenericScmProvider, secret v1.Secret, id int64) (*github.Client, *ghinstallation.Transport, error) {
 	if id <= 0 {
 		return nil, nil, fmt.Errorf("installation ID is required for scmProvider %q", scmProvider.GetName())
 	}
 
-	itr, err := ghinstallation.New(http.DefaultTransport, scmProvider.GetSpec().GitHub.AppID, id, secret.Data[githubAppPrivateKeySecretKey])
+	itr, err := getInstallationTransport(scmProvider, secret, id)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to create GitHub installation transport: %w", err)
+		return nil, nil, err
 	}
 
 	enterprise, baseUrl, uploadUrl := getUrls(scmProvider.GetSpec().GitHub.Domain)
 
-	var client *github.Client
+	// Every installation has its own rate limit quota and its own access to repositories.
+	scope := strconv.FormatInt(id, 10)
+	rateLimited := ratelimit.NewTransport(secret, scope, &evictingTransport{transport: itr, domain: scmProvider.GetSpec().GitHub.Domain})
+	httpClient := &http.Client{Transport: httpcache.NewTransport(secret, scope, rateLimited)}
 	if !enterprise {
-		client = github.NewClient(&http.Client{Transport: itr})
-		return client, itr, nil
+		return github.NewClient(httpClient), itr, nil
 	}
 
-	itr.BaseURL = baseUrl
-	client, err = github.NewClient(&http.Client{Transport: itr}).WithEnterpriseURLs(baseUrl, uploadUrl)
+	client, err := github.NewClient(httpClient).WithEnterpriseURLs(baseUrl, uploadUrl)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to create GitHub enterprise client: %w", err)
 	}
@@ -109,40 +118,110 @@ func getUrls(domain string) (enterprise bool, baseUrl, uploadUrl string) {
 	return true, baseUrl, uploadUrl
 }
 
-// installationIds caches installation IDs for organizations to avoid redundant API calls.
-var installationIds = make(map[orgAppId]int64)
+// installationKey identifies the installation of a GitHub App on an account. GitHub logins are case-insensitive, so
+// the owner is always lower case.
+type installationKey struct {
+	domain string
+	appID  int64
+	owner  string
+}
 
-// orgAppId is a composite key of organization and app ID for caching installation IDs.
-type orgAppId struct {
-	org string
-	id  int64
+// transportKey identifies the transport of a GitHub App installation. The private key is part of the key so that a
+// rotated key gets a new transport.
+type transportKey struct {
+	domain         string
+	appID          int64
+	installationID int64
+	privateKeyHash [sha256.Size]byte
 }
 
-// appInstallationIdCacheMutex protects access to the installationIds map.
-var appInstallationIdCacheMutex sync.RWMutex
+var (
+	// installationIds caches installation IDs for accounts to avoid redundant API calls.
+	installationIds = make(map[installationKey]int64)
+	// appInstallationIdCacheMutex protects access to the installationIds map.
+	appInstallationIdCacheMutex sync.RWMutex
 
-// GetClient retrieves a GitHub client for the specified organization using the provided SCM provider and secret.
-// We return a client for API calls and a transport that gets used for git operations via GitAuthenticationProvider.
-func GetClient(ctx context.Context, scmProvider v1alpha1.GenericScmProvider, secret v1.Secret, org string) (*github.Client, *ghinstallation.Transport, error) {
-	logger := log.FromContext(ctx)
+	// installationTransports caches installation transports, which in turn cache installation tokens.
+	installationTransports = make(map[transportKey]*ghinstallation.Transport)
+	// installationTransportsMutex protects access to the installationTransports map.
+	installationTransportsMutex sync.Mutex
+)
 
-	itr, err := ghinstallation.NewAppsTransport(http.DefaultTransport, scmProvider.GetSpec().GitHub.AppID, secret.Data[githubAppPrivateKeySecretKey])
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to create GitHub installation transport: %w", err)
+// getInstallationTransport returns the cached transport for the installation, creating it if needed.
+func getInstallationTransport(scmProvider v1alpha1.GenericScmProvider, secret v1.Secret, id int64) (*ghinstallation.Transport, error) {
+	privateKey := secret.Data[githubAppPrivateKeySecretKey]
+	key := transportKey{
+		domain:         scmProvider.GetSpec().GitHub.Domain,
+		appID:          scmProvider.GetSpec().GitHub.AppID,
+		installationID: id,
+		privateKeyHash: sha256.Sum256(privateKey),
 	}
 
-	enterprise, baseUrl, uploadUrl := getUrls(scmProvider.GetSpec().GitHub.Domain)
+	installationTransportsMutex.Lock()
+	defer installationTransportsMutex.Unlock()
 
-	var client *github.Client
-	if !enterprise {
-		client = github.NewClient(&http.Client{Transport: itr})
-	} else {
+	if itr, found := installationTransports[key]; found {
+		return itr, nil
+	}
+
+	itr, err := ghinstallation.New(baseTransport, key.appID, id, privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GitHub installation transport: %w", err)
+	}
+	// The base URL must be set before the transport is shared, since it is read on every request.
+	if enterprise, baseUrl, _ := getUrls(key.domain); enterprise {
 		itr.BaseURL = baseUrl
-		client, err = github.NewClient(&http.Client{Transport: itr}).WithEnterpriseURLs(baseUrl, uploadUrl)
-		if err != nil {
-			return nil, nil, fmt.Errorf("failed to create GitHub enterprise client: %w", err)
+	}
+	installationTransports[key] = itr
+	return itr, nil
+}
+
+// forgetInstallation removes an installation from the caches if err shows that it no longer exists, for example
+// because the app was uninstalled and installed again. The installation is discovered again on the next request.
+func forgetInstallation(domain string, err error) {
+	var httpErr *ghinstallation.HTTPError
+	if !errors.As(err, &httpErr) || httpErr.Response == nil || httpErr.Response.StatusCode != http.StatusNotFound {
+		return
+	}
+
+	appInstallationIdCacheMutex.Lock()
+	for key, id := range installationIds {
+		if key.domain == domain && id == httpErr.InstallationID {
+			delete(installationIds, key)
 		}
 	}
+	appInstallationIdCacheMutex.Unlock()
+
+	installationTransportsMutex.Lock()
+	for key := range installationTransports {
+		if key.domain == domain && key.installationID == httpErr.InstallationID {
+			delete(installationTransports, key)
+		}
+	}
+	installationTransportsMutex.Unlock()
+}
+
+// evictingTransport forgets the installation of the wrapped transport when GitHub reports that it no longer exists.
+type evictingTransport struct {
+	transport http.RoundTripper
+	domain    string
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *evictingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil {
+		forgetInstallation(t.domain, err)
+	}
+	return resp, err //nolint:wrapcheck // A RoundTripper must return the wrapped transport's error as is.
+}
+
+// GetClient retrieves a GitHub client for the specified organization using the provided SCM provider and secret.
+// We return a client for API calls and a transport that gets used for git operations via GitAuthenticationProvider.
+// If the ScmProvider does not set an installation ID, the installation is discovered from the app's installations by
+// the account login of org, so that one ScmProvider can serve every account the app is installed on.
+func GetClient(ctx context.Context, scmProvider v1alpha1.GenericScmProvider, secret v1.Secret, org string) (*github.Client, *ghinstallation.Transport, error) {
+	logger := log.FromContext(ctx)
 
 	// If an installation ID is already provided, use it directly.
 	if scmProvider.GetSpec().GitHub.InstallationID != 0 {
@@ -150,27 +229,81 @@ func GetClient(ctx context.Context, scmProvider v1alpha1.GenericScmProvider, sec
 		return getInstallationClient(scmProvider, secret, scmProvider.GetSpec().GitHub.InstallationID)
 	}
 
+	if org == "" {
+		return nil, nil, fmt.Errorf("an owner is required to discover the installation of app %d for scmProvider %q", scmProvider.GetSpec().GitHub.AppID, scmProvider.GetName())
+	}
+	key := installationKey{
+		domain: scmProvider.GetSpec().GitHub.Domain,
+		appID:  scmProvider.GetSpec().GitHub.AppID,
+		owner:  strings.ToLower(org),
+	}
+
 	appInstallationIdCacheMutex.RLock()
-	if id, found := installationIds[orgAppId{org: org, id: scmProvider.GetSpec().GitHub.AppID}]; found {
+	if id, found := installationIds[key]; found {
 		appInstallationIdCacheMutex.RUnlock()
 		logger.V(4).Info("found cached installation ID", "org", org, "id", id, "scmProvider", scmProvider.GetName())
 		return getInstallationClient(scmProvider, secret, id)
 	}
 	appInstallationIdCacheMutex.RUnlock()
 
-	var allInstallations []*github.Installation
-	opts := &github.ListOptions{PerPage: 100}
-
-	startTime := time.Now()
 	// Cache the installation IDs, we take out a lock for the entire loop to avoid locking/unlocking repeatedly. We also include the single
 	// read within the write lock.
 	// This lock should also help with the fact that on restart we won't slam the GitHub API with multiple requests to list installations.
 	appInstallationIdCacheMutex.Lock()
+	if id, found := installationIds[key]; found {
+		appInstallationIdCacheMutex.Unlock()
+		logger.V(4).Info("found cached installation ID", "org", org, "id", id, "scmProvider", scmProvider.GetName())
+		return getInstallationClient(scmProvider, secret, id)
+	}
+
+	allInstallations, err := listInstallations(ctx, scmProvider, secret)
+	if err != nil {
+		appInstallationIdCacheMutex.Unlock()
+		return nil, nil, err
+	}
+
+	for _, installation := range allInstallations {
+		if installation.Account != nil && installation.Account.Login != nil && installation.ID != nil {
+			installationIds[installationKey{domain: key.domain, appID: key.appID, owner: strings.ToLower(*installation.Account.Login)}] = *installation.ID
+			logger.V(4).Info("cached installation ID", "org", *installation.Account.Login, "id", *installation.ID, "scmProvider", scmProvider.GetName())
+		}
+	}
+
+	if id, found := installationIds[key]; found {
+		appInstallationIdCacheMutex.Unlock()
+		logger.V(4).Info("found cached installation ID after listing installations", "org", org, "id", id, "scmProvider", scmProvider.GetName())
+		return getInstallationClient(scmProvider, secret, id)
+	}
+	appInstallationIdCacheMutex.Unlock()
+	return nil, nil, fmt.Errorf("installation of app %d not found for org: %s", scmProvider.GetSpec().GitHub.AppID, org)
+}
+
+// listInstallations lists all installations of the GitHub App, authenticating as the app.
+func listInstallations(ctx context.Context, scmProvider v1alpha1.GenericScmProvider, secret v1.Secret) ([]*github.Installation, error) {
+	logger := log.FromContext(ctx)
+
+	atr, err := ghinstallation.NewAppsTransport(baseTransport, scmProvider.GetSpec().GitHub.AppID, secret.Data[githubAppPrivateKeySecretKey])
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GitHub apps transport: %w", err)
+	}
+
+	client := github.NewClient(&http.Client{Transport: atr})
+	if enterprise, baseUrl, uploadUrl := getUrls(scmProvider.GetSpec().GitHub.Domain); enterprise {
+		atr.BaseURL = baseUrl
+		client, err = client.WithEnterpriseURLs(baseUrl, uploadUrl)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create GitHub enterprise client: %w", err)
+		}
+	}
+
+	var allInstallations []*github.Installation
+	opts := &github.ListOptions{PerPage: 100}
+
+	startTime := time.Now()
 	for {
 		installations, resp, err := client.Apps.ListInstallations(ctx, opts)
 		if err != nil {
-			appInstallationIdCacheMutex.Unlock()
-			return nil, nil, fmt.Errorf("failed to list installations: %w", err)
+			return nil, fmt.Errorf("failed to list installations: %w", err)
 		}
 
 		allInstallations = append(allInstallations, installations...)
@@ -184,18 +317,5 @@ func GetClient(ctx context.Context, scmProvider v1alpha1.GenericScmProvider, sec
 	// generic.
 	logger.Info("github list installations time", "duration", time.Since(startTime), "count", len(allInstallations))
 
-	for _, installation := range allInstallations {
-		if installation.Account != nil && installation.Account.Login != nil && installation.ID != nil {
-			installationIds[orgAppId{org: *installation.Account.Login, id: scmProvider.GetSpec().GitHub.AppID}] = *installation.ID
-			logger.V(4).Info("cached installation ID", "org", *installation.Account.Login, "id", *installation.ID, "scmProvider", scmProvider.GetName())
-		}
-	}
-
-	if id, found := installationIds[orgAppId{org: org, id: scmProvider.GetSpec().GitHub.AppID}]; found {
-		appInstallationIdCacheMutex.Unlock()
-		logger.V(4).Info("found cached installation ID after listing installations", "org", org, "id", id, "scmProvider", scmProvider.GetName())
-		return getInstallationClient(scmProvider, secret, id)
-	}
-	appInstallationIdCacheMutex.Unlock()
-	return nil, nil, fmt.Errorf("installation of app %d not found for org: %s", scmProvider.GetSpec().GitHub.AppID, org)
+	return allInstallations, nil
 }
@@ -0,0 +1,626 @@
+package github
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/go-github/v71/github"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms"
+)
+
+func newPrivateKeySecret() v1.Secret {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	Expect(err).NotTo(HaveOccurred())
+	return v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "github-app", Namespace: "default"},
+		Data: map[string][]byte{
+			githubAppPrivateKeySecretKey: pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}),
+		},
+	}
+}
+
+// useFakeGitHubServer starts a fake GitHub Enterprise server for the spec and routes GitHub App requests to it. The
+// installation caches are cleared when the spec ends.
+func useFakeGitHubServer(installations map[string]int64) *fakeGitHubServer {
+	server := newFakeGitHubServer(installations)
+	DeferCleanup(server.Close)
+
+	previousTransport := baseTransport
+	baseTransport = server.Client().Transport
+	DeferCleanup(func() {
+		baseTransport = previousTransport
+		appInstallationIdCacheMutex.Lock()
+		clear(installationIds)
+		appInstallationIdCacheMutex.Unlock()
+		installationTransportsMutex.Lock()
+		clear(installationTransports)
+		installationTransportsMutex.Unlock()
+	})
+	return server
+}
+
+var _ = Describe("GitHub App installations", func() {
+	var server *fakeGitHubServer
+	var scmProvider *v1alpha1.ScmProvider
+	var secret v1.Secret
+
+	BeforeEach(func() {
+		server = useFakeGitHubServer(map[string]int64{"Org-One": 11, "org-two": 22})
+
+		scmProvider = &v1alpha1.ScmProvider{
+			ObjectMeta: metav1.ObjectMeta{Name: "github", Namespace: "default"},
+			Spec: v1alpha1.ScmProviderSpec{
+				GitHub: &v1alpha1.GitHub{Domain: strings.TrimPrefix(server.URL, "https://"), AppID: 1234},
+			},
+		}
+		secret = newPrivateKeySecret()
+	})
+
+	It("should discover the installation of each owner and share installation tokens", func(ctx SpecContext) {
+		By("Discovering installations for several owners with a single listing")
+		_, one, err := GetClient(ctx, scmProvider, secret, "org-one")
+		Expect(err).NotTo(HaveOccurred())
+		_, two, err := GetClient(ctx, scmProvider, secret, "org-two")
+		Expect(err).NotTo(HaveOccurred())
+
+		token, err := one.Token(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(token).To(Equal("token-11-1"))
+		token, err = two.Token(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(token).To(Equal("token-22-1"))
+
+		By("Reusing the cached installation and its token")
+		_, again, err := GetClient(ctx, scmProvider, secret, "ORG-ONE")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(again).To(BeIdenticalTo(one))
+		token, err = again.Token(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(token).To(Equal("token-11-1"))
+
+		listCalls, tokenCalls := server.counts()
+		Expect(listCalls).To(Equal(1))
+		Expect(tokenCalls).To(Equal(map[int64]int{11: 1, 22: 1}))
+	})
+
+	It("should use the installation ID of the ScmProvider without listing installations", func(ctx SpecContext) {
+		scmProvider.Spec.GitHub.InstallationID = 22
+		_, itr, err := GetClient(ctx, scmProvider, secret, "org-one")
+		Expect(err).NotTo(HaveOccurred())
+		token, err := itr.Token(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(token).To(Equal("token-22-1"))
+
+		listCalls, _ := server.counts()
+		Expect(listCalls).To(BeZero())
+	})
+
+	It("should fail for an owner the app is not installed on", func(ctx SpecContext) {
+		_, _, err := GetClient(ctx, scmProvider, secret, "org-three")
+		Expect(err).To(MatchError("installation of app 1234 not found for org: org-three"))
+	})
+
+	It("should discover the installation again after the app is reinstalled", func(ctx SpecContext) {
+		_, itr, err := GetClient(ctx, scmProvider, secret, "org-one")
+		Expect(err).NotTo(HaveOccurred())
+		provider := GitAuthenticationProvider{scmProvider: scmProvider, transport: itr}
+
+		server.setInstallations(map[string]int64{"Org-One": 33})
+		_, err = provider.GetToken(ctx)
+		Expect(err).To(HaveOccurred())
+
+		_, itr, err = GetClient(ctx, scmProvider, secret, "org-one")
+		Expect(err).NotTo(HaveOccurred())
+		token, err := itr.Token(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(token).To(Equal("token-33-1"))
+
+		listCalls, _ := server.counts()
+		Expect(listCalls).To(Equal(2))
+	})
+})
+
+var _ = Describe("CommitStatus", func() {
+	var server *fakeGitHubServer
+	var scmProvider *v1alpha1.ScmProvider
+	var commitStatus *v1alpha1.CommitStatus
+	var newProvider func(ctx SpecContext) *CommitStatus
+
+	BeforeEach(func() {
+		server = useFakeGitHubServer(map[string]int64{"org-one": 11})
+		scmProvider = &v1alpha1.ScmProvider{
+			ObjectMeta: metav1.ObjectMeta{Name: "github", Namespace: "default"},
+			Spec: v1alpha1.ScmProviderSpec{
+				GitHub: &v1alpha1.GitHub{Domain: strings.TrimPrefix(server.URL, "https://"), AppID: 1234, InstallationID: 11},
+			},
+		}
+
+		gitRepo := &v1alpha1.GitRepository{
+			ObjectMeta: metav1.ObjectMeta{Name: "repo", Namespace: "default"},
+			Spec: v1alpha1.GitRepositorySpec{
+				GitHub:         &v1alpha1.GitHubRepo{Owner: "org-one", Name: "deployments"},
+				ScmProviderRef: v1alpha1.ScmProviderObjectReference{Kind: v1alpha1.ScmProviderKind, Name: "github"},
+			},
+		}
+		scheme := runtime.NewScheme()
+		Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+		k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(gitRepo).Build()
+		secret := newPrivateKeySecret()
+
+		newProvider = func(ctx SpecContext) *CommitStatus {
+			provider, err := NewGithubCommitStatusProvider(ctx, k8sClient, scmProvider, secret, "org-one")
+			Expect(err).NotTo(HaveOccurred())
+			return provider
+		}
+
+		commitStatus = &v1alpha1.CommitStatus{
+			ObjectMeta: metav1.ObjectMeta{Name: "health", Namespace: "default"},
+			Spec: v1alpha1.CommitStatusSpec{
+				RepositoryReference: v1alpha1.ObjectReference{Name: "repo"},
+				Sha:                 "0123456789abcdef0123456789abcdef01234567",
+				Name:                "argocd-health",
+				Description:         strings.Repeat("healthy ", 30),
+				Phase:               v1alpha1.CommitPhaseSuccess,
+				Url:                 "https://argocd.example.com",
+			},
+		}
+	})
+
+	It("should create check runs by default", func(ctx SpecContext) {
+		result, err := newProvider(ctx).Set(ctx, commitStatus)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Status.Id).To(Equal("101"))
+
+		checkRuns, statuses := server.published()
+		Expect(checkRuns).To(HaveLen(1))
+		Expect(checkRuns[0]).To(HaveKeyWithValue("conclusion", "success"))
+		Expect(statuses).To(BeEmpty())
+	})
+
+	It("should create classic commit statuses in statuses mode", func(ctx SpecContext) {
+		scmProvider.Spec.GitHub.CommitStatusMode = v1alpha1.GitHubCommitStatusModeStatuses
+		result, err := newProvider(ctx).Set(ctx, commitStatus)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Status.Id).To(Equal("201"))
+		Expect(result.Status.Phase).To(Equal(v1alpha1.CommitPhaseSuccess))
+		Expect(result.Status.Sha).To(Equal(commitStatus.Spec.Sha))
+
+		checkRuns, statuses := server.published()
+		Expect(checkRuns).To(BeEmpty())
+		Expect(statuses).To(HaveLen(1))
+		Expect(statuses[0]).To(HaveKeyWithValue("sha", commitStatus.Spec.Sha))
+		Expect(statuses[0]).To(HaveKeyWithValue("state", "success"))
+		Expect(statuses[0]).To(HaveKeyWithValue("context", "argocd-health"))
+		Expect(statuses[0]).To(HaveKeyWithValue("target_url", "https://argocd.example.com"))
+		Expect(statuses[0]["description"]).To(HaveLen(maxStatusDescriptionLength))
+	})
+
+	It("should not split multi-byte characters when truncating descriptions", func() {
+		description := truncateStatusDescription(strings.Repeat("ü", 200))
+		Expect(utf8.ValidString(description)).To(BeTrue())
+		Expect(utf8.RuneCountInString(description)).To(Equal(maxStatusDescriptionLength))
+		Expect(truncateStatusDescription("healthy")).To(Equal("healthy"))
+	})
+
+	It("should not create a classic commit status when the latest one is unchanged", func(ctx SpecContext) {
+		scmProvider.Spec.GitHub.CommitStatusMode = v1alpha1.GitHubCommitStatusModeStatuses
+		provider := newProvider(ctx)
+		result, err := provider.Set(ctx, commitStatus)
+		Expect(err).NotTo(HaveOccurred())
+		result, err = provider.Set(ctx, result)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Status.Id).To(Equal("201"))
+
+		_, statuses := server.published()
+		Expect(statuses).To(HaveLen(1))
+
+		By("Creating a new status when the description changes")
+		result.Spec.Description = "Degraded"
+		_, err = provider.Set(ctx, result)
+		Expect(err).NotTo(HaveOccurred())
+		_, statuses = server.published()
+		Expect(statuses).To(HaveLen(2))
+	})
+
+	It("should create both and record the check run in both mode", func(ctx SpecContext) {
+		scmProvider.Spec.GitHub.CommitStatusMode = v1alpha1.GitHubCommitStatusModeBoth
+		provider := newProvider(ctx)
+		result, err := provider.Set(ctx, commitStatus)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Status.Id).To(Equal("101"))
+
+		By("Creating a new check run when the phase changes")
+		result.Spec.Phase = v1alpha1.CommitPhaseFailure
+		result, err = provider.Set(ctx, result)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Status.Id).To(Equal("102"))
+		Expect(result.Status.Phase).To(Equal(v1alpha1.CommitPhaseFailure))
+
+		checkRuns, statuses := server.published()
+		Expect(checkRuns).To(HaveLen(2))
+		Expect(statuses).To(HaveLen(2))
+		Expect(statuses[1]).To(HaveKeyWithValue("state", "failure"))
+	})
+
+	It("should read the check runs and classic commit statuses reported on a commit", func(ctx SpecContext) {
+		sha := commitStatus.Spec.Sha
+		server.reportChecks(sha, []map[string]any{
+			{"name": "e2e", "status": "completed", "conclusion": "success", "html_url": "https://github.example.com/runs/1", "output": map[string]any{"title": "All tests passed"}},
+			{"name": "lint", "status": "completed", "conclusion": "skipped"},
+			{"name": "unit", "status": "in_progress"},
+			{"name": "build", "status": "completed", "conclusion": "timed_out"},
+		}, []map[string]any{
+			{"context": "ci/jenkins", "state": "error", "description": "Build errored", "target_url": "https://jenkins.example.com/1"},
+			{"context": "ci/deploy", "state": "pending"},
+		})
+
+		gitRepo := &v1alpha1.GitRepository{Spec: v1alpha1.GitRepositorySpec{GitHub: &v1alpha1.GitHubRepo{Owner: "org-one", Name: "deployments"}}}
+		checks, err := newProvider(ctx).GetCommitChecks(ctx, gitRepo, sha)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(checks).To(Equal([]scms.CommitCheck{
+			{Name: "e2e", Phase: v1alpha1.CommitPhaseSuccess, Description: "All tests passed", Url: "https://github.example.com/runs/1"},
+			{Name: "lint", Phase: v1alpha1.CommitPhaseSuccess},
+			{Name: "unit", Phase: v1alpha1.CommitPhasePending},
+			{Name: "build", Phase: v1alpha1.CommitPhaseFailure},
+			{Name: "ci/jenkins", Phase: v1alpha1.CommitPhaseFailure, Description: "Build errored", Url: "https://jenkins.example.com/1"},
+			{Name: "ci/deploy", Phase: v1alpha1.CommitPhasePending},
+		}))
+	})
+})
+
+var _ = Describe("PullRequest", func() {
+	var server *fakeGitHubServer
+	var provider *PullRequest
+	var prObj v1alpha1.PullRequest
+
+	BeforeEach(func() {
+		server = useFakeGitHubServer(map[string]int64{"org-one": 11})
+
+		gitRepo := &v1alpha1.GitRepository{
+			ObjectMeta: metav1.ObjectMeta{Name: "repo", Namespace: "default"},
+			Spec: v1alpha1.GitRepositorySpec{
+				GitHub:         &v1alpha1.GitHubRepo{Owner: "org-one", Name: "deployments"},
+				ScmProviderRef: v1alpha1.ScmProviderObjectReference{Kind: v1alpha1.ScmProviderKind, Name: "github"},
+			},
+		}
+		scheme := runtime.NewScheme()
+		Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		client, err := github.NewClient(server.Client()).WithEnterpriseURLs(server.URL, server.URL)
+		Expect(err).NotTo(HaveOccurred())
+		provider = &PullRequest{
+			client:    client,
+			k8sClient: fake.NewClientBuilder().WithScheme(scheme).WithObjects(gitRepo).Build(),
+		}
+
+		prObj = v1alpha1.PullRequest{
+			ObjectMeta: metav1.ObjectMeta{Name: "promote-prod", Namespace: "default"},
+			Spec: v1alpha1.PullRequestSpec{
+				RepositoryReference: v1alpha1.ObjectReference{Name: "repo"},
+				Title:               "Promote to prod",
+				SourceBranch:        "environment/prod-next",
+				TargetBranch:        "environment/prod",
+				Reviewers:           []string{"jane", "org-one/platform"},
+				Assignees:           []string{"oncall"},
+				Labels:              []string{"promotion"},
+			},
+		}
+	})
+
+	It("should request reviews, assign and label the pull request when it is opened", func(ctx SpecContext) {
+		id, err := provider.Create(ctx, prObj.Spec.Title, prObj.Spec.SourceBranch, prObj.Spec.TargetBranch, "", prObj)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(id).To(Equal("1"))
+
+		pr := server.pullRequest(1)
+		Expect(pr.RequestedReviewers).To(Equal([]string{"jane"}))
+		Expect(pr.RequestedTeams).To(Equal([]string{"platform"}))
+		Expect(pr.Assignees).To(Equal([]string{"oncall"}))
+		Expect(pr.Labels).To(Equal([]string{"promotion"}))
+	})
+
+	It("should only add missing assignees and labels on update", func(ctx SpecContext) {
+		prObj.Spec.Reviewers = nil
+		id, err := provider.Create(ctx, prObj.Spec.Title, prObj.Spec.SourceBranch, prObj.Spec.TargetBranch, "", prObj)
+		Expect(err).NotTo(HaveOccurred())
+		prObj.Status.ID = id
+
+		By("Adding a label that was added to the PromotionStrategy")
+		prObj.Spec.Labels = append(prObj.Spec.Labels, "env/prod")
+		Expect(provider.Update(ctx, prObj.Spec.Title, "", prObj)).To(Succeed())
+		Expect(provider.Update(ctx, prObj.Spec.Title, "", prObj)).To(Succeed())
+
+		pr := server.pullRequest(1)
+		Expect(pr.RequestedReviewers).To(BeEmpty())
+		Expect(pr.Assignees).To(Equal([]string{"oncall"}))
+		Expect(pr.Labels).To(Equal([]string{"promotion", "env/prod"}))
+	})
+
+	It("should open the pull request when requesting reviewers fails and request them on update", func(ctx SpecContext) {
+		server.setRejectReviewRequests(true)
+		id, err := provider.Create(ctx, prObj.Spec.Title, prObj.Spec.SourceBranch, prObj.Spec.TargetBranch, "", prObj)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(id).To(Equal("1"))
+		prObj.Status.ID = id
+
+		pr := server.pullRequest(1)
+		Expect(pr.RequestedReviewers).To(BeEmpty())
+		Expect(pr.Assignees).To(Equal([]string{"oncall"}))
+		Expect(pr.Labels).To(Equal([]string{"promotion"}))
+
+		By("Requesting the reviewers once it works again")
+		server.setRejectReviewRequests(false)
+		Expect(provider.Update(ctx, prObj.Spec.Title, "", prObj)).To(Succeed())
+		Expect(provider.Update(ctx, prObj.Spec.Title, "", prObj)).To(Succeed())
+		pr = server.pullRequest(1)
+		Expect(pr.RequestedReviewers).To(Equal([]string{"jane"}))
+		Expect(pr.RequestedTeams).To(Equal([]string{"platform"}))
+
+		By("Not requesting a review again from reviewers who reviewed")
+		server.updatePullRequest(1, func(pr *fakePullRequest) {
+			pr.RequestedReviewers = nil
+			pr.RequestedTeams = nil
+			pr.Reviews = []map[string]any{{"user": map[string]any{"login": "jane"}, "state": "APPROVED"}}
+		})
+		Expect(provider.Update(ctx, prObj.Spec.Title, "", prObj)).To(Succeed())
+		pr = server.pullRequest(1)
+		Expect(pr.RequestedReviewers).To(BeEmpty())
+		Expect(pr.RequestedTeams).To(BeEmpty())
+	})
+
+	It("should open a draft and mark it ready for review once it is no longer a draft", func(ctx SpecContext) {
+		prObj.Spec.Draft = true
+		id, err := provider.Create(ctx, prObj.Spec.Title, prObj.Spec.SourceBranch, prObj.Spec.TargetBranch, "", prObj)
+		Expect(err).NotTo(HaveOccurred())
+		prObj.Status.ID = id
+		Expect(server.pullRequest(1).Draft).To(BeTrue())
+
+		By("Leaving the draft as it is while it should stay a draft")
+		Expect(provider.Update(ctx, prObj.Spec.Title, "", prObj)).To(Succeed())
+		Expect(server.mutations()).To(BeZero())
+
+		By("Marking it ready for review once the gates pass")
+		prObj.Spec.Draft = false
+		Expect(provider.Update(ctx, prObj.Spec.Title, "", prObj)).To(Succeed())
+		Expect(provider.Update(ctx, prObj.Spec.Title, "", prObj)).To(Succeed())
+		Expect(server.pullRequest(1).Draft).To(BeFalse())
+		Expect(server.mutations()).To(Equal(1))
+
+		By("Converting it back to a draft when a new change is proposed")
+		prObj.Spec.Draft = true
+		Expect(provider.Update(ctx, prObj.Spec.Title, "", prObj)).To(Succeed())
+		Expect(server.pullRequest(1).Draft).To(BeTrue())
+	})
+
+	It("should post a comment and edit it in place", func(ctx SpecContext) {
+		id, err := provider.Create(ctx, prObj.Spec.Title, prObj.Spec.SourceBranch, prObj.Spec.TargetBranch, "", prObj)
+		Expect(err).NotTo(HaveOccurred())
+		prObj.Status.ID = id
+
+		commentID, err := provider.UpsertComment(ctx, prObj, "", "pending")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(commentID).To(Equal("1"))
+
+		commentID, err = provider.UpsertComment(ctx, prObj, commentID, "success")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(commentID).To(Equal("1"))
+		Expect(server.commentBodies()).To(Equal(map[int64]string{1: "success"}))
+
+		By("Reporting a deleted comment as not found")
+		_, err = provider.UpsertComment(ctx, prObj, "42", "failure")
+		Expect(err).To(MatchError(scms.ErrCommentNotFound))
+	})
+
+	It("should merge directly when the branch does not require a merge queue", func(ctx SpecContext) {
+		id, err := provider.Create(ctx, prObj.Spec.Title, prObj.Spec.SourceBranch, prObj.Spec.TargetBranch, "", prObj)
+		Expect(err).NotTo(HaveOccurred())
+		prObj.Status.ID = id
+
+		Expect(provider.Merge(ctx, prObj)).To(Succeed())
+		Expect(server.pullRequest(1).Merged).To(BeTrue())
+	})
+
+	It("should add the pull request to the merge queue when the branch requires one", func(ctx SpecContext) {
+		server.requireMergeQueue(prObj.Spec.TargetBranch)
+		id, err := provider.Create(ctx, prObj.Spec.Title, prObj.Spec.SourceBranch, prObj.Spec.TargetBranch, "", prObj)
+		Expect(err).NotTo(HaveOccurred())
+		prObj.Status.ID = id
+		prObj.Spec.MergeSha = "0123456789abcdef0123456789abcdef01234567"
+
+		status, err := provider.GetMergeQueueStatus(ctx, prObj)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status).To(BeNil())
+
+		err = provider.Merge(ctx, prObj)
+		Expect(err).To(MatchError(scms.ErrMergeQueued))
+		Expect(err).To(MatchError(scms.ErrMergeScheduled))
+		pr := server.pullRequest(1)
+		Expect(pr.Merged).To(BeFalse())
+		Expect(pr.Queued).To(BeTrue())
+		Expect(pr.QueuedSha).To(Equal(prObj.Spec.MergeSha))
+
+		By("Reporting its entry in the merge queue")
+		status, err = provider.GetMergeQueueStatus(ctx, prObj)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status.State).To(Equal(v1alpha1.MergeQueueQueued))
+		Expect(status.Position).To(Equal(1))
+		Expect(status.Message).To(Equal("The merge queue entry is awaiting checks"))
+
+		By("Not adding it again while it is queued")
+		server.updatePullRequest(1, func(pr *fakePullRequest) { pr.QueuedSha = "" })
+		Expect(provider.Merge(ctx, prObj)).To(MatchError(scms.ErrMergeQueued))
+		Expect(server.pullRequest(1).QueuedSha).To(BeEmpty())
+	})
+
+	It("should not report branch protection for an unprotected branch", func(ctx SpecContext) {
+		id, err := provider.Create(ctx, prObj.Spec.Title, prObj.Spec.SourceBranch, prObj.Spec.TargetBranch, "", prObj)
+		Expect(err).NotTo(HaveOccurred())
+		prObj.Status.ID = id
+
+		protection, err := provider.GetBranchProtection(ctx, prObj)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(protection).To(BeNil())
+	})
+
+	It("should report the unmet requirements of the rulesets and classic protection of the target branch", func(ctx SpecContext) {
+		const headSha = "0123456789abcdef0123456789abcdef01234567"
+		server.protectBranch(prObj.Spec.TargetBranch,
+			[]map[string]any{
+				{"type": "required_status_checks", "parameters": map[string]any{
+					"required_status_checks": []map[string]any{{"context": "e2e"}, {"context": "lint"}},
+				}},
+				{"type": "pull_request", "parameters": map[string]any{"required_approving_review_count": 1}},
+			},
+			map[string]any{
+				"required_status_checks":        map[string]any{"strict": true, "contexts": []string{"e2e", "security/scan"}},
+				"required_pull_request_reviews": map[string]any{"required_approving_review_count": 2},
+				"enforce_admins":                map[string]any{"enabled": true},
+			})
+		server.reportChecks(headSha,
+			[]map[string]any{
+				{"name": "e2e", "status": "completed", "conclusion": "success"},
+				{"name": "lint", "status": "in_progress"},
+			},
+			[]map[string]any{{"context": "security/scan", "state": "success"}})
+		id, err := provider.Create(ctx, prObj.Spec.Title, prObj.Spec.SourceBranch, prObj.Spec.TargetBranch, "", prObj)
+		Expect(err).NotTo(HaveOccurred())
+		prObj.Status.ID = id
+		server.updatePullRequest(1, func(pr *fakePullRequest) {
+			pr.HeadSha = headSha
+			pr.Reviews = []map[string]any{
+				{"user": map[string]any{"login": "jane"}, "state": "APPROVED"},
+				{"user": map[string]any{"login": "jane"}, "state": "COMMENTED"},
+				{"user": map[string]any{"login": "joe"}, "state": "APPROVED"},
+				{"user": map[string]any{"login": "joe"}, "state": "CHANGES_REQUESTED"},
+			}
+		})
+
+		protection, err := provider.GetBranchProtection(ctx, prObj)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(protection).To(Equal(&scms.BranchProtection{
+			RequiredChecks:    []string{"e2e", "lint", "security/scan"},
+			UnmetChecks:       []string{"lint"},
+			RequiredApprovals: 2,
+			Approvals:         1,
+		}))
+		Expect(protection.Satisfied()).To(BeFalse())
+		Expect(protection.Message()).To(Equal("Branch protection is not satisfied: required checks have not succeeded: lint; 1 of 2 required approvals were given"))
+
+		By("Meeting the requirements")
+		server.reportChecks(headSha,
+			[]map[string]any{
+				{"name": "e2e", "status": "completed", "conclusion": "success"},
+				{"name": "lint", "status": "completed", "conclusion": "skipped"},
+			},
+			[]map[string]any{{"context": "security/scan", "state": "success"}})
+		server.updatePullRequest(1, func(pr *fakePullRequest) {
+			pr.Reviews = append(pr.Reviews, map[string]any{"user": map[string]any{"login": "joe"}, "state": "APPROVED"})
+		})
+		protection, err = provider.GetBranchProtection(ctx, prObj)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(protection.Satisfied()).To(BeTrue())
+	})
+
+	It("should leave out requirements that the credentials can bypass", func(ctx SpecContext) {
+		server.setRepositoryAccess(true, true)
+		server.addRuleset(1, "never")
+		server.addRuleset(2, "always")
+		server.addRuleset(3, "pull_request")
+		server.protectBranch(prObj.Spec.TargetBranch,
+			[]map[string]any{
+				{"type": "required_status_checks", "ruleset_id": 1, "parameters": map[string]any{
+					"required_status_checks": []map[string]any{{"context": "e2e"}},
+				}},
+				{"type": "required_status_checks", "ruleset_id": 2, "parameters": map[string]any{
+					"required_status_checks": []map[string]any{{"context": "lint"}},
+				}},
+				{"type": "pull_request", "ruleset_id": 3, "parameters": map[string]any{"required_approving_review_count": 1}},
+			},
+			map[string]any{
+				"required_pull_request_reviews": map[string]any{"required_approving_review_count": 2},
+				"enforce_admins":                map[string]any{"enabled": false},
+			})
+		id, err := provider.Create(ctx, prObj.Spec.Title, prObj.Spec.SourceBranch, prObj.Spec.TargetBranch, "", prObj)
+		Expect(err).NotTo(HaveOccurred())
+		prObj.Status.ID = id
+		prObj.Spec.MergeSha = "0123456789abcdef0123456789abcdef01234567"
+
+		protection, err := provider.GetBranchProtection(ctx, prObj)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(protection).To(Equal(&scms.BranchProtection{
+			RequiredChecks: []string{"e2e"},
+			UnmetChecks:    []string{"e2e"},
+		}))
+
+		By("Not protecting the branch when every requirement can be bypassed")
+		server.addRuleset(1, "always")
+		protection, err = provider.GetBranchProtection(ctx, prObj)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(protection).To(BeNil())
+	})
+
+	It("should enforce classic protection that is not enforced for administrators on credentials that are not", func(ctx SpecContext) {
+		server.setRepositoryAccess(false, true)
+		server.protectBranch(prObj.Spec.TargetBranch, nil, map[string]any{
+			"required_status_checks":        map[string]any{"strict": true, "contexts": []string{"e2e"}},
+			"required_pull_request_reviews": map[string]any{"required_approving_review_count": 1},
+			"enforce_admins":                map[string]any{"enabled": false},
+		})
+		id, err := provider.Create(ctx, prObj.Spec.Title, prObj.Spec.SourceBranch, prObj.Spec.TargetBranch, "", prObj)
+		Expect(err).NotTo(HaveOccurred())
+		prObj.Status.ID = id
+		prObj.Spec.MergeSha = "0123456789abcdef0123456789abcdef01234567"
+
+		protection, err := provider.GetBranchProtection(ctx, prObj)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(protection).To(Equal(&scms.BranchProtection{
+			RequiredChecks:    []string{"e2e"},
+			UnmetChecks:       []string{"e2e"},
+			RequiredApprovals: 1,
+		}))
+
+		By("Leaving it out for administrators")
+		server.setRepositoryAccess(true, true)
+		protection, err = provider.GetBranchProtection(ctx, prObj)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(protection).To(BeNil())
+	})
+
+	It("should read the required checks from the branch when classic protection is not readable", func(ctx SpecContext) {
+		server.setRepositoryAccess(false, false)
+		server.protectBranch(prObj.Spec.TargetBranch, nil, map[string]any{
+			"required_status_checks": map[string]any{"strict": true, "checks": []map[string]any{{"context": "e2e"}}},
+			"enforce_admins":         map[string]any{"enabled": true},
+		})
+		id, err := provider.Create(ctx, prObj.Spec.Title, prObj.Spec.SourceBranch, prObj.Spec.TargetBranch, "", prObj)
+		Expect(err).NotTo(HaveOccurred())
+		prObj.Status.ID = id
+		prObj.Spec.MergeSha = "0123456789abcdef0123456789abcdef01234567"
+
+		protection, err := provider.GetBranchProtection(ctx, prObj)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(protection).To(Equal(&scms.BranchProtection{
+			RequiredChecks: []string{"e2e"},
+			UnmetChecks:    []string{"e2e"},
+		}))
+	})
+
+	It("should build the GraphQL URL for GitHub and GitHub Enterprise Server", func() {
+		Expect(graphQLURL(github.NewClient(nil).BaseURL)).To(Equal("https://api.github.com/graphql"))
+		client, err := github.NewClient(nil).WithEnterpriseURLs("https://github.example.com", "https://github.example.com")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(graphQLURL(client.BaseURL)).To(Equal("https://github.example.com/api/graphql"))
+	})
+})
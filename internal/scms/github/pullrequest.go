@@ -2,12 +2,19 @@ package github
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/go-github/v71/github"
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
@@ -23,7 +30,12 @@ type PullRequest struct {
 	k8sClient client.Client
 }
 
-var _ scms.PullRequestProvider = &PullRequest{}
+var (
+	_ scms.PullRequestProvider           = &PullRequest{}
+	_ scms.PullRequestMergeQueueProvider = &PullRequest{}
+
+	_ scms.PullRequestBranchProtectionProvider = &PullRequest{}
+)
 
 // NewGithubPullRequestProvider creates a new instance of PullRequest for GitHub.
 func NewGithubPullRequestProvider(ctx context.Context, k8sClient client.Client, scmProvider v1alpha1.GenericScmProvider, secret v1.Secret, org string) (*PullRequest, error) {
@@ -47,6 +59,7 @@ func (pr *PullRequest) Create(ctx context.Context, title, head, base, descriptio
 		Head:  github.Ptr(head),
 		Base:  github.Ptr(base),
 		Body:  github.Ptr(description),
+		Draft: github.Ptr(pullRequest.Spec.Draft),
 	}
 
 	gitRepo, err := utils.GetGitRepositoryFromObjectKey(ctx, pr.k8sClient, client.ObjectKey{Namespace: pullRequest.Namespace, Name: pullRequest.Spec.RepositoryReference.Name})
@@ -69,6 +82,17 @@ func (pr *PullRequest) Create(ctx context.Context, title, head, base, descriptio
 		"url", response.Request.URL)
 	logger.Info("github response status", "status", response.Status)
 
+	// GitHub does not accept reviewers, assignees or labels when opening a pull request, so they are added afterwards.
+	// The pull request is open at this point, so failures are only logged: returning an error would lose its number
+	// and open a duplicate on the next reconcile. Update adds whatever is still missing.
+	users, teams := scms.SplitReviewers(pullRequest.Spec.Reviewers)
+	if err := pr.requestReviewers(ctx, gitRepo, *githubPullRequest.Number, users, teams); err != nil {
+		logger.Error(err, "failed to request reviewers on new pull request, retrying on update", "number", *githubPullRequest.Number)
+	}
+	if err := pr.addAssigneesAndLabels(ctx, gitRepo, githubPullRequest, pullRequest); err != nil {
+		logger.Error(err, "failed to add assignees and labels to new pull request, retrying on update", "number", *githubPullRequest.Number)
+	}
+
 	return strconv.Itoa(*githubPullRequest.Number), nil
 }
 
@@ -92,7 +116,7 @@ func (pr *PullRequest) Update(ctx context.Context, title, description string, pu
 	}
 
 	start := time.Now()
-	_, response, err := pr.client.PullRequests.Edit(ctx, gitRepo.Spec.GitHub.Owner, gitRepo.Spec.GitHub.Name, prNumber, newPR)
+	githubPullRequest, response, err := pr.client.PullRequests.Edit(ctx, gitRepo.Spec.GitHub.Owner, gitRepo.Spec.GitHub.Name, prNumber, newPR)
 	if response != nil {
 		metrics.RecordSCMCall(gitRepo, metrics.SCMAPIPullRequest, metrics.SCMOperationUpdate, response.StatusCode, time.Since(start), getRateLimitMetrics(response.Rate))
 	}
@@ -107,6 +131,167 @@ func (pr *PullRequest) Update(ctx context.Context, title, description string, pu
 	logger.V(4).Info("github response status",
 		"status", response.Status)
 
+	if githubPullRequest.GetDraft() != pullRequest.Spec.Draft {
+		if err := pr.setDraft(ctx, gitRepo, githubPullRequest, pullRequest.Spec.Draft); err != nil {
+			return err
+		}
+		logger.Info("changed pull request draft state", "number", prNumber, "draft", pullRequest.Spec.Draft)
+	}
+
+	// Add the reviewers, assignees and labels that are missing, for example because they were configured after the
+	// pull request was opened or because adding them failed when it was opened.
+	if err := pr.requestMissingReviewers(ctx, gitRepo, githubPullRequest, pullRequest.Spec.Reviewers); err != nil {
+		return err
+	}
+	return pr.addAssigneesAndLabels(ctx, gitRepo, githubPullRequest, pullRequest)
+}
+
+// setDraft converts the pull request to a draft or marks it ready for review. The REST API cannot change the draft
+// state of a pull request, so this uses the GraphQL API.
+func (pr *PullRequest) setDraft(ctx context.Context, gitRepo *v1alpha1.GitRepository, githubPullRequest *github.PullRequest, draft bool) error {
+	mutation := "markPullRequestReadyForReview"
+	if draft {
+		mutation = "convertPullRequestToDraft"
+	}
+	query := fmt.Sprintf("mutation($id: ID!) { %s(input: {pullRequestId: $id}) { clientMutationId } }", mutation)
+	if err := pr.graphQL(ctx, gitRepo, metrics.SCMOperationUpdate, query, map[string]any{"id": githubPullRequest.GetNodeID()}, nil); err != nil {
+		return fmt.Errorf("failed to %s pull request #%d: %w", mutation, githubPullRequest.GetNumber(), err)
+	}
+	return nil
+}
+
+// graphQL sends a GraphQL query or mutation and decodes the data of the response into data, if it is not nil.
+func (pr *PullRequest) graphQL(ctx context.Context, gitRepo *v1alpha1.GitRepository, operation metrics.SCMOperation, query string, variables map[string]any, data any) error {
+	req, err := pr.client.NewRequest(http.MethodPost, graphQLURL(pr.client.BaseURL), map[string]any{"query": query, "variables": variables})
+	if err != nil {
+		return fmt.Errorf("failed to create GraphQL request: %w", err)
+	}
+	var result struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+
+	start := time.Now()
+	response, err := pr.client.Do(ctx, req, &result)
+	if response != nil {
+		metrics.RecordSCMCall(gitRepo, metrics.SCMAPIPullRequest, operation, response.StatusCode, time.Since(start), getRateLimitMetrics(response.Rate))
+	}
+	if err != nil {
+		return err //nolint:wrapcheck // Error wrapping handled by the caller
+	}
+	if len(result.Errors) > 0 {
+		return errors.New(result.Errors[0].Message)
+	}
+	if data != nil && len(result.Data) > 0 {
+		if err := json.Unmarshal(result.Data, data); err != nil {
+			return fmt.Errorf("failed to decode GraphQL response: %w", err)
+		}
+	}
+	return nil
+}
+
+// graphQLURL returns the URL of the GraphQL API for the REST API base URL: https://api.github.com/graphql for GitHub,
+// and https://<host>/api/graphql for GitHub Enterprise Server, whose REST API is served under /api/v3/.
+func graphQLURL(baseURL *url.URL) string {
+	graphQL := *baseURL
+	if strings.HasSuffix(graphQL.Path, "/api/v3/") {
+		graphQL.Path = strings.TrimSuffix(graphQL.Path, "v3/") + "graphql"
+	} else {
+		graphQL.Path = strings.TrimSuffix(graphQL.Path, "/") + "/graphql"
+	}
+	return graphQL.String()
+}
+
+// requestReviewers requests a review from the users and teams on the pull request. Teams are given by their slug.
+func (pr *PullRequest) requestReviewers(ctx context.Context, gitRepo *v1alpha1.GitRepository, prNumber int, users, teams []string) error {
+	if len(users) == 0 && len(teams) == 0 {
+		return nil
+	}
+
+	start := time.Now()
+	_, response, err := pr.client.PullRequests.RequestReviewers(ctx, gitRepo.Spec.GitHub.Owner, gitRepo.Spec.GitHub.Name, prNumber, github.ReviewersRequest{
+		Reviewers:     users,
+		TeamReviewers: teams,
+	})
+	if response != nil {
+		metrics.RecordSCMCall(gitRepo, metrics.SCMAPIPullRequest, metrics.SCMOperationUpdate, response.StatusCode, time.Since(start), getRateLimitMetrics(response.Rate))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to request reviewers on pull request #%d: %w", prNumber, err)
+	}
+	return nil
+}
+
+// requestMissingReviewers requests a review from the users and "<organization>/<team>" teams whose review is neither
+// requested nor given. GitHub removes a user or team from the requested reviewers once they review, so users are
+// checked against the reviews of the pull request, and teams are only requested again while it has no reviews.
+func (pr *PullRequest) requestMissingReviewers(ctx context.Context, gitRepo *v1alpha1.GitRepository, githubPullRequest *github.PullRequest, reviewers []string) error {
+	users, teams := scms.SplitReviewers(reviewers)
+	users = slices.DeleteFunc(users, func(login string) bool {
+		return slices.ContainsFunc(githubPullRequest.RequestedReviewers, func(user *github.User) bool { return strings.EqualFold(user.GetLogin(), login) })
+	})
+	teams = slices.DeleteFunc(teams, func(slug string) bool {
+		return slices.ContainsFunc(githubPullRequest.RequestedTeams, func(team *github.Team) bool { return strings.EqualFold(team.GetSlug(), slug) })
+	})
+	if len(users) == 0 && len(teams) == 0 {
+		return nil
+	}
+
+	reviews, err := pr.listReviews(ctx, gitRepo, githubPullRequest.GetNumber())
+	if err != nil {
+		return err
+	}
+	users = slices.DeleteFunc(users, func(login string) bool {
+		return slices.ContainsFunc(reviews, func(review *github.PullRequestReview) bool {
+			return strings.EqualFold(review.GetUser().GetLogin(), login)
+		})
+	})
+	if len(reviews) > 0 {
+		teams = nil
+	}
+	return pr.requestReviewers(ctx, gitRepo, githubPullRequest.GetNumber(), users, teams)
+}
+
+// addAssigneesAndLabels adds the assignees and labels of the PullRequest that the pull request on GitHub does not have
+// yet. Assignees and labels added by others are left in place.
+func (pr *PullRequest) addAssigneesAndLabels(ctx context.Context, gitRepo *v1alpha1.GitRepository, githubPullRequest *github.PullRequest, pullRequest v1alpha1.PullRequest) error {
+	prNumber := githubPullRequest.GetNumber()
+
+	var assignees []string
+	for _, assignee := range pullRequest.Spec.Assignees {
+		if !slices.ContainsFunc(githubPullRequest.Assignees, func(user *github.User) bool { return strings.EqualFold(user.GetLogin(), assignee) }) {
+			assignees = append(assignees, assignee)
+		}
+	}
+	if len(assignees) > 0 {
+		start := time.Now()
+		_, response, err := pr.client.Issues.AddAssignees(ctx, gitRepo.Spec.GitHub.Owner, gitRepo.Spec.GitHub.Name, prNumber, assignees)
+		if response != nil {
+			metrics.RecordSCMCall(gitRepo, metrics.SCMAPIPullRequest, metrics.SCMOperationUpdate, response.StatusCode, time.Since(start), getRateLimitMetrics(response.Rate))
+		}
+		if err != nil {
+			return fmt.Errorf("failed to add assignees to pull request #%d: %w", prNumber, err)
+		}
+	}
+
+	var labels []string
+	for _, label := range pullRequest.Spec.Labels {
+		if !slices.ContainsFunc(githubPullRequest.Labels, func(existing *github.Label) bool { return strings.EqualFold(existing.GetName(), label) }) {
+			labels = append(labels, label)
+		}
+	}
+	if len(labels) > 0 {
+		start := time.Now()
+		_, response, err := pr.client.Issues.AddLabelsToIssue(ctx, gitRepo.Spec.GitHub.Owner, gitRepo.Spec.GitHub.Name, prNumber, labels)
+		if response != nil {
+			metrics.RecordSCMCall(gitRepo, metrics.SCMAPIPullRequest, metrics.SCMOperationUpdate, response.StatusCode, time.Since(start), getRateLimitMetrics(response.Rate))
+		}
+		if err != nil {
+			return fmt.Errorf("failed to add labels to pull request #%d: %w", prNumber, err)
+		}
+	}
 	return nil
 }
 
@@ -147,7 +332,8 @@ func (pr *PullRequest) Close(ctx context.Context, pullRequest v1alpha1.PullReque
 	return nil
 }
 
-// Merge merges an existing pull request with the specified commit message.
+// Merge merges an existing pull request with the specified commit message. If the target branch requires a merge
+// queue, the pull request is added to the queue instead and scms.ErrMergeQueued is returned.
 func (pr *PullRequest) Merge(ctx context.Context, pullRequest v1alpha1.PullRequest) error {
 	logger := log.FromContext(ctx)
 
@@ -160,6 +346,23 @@ func (pr *PullRequest) Merge(ctx context.Context, pullRequest v1alpha1.PullReque
 		return fmt.Errorf("failed to get GitRepository: %w", err)
 	}
 
+	queue, err := pr.getMergeQueue(ctx, gitRepo, pullRequest.Spec.TargetBranch, prNumber)
+	if err != nil {
+		// GitHub Enterprise Server versions without merge queues reject the query. Merging directly is refused anyway
+		// if the branch requires a merge queue.
+		logger.Info("failed to look up the merge queue, merging directly", "error", err.Error())
+	}
+	if queue.required {
+		// The merge queue merges the pull request with the merge method and commit message configured for the queue.
+		if queue.entry == nil {
+			if err := pr.enqueue(ctx, gitRepo, prNumber, queue.pullRequestID, pullRequest.Spec.MergeSha); err != nil {
+				return err
+			}
+			logger.Info("pull request added to the merge queue", "pullRequest", prNumber, "branch", pullRequest.Spec.TargetBranch)
+		}
+		return scms.ErrMergeQueued
+	}
+
 	start := time.Now()
 	_, response, err := pr.client.PullRequests.Merge(
 		ctx,
@@ -189,6 +392,385 @@ func (pr *PullRequest) Merge(ctx context.Context, pullRequest v1alpha1.PullReque
 	return nil
 }
 
+// mergeQueueQuery looks up whether a branch requires a merge queue, and the node ID and merge queue entry of a pull
+// request. The REST API has no merge queue endpoints.
+const mergeQueueQuery = `query($owner: String!, $name: String!, $number: Int!, $branch: String!) {
+  repository(owner: $owner, name: $name) {
+    mergeQueue(branch: $branch) { id }
+    pullRequest(number: $number) { id mergeQueueEntry { state position enqueuedAt } }
+  }
+}`
+
+// mergeQueue is the merge queue of a pull request's target branch.
+type mergeQueue struct {
+	// required is whether the target branch requires a merge queue.
+	required bool
+	// pullRequestID is the GraphQL node ID of the pull request.
+	pullRequestID string
+	// entry is the pull request's entry in the merge queue, or nil if it is not queued.
+	entry *mergeQueueEntry
+}
+
+// mergeQueueEntry is a pull request's entry in a merge queue.
+type mergeQueueEntry struct {
+	State      string    `json:"state"`
+	Position   int       `json:"position"`
+	EnqueuedAt time.Time `json:"enqueuedAt"`
+}
+
+// getMergeQueue returns the merge queue of the pull request's target branch.
+func (pr *PullRequest) getMergeQueue(ctx context.Context, gitRepo *v1alpha1.GitRepository, branch string, prNumber int) (mergeQueue, error) {
+	var data struct {
+		Repository struct {
+			MergeQueue *struct {
+				ID string `json:"id"`
+			} `json:"mergeQueue"`
+			PullRequest struct {
+				ID              string           `json:"id"`
+				MergeQueueEntry *mergeQueueEntry `json:"mergeQueueEntry"`
+			} `json:"pullRequest"`
+		} `json:"repository"`
+	}
+	variables := map[string]any{
+		"owner":  gitRepo.Spec.GitHub.Owner,
+		"name":   gitRepo.Spec.GitHub.Name,
+		"number": prNumber,
+		"branch": branch,
+	}
+	if err := pr.graphQL(ctx, gitRepo, metrics.SCMOperationGet, mergeQueueQuery, variables, &data); err != nil {
+		return mergeQueue{}, fmt.Errorf("failed to get merge queue of pull request #%d: %w", prNumber, err)
+	}
+	return mergeQueue{
+		required:      data.Repository.MergeQueue != nil,
+		pullRequestID: data.Repository.PullRequest.ID,
+		entry:         data.Repository.PullRequest.MergeQueueEntry,
+	}, nil
+}
+
+// enqueue adds the pull request to the merge queue of its target branch, provided its head is still mergeSha.
+func (pr *PullRequest) enqueue(ctx context.Context, gitRepo *v1alpha1.GitRepository, prNumber int, pullRequestID, mergeSha string) error {
+	const mutation = `mutation($id: ID!, $sha: GitObjectID) {
+  enqueuePullRequest(input: {pullRequestId: $id, expectedHeadOid: $sha}) { mergeQueueEntry { position } }
+}`
+	variables := map[string]any{"id": pullRequestID, "sha": nil}
+	if mergeSha != "" {
+		variables["sha"] = mergeSha
+	}
+	if err := pr.graphQL(ctx, gitRepo, metrics.SCMOperationMerge, mutation, variables, nil); err != nil {
+		return fmt.Errorf("failed to add pull request #%d to the merge queue: %w", prNumber, err)
+	}
+	return nil
+}
+
+// GetMergeQueueStatus returns the state of the pull request in the merge queue of its target branch.
+func (pr *PullRequest) GetMergeQueueStatus(ctx context.Context, pullRequest v1alpha1.PullRequest) (*v1alpha1.PullRequestMergeQueueStatus, error) {
+	prNumber, err := strconv.Atoi(pullRequest.Status.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert PR number to int: %w", err)
+	}
+	gitRepo, err := utils.GetGitRepositoryFromObjectKey(ctx, pr.k8sClient, client.ObjectKey{Namespace: pullRequest.Namespace, Name: pullRequest.Spec.RepositoryReference.Name})
+	if err != nil || gitRepo == nil {
+		return nil, fmt.Errorf("failed to get GitRepository: %w", err)
+	}
+
+	queue, err := pr.getMergeQueue(ctx, gitRepo, pullRequest.Spec.TargetBranch, prNumber)
+	if err != nil || queue.entry == nil {
+		return nil, err
+	}
+	return &v1alpha1.PullRequestMergeQueueStatus{
+		State:      v1alpha1.MergeQueueQueued,
+		Position:   queue.entry.Position,
+		EnqueuedAt: metav1.NewTime(queue.entry.EnqueuedAt),
+		// The entry state is one of AWAITING_CHECKS, LOCKED, MERGEABLE, QUEUED and UNMERGEABLE.
+		Message: "The merge queue entry is " + strings.ReplaceAll(strings.ToLower(queue.entry.State), "_", " "),
+	}, nil
+}
+
+// GetBranchProtection returns the required checks and approving reviews of the pull request's target branch, from both
+// its classic branch protection and the repository rulesets that apply to it, and which of them the pull request meets.
+func (pr *PullRequest) GetBranchProtection(ctx context.Context, pullRequest v1alpha1.PullRequest) (*scms.BranchProtection, error) {
+	prNumber, err := strconv.Atoi(pullRequest.Status.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert PR number to int: %w", err)
+	}
+	gitRepo, err := utils.GetGitRepositoryFromObjectKey(ctx, pr.k8sClient, client.ObjectKey{Namespace: pullRequest.Namespace, Name: pullRequest.Spec.RepositoryReference.Name})
+	if err != nil || gitRepo == nil {
+		return nil, fmt.Errorf("failed to get GitRepository: %w", err)
+	}
+
+	protection, err := pr.getBranchRequirements(ctx, gitRepo, pullRequest.Spec.TargetBranch)
+	if err != nil || protection == nil {
+		return nil, err
+	}
+
+	if len(protection.RequiredChecks) > 0 {
+		sha := pullRequest.Spec.MergeSha
+		if sha == "" {
+			if sha, err = pr.getHeadSha(ctx, gitRepo, prNumber); err != nil {
+				return nil, err
+			}
+		}
+		checks, err := listCommitChecks(ctx, pr.client, gitRepo, sha)
+		if err != nil {
+			return nil, err
+		}
+		protection.UnmetChecks = unmetChecks(protection.RequiredChecks, checks)
+	}
+	if protection.RequiredApprovals > 0 {
+		if protection.Approvals, err = pr.countApprovals(ctx, gitRepo, prNumber); err != nil {
+			return nil, err
+		}
+	}
+	return protection, nil
+}
+
+// getBranchRequirements returns the required checks and approving reviews of a branch, or nil if neither its classic
+// branch protection nor a repository ruleset requires any. Requirements that GitHub would let the credentials bypass
+// when merging are left out: rulesets that the credentials can bypass, and classic branch protection that is not
+// enforced for administrators if the credentials are one. Reading classic branch protection needs administration
+// permission on the repository; without it, the required checks are read from the branch instead.
+func (pr *PullRequest) getBranchRequirements(ctx context.Context, gitRepo *v1alpha1.GitRepository, branch string) (*scms.BranchProtection, error) {
+	logger := log.FromContext(ctx)
+	protected := false
+	protection := &scms.BranchProtection{}
+
+	start := time.Now()
+	rules, response, err := pr.client.Repositories.GetRulesForBranch(ctx, gitRepo.Spec.GitHub.Owner, gitRepo.Spec.GitHub.Name, branch)
+	if response != nil {
+		metrics.RecordSCMCall(gitRepo, metrics.SCMAPIPullRequest, metrics.SCMOperationGet, response.StatusCode, time.Since(start), getRateLimitMetrics(response.Rate))
+	}
+	switch {
+	case err != nil && response != nil && response.StatusCode == http.StatusNotFound:
+		// GitHub Enterprise Server versions without repository rulesets.
+		logger.V(4).Info("repository rules are not available", "branch", branch)
+	case err != nil:
+		return nil, fmt.Errorf("failed to get the rules of branch %q: %w", branch, err)
+	case rules != nil:
+		// Several rules usually come from the same ruleset, so each ruleset is only read once.
+		bypass := map[int64]bool{}
+		canBypass := func(rulesetID int64) (bool, error) {
+			if skip, ok := bypass[rulesetID]; ok {
+				return skip, nil
+			}
+			skip, err := pr.canBypassRuleset(ctx, gitRepo, rulesetID)
+			if err != nil {
+				return false, err
+			}
+			bypass[rulesetID] = skip
+			return skip, nil
+		}
+		for _, rule := range rules.RequiredStatusChecks {
+			skip, err := canBypass(rule.RulesetID)
+			if err != nil {
+				return nil, err
+			}
+			if skip {
+				continue
+			}
+			protected = true
+			for _, check := range rule.Parameters.RequiredStatusChecks {
+				protection.RequiredChecks = append(protection.RequiredChecks, check.Context)
+			}
+		}
+		for _, rule := range rules.PullRequest {
+			skip, err := canBypass(rule.RulesetID)
+			if err != nil {
+				return nil, err
+			}
+			if skip {
+				continue
+			}
+			protected = true
+			protection.RequiredApprovals = max(protection.RequiredApprovals, rule.Parameters.RequiredApprovingReviewCount)
+		}
+	}
+
+	start = time.Now()
+	branchProtection, response, err := pr.client.Repositories.GetBranchProtection(ctx, gitRepo.Spec.GitHub.Owner, gitRepo.Spec.GitHub.Name, branch)
+	if response != nil {
+		metrics.RecordSCMCall(gitRepo, metrics.SCMAPIPullRequest, metrics.SCMOperationGet, response.StatusCode, time.Since(start), getRateLimitMetrics(response.Rate))
+	}
+	switch {
+	case errors.Is(err, github.ErrBranchNotProtected):
+	case err != nil && response != nil && (response.StatusCode == http.StatusForbidden || response.StatusCode == http.StatusNotFound):
+		// Reading classic branch protection needs the Administration permission. Credentials without it are not
+		// administrators, so the protection applies to them in full. The required checks are also part of the branch,
+		// which only needs read access, so those are still enforced.
+		logger.V(4).Info("classic branch protection is not readable with the configured credentials, reading the required checks of the branch", "branch", branch, "status", response.Status)
+		checks, err := pr.getBranchRequiredChecks(ctx, gitRepo, branch)
+		if err != nil {
+			return nil, err
+		}
+		if len(checks) > 0 {
+			protected = true
+			protection.RequiredChecks = append(protection.RequiredChecks, checks...)
+		}
+	case err != nil:
+		return nil, fmt.Errorf("failed to get the protection of branch %q: %w", branch, err)
+	default:
+		if !branchProtection.GetEnforceAdmins().Enabled {
+			// Being able to read branch protection does not make the credentials an administrator, for example with the
+			// read-only Administration permission of a GitHub App. Only administrators can bypass it.
+			admin, err := pr.isRepositoryAdmin(ctx, gitRepo)
+			if err != nil {
+				return nil, err
+			}
+			if admin {
+				logger.V(4).Info("classic branch protection is not enforced for administrators", "branch", branch)
+				break
+			}
+		}
+		if checks := branchProtection.GetRequiredStatusChecks(); checks != nil {
+			protected = true
+			for _, check := range checks.GetChecks() {
+				protection.RequiredChecks = append(protection.RequiredChecks, check.Context)
+			}
+			protection.RequiredChecks = append(protection.RequiredChecks, checks.GetContexts()...)
+		}
+		if reviews := branchProtection.GetRequiredPullRequestReviews(); reviews != nil {
+			protected = true
+			protection.RequiredApprovals = max(protection.RequiredApprovals, reviews.RequiredApprovingReviewCount)
+		}
+	}
+
+	if !protected {
+		return nil, nil
+	}
+	slices.Sort(protection.RequiredChecks)
+	protection.RequiredChecks = slices.Compact(protection.RequiredChecks)
+	return protection, nil
+}
+
+// getBranchRequiredChecks returns the checks that the classic branch protection of the branch requires, as reported by
+// the branch itself. Unlike the branch protection, the branch can be read without the Administration permission.
+func (pr *PullRequest) getBranchRequiredChecks(ctx context.Context, gitRepo *v1alpha1.GitRepository, branch string) ([]string, error) {
+	start := time.Now()
+	githubBranch, response, err := pr.client.Repositories.GetBranch(ctx, gitRepo.Spec.GitHub.Owner, gitRepo.Spec.GitHub.Name, branch, 1)
+	if response != nil {
+		metrics.RecordSCMCall(gitRepo, metrics.SCMAPIPullRequest, metrics.SCMOperationGet, response.StatusCode, time.Since(start), getRateLimitMetrics(response.Rate))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get branch %q: %w", branch, err)
+	}
+
+	checks := githubBranch.GetProtection().GetRequiredStatusChecks()
+	if checks == nil {
+		return nil, nil
+	}
+	var contexts []string
+	for _, check := range checks.GetChecks() {
+		contexts = append(contexts, check.Context)
+	}
+	return append(contexts, checks.GetContexts()...), nil
+}
+
+// isRepositoryAdmin returns whether the credentials have admin permission on the repository. Repositories whose
+// permissions are not reported are treated as not administered by the credentials.
+func (pr *PullRequest) isRepositoryAdmin(ctx context.Context, gitRepo *v1alpha1.GitRepository) (bool, error) {
+	start := time.Now()
+	repository, response, err := pr.client.Repositories.Get(ctx, gitRepo.Spec.GitHub.Owner, gitRepo.Spec.GitHub.Name)
+	if response != nil {
+		metrics.RecordSCMCall(gitRepo, metrics.SCMAPIPullRequest, metrics.SCMOperationGet, response.StatusCode, time.Since(start), getRateLimitMetrics(response.Rate))
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get repository %s/%s: %w", gitRepo.Spec.GitHub.Owner, gitRepo.Spec.GitHub.Name, err)
+	}
+	return repository.GetPermissions()["admin"], nil
+}
+
+// canBypassRuleset returns whether the credentials can bypass the ruleset when merging a pull request. A ruleset that
+// cannot be read is treated as one that cannot be bypassed.
+func (pr *PullRequest) canBypassRuleset(ctx context.Context, gitRepo *v1alpha1.GitRepository, rulesetID int64) (bool, error) {
+	start := time.Now()
+	ruleset, response, err := pr.client.Repositories.GetRuleset(ctx, gitRepo.Spec.GitHub.Owner, gitRepo.Spec.GitHub.Name, rulesetID, true)
+	if response != nil {
+		metrics.RecordSCMCall(gitRepo, metrics.SCMAPIPullRequest, metrics.SCMOperationGet, response.StatusCode, time.Since(start), getRateLimitMetrics(response.Rate))
+	}
+	switch {
+	case err != nil && response != nil && (response.StatusCode == http.StatusForbidden || response.StatusCode == http.StatusNotFound):
+		log.FromContext(ctx).V(4).Info("ruleset is not readable with the configured credentials", "rulesetID", rulesetID, "status", response.Status)
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("failed to get ruleset %d: %w", rulesetID, err)
+	}
+	// Actors with the pull_request bypass mode can bypass the ruleset by merging a pull request.
+	if ruleset.CurrentUserCanBypass == nil {
+		return false, nil
+	}
+	mode := *ruleset.CurrentUserCanBypass
+	return mode == github.BypassModeAlways || mode == github.BypassModePullRequest, nil
+}
+
+// getHeadSha returns the SHA of the head commit of the pull request.
+func (pr *PullRequest) getHeadSha(ctx context.Context, gitRepo *v1alpha1.GitRepository, prNumber int) (string, error) {
+	start := time.Now()
+	githubPullRequest, response, err := pr.client.PullRequests.Get(ctx, gitRepo.Spec.GitHub.Owner, gitRepo.Spec.GitHub.Name, prNumber)
+	if response != nil {
+		metrics.RecordSCMCall(gitRepo, metrics.SCMAPIPullRequest, metrics.SCMOperationGet, response.StatusCode, time.Since(start), getRateLimitMetrics(response.Rate))
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get pull request #%d: %w", prNumber, err)
+	}
+	return githubPullRequest.GetHead().GetSHA(), nil
+}
+
+// countApprovals returns the number of users whose latest review of the pull request approves it. Comments do not
+// replace an earlier approval.
+func (pr *PullRequest) countApprovals(ctx context.Context, gitRepo *v1alpha1.GitRepository, prNumber int) (int, error) {
+	reviews, err := pr.listReviews(ctx, gitRepo, prNumber)
+	if err != nil {
+		return 0, err
+	}
+	latest := map[string]string{}
+	for _, review := range reviews {
+		if state := review.GetState(); state != "COMMENTED" && state != "PENDING" {
+			latest[review.GetUser().GetLogin()] = state
+		}
+	}
+
+	approvals := 0
+	for _, state := range latest {
+		if state == "APPROVED" {
+			approvals++
+		}
+	}
+	return approvals, nil
+}
+
+// listReviews returns the reviews of the pull request, in chronological order.
+func (pr *PullRequest) listReviews(ctx context.Context, gitRepo *v1alpha1.GitRepository, prNumber int) ([]*github.PullRequestReview, error) {
+	var reviews []*github.PullRequestReview
+	opts := &github.ListOptions{PerPage: listPageSize}
+	for {
+		start := time.Now()
+		page, response, err := pr.client.PullRequests.ListReviews(ctx, gitRepo.Spec.GitHub.Owner, gitRepo.Spec.GitHub.Name, prNumber, opts)
+		if response != nil {
+			metrics.RecordSCMCall(gitRepo, metrics.SCMAPIPullRequest, metrics.SCMOperationList, response.StatusCode, time.Since(start), getRateLimitMetrics(response.Rate))
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list reviews of pull request #%d: %w", prNumber, err)
+		}
+		reviews = append(reviews, page...)
+		if response == nil || response.NextPage == 0 {
+			return reviews, nil
+		}
+		opts.Page = response.NextPage
+	}
+}
+
+// unmetChecks returns the required checks that no successful check reported on the commit matches by name.
+func unmetChecks(required []string, checks []scms.CommitCheck) []string {
+	var unmet []string
+	for _, name := range required {
+		if !slices.ContainsFunc(checks, func(check scms.CommitCheck) bool {
+			return check.Name == name && check.Phase == v1alpha1.CommitPhaseSuccess
+		}) {
+			unmet = append(unmet, name)
+		}
+	}
+	return unmet
+}
+
 // FindOpen checks if a pull request is open and returns its status.
 func (pr *PullRequest) FindOpen(ctx context.Context, pullRequest v1alpha1.PullRequest) (bool, string, time.Time, error) {
 	logger := log.FromContext(ctx)
@@ -242,3 +824,47 @@ func (pr *PullRequest) GetUrl(ctx context.Context, pullRequest v1alpha1.PullRequ
 
 	return fmt.Sprintf("https://%s/%s/%s/pull/%d", pr.client.BaseURL.Host, gitRepo.Spec.GitHub.Owner, gitRepo.Spec.GitHub.Name, prNumber), nil
 }
+
+// UpsertComment posts an issue comment on the pull request, or edits the comment with the given ID.
+func (pr *PullRequest) UpsertComment(ctx context.Context, pullRequest v1alpha1.PullRequest, commentID, body string) (string, error) {
+	gitRepo, err := utils.GetGitRepositoryFromObjectKey(ctx, pr.k8sClient, client.ObjectKey{Namespace: pullRequest.Namespace, Name: pullRequest.Spec.RepositoryReference.Name})
+	if err != nil || gitRepo == nil {
+		return "", fmt.Errorf("failed to get GitRepository: %w", err)
+	}
+
+	comment := &github.IssueComment{Body: github.Ptr(body)}
+	if commentID != "" {
+		id, err := strconv.ParseInt(commentID, 10, 64)
+		if err != nil {
+			return "", fmt.Errorf("failed to convert comment ID to int: %w", err)
+		}
+
+		start := time.Now()
+		_, response, err := pr.client.Issues.EditComment(ctx, gitRepo.Spec.GitHub.Owner, gitRepo.Spec.GitHub.Name, id, comment)
+		if response != nil {
+			metrics.RecordSCMCall(gitRepo, metrics.SCMAPIPullRequest, metrics.SCMOperationUpdate, response.StatusCode, time.Since(start), getRateLimitMetrics(response.Rate))
+		}
+		if response != nil && response.StatusCode == http.StatusNotFound {
+			return "", fmt.Errorf("failed to edit comment %d: %w: %w", id, scms.ErrCommentNotFound, err)
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to edit comment %d: %w", id, err)
+		}
+		return commentID, nil
+	}
+
+	prNumber, err := strconv.Atoi(pullRequest.Status.ID)
+	if err != nil {
+		return "", fmt.Errorf("failed to convert PR number to int: %w", err)
+	}
+
+	start := time.Now()
+	created, response, err := pr.client.Issues.CreateComment(ctx, gitRepo.Spec.GitHub.Owner, gitRepo.Spec.GitHub.Name, prNumber, comment)
+	if response != nil {
+		metrics.RecordSCMCall(gitRepo, metrics.SCMAPIPullRequest, metrics.SCMOperationCreate, response.StatusCode, time.Since(start), getRateLimitMetrics(response.Rate))
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to comment on pull request #%d: %w", prNumber, err)
+	}
+	return strconv.FormatInt(created.GetID(), 10), nil
+}
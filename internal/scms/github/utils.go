@@ -2,7 +2,6 @@ package github
 
 import (
 	"context"
-	"fmt"
 	"net/http"
 	"time"
 
@@ -26,10 +25,7 @@ func ApplyHTTPAuth(ctx context.Context, scmProvider v1alpha1.GenericScmProvider,
 	if err != nil {
 		return nil, err
 	}
-	if scmProvider.GetSpec().GitHub.Domain != "" {
-		itr.BaseURL = fmt.Sprintf("https://%s/api/v3", scmProvider.GetSpec().GitHub.Domain)
-	}
-	return itr, nil
+	return &evictingTransport{transport: itr, domain: scmProvider.GetSpec().GitHub.Domain}, nil
 }
 
 // getRateLimitMetrics converts the GitHub rate limit struct to one acceptable for the metrics package.
@@ -52,7 +52,10 @@ type CommitStatus struct {
 	k8sClient client.Client
 }
 
-var _ scms.CommitStatusProvider = &CommitStatus{}
+var (
+	_ scms.CommitStatusProvider = &CommitStatus{}
+	_ scms.CommitCheckProvider  = &CommitStatus{}
+)
 
 // NewGitlabCommitStatusProvider creates a new instance of CommitStatus for GitLab.
 func NewGitlabCommitStatusProvider(k8sClient client.Client, secret v1.Secret, domain string) (*CommitStatus, error) {
@@ -128,3 +131,42 @@ func (cs *CommitStatus) Set(ctx context.Context, commitStatus *v1alpha1.CommitSt
 	commitStatus.Status.Sha = commitStatus.Spec.Sha
 	return commitStatus, nil
 }
+
+// GetCommitChecks returns the latest commit statuses reported on sha, including the jobs of its pipelines. Jobs that
+// are allowed to fail are successful whatever their outcome, and canceled jobs are failures.
+func (cs *CommitStatus) GetCommitChecks(ctx context.Context, gitRepo *v1alpha1.GitRepository, sha string) ([]scms.CommitCheck, error) {
+	logger := log.FromContext(ctx)
+	var checks []scms.CommitCheck
+
+	opts := &gitlab.GetCommitStatusesOptions{ListOptions: gitlab.ListOptions{PerPage: 100}}
+	for {
+		start := time.Now()
+		statuses, resp, err := cs.client.Commits.GetCommitStatuses(gitRepo.Spec.GitLab.ProjectID, sha, opts, gitlab.WithContext(ctx))
+		if resp != nil {
+			metrics.RecordSCMCall(gitRepo, metrics.SCMAPICommitStatus, metrics.SCMOperationList, resp.StatusCode, time.Since(start), nil)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list commit statuses for %q: %w", sha, err)
+		}
+		logGitLabRateLimitsIfAvailable(logger, gitRepo.Spec.ScmProviderRef.Name, resp)
+
+		for _, status := range statuses {
+			phase := commitStatusStateToPhase(gitlab.BuildStateValue(status.Status))
+			if status.AllowFailure && phase == v1alpha1.CommitPhaseFailure {
+				phase = v1alpha1.CommitPhaseSuccess
+			}
+			checks = append(checks, scms.CommitCheck{
+				Name:        status.Name,
+				Phase:       phase,
+				Description: status.Description,
+				Url:         status.TargetURL,
+			})
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return checks, nil
+}
@@ -0,0 +1,39 @@
+package gitlab
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms"
+)
+
+var _ = Describe("CommitStatus checks", func() {
+	It("should read the commit statuses and jobs reported on a commit", func(ctx SpecContext) {
+		server := newFakeGitLabServer(&fakeMergeRequest{})
+		DeferCleanup(server.Close)
+		server.reportCommitStatuses(mergeSha,
+			map[string]any{"name": "e2e", "status": "success", "description": "All tests passed", "target_url": "https://gitlab.example.com/jobs/1"},
+			map[string]any{"name": "lint", "status": "failed", "allow_failure": true},
+			map[string]any{"name": "unit", "status": "running"},
+			map[string]any{"name": "deploy", "status": "manual"},
+			map[string]any{"name": "build", "status": "canceled"},
+		)
+
+		client, err := gitlab.NewClient("token", gitlab.WithBaseURL(server.URL+"/api/v4"))
+		Expect(err).NotTo(HaveOccurred())
+		provider := &CommitStatus{client: client}
+		gitRepo := &v1alpha1.GitRepository{Spec: v1alpha1.GitRepositorySpec{GitLab: &v1alpha1.GitLabRepo{ProjectID: 7}}}
+
+		checks, err := provider.GetCommitChecks(ctx, gitRepo, mergeSha)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(checks).To(Equal([]scms.CommitCheck{
+			{Name: "e2e", Phase: v1alpha1.CommitPhaseSuccess, Description: "All tests passed", Url: "https://gitlab.example.com/jobs/1"},
+			{Name: "lint", Phase: v1alpha1.CommitPhaseSuccess},
+			{Name: "unit", Phase: v1alpha1.CommitPhasePending},
+			{Name: "deploy", Phase: v1alpha1.CommitPhasePending},
+			{Name: "build", Phase: v1alpha1.CommitPhaseFailure},
+		}))
+	})
+})
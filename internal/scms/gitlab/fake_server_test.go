@@ -0,0 +1,266 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+)
+
+// fakeMergeRequest is a merge request stored by the fake GitLab server.
+type fakeMergeRequest struct {
+	sha            string
+	state          string
+	pipelineStatus string
+	approvalsLeft  int
+	autoMerge      bool
+	mergeCalls     int
+	// onTrain is whether the merge request is on the merge train of its target branch.
+	onTrain bool
+}
+
+// fakeGitLabServer is an in-memory implementation of the GitLab merge request endpoints used to merge and to report
+// approvals and pipelines.
+type fakeGitLabServer struct {
+	*httptest.Server
+
+	mu sync.Mutex
+	mr *fakeMergeRequest
+	// users maps usernames to user IDs.
+	users map[string]int64
+	// created holds the options merge requests were created with.
+	created []map[string]any
+	// notes holds the bodies of the notes on the merge request, by note ID minus one.
+	notes []string
+	// mergeTrains is whether the project merges merge requests through merge trains.
+	mergeTrains bool
+	// trainBranches holds the target branches whose merge train was listed.
+	trainBranches []string
+	// commitStatuses holds the commit statuses reported on each commit SHA.
+	commitStatuses map[string][]map[string]any
+}
+
+func newFakeGitLabServer(mr *fakeMergeRequest) *fakeGitLabServer {
+	f := &fakeGitLabServer{mr: mr, users: map[string]int64{}, commitStatuses: map[string][]map[string]any{}}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v4/users", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		users := []map[string]any{}
+		username := r.URL.Query().Get("username")
+		if id, ok := f.users[username]; ok {
+			users = append(users, map[string]any{"id": id, "username": username})
+		}
+		writeJSON(w, http.StatusOK, users)
+	})
+	mux.HandleFunc("GET /api/v4/projects/{pid}/repository/commits/{sha}/statuses", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		writeJSON(w, http.StatusOK, append([]map[string]any{}, f.commitStatuses[r.PathValue("sha")]...))
+	})
+	mux.HandleFunc("POST /api/v4/projects/{pid}/merge_requests", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		var input map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&input)
+		f.created = append(f.created, input)
+		writeJSON(w, http.StatusCreated, f.mergeRequestJSON())
+	})
+	mux.HandleFunc("POST /api/v4/projects/{pid}/merge_requests/{iid}/notes", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		var input struct {
+			Body string `json:"body"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&input)
+		f.notes = append(f.notes, input.Body)
+		writeJSON(w, http.StatusCreated, map[string]any{"id": len(f.notes), "body": input.Body})
+	})
+	mux.HandleFunc("PUT /api/v4/projects/{pid}/merge_requests/{iid}/notes/{id}", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		id, err := strconv.Atoi(r.PathValue("id"))
+		if err != nil || id < 1 || id > len(f.notes) {
+			writeJSON(w, http.StatusNotFound, map[string]any{"message": "404 Not found"})
+			return
+		}
+		var input struct {
+			Body string `json:"body"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&input)
+		f.notes[id-1] = input.Body
+		writeJSON(w, http.StatusOK, map[string]any{"id": id, "body": input.Body})
+	})
+	mux.HandleFunc("GET /api/v4/projects/{pid}/merge_requests/{iid}/approvals", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		approvedBy := []map[string]any{}
+		if f.mr.approvalsLeft == 0 {
+			approvedBy = append(approvedBy, map[string]any{"user": map[string]any{"username": "reviewer"}})
+		}
+		writeJSON(w, http.StatusOK, map[string]any{
+			"approved":           f.mr.approvalsLeft == 0,
+			"approvals_required": 1,
+			"approvals_left":     f.mr.approvalsLeft,
+			"approved_by":        approvedBy,
+		})
+	})
+	mux.HandleFunc("GET /api/v4/projects/{pid}/merge_requests/{iid}", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		writeJSON(w, http.StatusOK, f.mergeRequestJSON())
+	})
+	mux.HandleFunc("PUT /api/v4/projects/{pid}/merge_requests/{iid}", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		writeJSON(w, http.StatusOK, f.mergeRequestJSON())
+	})
+	mux.HandleFunc("PUT /api/v4/projects/{pid}/merge_requests/{iid}/merge", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		var input struct {
+			AutoMerge bool   `json:"auto_merge"`
+			SHA       string `json:"sha"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&input)
+		f.mr.mergeCalls++
+		switch {
+		case input.SHA != f.mr.sha:
+			writeJSON(w, http.StatusConflict, map[string]any{"message": "SHA does not match HEAD of source branch"})
+		case f.mr.pipelineStatus == "success":
+			f.mr.state = "merged"
+			writeJSON(w, http.StatusOK, f.mergeRequestJSON())
+		case input.AutoMerge:
+			f.mr.autoMerge = true
+			writeJSON(w, http.StatusOK, f.mergeRequestJSON())
+		default:
+			writeJSON(w, http.StatusMethodNotAllowed, map[string]any{"message": "405 Method Not Allowed"})
+		}
+	})
+	mux.HandleFunc("GET /api/v4/projects/{pid}", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		writeJSON(w, http.StatusOK, map[string]any{"id": 7, "merge_trains_enabled": f.mergeTrains})
+	})
+	mux.HandleFunc("GET /api/v4/projects/{pid}/merge_trains/{branch}", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.trainBranches = append(f.trainBranches, r.PathValue("branch"))
+		cars := []map[string]any{{"id": 1, "merge_request": map[string]any{"iid": 5}, "status": "fresh"}}
+		if f.mr.onTrain {
+			cars = append(cars, map[string]any{"id": 2, "merge_request": map[string]any{"iid": 1}, "status": "idle", "created_at": "2024-01-01T00:00:00Z"})
+		}
+		writeJSON(w, http.StatusOK, cars)
+	})
+	mux.HandleFunc("POST /api/v4/projects/{pid}/merge_trains/merge_requests/{iid}", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		var input struct {
+			AutoMerge bool   `json:"auto_merge"`
+			SHA       string `json:"sha"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&input)
+		f.mr.mergeCalls++
+		switch {
+		case input.SHA != f.mr.sha:
+			writeJSON(w, http.StatusConflict, map[string]any{"message": "SHA does not match HEAD of source branch"})
+		case f.mr.pipelineStatus == "success":
+			f.mr.onTrain = true
+			writeJSON(w, http.StatusCreated, []map[string]any{})
+		case input.AutoMerge:
+			f.mr.autoMerge = true
+			writeJSON(w, http.StatusAccepted, []map[string]any{})
+		default:
+			writeJSON(w, http.StatusBadRequest, map[string]any{"message": "The pipeline has not succeeded"})
+		}
+	})
+	mux.HandleFunc("POST /api/v4/projects/{pid}/merge_requests/{iid}/cancel_merge_when_pipeline_succeeds", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.mr.autoMerge = false
+		writeJSON(w, http.StatusCreated, f.mergeRequestJSON())
+	})
+
+	f.Server = httptest.NewServer(mux)
+	return f
+}
+
+// mergeRequestJSON renders the merge request. The caller must hold f.mu.
+func (f *fakeGitLabServer) mergeRequestJSON() map[string]any {
+	detailedMergeStatus := "mergeable"
+	switch {
+	case f.mr.approvalsLeft > 0:
+		detailedMergeStatus = "not_approved"
+	case f.mr.pipelineStatus != "success":
+		detailedMergeStatus = "ci_still_running"
+	}
+	mr := map[string]any{
+		"iid":                          1,
+		"sha":                          f.mr.sha,
+		"state":                        f.mr.state,
+		"target_branch":                "environment/prod",
+		"merge_when_pipeline_succeeds": f.mr.autoMerge,
+		"detailed_merge_status":        detailedMergeStatus,
+	}
+	if f.mr.pipelineStatus != "" {
+		mr["head_pipeline"] = map[string]any{"id": 42, "status": f.mr.pipelineStatus}
+	}
+	return mr
+}
+
+// update changes the stored merge request.
+// reportCommitStatuses records the commit statuses reported on sha.
+func (f *fakeGitLabServer) reportCommitStatuses(sha string, statuses ...map[string]any) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.commitStatuses[sha] = statuses
+}
+
+func (f *fakeGitLabServer) update(change func(mr *fakeMergeRequest)) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	change(f.mr)
+}
+
+// useMergeTrains makes the project merge merge requests through merge trains.
+func (f *fakeGitLabServer) useMergeTrains() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.mergeTrains = true
+}
+
+// listedTrains returns the target branches whose merge train was listed.
+func (f *fakeGitLabServer) listedTrains() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.trainBranches...)
+}
+
+// createdWith returns the options merge requests were created with.
+func (f *fakeGitLabServer) createdWith() []map[string]any {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.created
+}
+
+// noteBodies returns the bodies of the notes on the merge request.
+func (f *fakeGitLabServer) noteBodies() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.notes...)
+}
+
+// snapshot returns a copy of the stored merge request.
+func (f *fakeGitLabServer) snapshot() fakeMergeRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return *f.mr
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
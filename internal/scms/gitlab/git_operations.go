@@ -3,10 +3,13 @@ package gitlab
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"net/url"
 
 	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
 	"github.com/argoproj-labs/gitops-promoter/internal/scms"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms/httpcache"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms/ratelimit"
 	gitlab "gitlab.com/gitlab-org/api/client-go"
 	v1 "k8s.io/api/core/v1"
 )
@@ -65,7 +68,9 @@ func GetClient(secret v1.Secret, domain string) (*gitlab.Client, error) {
 		return nil, fmt.Errorf("secret %q is missing required data key 'token'", secret.Name)
 	}
 
-	opts := []gitlab.ClientOptionFunc{}
+	opts := []gitlab.ClientOptionFunc{
+		gitlab.WithHTTPClient(&http.Client{Transport: httpcache.NewTransport(secret, "", ratelimit.NewTransport(secret, "", nil))}),
+	}
 	if domain != "" {
 		opts = append(opts, gitlab.WithBaseURL(fmt.Sprintf("https://%s/api/v4", domain)))
 	}
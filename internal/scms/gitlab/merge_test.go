@@ -0,0 +1,226 @@
+package gitlab
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms"
+	promoterConditions "github.com/argoproj-labs/gitops-promoter/internal/types/conditions"
+)
+
+const mergeSha = "0123456789abcdef0123456789abcdef01234567"
+
+var _ = Describe("PullRequest merging", func() {
+	var server *fakeGitLabServer
+	var newProvider func(mergeWhenPipelineSucceeds bool) *PullRequest
+	var prObj v1alpha1.PullRequest
+
+	BeforeEach(func() {
+		server = newFakeGitLabServer(&fakeMergeRequest{sha: mergeSha, state: "opened", pipelineStatus: "running", approvalsLeft: 1})
+		DeferCleanup(server.Close)
+
+		gitRepo := &v1alpha1.GitRepository{
+			ObjectMeta: metav1.ObjectMeta{Name: "repo", Namespace: "default"},
+			Spec: v1alpha1.GitRepositorySpec{
+				GitLab:         &v1alpha1.GitLabRepo{Namespace: "group", Name: "deployments", ProjectID: 7},
+				ScmProviderRef: v1alpha1.ScmProviderObjectReference{Kind: v1alpha1.ScmProviderKind, Name: "gitlab"},
+			},
+		}
+		scheme := runtime.NewScheme()
+		Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+		k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(gitRepo).Build()
+
+		client, err := gitlab.NewClient("token", gitlab.WithBaseURL(server.URL+"/api/v4"))
+		Expect(err).NotTo(HaveOccurred())
+		newProvider = func(mergeWhenPipelineSucceeds bool) *PullRequest {
+			return &PullRequest{client: client, k8sClient: k8sClient, mergeWhenPipelineSucceeds: mergeWhenPipelineSucceeds}
+		}
+
+		prObj = v1alpha1.PullRequest{
+			ObjectMeta: metav1.ObjectMeta{Name: "promote", Namespace: "default", Generation: 3},
+			Spec: v1alpha1.PullRequestSpec{
+				RepositoryReference: v1alpha1.ObjectReference{Name: "repo"},
+				MergeSha:            mergeSha,
+				TargetBranch:        "environment/prod",
+			},
+			Status: v1alpha1.PullRequestStatus{ID: "1"},
+		}
+	})
+
+	It("should report approval and pipeline conditions", func(ctx SpecContext) {
+		conditions, err := newProvider(false).GetConditions(ctx, prObj)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(conditions).To(HaveLen(2))
+		Expect(conditions[0].Type).To(Equal(string(promoterConditions.Approved)))
+		Expect(conditions[0].Status).To(Equal(metav1.ConditionFalse))
+		Expect(conditions[0].Reason).To(Equal(string(promoterConditions.ApprovalsRequired)))
+		Expect(conditions[0].ObservedGeneration).To(Equal(int64(3)))
+		Expect(conditions[1].Type).To(Equal(string(promoterConditions.PipelineSucceeded)))
+		Expect(conditions[1].Status).To(Equal(metav1.ConditionFalse))
+		Expect(conditions[1].Reason).To(Equal(string(promoterConditions.PipelineRunning)))
+
+		server.update(func(mr *fakeMergeRequest) {
+			mr.approvalsLeft = 0
+			mr.pipelineStatus = "failed"
+		})
+		conditions, err = newProvider(false).GetConditions(ctx, prObj)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(conditions[0].Status).To(Equal(metav1.ConditionTrue))
+		Expect(conditions[0].Reason).To(Equal(string(promoterConditions.ApprovalsSatisfied)))
+		Expect(conditions[1].Reason).To(Equal(string(promoterConditions.PipelineFailed)))
+
+		server.update(func(mr *fakeMergeRequest) { mr.pipelineStatus = "" })
+		conditions, err = newProvider(false).GetConditions(ctx, prObj)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(conditions[1].Status).To(Equal(metav1.ConditionUnknown))
+		Expect(conditions[1].Reason).To(Equal(string(promoterConditions.PipelineNotFound)))
+	})
+
+	It("should not merge a merge request that is not approved", func(ctx SpecContext) {
+		err := newProvider(false).Merge(ctx, prObj)
+		Expect(err).To(MatchError("merge request !1 cannot be merged until it is approved: 1 more approvals required"))
+		Expect(server.snapshot().mergeCalls).To(BeZero())
+	})
+
+	It("should explain why a direct merge was refused", func(ctx SpecContext) {
+		server.update(func(mr *fakeMergeRequest) { mr.approvalsLeft = 0 })
+		err := newProvider(false).Merge(ctx, prObj)
+		Expect(err).To(MatchError(ContainSubstring(`detailed merge status "ci_still_running"`)))
+	})
+
+	It("should merge when the pipeline succeeds", func(ctx SpecContext) {
+		server.update(func(mr *fakeMergeRequest) { mr.approvalsLeft = 0 })
+		provider := newProvider(true)
+
+		By("Scheduling the merge while the pipeline runs")
+		Expect(provider.Merge(ctx, prObj)).To(MatchError(scms.ErrMergeScheduled))
+		Expect(server.snapshot().autoMerge).To(BeTrue())
+
+		By("Not scheduling it again on later reconciliations")
+		Expect(provider.Merge(ctx, prObj)).To(MatchError(scms.ErrMergeScheduled))
+		Expect(server.snapshot().mergeCalls).To(Equal(1))
+
+		By("Canceling auto-merge and refusing the merge when the source branch moved past the merge SHA")
+		server.update(func(mr *fakeMergeRequest) { mr.sha = "fedcba9876543210fedcba9876543210fedcba98" })
+		Expect(provider.Merge(ctx, prObj)).To(MatchError(ContainSubstring("SHA does not match HEAD of source branch")))
+		Expect(server.snapshot().autoMerge).To(BeFalse())
+	})
+
+	It("should cancel auto-merge when the pull request is updated to stay open", func(ctx SpecContext) {
+		server.update(func(mr *fakeMergeRequest) {
+			mr.approvalsLeft = 0
+			mr.autoMerge = true
+		})
+		Expect(newProvider(true).Update(ctx, "Promote", "", prObj)).To(Succeed())
+		Expect(server.snapshot().autoMerge).To(BeFalse())
+	})
+
+	It("should report a merge as done when GitLab merges right away", func(ctx SpecContext) {
+		server.update(func(mr *fakeMergeRequest) {
+			mr.approvalsLeft = 0
+			mr.pipelineStatus = "success"
+		})
+		Expect(newProvider(true).Merge(ctx, prObj)).To(Succeed())
+		Expect(server.snapshot().state).To(Equal("merged"))
+	})
+
+	It("should add the merge request to the merge train when the project uses merge trains", func(ctx SpecContext) {
+		server.useMergeTrains()
+		server.update(func(mr *fakeMergeRequest) {
+			mr.approvalsLeft = 0
+			mr.pipelineStatus = "success"
+		})
+		provider := newProvider(false)
+
+		status, err := provider.GetMergeQueueStatus(ctx, prObj)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status).To(BeNil())
+
+		Expect(provider.Merge(ctx, prObj)).To(MatchError(scms.ErrMergeQueued))
+		Expect(server.snapshot().onTrain).To(BeTrue())
+		Expect(server.snapshot().state).To(Equal("opened"))
+		Expect(server.listedTrains()).To(ContainElement("environment/prod"))
+
+		By("Reporting its position on the train")
+		status, err = provider.GetMergeQueueStatus(ctx, prObj)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status.State).To(Equal(v1alpha1.MergeQueueQueued))
+		Expect(status.Position).To(Equal(2))
+		Expect(status.Message).To(Equal("The merge train car is idle"))
+		Expect(status.EnqueuedAt.IsZero()).To(BeFalse())
+
+		By("Not adding it again while it is on the train")
+		Expect(provider.Merge(ctx, prObj)).To(MatchError(scms.ErrMergeQueued))
+		Expect(server.snapshot().mergeCalls).To(Equal(1))
+
+		By("Reporting it as no longer queued once GitLab drops it from the train")
+		server.update(func(mr *fakeMergeRequest) { mr.onTrain = false })
+		status, err = provider.GetMergeQueueStatus(ctx, prObj)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status).To(BeNil())
+	})
+
+	It("should report a merge request waiting for its pipeline to join the merge train as queued", func(ctx SpecContext) {
+		server.useMergeTrains()
+		server.update(func(mr *fakeMergeRequest) { mr.approvalsLeft = 0 })
+		provider := newProvider(false)
+
+		Expect(provider.Merge(ctx, prObj)).To(MatchError(scms.ErrMergeQueued))
+		Expect(server.snapshot().autoMerge).To(BeTrue())
+		Expect(server.snapshot().onTrain).To(BeFalse())
+
+		status, err := provider.GetMergeQueueStatus(ctx, prObj)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status.State).To(Equal(v1alpha1.MergeQueueQueued))
+		Expect(status.Message).To(Equal("The merge request joins the merge train once its pipeline succeeds"))
+	})
+
+	It("should open the merge request with reviewers, assignees and labels", func(ctx SpecContext) {
+		server.update(func(*fakeMergeRequest) {
+			server.users["jane"] = 11
+			server.users["oncall"] = 12
+		})
+		prObj.Spec.Reviewers = []string{"jane"}
+		prObj.Spec.Assignees = []string{"oncall"}
+		prObj.Spec.Labels = []string{"promotion", "env/prod"}
+
+		id, err := newProvider(false).Create(ctx, "Promote", "environment/prod-next", "environment/prod", "", prObj)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(id).To(Equal("1"))
+		created := server.createdWith()
+		Expect(created).To(HaveLen(1))
+		Expect(created[0]).To(HaveKeyWithValue("reviewer_ids", []any{11.0}))
+		Expect(created[0]).To(HaveKeyWithValue("assignee_ids", []any{12.0}))
+		Expect(created[0]).To(HaveKeyWithValue("labels", "promotion,env/prod"))
+		Expect(created[0]).To(HaveKeyWithValue("title", "Promote"))
+
+		By("Refusing to open it for an unknown user")
+		prObj.Spec.Reviewers = []string{"nobody"}
+		_, err = newProvider(false).Create(ctx, "Promote", "environment/prod-next", "environment/prod", "", prObj)
+		Expect(err).To(MatchError(`failed to resolve reviewers: user "nobody" does not match a GitLab user`))
+	})
+
+	It("should mark draft merge requests by their title", func(ctx SpecContext) {
+		prObj.Spec.Draft = true
+		_, err := newProvider(false).Create(ctx, "Promote", "environment/prod-next", "environment/prod", "", prObj)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(server.createdWith()[0]).To(HaveKeyWithValue("title", "Draft: Promote"))
+		Expect(draftTitle("Promote", false)).To(Equal("Promote"))
+	})
+
+	It("should post a note and update it in place", func(ctx SpecContext) {
+		noteID, err := newProvider(false).UpsertComment(ctx, prObj, "", "pending")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(noteID).To(Equal("1"))
+
+		noteID, err = newProvider(false).UpsertComment(ctx, prObj, noteID, "success")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(noteID).To(Equal("1"))
+		Expect(server.noteBodies()).To(Equal([]string{"success"}))
+	})
+})
@@ -4,38 +4,48 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"strconv"
 	"time"
 
 	gitlab "gitlab.com/gitlab-org/api/client-go"
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
 	"github.com/argoproj-labs/gitops-promoter/internal/metrics"
 	"github.com/argoproj-labs/gitops-promoter/internal/scms"
+	promoterConditions "github.com/argoproj-labs/gitops-promoter/internal/types/conditions"
 	"github.com/argoproj-labs/gitops-promoter/internal/utils"
 )
 
 // PullRequest implements the scms.PullRequestProvider interface for GitLab.
 type PullRequest struct {
-	client    *gitlab.Client
-	k8sClient client.Client
+	client                    *gitlab.Client
+	k8sClient                 client.Client
+	mergeWhenPipelineSucceeds bool
 }
 
-var _ scms.PullRequestProvider = &PullRequest{}
+var (
+	_ scms.PullRequestProvider           = &PullRequest{}
+	_ scms.PullRequestConditionsProvider = &PullRequest{}
+	_ scms.PullRequestMergeQueueProvider = &PullRequest{}
+)
 
-// NewGitlabPullRequestProvider creates a new instance of PullRequest for GitLab.
-func NewGitlabPullRequestProvider(k8sClient client.Client, secret v1.Secret, domain string) (*PullRequest, error) {
+// NewGitlabPullRequestProvider creates a new instance of PullRequest for GitLab. If mergeWhenPipelineSucceeds is set,
+// merge requests are set to merge automatically when their pipeline succeeds instead of being merged directly.
+func NewGitlabPullRequestProvider(k8sClient client.Client, secret v1.Secret, domain string, mergeWhenPipelineSucceeds bool) (*PullRequest, error) {
 	client, err := GetClient(secret, domain)
 	if err != nil {
 		return nil, err
 	}
 
 	return &PullRequest{
-		client:    client,
-		k8sClient: k8sClient,
+		client:                    client,
+		k8sClient:                 k8sClient,
+		mergeWhenPipelineSucceeds: mergeWhenPipelineSucceeds,
 	}, nil
 }
 
@@ -52,11 +62,28 @@ func (pr *PullRequest) Create(ctx context.Context, title, head, base, desc strin
 	}
 
 	options := &gitlab.CreateMergeRequestOptions{
-		Title:        gitlab.Ptr(title),
+		Title:        gitlab.Ptr(draftTitle(title, prObj.Spec.Draft)),
 		SourceBranch: gitlab.Ptr(head),
 		TargetBranch: gitlab.Ptr(base),
 		Description:  gitlab.Ptr(desc),
 	}
+	if len(prObj.Spec.Reviewers) > 0 {
+		reviewerIDs, err := pr.resolveUserIDs(ctx, repo, prObj.Spec.Reviewers)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve reviewers: %w", err)
+		}
+		options.ReviewerIDs = &reviewerIDs
+	}
+	if len(prObj.Spec.Assignees) > 0 {
+		assigneeIDs, err := pr.resolveUserIDs(ctx, repo, prObj.Spec.Assignees)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve assignees: %w", err)
+		}
+		options.AssigneeIDs = &assigneeIDs
+	}
+	if len(prObj.Spec.Labels) > 0 {
+		options.Labels = gitlab.Ptr(gitlab.LabelOptions(prObj.Spec.Labels))
+	}
 
 	start := time.Now()
 	mr, resp, err := pr.client.MergeRequests.CreateMergeRequest(
@@ -99,12 +126,12 @@ func (pr *PullRequest) Update(ctx context.Context, title, description string, pr
 	}
 
 	options := &gitlab.UpdateMergeRequestOptions{
-		Title:       gitlab.Ptr(title),
+		Title:       gitlab.Ptr(draftTitle(title, prObj.Spec.Draft)),
 		Description: gitlab.Ptr(description),
 	}
 
 	start := time.Now()
-	_, resp, err := pr.client.MergeRequests.UpdateMergeRequest(
+	mr, resp, err := pr.client.MergeRequests.UpdateMergeRequest(
 		repo.Spec.GitLab.ProjectID,
 		mrIID,
 		options,
@@ -117,6 +144,15 @@ func (pr *PullRequest) Update(ctx context.Context, title, description string, pr
 		return fmt.Errorf("failed to update merge request: %w", err)
 	}
 
+	// The pull request is only updated while it should stay open. If auto-merge was set when it was last asked to
+	// merge, cancel it so that GitLab does not merge past the promotion gates.
+	if pr.mergeWhenPipelineSucceeds && mr.MergeWhenPipelineSucceeds {
+		if err := pr.cancelMergeWhenPipelineSucceeds(ctx, repo, mrIID); err != nil {
+			return err
+		}
+		logger.Info("canceled merge when pipeline succeeds", "mergeRequest", mrIID)
+	}
+
 	logGitLabRateLimitsIfAvailable(
 		logger,
 		prObj.Spec.RepositoryReference.Name,
@@ -174,7 +210,11 @@ func (pr *PullRequest) Close(ctx context.Context, prObj v1alpha1.PullRequest) er
 	return nil
 }
 
-// Merge merges an existing pull request with the specified commit message.
+// Merge merges an existing pull request with the specified commit message. The merge request must be approved
+// according to the project's approval rules. If the project uses merge trains, the merge request is added to the merge
+// train of its target branch and scms.ErrMergeQueued is returned. Otherwise, if the provider merges when the pipeline
+// succeeds, the merge request is set to auto-merge and scms.ErrMergeScheduled is returned unless GitLab merged it right
+// away.
 func (pr *PullRequest) Merge(ctx context.Context, prObj v1alpha1.PullRequest) error {
 	logger := log.FromContext(ctx)
 
@@ -191,8 +231,41 @@ func (pr *PullRequest) Merge(ctx context.Context, prObj v1alpha1.PullRequest) er
 		return fmt.Errorf("failed to get repo: %w", err)
 	}
 
+	approvals, err := pr.getApprovals(ctx, repo, mrIID)
+	if err != nil {
+		return err
+	}
+	if !approvals.Approved {
+		return fmt.Errorf("merge request !%d cannot be merged until it is approved: %d more approvals required", mrIID, approvals.ApprovalsLeft)
+	}
+
+	mr, err := pr.getMergeRequest(ctx, repo, mrIID)
+	if err != nil {
+		return err
+	}
+
+	mergeTrains, err := pr.mergeTrainsEnabled(ctx, repo)
+	if err != nil {
+		return err
+	}
+	if mergeTrains {
+		return pr.addToMergeTrain(ctx, repo, mr, mrIID, prObj.Spec.MergeSha)
+	}
+
+	if pr.mergeWhenPipelineSucceeds && mr.MergeWhenPipelineSucceeds {
+		if mr.SHA == prObj.Spec.MergeSha {
+			logger.V(4).Info("merge request is already set to merge when the pipeline succeeds", "sha", mr.SHA)
+			return scms.ErrMergeScheduled
+		}
+		// The source branch moved past the merge SHA since auto-merge was set. Cancel it, so that the merge below is
+		// refused instead of GitLab merging commits that were not promoted.
+		if err := pr.cancelMergeWhenPipelineSucceeds(ctx, repo, mrIID); err != nil {
+			return err
+		}
+	}
+
 	options := &gitlab.AcceptMergeRequestOptions{
-		AutoMerge:                gitlab.Ptr(false),
+		AutoMerge:                gitlab.Ptr(pr.mergeWhenPipelineSucceeds),
 		ShouldRemoveSourceBranch: gitlab.Ptr(false),
 		Squash:                   gitlab.Ptr(false),
 		SHA:                      gitlab.Ptr(prObj.Spec.MergeSha),
@@ -203,7 +276,7 @@ func (pr *PullRequest) Merge(ctx context.Context, prObj v1alpha1.PullRequest) er
 	}
 
 	start := time.Now()
-	_, resp, err := pr.client.MergeRequests.AcceptMergeRequest(
+	merged, resp, err := pr.client.MergeRequests.AcceptMergeRequest(
 		repo.Spec.GitLab.ProjectID,
 		mrIID,
 		options,
@@ -213,7 +286,9 @@ func (pr *PullRequest) Merge(ctx context.Context, prObj v1alpha1.PullRequest) er
 		metrics.RecordSCMCall(repo, metrics.SCMAPIPullRequest, metrics.SCMOperationMerge, resp.StatusCode, time.Since(start), nil)
 	}
 	if err != nil {
-		return err //nolint:wrapcheck // Error wrapping handled at top level
+		// A 405 or 422 from GitLab does not say why the merge request cannot be merged, but its detailed merge status
+		// does, e.g. "ci_must_pass" or "discussions_not_resolved".
+		return fmt.Errorf("failed to merge merge request !%d with detailed merge status %q: %w", mrIID, mr.DetailedMergeStatus, err)
 	}
 
 	logGitLabRateLimitsIfAvailable(
@@ -224,9 +299,278 @@ func (pr *PullRequest) Merge(ctx context.Context, prObj v1alpha1.PullRequest) er
 	logger.V(4).Info("gitlab response status",
 		"status", resp.Status)
 
+	if pr.mergeWhenPipelineSucceeds && merged.State != "merged" {
+		logger.Info("merge request set to merge when the pipeline succeeds", "mergeRequest", mrIID)
+		return scms.ErrMergeScheduled
+	}
+
 	return nil
 }
 
+// GetConditions reports whether the merge request is approved and whether the pipeline of its head commit succeeded.
+func (pr *PullRequest) GetConditions(ctx context.Context, prObj v1alpha1.PullRequest) ([]metav1.Condition, error) {
+	mrIID, err := strconv.ParseInt(prObj.Status.ID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert MR ID %q to int64: %w", prObj.Status.ID, err)
+	}
+
+	repo, err := utils.GetGitRepositoryFromObjectKey(ctx, pr.k8sClient, client.ObjectKey{
+		Namespace: prObj.Namespace,
+		Name:      prObj.Spec.RepositoryReference.Name,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repo: %w", err)
+	}
+
+	approvals, err := pr.getApprovals(ctx, repo, mrIID)
+	if err != nil {
+		return nil, err
+	}
+	mr, err := pr.getMergeRequest(ctx, repo, mrIID)
+	if err != nil {
+		return nil, err
+	}
+
+	approved := metav1.Condition{
+		Type:               string(promoterConditions.Approved),
+		Status:             metav1.ConditionTrue,
+		Reason:             string(promoterConditions.ApprovalsSatisfied),
+		Message:            fmt.Sprintf("Merge request has %d of %d required approvals", len(approvals.ApprovedBy), approvals.ApprovalsRequired),
+		ObservedGeneration: prObj.Generation,
+	}
+	if !approvals.Approved {
+		approved.Status = metav1.ConditionFalse
+		approved.Reason = string(promoterConditions.ApprovalsRequired)
+		approved.Message = fmt.Sprintf("Merge request needs %d more approvals", approvals.ApprovalsLeft)
+	}
+
+	return []metav1.Condition{approved, pipelineCondition(mr, prObj.Generation)}, nil
+}
+
+// pipelineCondition returns the PipelineSucceeded condition for the head pipeline of the merge request.
+func pipelineCondition(mr *gitlab.MergeRequest, generation int64) metav1.Condition {
+	condition := metav1.Condition{
+		Type:               string(promoterConditions.PipelineSucceeded),
+		ObservedGeneration: generation,
+	}
+	if mr.HeadPipeline == nil {
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = string(promoterConditions.PipelineNotFound)
+		condition.Message = "Merge request has no pipeline"
+		return condition
+	}
+
+	condition.Message = fmt.Sprintf("Pipeline %d is %s", mr.HeadPipeline.ID, mr.HeadPipeline.Status)
+	switch mr.HeadPipeline.Status {
+	case string(gitlab.Success):
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = string(promoterConditions.PipelineSuccess)
+	case string(gitlab.Failed), string(gitlab.Canceled), string(gitlab.Skipped):
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = string(promoterConditions.PipelineFailed)
+	default:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = string(promoterConditions.PipelineRunning)
+	}
+	return condition
+}
+
+// draftTitle returns the title of a merge request, prefixed with "Draft: " if it is a draft. GitLab marks merge
+// requests as drafts by their title, so setting the title without the prefix marks it ready.
+func draftTitle(title string, draft bool) string {
+	if draft {
+		return "Draft: " + title
+	}
+	return title
+}
+
+// resolveUserIDs returns the IDs of the GitLab users with the given usernames.
+func (pr *PullRequest) resolveUserIDs(ctx context.Context, repo *v1alpha1.GitRepository, usernames []string) ([]int64, error) {
+	ids := make([]int64, 0, len(usernames))
+	for _, username := range usernames {
+		start := time.Now()
+		users, resp, err := pr.client.Users.ListUsers(&gitlab.ListUsersOptions{Username: gitlab.Ptr(username)}, gitlab.WithContext(ctx))
+		if resp != nil {
+			metrics.RecordSCMCall(repo, metrics.SCMAPIPullRequest, metrics.SCMOperationGet, resp.StatusCode, time.Since(start), nil)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up user %q: %w", username, err)
+		}
+		if len(users) == 0 {
+			return nil, fmt.Errorf("user %q does not match a GitLab user", username)
+		}
+		ids = append(ids, users[0].ID)
+	}
+	return ids, nil
+}
+
+// cancelMergeWhenPipelineSucceeds cancels auto-merge of a merge request.
+func (pr *PullRequest) cancelMergeWhenPipelineSucceeds(ctx context.Context, repo *v1alpha1.GitRepository, mrIID int64) error {
+	start := time.Now()
+	_, resp, err := pr.client.MergeRequests.CancelMergeWhenPipelineSucceeds(repo.Spec.GitLab.ProjectID, mrIID, gitlab.WithContext(ctx))
+	if resp != nil {
+		metrics.RecordSCMCall(repo, metrics.SCMAPIPullRequest, metrics.SCMOperationUpdate, resp.StatusCode, time.Since(start), nil)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to cancel merge when pipeline succeeds for merge request !%d: %w", mrIID, err)
+	}
+	return nil
+}
+
+// mergeTrainsEnabled returns whether the project merges merge requests through merge trains.
+func (pr *PullRequest) mergeTrainsEnabled(ctx context.Context, repo *v1alpha1.GitRepository) (bool, error) {
+	start := time.Now()
+	project, resp, err := pr.client.Projects.GetProject(repo.Spec.GitLab.ProjectID, nil, gitlab.WithContext(ctx))
+	if resp != nil {
+		metrics.RecordSCMCall(repo, metrics.SCMAPIPullRequest, metrics.SCMOperationGet, resp.StatusCode, time.Since(start), nil)
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get project %d: %w", repo.Spec.GitLab.ProjectID, err)
+	}
+	return project.MergeTrainsEnabled, nil
+}
+
+// addToMergeTrain adds the merge request to the merge train of its target branch and returns scms.ErrMergeQueued. A
+// merge request whose pipeline has not succeeded yet is set to auto-merge and joins the train once it does.
+func (pr *PullRequest) addToMergeTrain(ctx context.Context, repo *v1alpha1.GitRepository, mr *gitlab.MergeRequest, mrIID int64, mergeSha string) error {
+	logger := log.FromContext(ctx)
+
+	car, _, err := pr.getMergeTrainCar(ctx, repo, mr.TargetBranch, mrIID)
+	if err != nil {
+		return err
+	}
+	if car != nil || (mr.MergeWhenPipelineSucceeds && mr.SHA == mergeSha) {
+		logger.V(4).Info("merge request is already on the merge train", "mergeRequest", mrIID)
+		return scms.ErrMergeQueued
+	}
+	if mr.MergeWhenPipelineSucceeds {
+		// The source branch moved past the merge SHA since auto-merge was set. Cancel it, so that the train is refused
+		// below instead of merging commits that were not promoted.
+		if err := pr.cancelMergeWhenPipelineSucceeds(ctx, repo, mrIID); err != nil {
+			return err
+		}
+	}
+
+	start := time.Now()
+	_, resp, err := pr.client.MergeTrains.AddMergeRequestToMergeTrain(
+		repo.Spec.GitLab.ProjectID,
+		mrIID,
+		&gitlab.AddMergeRequestToMergeTrainOptions{
+			AutoMerge: gitlab.Ptr(true),
+			SHA:       gitlab.Ptr(mergeSha),
+		},
+		gitlab.WithContext(ctx),
+	)
+	if resp != nil {
+		metrics.RecordSCMCall(repo, metrics.SCMAPIPullRequest, metrics.SCMOperationMerge, resp.StatusCode, time.Since(start), nil)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to add merge request !%d to the merge train with detailed merge status %q: %w", mrIID, mr.DetailedMergeStatus, err)
+	}
+	logger.Info("merge request added to the merge train", "mergeRequest", mrIID, "branch", mr.TargetBranch)
+	return scms.ErrMergeQueued
+}
+
+// getMergeTrainCar returns the merge request's car on the active merge train of targetBranch and its position on the
+// train, starting at 1, or nil if the merge request is not on the train.
+func (pr *PullRequest) getMergeTrainCar(ctx context.Context, repo *v1alpha1.GitRepository, targetBranch string, mrIID int64) (*gitlab.MergeTrain, int, error) {
+	start := time.Now()
+	cars, resp, err := pr.client.MergeTrains.ListMergeRequestInMergeTrain(
+		repo.Spec.GitLab.ProjectID,
+		targetBranch,
+		&gitlab.ListMergeTrainsOptions{
+			ListOptions: gitlab.ListOptions{PerPage: 100},
+			Scope:       gitlab.Ptr("active"),
+			Sort:        gitlab.Ptr("asc"),
+		},
+		gitlab.WithContext(ctx),
+	)
+	if resp != nil {
+		metrics.RecordSCMCall(repo, metrics.SCMAPIPullRequest, metrics.SCMOperationList, resp.StatusCode, time.Since(start), nil)
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list the merge train of branch %q: %w", targetBranch, err)
+	}
+	for i, car := range cars {
+		if car.MergeRequest != nil && car.MergeRequest.IID == mrIID {
+			return car, i + 1, nil
+		}
+	}
+	return nil, 0, nil
+}
+
+// GetMergeQueueStatus returns the state of the merge request on the merge train of its target branch. A merge request
+// that is set to auto-merge is waiting for its pipeline before it joins the train, so it is reported as queued too.
+func (pr *PullRequest) GetMergeQueueStatus(ctx context.Context, prObj v1alpha1.PullRequest) (*v1alpha1.PullRequestMergeQueueStatus, error) {
+	mrIID, err := strconv.ParseInt(prObj.Status.ID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert MR number to int64: %w", err)
+	}
+
+	repo, err := utils.GetGitRepositoryFromObjectKey(ctx, pr.k8sClient, client.ObjectKey{
+		Namespace: prObj.Namespace,
+		Name:      prObj.Spec.RepositoryReference.Name,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repo: %w", err)
+	}
+
+	car, position, err := pr.getMergeTrainCar(ctx, repo, prObj.Spec.TargetBranch, mrIID)
+	if err != nil {
+		return nil, err
+	}
+	if car != nil {
+		status := &v1alpha1.PullRequestMergeQueueStatus{
+			State:    v1alpha1.MergeQueueQueued,
+			Position: position,
+			// The car status is one of idle, stale, fresh and merging.
+			Message: fmt.Sprintf("The merge train car is %s", car.Status),
+		}
+		if car.CreatedAt != nil {
+			status.EnqueuedAt = metav1.NewTime(*car.CreatedAt)
+		}
+		return status, nil
+	}
+
+	mr, err := pr.getMergeRequest(ctx, repo, mrIID)
+	if err != nil {
+		return nil, err
+	}
+	if mr.MergeWhenPipelineSucceeds {
+		return &v1alpha1.PullRequestMergeQueueStatus{
+			State:   v1alpha1.MergeQueueQueued,
+			Message: "The merge request joins the merge train once its pipeline succeeds",
+		}, nil
+	}
+	return nil, nil
+}
+
+// getMergeRequest retrieves a merge request, including its head pipeline and detailed merge status.
+func (pr *PullRequest) getMergeRequest(ctx context.Context, repo *v1alpha1.GitRepository, mrIID int64) (*gitlab.MergeRequest, error) {
+	start := time.Now()
+	mr, resp, err := pr.client.MergeRequests.GetMergeRequest(repo.Spec.GitLab.ProjectID, mrIID, nil, gitlab.WithContext(ctx))
+	if resp != nil {
+		metrics.RecordSCMCall(repo, metrics.SCMAPIPullRequest, metrics.SCMOperationGet, resp.StatusCode, time.Since(start), nil)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get merge request !%d: %w", mrIID, err)
+	}
+	return mr, nil
+}
+
+// getApprovals retrieves the approval state of a merge request.
+func (pr *PullRequest) getApprovals(ctx context.Context, repo *v1alpha1.GitRepository, mrIID int64) (*gitlab.MergeRequestApprovals, error) {
+	start := time.Now()
+	approvals, resp, err := pr.client.MergeRequestApprovals.GetConfiguration(repo.Spec.GitLab.ProjectID, mrIID, gitlab.WithContext(ctx))
+	if resp != nil {
+		metrics.RecordSCMCall(repo, metrics.SCMAPIPullRequest, metrics.SCMOperationGet, resp.StatusCode, time.Since(start), nil)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get approvals of merge request !%d: %w", mrIID, err)
+	}
+	return approvals, nil
+}
+
 // FindOpen checks if a pull request is open and returns its status.
 func (pr *PullRequest) FindOpen(ctx context.Context, pullRequest v1alpha1.PullRequest) (bool, string, time.Time, error) {
 	logger := log.FromContext(ctx)
@@ -288,6 +632,52 @@ func (pr *PullRequest) GetUrl(ctx context.Context, prObj v1alpha1.PullRequest) (
 	return FormatMergeRequestUrl(pr.client, repo.Spec.GitLab, prObj.Status.ID), nil
 }
 
+// UpsertComment posts a note on the merge request, or updates the note with the given ID.
+func (pr *PullRequest) UpsertComment(ctx context.Context, prObj v1alpha1.PullRequest, commentID, body string) (string, error) {
+	mrIID, err := strconv.ParseInt(prObj.Status.ID, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("failed to convert MR ID %q to int64: %w", prObj.Status.ID, err)
+	}
+
+	repo, err := utils.GetGitRepositoryFromObjectKey(ctx, pr.k8sClient, client.ObjectKey{
+		Namespace: prObj.Namespace,
+		Name:      prObj.Spec.RepositoryReference.Name,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get repo: %w", err)
+	}
+
+	if commentID != "" {
+		noteID, err := strconv.ParseInt(commentID, 10, 64)
+		if err != nil {
+			return "", fmt.Errorf("failed to convert note ID %q to int64: %w", commentID, err)
+		}
+
+		start := time.Now()
+		_, resp, err := pr.client.Notes.UpdateMergeRequestNote(repo.Spec.GitLab.ProjectID, mrIID, noteID, &gitlab.UpdateMergeRequestNoteOptions{Body: gitlab.Ptr(body)}, gitlab.WithContext(ctx))
+		if resp != nil {
+			metrics.RecordSCMCall(repo, metrics.SCMAPIPullRequest, metrics.SCMOperationUpdate, resp.StatusCode, time.Since(start), nil)
+		}
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return "", fmt.Errorf("failed to update note %d: %w: %w", noteID, scms.ErrCommentNotFound, err)
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to update note %d: %w", noteID, err)
+		}
+		return commentID, nil
+	}
+
+	start := time.Now()
+	note, resp, err := pr.client.Notes.CreateMergeRequestNote(repo.Spec.GitLab.ProjectID, mrIID, &gitlab.CreateMergeRequestNoteOptions{Body: gitlab.Ptr(body)}, gitlab.WithContext(ctx))
+	if resp != nil {
+		metrics.RecordSCMCall(repo, metrics.SCMAPIPullRequest, metrics.SCMOperationCreate, resp.StatusCode, time.Since(start), nil)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to create note on merge request: %w", err)
+	}
+	return strconv.FormatInt(note.ID, 10), nil
+}
+
 // FormatMergeRequestUrl constructs a GitLab merge request URL from the client's base URL and repository details.
 // The client's BaseURL() returns the full API URL including protocol (e.g., "https://gitlab.example.com/api/v4").
 // This function extracts the scheme and host to construct the web UI URL for the merge request.
@@ -52,6 +52,19 @@ func buildStateToPhase(buildState gitlab.BuildStateValue) v1alpha1.CommitStatusP
 	}
 }
 
+// commitStatusStateToPhase maps the state of a commit status read from GitLab, which may be any job state, to a
+// CommitStatusPhase. Jobs that have not finished yet are pending, and skipped jobs are successful.
+func commitStatusStateToPhase(state gitlab.BuildStateValue) v1alpha1.CommitStatusPhase {
+	switch state {
+	case gitlab.Success, gitlab.Skipped:
+		return v1alpha1.CommitPhaseSuccess
+	case gitlab.Failed, gitlab.Canceled:
+		return v1alpha1.CommitPhaseFailure
+	default:
+		return v1alpha1.CommitPhasePending
+	}
+}
+
 // ApplyHTTPAuth applies GitLab authentication to the HTTP request using a PRIVATE-TOKEN header.
 func ApplyHTTPAuth(secret corev1.Secret, req *http.Request) error {
 	token := string(secret.Data["token"])
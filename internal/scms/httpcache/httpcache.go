@@ -0,0 +1,222 @@
+// Package httpcache provides the HTTP middleware that caches the responses to SCM API reads. It revalidates cached
+// responses with conditional requests, so that an unchanged resource costs a 304 response, which GitHub and GitLab do
+// not count against the rate limit, instead of a full response. Every request still reaches the SCM, so callers never
+// see stale data.
+package httpcache
+
+import (
+	"bytes"
+	"container/list"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/argoproj-labs/gitops-promoter/internal/metrics"
+)
+
+const (
+	// maxCacheBytes bounds the size of the bodies held by the shared cache.
+	maxCacheBytes = 32 << 20
+	// maxEntryBytes is the size of the largest body that is cached. Larger responses are passed through as is.
+	maxEntryBytes = 1 << 20
+)
+
+// shared is the cache of every SCM client. Entries are keyed by credential, so clients never see each other's data.
+var shared = newCache(maxCacheBytes)
+
+// entry is a cached response.
+type entry struct {
+	key          string
+	etag         string
+	lastModified string
+	header       http.Header
+	body         []byte
+}
+
+// cache is a least recently used cache of responses, bounded by the total size of their bodies.
+type cache struct {
+	mu       sync.Mutex
+	maxBytes int
+	size     int
+	lru      *list.List
+	entries  map[string]*list.Element
+}
+
+func newCache(maxBytes int) *cache {
+	return &cache{
+		maxBytes: maxBytes,
+		lru:      list.New(),
+		entries:  map[string]*list.Element{},
+	}
+}
+
+// get returns the entry for key and marks it as recently used.
+func (c *cache) get(key string) *entry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	element, ok := c.entries[key]
+	if !ok {
+		return nil
+	}
+	c.lru.MoveToFront(element)
+	return element.Value.(*entry) //nolint:forcetypeassert // The list only holds entries.
+}
+
+// put stores e, replacing any entry with the same key, and evicts the least recently used entries until the cache fits.
+func (c *cache) put(e *entry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.removeLocked(e.key)
+	c.entries[e.key] = c.lru.PushFront(e)
+	c.size += len(e.body)
+	for c.size > c.maxBytes {
+		c.removeLocked(c.lru.Back().Value.(*entry).key) //nolint:forcetypeassert // The list only holds entries.
+	}
+}
+
+// remove deletes the entry for key, if any.
+func (c *cache) remove(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.removeLocked(key)
+}
+
+func (c *cache) removeLocked(key string) {
+	element, ok := c.entries[key]
+	if !ok {
+		return
+	}
+	c.lru.Remove(element)
+	delete(c.entries, key)
+	c.size -= len(element.Value.(*entry).body) //nolint:forcetypeassert // The list only holds entries.
+}
+
+type transport struct {
+	secret types.NamespacedName
+	scope  string
+	base   http.RoundTripper
+	cache  *cache
+}
+
+// NewTransport returns a transport that sends requests with base and caches the responses to reads made with the
+// credential in secret. scope tells apart credentials that share a secret but not their access, such as the
+// installations of a GitHub App, and is empty for credentials that have a single identity.
+func NewTransport(secret v1.Secret, scope string, base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &transport{
+		secret: types.NamespacedName{Namespace: secret.Namespace, Name: secret.Name},
+		scope:  scope,
+		base:   base,
+		cache:  shared,
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !cacheable(req) {
+		return t.base.RoundTrip(req) //nolint:wrapcheck // A RoundTripper must return the wrapped transport's error as is.
+	}
+
+	key := fmt.Sprintf("%s\x00%s\x00%s\x00%s", t.secret, t.scope, req.URL.String(), req.Header.Get("Accept"))
+	cached := t.cache.get(key)
+	if cached != nil {
+		// The request must not be modified, so the validators are set on a copy.
+		req = req.Clone(req.Context())
+		if cached.etag != "" {
+			req.Header.Set("If-None-Match", cached.etag)
+		}
+		if cached.lastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.lastModified)
+		}
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return resp, err //nolint:wrapcheck // A RoundTripper must return the wrapped transport's error as is.
+	}
+
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		metrics.RecordSCMCacheRequest(req.URL.Host, metrics.SCMCacheResultHit)
+		return cached.response(resp), nil
+	}
+	metrics.RecordSCMCacheRequest(req.URL.Host, metrics.SCMCacheResultMiss)
+
+	etag := resp.Header.Get("ETag")
+	lastModified := resp.Header.Get("Last-Modified")
+	if resp.StatusCode != http.StatusOK || (etag == "" && lastModified == "") || noStore(resp) {
+		t.cache.remove(key)
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxEntryBytes+1))
+	if err != nil {
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if len(body) > maxEntryBytes {
+		t.cache.remove(key)
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+		return resp, nil
+	}
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	t.cache.put(&entry{
+		key:          key,
+		etag:         etag,
+		lastModified: lastModified,
+		header:       resp.Header.Clone(),
+		body:         body,
+	})
+	return resp, nil
+}
+
+// response returns the cached response, with the headers of notModified, which carry the current rate limit, in place
+// of the cached ones.
+func (e *entry) response(notModified *http.Response) *http.Response {
+	_ = notModified.Body.Close()
+
+	header := e.header.Clone()
+	for name, values := range notModified.Header {
+		header[name] = values
+	}
+	header.Set("Content-Length", strconv.Itoa(len(e.body)))
+
+	resp := *notModified
+	resp.Status = fmt.Sprintf("%d %s", http.StatusOK, http.StatusText(http.StatusOK))
+	resp.StatusCode = http.StatusOK
+	resp.Header = header
+	resp.ContentLength = int64(len(e.body))
+	resp.Body = io.NopCloser(bytes.NewReader(e.body))
+	return &resp
+}
+
+// cacheable returns whether the response to req can be cached. Requests that carry their own validators or ask for part
+// of a resource are passed through as is.
+func cacheable(req *http.Request) bool {
+	return req.Method == http.MethodGet &&
+		req.Header.Get("Range") == "" &&
+		req.Header.Get("If-None-Match") == "" &&
+		req.Header.Get("If-Modified-Since") == ""
+}
+
+// noStore returns whether resp asks not to be cached.
+func noStore(resp *http.Response) bool {
+	for _, directive := range strings.Split(resp.Header.Get("Cache-Control"), ",") {
+		if strings.EqualFold(strings.TrimSpace(directive), "no-store") {
+			return true
+		}
+	}
+	return false
+}
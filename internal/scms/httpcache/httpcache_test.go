@@ -0,0 +1,131 @@
+package httpcache
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Transport", func() {
+	var (
+		server    *httptest.Server
+		etag      string
+		body      string
+		remaining int
+		sent      []string
+		newClient func(secretName string) *http.Client
+	)
+
+	BeforeEach(func() {
+		etag = `"v1"`
+		body = `{"state":"open"}`
+		remaining = 5000
+		sent = nil
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			sent = append(sent, r.Header.Get("If-None-Match"))
+			remaining--
+			w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+			w.Header().Set("ETag", etag)
+			if r.Header.Get("If-None-Match") == etag {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(body))
+		}))
+		// Every spec uses its own secret, since the cache is shared by every client.
+		newClient = func(secretName string) *http.Client {
+			secret := v1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: secretName}}
+			return &http.Client{Transport: NewTransport(secret, "", nil)}
+		}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	get := func(client *http.Client) *http.Response {
+		resp, err := client.Get(server.URL + "/pulls/1")
+		Expect(err).NotTo(HaveOccurred())
+		return resp
+	}
+
+	read := func(resp *http.Response) string {
+		defer func() { Expect(resp.Body.Close()).To(Succeed()) }()
+		data, err := io.ReadAll(resp.Body)
+		Expect(err).NotTo(HaveOccurred())
+		return string(data)
+	}
+
+	It("revalidates cached responses and serves them on 304", func() {
+		client := newClient("revalidates")
+		Expect(read(get(client))).To(Equal(body))
+
+		resp := get(client)
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		Expect(resp.Header.Get("Content-Type")).To(Equal("application/json"))
+		Expect(resp.Header.Get("X-RateLimit-Remaining")).To(Equal("4998"))
+		Expect(read(resp)).To(Equal(body))
+		Expect(sent).To(Equal([]string{"", `"v1"`}))
+	})
+
+	It("replaces cached responses that changed", func() {
+		client := newClient("replaces")
+		Expect(read(get(client))).To(Equal(body))
+
+		etag = `"v2"`
+		body = `{"state":"closed"}`
+		Expect(read(get(client))).To(Equal(body))
+		Expect(read(get(client))).To(Equal(body))
+		Expect(sent).To(Equal([]string{"", `"v1"`, `"v2"`}))
+	})
+
+	It("does not share responses between credentials", func() {
+		Expect(read(get(newClient("first")))).To(Equal(body))
+		Expect(read(get(newClient("second")))).To(Equal(body))
+		Expect(sent).To(Equal([]string{"", ""}))
+	})
+
+	It("does not cache writes", func() {
+		client := newClient("writes")
+		for range 2 {
+			resp, err := client.Post(server.URL+"/pulls/1", "application/json", strings.NewReader(`{}`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(read(resp)).To(Equal(body))
+		}
+		Expect(sent).To(Equal([]string{"", ""}))
+	})
+
+	It("passes through responses that are too large to cache", func() {
+		client := newClient("large")
+		body = strings.Repeat("a", maxEntryBytes+1)
+		Expect(read(get(client))).To(Equal(body))
+		Expect(read(get(client))).To(Equal(body))
+		Expect(sent).To(Equal([]string{"", ""}))
+	})
+})
+
+var _ = Describe("cache", func() {
+	It("evicts the least recently used entries", func() {
+		c := newCache(10)
+		c.put(&entry{key: "a", body: []byte("aaaa")})
+		c.put(&entry{key: "b", body: []byte("bbbb")})
+		Expect(c.get("a")).NotTo(BeNil())
+
+		c.put(&entry{key: "c", body: []byte("cccc")})
+		Expect(c.get("a")).NotTo(BeNil())
+		Expect(c.get("b")).To(BeNil())
+		Expect(c.get("c")).NotTo(BeNil())
+		Expect(c.size).To(Equal(8))
+
+		c.put(&entry{key: "c", body: []byte("cc")})
+		Expect(c.size).To(Equal(6))
+	})
+})
@@ -2,19 +2,39 @@ package scms
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
 	"github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
 )
 
+// ErrMergeScheduled is returned by PullRequestProvider.Merge when the SCM accepted the merge but will perform it later,
+// for example once the pull request's pipeline succeeds. The pull request stays open until the SCM merges it, and Merge
+// is called again on later reconciliations, so it must be idempotent.
+var ErrMergeScheduled = errors.New("merge scheduled")
+
+// ErrCommentNotFound is returned by PullRequestProvider.UpsertComment when the comment to update no longer exists, for
+// example because someone deleted it. The caller posts a new comment instead.
+var ErrCommentNotFound = errors.New("comment not found")
+
+// ErrMergeQueued is returned by PullRequestProvider.Merge when the target branch requires a merge queue and the pull
+// request was added to it, or already is in it, instead of being merged. It is an ErrMergeScheduled.
+var ErrMergeQueued = fmt.Errorf("%w: added to the merge queue", ErrMergeScheduled)
+
 // PullRequestProvider defines the interface for managing pull requests in a source control management system.
 type PullRequestProvider interface {
-	// Create creates a new pull request with the specified title, head, base, and description.
+	// Create creates a new pull request with the specified title, head, base, and description. The pull request is
+	// opened as a draft, or marked as work in progress, if pullRequest.Spec.Draft is set.
 	Create(ctx context.Context, title, head, base, description string, pullRequest v1alpha1.PullRequest) (string, error)
 	// Close closes an existing pull request.
 	// pullRequest.Status.ID is guaranteed to be set when this is called.
 	Close(ctx context.Context, pullRequest v1alpha1.PullRequest) error
-	// Update updates an existing pull request with the specified title, description, and pull request details.
+	// Update updates an existing pull request with the specified title, description, and pull request details. It
+	// marks the pull request as a draft or as ready for review to match pullRequest.Spec.Draft.
 	// pullRequest.Status.ID is guaranteed to be set when this is called.
 	Update(ctx context.Context, title, description string, pullRequest v1alpha1.PullRequest) error
 	// Merge merges an existing pull request with the specified commit message.
@@ -25,4 +45,89 @@ type PullRequestProvider interface {
 	FindOpen(ctx context.Context, pullRequest v1alpha1.PullRequest) (found bool, id string, creationTime time.Time, err error)
 	// GetUrl retrieves the URL of the pull request.
 	GetUrl(ctx context.Context, pullRequest v1alpha1.PullRequest) (string, error)
+	// UpsertComment posts a comment with the given body on the pull request, or replaces the body of the comment with
+	// the given ID if it is set, and returns the ID of the comment. Providers whose comments cannot be edited post a
+	// new comment and return an empty ID. If the comment with the given ID no longer exists, an error wrapping
+	// ErrCommentNotFound is returned.
+	// pullRequest.Status.ID is guaranteed to be set when this is called.
+	UpsertComment(ctx context.Context, pullRequest v1alpha1.PullRequest, commentID, body string) (string, error)
+}
+
+// PullRequestConditionsProvider is an optional interface for PullRequestProviders that report the SCM's view of whether
+// a pull request can be merged, such as its approvals and pipeline. The conditions are set on the PullRequest status.
+type PullRequestConditionsProvider interface {
+	// GetConditions returns conditions describing the open pull request on the SCM.
+	// pullRequest.Status.ID is guaranteed to be set when this is called.
+	GetConditions(ctx context.Context, pullRequest v1alpha1.PullRequest) ([]metav1.Condition, error)
+}
+
+// PullRequestMergeQueueProvider is an optional interface for PullRequestProviders whose SCM can require pull requests
+// to be merged through a merge queue. Their Merge adds the pull request to the queue of a branch that requires one and
+// returns ErrMergeQueued.
+type PullRequestMergeQueueProvider interface {
+	// GetMergeQueueStatus returns the state of the open pull request in the merge queue of its target branch, or nil if
+	// it is not in the queue. Only State, Position, EnqueuedAt and Message are used.
+	// pullRequest.Status.ID is guaranteed to be set when this is called.
+	GetMergeQueueStatus(ctx context.Context, pullRequest v1alpha1.PullRequest) (*v1alpha1.PullRequestMergeQueueStatus, error)
+}
+
+// PullRequestBranchProtectionProvider is an optional interface for PullRequestProviders whose SCM can protect the
+// target branch of a pull request with required checks and reviews. The PullRequest controller does not merge a pull
+// request whose branch protection is not satisfied, since the SCM would refuse the merge.
+type PullRequestBranchProtectionProvider interface {
+	// GetBranchProtection returns the requirements that the protection of the pull request's target branch places on
+	// the open pull request, and which of them are met, or nil if the target branch is not protected.
+	// pullRequest.Status.ID is guaranteed to be set when this is called.
+	GetBranchProtection(ctx context.Context, pullRequest v1alpha1.PullRequest) (*BranchProtection, error)
+}
+
+// BranchProtection describes the requirements that branch protection places on a pull request, and which of them the
+// pull request meets.
+type BranchProtection struct {
+	// RequiredChecks are the names of the checks that must succeed on the head commit of the pull request.
+	RequiredChecks []string
+	// UnmetChecks are the required checks that have not succeeded on the head commit of the pull request.
+	UnmetChecks []string
+	// RequiredApprovals is the number of approving reviews the pull request needs.
+	RequiredApprovals int
+	// Approvals is the number of approving reviews the pull request has.
+	Approvals int
+	// UnmetRequirements describe other requirements that the pull request does not meet, in the words of the SCM, for
+	// SCMs that only report why a merge would be refused.
+	UnmetRequirements []string
+}
+
+// Satisfied returns whether the pull request meets all the requirements of the branch protection.
+func (b BranchProtection) Satisfied() bool {
+	return len(b.UnmetChecks) == 0 && b.Approvals >= b.RequiredApprovals && len(b.UnmetRequirements) == 0
+}
+
+// Message describes the unmet requirements of the branch protection, or that all of them are met.
+func (b BranchProtection) Message() string {
+	if b.Satisfied() {
+		return fmt.Sprintf("Branch protection is satisfied: %d required checks succeeded and %d of %d required approvals were given",
+			len(b.RequiredChecks), b.Approvals, b.RequiredApprovals)
+	}
+	var unmet []string
+	if len(b.UnmetChecks) > 0 {
+		unmet = append(unmet, "required checks have not succeeded: "+strings.Join(b.UnmetChecks, ", "))
+	}
+	if b.Approvals < b.RequiredApprovals {
+		unmet = append(unmet, fmt.Sprintf("%d of %d required approvals were given", b.Approvals, b.RequiredApprovals))
+	}
+	unmet = append(unmet, b.UnmetRequirements...)
+	return "Branch protection is not satisfied: " + strings.Join(unmet, "; ")
+}
+
+// SplitReviewers splits pull request reviewers into users and teams. Teams are written as "<organization>/<team>"; the
+// returned teams are the team part only.
+func SplitReviewers(reviewers []string) (users, teams []string) {
+	for _, reviewer := range reviewers {
+		if _, team, ok := strings.Cut(reviewer, "/"); ok {
+			teams = append(teams, team)
+		} else {
+			users = append(users, reviewer)
+		}
+	}
+	return users, teams
 }
@@ -0,0 +1,443 @@
+// Package ratelimit provides the HTTP middleware that SCM clients send their API requests through. It tracks the rate
+// limit quota of each credential on each SCM host, paces requests as the quota runs low, defers requests that can
+// wait, and honours Retry-After and secondary rate limits, so that a burst of promotions slows down instead of
+// stalling on a throttled SCM.
+package ratelimit
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/argoproj-labs/gitops-promoter/internal/metrics"
+)
+
+const (
+	// lowQuotaFraction is the fraction of the quota below which requests are paced and deferrable requests deferred.
+	lowQuotaFraction = 0.1
+	// maxWait is the longest a request waits for quota before it fails with a ThrottledError.
+	maxWait = 30 * time.Second
+	// secondaryRateLimitBackoff is how long to wait after a secondary rate limit that does not say how long to wait.
+	// GitHub asks clients to wait at least a minute, and exponentially longer while they keep hitting the limit.
+	secondaryRateLimitBackoff = time.Minute
+	// maxBackoff caps the exponential backoff after repeated rate limits.
+	maxBackoff = 15 * time.Minute
+	// maxBodyPeek is how much of a forbidden response is read to tell a secondary rate limit from a permission error.
+	maxBodyPeek = 64 << 10
+)
+
+// ThrottledError is returned for a request that was not sent because its credential is being throttled.
+type ThrottledError struct {
+	// Host is the SCM host the request was for.
+	Host string
+	// RetryAfter is how long to wait before sending the request again.
+	RetryAfter time.Duration
+	// Reason says why the credential is throttled.
+	Reason string
+	// Deferred is true if the request could wait and was deferred because the quota is low, rather than rejected
+	// because the quota is exhausted.
+	Deferred bool
+}
+
+// Error implements error.
+func (e *ThrottledError) Error() string {
+	if e.Deferred {
+		return fmt.Sprintf("request to %s deferred for %s: %s", e.Host, e.RetryAfter.Round(time.Second), e.Reason)
+	}
+	return fmt.Sprintf("requests to %s are throttled for %s: %s", e.Host, e.RetryAfter.Round(time.Second), e.Reason)
+}
+
+// Deferred returns how long to wait before retrying a request that was deferred because it could wait, and whether
+// err is such a deferral.
+func Deferred(err error) (time.Duration, bool) {
+	var throttledErr *ThrottledError
+	if !errors.As(err, &throttledErr) || !throttledErr.Deferred {
+		return 0, false
+	}
+	return throttledErr.RetryAfter, true
+}
+
+type deferrableKey struct{}
+
+// WithDeferrable returns a context whose requests can be deferred while the quota is low, rather than paced. Use it
+// for calls that are repeated anyway, such as polling for the state of a pull request.
+func WithDeferrable(ctx context.Context) context.Context {
+	return context.WithValue(ctx, deferrableKey{}, true)
+}
+
+func isDeferrable(ctx context.Context) bool {
+	deferrable, _ := ctx.Value(deferrableKey{}).(bool)
+	return deferrable
+}
+
+// Throttle describes a credential whose requests to a host are being held back.
+type Throttle struct {
+	// Host is the SCM host.
+	Host string
+	// Scope tells apart credentials that share a secret but not a quota, such as the installations of a GitHub App.
+	Scope string
+	// Until is when the throttling is expected to end.
+	Until time.Time
+	// Exhausted is true if every request is held back, and false if only deferrable requests are deferred because the
+	// quota is low.
+	Exhausted bool
+	// Reason says why the credential is throttled.
+	Reason string
+}
+
+// Limiter tracks the rate limit quota of a credential on a host.
+type Limiter struct {
+	secret types.NamespacedName
+	scope  string
+	host   string
+
+	mu sync.Mutex
+	// known is true once a response reported the quota.
+	known     bool
+	limit     int
+	remaining int
+	reset     time.Time
+	// next is the earliest time the next request may be sent while the quota is low.
+	next time.Time
+	// blockedUntil is when requests may be sent again after the SCM throttled the credential.
+	blockedUntil  time.Time
+	blockedReason string
+	// backoffs is the number of throttled responses in a row.
+	backoffs int
+}
+
+type limiterKey struct {
+	secret types.NamespacedName
+	scope  string
+	host   string
+}
+
+var (
+	// limiters holds the limiter of every credential and host that requests were sent for.
+	limiters = make(map[limiterKey]*Limiter)
+	// limitersMutex protects access to the limiters map.
+	limitersMutex sync.Mutex
+
+	// listeners are notified when a credential starts being throttled.
+	listeners []func(secret types.NamespacedName)
+	// listenersMutex protects access to the listeners slice.
+	listenersMutex sync.RWMutex
+)
+
+// For returns the limiter of the credential in secret for host. scope tells apart credentials that share a secret but
+// not a quota, and is empty for credentials that have a single quota.
+func For(secret types.NamespacedName, scope, host string) *Limiter {
+	key := limiterKey{secret: secret, scope: scope, host: host}
+
+	limitersMutex.Lock()
+	defer limitersMutex.Unlock()
+
+	if limiter, found := limiters[key]; found {
+		return limiter
+	}
+	limiter := &Limiter{secret: secret, scope: scope, host: host}
+	limiters[key] = limiter
+	return limiter
+}
+
+// OnThrottle registers fn to be called with the secret of a credential when it starts being throttled. fn must not
+// block.
+func OnThrottle(fn func(secret types.NamespacedName)) {
+	listenersMutex.Lock()
+	defer listenersMutex.Unlock()
+	listeners = append(listeners, fn)
+}
+
+// Throttles returns the hosts for which the credentials in secret are being throttled, sorted by host and scope.
+func Throttles(secret types.NamespacedName) []Throttle {
+	limitersMutex.Lock()
+	matching := make([]*Limiter, 0, len(limiters))
+	for key, limiter := range limiters {
+		if key.secret == secret {
+			matching = append(matching, limiter)
+		}
+	}
+	limitersMutex.Unlock()
+
+	now := time.Now()
+	throttles := []Throttle{}
+	for _, limiter := range matching {
+		limiter.mu.Lock()
+		throttle, throttled := limiter.throttleLocked(now)
+		limiter.mu.Unlock()
+		if throttled {
+			throttles = append(throttles, throttle)
+		}
+	}
+	slices.SortFunc(throttles, func(a, b Throttle) int {
+		if c := strings.Compare(a.Host, b.Host); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Scope, b.Scope)
+	})
+	return throttles
+}
+
+// Wait blocks until a request may be sent. It returns a ThrottledError instead if the request would have to wait
+// longer than maxWait, or if ctx is deferrable and the quota is low.
+func (l *Limiter) Wait(ctx context.Context) error {
+	delay, err := l.reserve(time.Now(), isDeferrable(ctx))
+	if err != nil {
+		var throttledErr *ThrottledError
+		if errors.As(err, &throttledErr) && throttledErr.Deferred {
+			metrics.RecordSCMCallThrottled(l.host, metrics.SCMThrottleActionDeferred)
+		} else {
+			metrics.RecordSCMCallThrottled(l.host, metrics.SCMThrottleActionRejected)
+		}
+		return err
+	}
+	if delay <= 0 {
+		return nil
+	}
+
+	metrics.RecordSCMCallThrottled(l.host, metrics.SCMThrottleActionDelayed)
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err() //nolint:wrapcheck // The context error is returned as is, like an HTTP client would.
+	case <-timer.C:
+		return nil
+	}
+}
+
+// reserve returns how long a request sent at now must wait.
+func (l *Limiter) reserve(now time.Time, deferrable bool) (time.Duration, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Before(l.blockedUntil) {
+		return l.hold(l.blockedUntil.Sub(now), l.blockedReason, deferrable)
+	}
+	if !l.lowLocked(now) {
+		return 0, nil
+	}
+	if l.remaining <= 0 {
+		return l.hold(l.reset.Sub(now), "the rate limit quota is exhausted", deferrable)
+	}
+	if deferrable {
+		return 0, l.throttledError(l.reset.Sub(now), "the rate limit quota is low", true)
+	}
+
+	// Spread the remaining quota over the time until it resets, so that it is not used up before then.
+	slot := now
+	if l.next.After(slot) {
+		slot = l.next
+	}
+	delay := slot.Sub(now)
+	if delay > maxWait {
+		return 0, l.throttledError(delay, "the rate limit quota is low", false)
+	}
+	l.next = slot.Add(l.reset.Sub(now) / time.Duration(l.remaining))
+	l.remaining--
+	return delay, nil
+}
+
+// hold returns how long a request must wait while the credential is blocked, or a ThrottledError if it cannot wait.
+func (l *Limiter) hold(delay time.Duration, reason string, deferrable bool) (time.Duration, error) {
+	if deferrable || delay > maxWait {
+		return 0, l.throttledError(delay, reason, deferrable)
+	}
+	return delay, nil
+}
+
+func (l *Limiter) throttledError(retryAfter time.Duration, reason string, deferred bool) *ThrottledError {
+	return &ThrottledError{Host: l.host, RetryAfter: retryAfter, Reason: reason, Deferred: deferred}
+}
+
+// lowLocked returns whether the quota is low. The caller must hold l.mu.
+func (l *Limiter) lowLocked(now time.Time) bool {
+	if !l.known || !now.Before(l.reset) {
+		return false
+	}
+	return l.remaining <= 0 || (l.limit > 0 && float64(l.remaining) < float64(l.limit)*lowQuotaFraction)
+}
+
+// throttleLocked returns the throttle of the limiter, if any. The caller must hold l.mu.
+func (l *Limiter) throttleLocked(now time.Time) (Throttle, bool) {
+	throttle := Throttle{Host: l.host, Scope: l.scope}
+	switch {
+	case now.Before(l.blockedUntil):
+		throttle.Until, throttle.Exhausted, throttle.Reason = l.blockedUntil, true, l.blockedReason
+	case l.lowLocked(now) && l.remaining <= 0:
+		throttle.Until, throttle.Exhausted, throttle.Reason = l.reset, true, "the rate limit quota is exhausted"
+	case l.lowLocked(now):
+		throttle.Until, throttle.Reason = l.reset, fmt.Sprintf("%d of %d requests remain", l.remaining, l.limit)
+	default:
+		return Throttle{}, false
+	}
+	return throttle, true
+}
+
+// Observe updates the quota from the rate limit headers of resp, and blocks requests if resp shows that the credential
+// is being throttled. The body of a forbidden response may be read to find out whether it is a secondary rate limit;
+// it is replaced so that the caller can still read it.
+func (l *Limiter) Observe(resp *http.Response) {
+	secondary := false
+	if resp.StatusCode == http.StatusForbidden && resp.Header.Get("Retry-After") == "" && resp.Body != nil {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxBodyPeek))
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+		lowerBody := strings.ToLower(string(body))
+		secondary = strings.Contains(lowerBody, "secondary rate limit") || strings.Contains(lowerBody, "abuse detection")
+	}
+	l.observe(time.Now(), resp.StatusCode, resp.Header, secondary)
+}
+
+// ObserveStatus blocks requests if statusCode shows that the credential is being throttled. It is used for SCM clients
+// that do not expose response headers.
+func (l *Limiter) ObserveStatus(statusCode int) {
+	l.observe(time.Now(), statusCode, nil, false)
+}
+
+func (l *Limiter) observe(now time.Time, statusCode int, header http.Header, secondary bool) {
+	l.mu.Lock()
+	_, wasThrottled := l.throttleLocked(now)
+
+	if limit, remaining, reset, ok := parseQuota(header, now); ok {
+		l.known, l.limit, l.remaining, l.reset = true, limit, remaining, reset
+	}
+	retryAfter, hasRetryAfter := parseRetryAfter(header, now)
+	exhausted := l.known && l.remaining <= 0 && now.Before(l.reset)
+
+	throttled := statusCode == http.StatusTooManyRequests || secondary ||
+		(statusCode == http.StatusForbidden && (hasRetryAfter || exhausted)) ||
+		(statusCode == http.StatusServiceUnavailable && hasRetryAfter)
+	switch {
+	case throttled:
+		l.backoffs++
+		var wait time.Duration
+		var reason string
+		switch {
+		case hasRetryAfter:
+			wait, reason = retryAfter, fmt.Sprintf("the SCM asked to retry after %s", retryAfter.Round(time.Second))
+		case exhausted:
+			wait, reason = l.reset.Sub(now), "the rate limit quota is exhausted"
+		default:
+			wait, reason = backoff(l.backoffs), "the SCM reported a secondary rate limit"
+		}
+		if until := now.Add(wait); until.After(l.blockedUntil) {
+			l.blockedUntil, l.blockedReason = until, reason
+		}
+	case statusCode < http.StatusBadRequest:
+		l.backoffs = 0
+	}
+
+	_, isThrottled := l.throttleLocked(now)
+	l.mu.Unlock()
+
+	if !wasThrottled && isThrottled {
+		listenersMutex.RLock()
+		defer listenersMutex.RUnlock()
+		for _, listener := range listeners {
+			listener(l.secret)
+		}
+	}
+}
+
+// backoff returns how long to wait after the given number of rate limited responses in a row.
+func backoff(backoffs int) time.Duration {
+	wait := secondaryRateLimitBackoff
+	for i := 1; i < backoffs && wait < maxBackoff; i++ {
+		wait *= 2
+	}
+	return min(wait, maxBackoff)
+}
+
+// parseQuota reads the rate limit quota from the headers used by GitHub, GitLab, Gitea, Forgejo and Azure DevOps.
+func parseQuota(header http.Header, now time.Time) (limit, remaining int, reset time.Time, ok bool) {
+	remaining, ok = headerInt(header, "X-RateLimit-Remaining", "RateLimit-Remaining")
+	if !ok {
+		return 0, 0, time.Time{}, false
+	}
+	limit, _ = headerInt(header, "X-RateLimit-Limit", "RateLimit-Limit")
+	resetValue, ok := headerInt(header, "X-RateLimit-Reset", "RateLimit-Reset")
+	if !ok {
+		return 0, 0, time.Time{}, false
+	}
+	// The reset is a Unix timestamp on most SCMs, and the number of seconds until the reset on some.
+	if resetValue > 1_000_000_000 {
+		reset = time.Unix(int64(resetValue), 0)
+	} else {
+		reset = now.Add(time.Duration(resetValue) * time.Second)
+	}
+	return limit, remaining, reset, true
+}
+
+// parseRetryAfter reads the Retry-After header, which is either a number of seconds or an HTTP date.
+func parseRetryAfter(header http.Header, now time.Time) (time.Duration, bool) {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(max(seconds, 0)) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+func headerInt(header http.Header, names ...string) (int, bool) {
+	for _, name := range names {
+		if value := header.Get(name); value != "" {
+			parsed, err := strconv.Atoi(value)
+			return parsed, err == nil
+		}
+	}
+	return 0, false
+}
+
+// transport sends requests through the limiter of their host.
+type transport struct {
+	secret types.NamespacedName
+	scope  string
+	base   http.RoundTripper
+}
+
+// NewTransport returns a transport that sends requests with base through the limiter of the credential in secret for
+// the request's host. scope tells apart credentials that share a secret but not a quota, such as the installations of
+// a GitHub App, and is empty for credentials that have a single quota.
+func NewTransport(secret v1.Secret, scope string, base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &transport{
+		secret: types.NamespacedName{Namespace: secret.Namespace, Name: secret.Name},
+		scope:  scope,
+		base:   base,
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	limiter := For(t.secret, t.scope, req.URL.Host)
+	if err := limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return resp, err //nolint:wrapcheck // A RoundTripper must return the wrapped transport's error as is.
+	}
+	limiter.Observe(resp)
+	return resp, nil
+}
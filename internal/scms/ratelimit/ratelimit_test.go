@@ -0,0 +1,178 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("Transport", func() {
+	var (
+		server  *httptest.Server
+		secret  v1.Secret
+		client  *http.Client
+		respond func(w http.ResponseWriter)
+		calls   int
+		mu      sync.Mutex
+	)
+
+	BeforeEach(func() {
+		calls = 0
+		respond = func(w http.ResponseWriter) { w.WriteHeader(http.StatusOK) }
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+			calls++
+			respond(w)
+		}))
+		// Every spec uses its own secret, since limiters are shared by every client of a credential.
+		secret = v1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: CurrentSpecReport().LeafNodeText}}
+		client = &http.Client{Transport: NewTransport(secret, "", nil)}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	get := func(ctx context.Context) (*http.Response, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+		Expect(err).NotTo(HaveOccurred())
+		return client.Do(req)
+	}
+
+	host := func() string {
+		serverURL, err := url.Parse(server.URL)
+		Expect(err).NotTo(HaveOccurred())
+		return serverURL.Host
+	}
+
+	quota := func(remaining int) func(w http.ResponseWriter) {
+		return func(w http.ResponseWriter) {
+			w.Header().Set("X-RateLimit-Limit", "100")
+			w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+			w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10))
+			w.WriteHeader(http.StatusOK)
+		}
+	}
+
+	It("sends requests while the quota is healthy", func() {
+		respond = quota(90)
+		for range 3 {
+			resp, err := get(WithDeferrable(context.Background()))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.Body.Close()).To(Succeed())
+		}
+		Expect(calls).To(Equal(3))
+		Expect(Throttles(types.NamespacedName{Namespace: "default", Name: secret.Name})).To(BeEmpty())
+	})
+
+	It("defers deferrable requests while the quota is low", func() {
+		respond = quota(5)
+		resp, err := get(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.Body.Close()).To(Succeed())
+
+		_, err = get(WithDeferrable(context.Background()))
+		retryAfter, deferred := Deferred(err)
+		Expect(deferred).To(BeTrue())
+		Expect(retryAfter).To(BeNumerically("~", time.Hour, time.Minute))
+		Expect(calls).To(Equal(1))
+
+		By("sending requests that cannot wait")
+		resp, err = get(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.Body.Close()).To(Succeed())
+		Expect(calls).To(Equal(2))
+
+		throttles := Throttles(types.NamespacedName{Namespace: "default", Name: secret.Name})
+		Expect(throttles).To(HaveLen(1))
+		Expect(throttles[0].Host).To(Equal(host()))
+		Expect(throttles[0].Exhausted).To(BeFalse())
+	})
+
+	It("honours Retry-After and notifies listeners", func() {
+		var notified []types.NamespacedName
+		OnThrottle(func(throttled types.NamespacedName) {
+			mu.Lock()
+			defer mu.Unlock()
+			notified = append(notified, throttled)
+		})
+
+		respond = func(w http.ResponseWriter) {
+			w.Header().Set("Retry-After", "120")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+		resp, err := get(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusTooManyRequests))
+		Expect(resp.Body.Close()).To(Succeed())
+
+		_, err = get(context.Background())
+		var throttledErr *ThrottledError
+		Expect(errors.As(err, &throttledErr)).To(BeTrue())
+		Expect(throttledErr.Deferred).To(BeFalse())
+		Expect(throttledErr.RetryAfter).To(BeNumerically("~", 2*time.Minute, 5*time.Second))
+		Expect(calls).To(Equal(1))
+
+		throttles := Throttles(types.NamespacedName{Namespace: "default", Name: secret.Name})
+		Expect(throttles).To(HaveLen(1))
+		Expect(throttles[0].Exhausted).To(BeTrue())
+
+		mu.Lock()
+		defer mu.Unlock()
+		Expect(notified).To(ContainElement(types.NamespacedName{Namespace: "default", Name: secret.Name}))
+	})
+
+	It("backs off after a secondary rate limit and leaves the body readable", func() {
+		const message = `{"message":"You have exceeded a secondary rate limit."}`
+		respond = func(w http.ResponseWriter) {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(message))
+		}
+		resp, err := get(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		body, err := io.ReadAll(resp.Body)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.Body.Close()).To(Succeed())
+		Expect(string(body)).To(Equal(message))
+
+		_, err = get(context.Background())
+		var throttledErr *ThrottledError
+		Expect(errors.As(err, &throttledErr)).To(BeTrue())
+		Expect(throttledErr.RetryAfter).To(BeNumerically("~", secondaryRateLimitBackoff, 5*time.Second))
+	})
+
+	It("does not throttle forbidden responses that are not rate limits", func() {
+		respond = func(w http.ResponseWriter) {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"message":"Resource not accessible by integration"}`))
+		}
+		for range 2 {
+			resp, err := get(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.Body.Close()).To(Succeed())
+		}
+		Expect(calls).To(Equal(2))
+	})
+})
+
+var _ = Describe("backoff", func() {
+	It("doubles up to the maximum", func() {
+		Expect(backoff(1)).To(Equal(time.Minute))
+		Expect(backoff(2)).To(Equal(2 * time.Minute))
+		Expect(backoff(3)).To(Equal(4 * time.Minute))
+		Expect(backoff(10)).To(Equal(maxBackoff))
+	})
+})
@@ -8,6 +8,7 @@ import (
 
 	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
 	"golang.org/x/time/rate"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -15,6 +16,16 @@ import (
 const (
 	// ControllerConfigurationName is the name of the global controller configuration resource.
 	ControllerConfigurationName = "promoter-controller-configuration"
+
+	// DefaultMaxConcurrentStatusOperations is the default for ChangeTransferPolicyConfiguration.MaxConcurrentStatusOperations.
+	DefaultMaxConcurrentStatusOperations = 4
+
+	// DefaultWebhookMaxPayloadBytes is the default for WebhookReceiverConfiguration.MaxPayloadBytes. It matches the
+	// largest payload GitHub sends.
+	DefaultWebhookMaxPayloadBytes = 25 * 1024 * 1024
+
+	// DefaultWebhookDeliveryDeduplicationWindow is the default for WebhookReceiverConfiguration.DeliveryDeduplicationWindow.
+	DefaultWebhookDeliveryDeduplicationWindow = 10 * time.Minute
 )
 
 // ControllerConfigurationTypes is a constraint that defines the set of controller configuration types
@@ -30,6 +41,7 @@ const (
 //   - TimedCommitStatusConfiguration
 //   - GitCommitStatusConfiguration
 //   - WebRequestCommitStatusConfiguration
+//   - ScmCheckCommitStatusConfiguration
 type ControllerConfigurationTypes interface {
 	promoterv1alpha1.PromotionStrategyConfiguration |
 		promoterv1alpha1.ChangeTransferPolicyConfiguration |
@@ -38,7 +50,8 @@ type ControllerConfigurationTypes interface {
 		promoterv1alpha1.ArgoCDCommitStatusConfiguration |
 		promoterv1alpha1.TimedCommitStatusConfiguration |
 		promoterv1alpha1.GitCommitStatusConfiguration |
-		promoterv1alpha1.WebRequestCommitStatusConfiguration
+		promoterv1alpha1.WebRequestCommitStatusConfiguration |
+		promoterv1alpha1.ScmCheckCommitStatusConfiguration
 }
 
 // ControllerResultTypes is a constraint that defines the set of result types returned by controller
@@ -165,6 +178,44 @@ func (m *Manager) GetPullRequestControllersTemplate(ctx context.Context) (promot
 	return config.Spec.PullRequest.Template, nil
 }
 
+// GetChangeTransferPolicyMaxConcurrentStatusOperations retrieves the maximum number of network-bound operations a
+// ChangeTransferPolicy reconcile may run in parallel while calculating its status.
+//
+// This method requires the manager's cache to be started, so call it from within your Reconcile method.
+//
+// Returns the configured value, or DefaultMaxConcurrentStatusOperations if it is unset.
+func (m *Manager) GetChangeTransferPolicyMaxConcurrentStatusOperations(ctx context.Context) (int, error) {
+	config, err := m.getControllerConfiguration(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get controller configuration: %w", err)
+	}
+	if config.Spec.ChangeTransferPolicy.MaxConcurrentStatusOperations <= 0 {
+		return DefaultMaxConcurrentStatusOperations, nil
+	}
+	return config.Spec.ChangeTransferPolicy.MaxConcurrentStatusOperations, nil
+}
+
+// GetWebhookReceiverConfiguration retrieves the configuration of the webhook receiver.
+//
+// This method requires the manager's cache to be started.
+//
+// Returns the configuration with DefaultWebhookMaxPayloadBytes and DefaultWebhookDeliveryDeduplicationWindow filled in
+// for unset fields.
+func (m *Manager) GetWebhookReceiverConfiguration(ctx context.Context) (promoterv1alpha1.WebhookReceiverConfiguration, error) {
+	config, err := m.getControllerConfiguration(ctx)
+	if err != nil {
+		return promoterv1alpha1.WebhookReceiverConfiguration{}, fmt.Errorf("failed to get controller configuration: %w", err)
+	}
+	webhookReceiver := *config.Spec.WebhookReceiver.DeepCopy()
+	if webhookReceiver.MaxPayloadBytes <= 0 {
+		webhookReceiver.MaxPayloadBytes = DefaultWebhookMaxPayloadBytes
+	}
+	if webhookReceiver.DeliveryDeduplicationWindow == nil {
+		webhookReceiver.DeliveryDeduplicationWindow = &metav1.Duration{Duration: DefaultWebhookDeliveryDeduplicationWindow}
+	}
+	return webhookReceiver, nil
+}
+
 // GetRequeueDuration retrieves the requeue duration for a specific controller type.
 // The type parameter T must satisfy the ControllerConfigurationTypes constraint.
 //
@@ -285,6 +336,8 @@ func getWorkQueueForController[T ControllerConfigurationTypes](ctx context.Conte
 		return config.Spec.GitCommitStatus.WorkQueue, nil
 	case promoterv1alpha1.WebRequestCommitStatusConfiguration:
 		return config.Spec.WebRequestCommitStatus.WorkQueue, nil
+	case promoterv1alpha1.ScmCheckCommitStatusConfiguration:
+		return config.Spec.ScmCheckCommitStatus.WorkQueue, nil
 	default:
 		return promoterv1alpha1.WorkQueue{}, fmt.Errorf("unsupported configuration type: %T", cfg)
 	}
@@ -15,6 +15,30 @@ const (
 	Ready CommonType = "Ready"
 )
 
+// Condition types that apply to PullRequest.
+const (
+	// Approved is the condition type for a pull request that has the approvals the SCM requires to merge it.
+	Approved CommonType = "Approved"
+	// PipelineSucceeded is the condition type for a pull request whose head commit pipeline succeeded on the SCM.
+	PipelineSucceeded CommonType = "PipelineSucceeded"
+	// BranchProtectionSatisfied is the condition type for a pull request that meets the required checks and reviews of
+	// its target branch's protection on the SCM.
+	BranchProtectionSatisfied CommonType = "BranchProtectionSatisfied"
+)
+
+// Condition types that apply to ChangeTransferPolicy.
+const (
+	// Conflicted is the condition type for a ChangeTransferPolicy whose proposed branch conflicts with its active branch
+	// and the conflict was left unresolved.
+	Conflicted CommonType = "Conflicted"
+)
+
+// Condition types that apply to ScmProvider and ClusterScmProvider.
+const (
+	// Throttled is the condition type for an SCM provider whose credentials are being rate limited by the SCM.
+	Throttled CommonType = "Throttled"
+)
+
 // Reasons that apply to all CRDs.
 const (
 	// ReconciliationError is the condition type for an error during reconciliation.
@@ -33,6 +57,34 @@ const (
 const (
 	// PullRequestNotReady is the condition type for a pull request not being ready.
 	PullRequestNotReady CommonReason = "PullRequestNotReady"
+	// MergeConflict is the reason for a proposed branch that conflicts with the active branch and was left
+	// unresolved because the conflict resolution strategy is "fail".
+	MergeConflict CommonReason = "MergeConflict"
+	// RehydrationRequested is the reason for a proposed branch that conflicts with the active branch and is
+	// waiting for the hydrator to re-hydrate it because the conflict resolution strategy is "rebuild".
+	RehydrationRequested CommonReason = "RehydrationRequested"
+)
+
+// Reasons that apply to PullRequest.
+const (
+	// ApprovalsSatisfied is the condition reason for a pull request that has all required approvals.
+	ApprovalsSatisfied CommonReason = "ApprovalsSatisfied"
+	// ApprovalsRequired is the condition reason for a pull request that still needs approvals before it can be merged.
+	ApprovalsRequired CommonReason = "ApprovalsRequired"
+	// PipelineSuccess is the condition reason for a pull request whose pipeline succeeded.
+	PipelineSuccess CommonReason = "PipelineSuccess"
+	// PipelineRunning is the condition reason for a pull request whose pipeline has not finished.
+	PipelineRunning CommonReason = "PipelineRunning"
+	// PipelineFailed is the condition reason for a pull request whose pipeline failed or was canceled.
+	PipelineFailed CommonReason = "PipelineFailed"
+	// PipelineNotFound is the condition reason for a pull request without a pipeline.
+	PipelineNotFound CommonReason = "PipelineNotFound"
+	// BranchProtectionMet is the condition reason for a pull request that meets all requirements of its target branch's
+	// protection.
+	BranchProtectionMet CommonReason = "BranchProtectionMet"
+	// BranchProtectionNotSatisfied is the condition reason for a pull request that does not meet the required checks or
+	// reviews of its target branch's protection, so that the SCM would refuse to merge it.
+	BranchProtectionNotSatisfied CommonReason = "BranchProtectionNotSatisfied"
 )
 
 // Reasons that apply to PromotionStrategy.
@@ -42,3 +94,15 @@ const (
 	// PreviousEnvironmentCommitStatusNotReady is the condition type for a previous environment commit status not being ready.
 	PreviousEnvironmentCommitStatusNotReady CommonReason = "PreviousEnvironmentCommitStatusNotReady"
 )
+
+// Reasons that apply to ScmProvider and ClusterScmProvider.
+const (
+	// NotThrottled is the condition reason for an SCM provider whose credentials are not being rate limited.
+	NotThrottled CommonReason = "NotThrottled"
+	// RateLimitLow is the condition reason for an SCM provider whose rate limit quota is low, so that requests are paced
+	// and polling is deferred.
+	RateLimitLow CommonReason = "RateLimitLow"
+	// RateLimitExceeded is the condition reason for an SCM provider whose rate limit quota is exhausted, or that hit a
+	// secondary rate limit, so that no requests are sent until the SCM allows them again.
+	RateLimitExceeded CommonReason = "RateLimitExceeded"
+)
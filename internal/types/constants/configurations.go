@@ -33,4 +33,8 @@ const (
 	// WebRequestCommitStatusControllerFieldOwner is the field owner for Server-Side Apply operations
 	// performed by the WebRequestCommitStatus controller.
 	WebRequestCommitStatusControllerFieldOwner = "promoter.argoproj.io/webrequestcommitstatus-controller"
+
+	// ScmCheckCommitStatusControllerFieldOwner is the field owner for Server-Side Apply operations
+	// performed by the ScmCheckCommitStatus controller.
+	ScmCheckCommitStatusControllerFieldOwner = "promoter.argoproj.io/scmcheckcommitstatus-controller"
 )
@@ -7,6 +7,16 @@ const (
 	ResolvedConflictReason = "ResolvedConflict"
 	// ResolvedConflictMessage is the message for a resolved conflict event.
 	ResolvedConflictMessage = "Merged %s into %s with 'ours' strategy to resolve conflicts"
+	// MergeConflictReason indicates that the proposed branch conflicts with the active branch and the conflict was left
+	// unresolved because the conflict resolution strategy is "fail".
+	MergeConflictReason = "MergeConflict"
+	// MergeConflictMessage is the message for an unresolved merge conflict event.
+	MergeConflictMessage = "Branch %s conflicts with %s in %d path(s), resolve the conflict manually"
+	// RehydrationRequestedReason indicates that the proposed branch conflicts with the active branch and is waiting for
+	// the hydrator to re-hydrate it because the conflict resolution strategy is "rebuild".
+	RehydrationRequestedReason = "RehydrationRequested"
+	// RehydrationRequestedMessage is the message for a re-hydration requested event.
+	RehydrationRequestedMessage = "Branch %s conflicts with %s in %d path(s), waiting for the hydrator to re-hydrate dry commit %s"
 
 	// TooManyMatchingShaReason indicates that there are too many matching SHAs for the active or proposed commit status.
 	TooManyMatchingShaReason = "TooManyMatchingSha"
@@ -28,6 +38,26 @@ const (
 	// PullRequestUpdatedReason indicates that a pull request has been updated.
 	PullRequestUpdatedReason = "PullRequestUpdated"
 
+	// GateSummaryCommentFailedReason indicates that the comment summarizing a pull request's gates could not be posted.
+	GateSummaryCommentFailedReason = "GateSummaryCommentFailed"
+	// GateSummaryCommentFailedMessage is the message for a gate summary comment that could not be posted.
+	GateSummaryCommentFailedMessage = "Failed to post the gate summary comment on Pull Request %s: %s"
+
+	// PullRequestEnqueuedReason indicates that a pull request has been added to the merge queue of its target branch.
+	PullRequestEnqueuedReason = "PullRequestEnqueued"
+	// PullRequestEnqueuedMessage is the message for a pull request added to a merge queue.
+	PullRequestEnqueuedMessage = "Pull Request %s added to the merge queue"
+	// PullRequestDequeuedReason indicates that the SCM removed a pull request from the merge queue without merging it.
+	PullRequestDequeuedReason = "PullRequestDequeued"
+	// PullRequestDequeuedMessage is the message for a pull request removed from a merge queue.
+	PullRequestDequeuedMessage = "Pull Request %s was removed from the merge queue without being merged"
+
+	// PullRequestMergeBlockedReason indicates that a pull request is not merged because its target branch's protection
+	// is not satisfied.
+	PullRequestMergeBlockedReason = "PullRequestMergeBlocked"
+	// PullRequestMergeBlockedMessage is the message for a pull request whose merge is blocked by branch protection.
+	PullRequestMergeBlockedMessage = "Pull Request %s is not merged yet. %s"
+
 	// CommitStatusSetReason indicates that a commit status has been set.
 	CommitStatusSetReason = "CommitStatusSet"
 
@@ -89,7 +89,10 @@ import (
 	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
 	"github.com/argoproj-labs/gitops-promoter/internal/scms/azuredevops"
 	"github.com/argoproj-labs/gitops-promoter/internal/scms/bitbucket_cloud"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms/bitbucket_server"
 	"github.com/argoproj-labs/gitops-promoter/internal/scms/forgejo"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms/generic"
+	"github.com/argoproj-labs/gitops-promoter/internal/scms/gerrit"
 	"github.com/argoproj-labs/gitops-promoter/internal/scms/gitea"
 	"github.com/argoproj-labs/gitops-promoter/internal/scms/github"
 	"github.com/argoproj-labs/gitops-promoter/internal/scms/gitlab"
@@ -467,6 +470,13 @@ func ApplySCMAuth(ctx context.Context, scmProvider promoterv1alpha1.GenericScmPr
 		logger.V(4).Info("Applied SCM authentication", "provider", "BitbucketCloud", "scmProvider", scmProvider.GetName())
 		return nil, nil
 
+	case spec.BitbucketServer != nil:
+		if err := bitbucket_server.ApplyHTTPAuth(secret, req); err != nil {
+			return nil, fmt.Errorf("failed to apply Bitbucket Data Center SCM auth: %w", err)
+		}
+		logger.V(4).Info("Applied SCM authentication", "provider", "BitbucketServer", "scmProvider", scmProvider.GetName())
+		return nil, nil
+
 	case spec.AzureDevOps != nil:
 		if err := azuredevops.ApplyHTTPAuth(secret, req); err != nil {
 			return nil, fmt.Errorf("failed to apply Azure DevOps SCM auth: %w", err)
@@ -474,6 +484,20 @@ func ApplySCMAuth(ctx context.Context, scmProvider promoterv1alpha1.GenericScmPr
 		logger.V(4).Info("Applied SCM authentication", "provider", "AzureDevOps", "scmProvider", scmProvider.GetName())
 		return nil, nil
 
+	case spec.Gerrit != nil:
+		if err := gerrit.ApplyHTTPAuth(secret, req); err != nil {
+			return nil, fmt.Errorf("failed to apply Gerrit SCM auth: %w", err)
+		}
+		logger.V(4).Info("Applied SCM authentication", "provider", "Gerrit", "scmProvider", scmProvider.GetName())
+		return nil, nil
+
+	case spec.Generic != nil:
+		if err := generic.ApplyHTTPAuth(secret, req); err != nil {
+			return nil, fmt.Errorf("failed to apply generic SCM auth: %w", err)
+		}
+		logger.V(4).Info("Applied SCM authentication", "provider", "Generic", "scmProvider", scmProvider.GetName())
+		return nil, nil
+
 	case spec.Fake != nil:
 		logger.V(4).Info("SCM provider is Fake, no authentication applied")
 		return nil, nil
@@ -67,7 +67,10 @@ func GetScmProviderFromGitRepository(ctx context.Context, k8sClient client.Clien
 		(repositoryRef.Spec.Forgejo != nil && provider.GetSpec().Forgejo == nil) ||
 		(repositoryRef.Spec.Gitea != nil && provider.GetSpec().Gitea == nil) ||
 		(repositoryRef.Spec.BitbucketCloud != nil && provider.GetSpec().BitbucketCloud == nil) ||
+		(repositoryRef.Spec.BitbucketServer != nil && provider.GetSpec().BitbucketServer == nil) ||
 		(repositoryRef.Spec.AzureDevOps != nil && provider.GetSpec().AzureDevOps == nil) ||
+		(repositoryRef.Spec.Gerrit != nil && provider.GetSpec().Gerrit == nil) ||
+		(repositoryRef.Spec.Generic != nil && provider.GetSpec().Generic == nil) ||
 		(repositoryRef.Spec.Fake != nil && provider.GetSpec().Fake == nil) {
 		return nil, errors.New("wrong ScmProvider configured for Repository")
 	}
@@ -0,0 +1,107 @@
+package webhookreceiver
+
+import (
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+)
+
+// pruneInterval is how often the delivery cache and the source rate limiter drop entries they no longer need.
+const pruneInterval = time.Minute
+
+// deliveryCache remembers the delivery IDs of accepted webhook deliveries, so that deliveries that are replayed or
+// retried by the SCM are only processed once.
+type deliveryCache struct {
+	mu sync.Mutex
+	// expiries maps delivery IDs to the time they are forgotten.
+	expiries  map[string]time.Time
+	lastPrune time.Time
+}
+
+func newDeliveryCache() *deliveryCache {
+	return &deliveryCache{expiries: map[string]time.Time{}}
+}
+
+// add remembers the delivery ID until now+window. It returns false if the delivery ID is already remembered.
+func (c *deliveryCache) add(id string, window time.Duration, now time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if now.Sub(c.lastPrune) >= pruneInterval {
+		for deliveryID, expiry := range c.expiries {
+			if !now.Before(expiry) {
+				delete(c.expiries, deliveryID)
+			}
+		}
+		c.lastPrune = now
+	}
+
+	if expiry, ok := c.expiries[id]; ok && now.Before(expiry) {
+		return false
+	}
+	c.expiries[id] = now.Add(window)
+	return true
+}
+
+// remove forgets the delivery ID, so that a retry of a delivery that could not be processed is accepted.
+func (c *deliveryCache) remove(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.expiries, id)
+}
+
+// sourceRateLimiter limits the webhook deliveries accepted from each source with a token bucket per source.
+type sourceRateLimiter struct {
+	mu        sync.Mutex
+	limiters  map[string]*rate.Limiter
+	lastPrune time.Time
+}
+
+func newSourceRateLimiter() *sourceRateLimiter {
+	return &sourceRateLimiter{limiters: map[string]*rate.Limiter{}}
+}
+
+// allow returns true if a delivery from the source is within the limit. Changes to the bucket apply to existing
+// sources as well.
+func (l *sourceRateLimiter) allow(source string, bucket promoterv1alpha1.Bucket, now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Sub(l.lastPrune) >= pruneInterval {
+		// A full bucket behaves the same as a new one, so it can be dropped.
+		for s, limiter := range l.limiters {
+			if limiter.TokensAt(now) >= float64(limiter.Burst()) {
+				delete(l.limiters, s)
+			}
+		}
+		l.lastPrune = now
+	}
+
+	limiter, ok := l.limiters[source]
+	if !ok {
+		limiter = rate.NewLimiter(rate.Limit(bucket.Qps), bucket.Bucket)
+		l.limiters[source] = limiter
+	}
+	if limiter.Limit() != rate.Limit(bucket.Qps) {
+		limiter.SetLimitAt(now, rate.Limit(bucket.Qps))
+	}
+	if limiter.Burst() != bucket.Bucket {
+		limiter.SetBurstAt(now, bucket.Bucket)
+	}
+	return limiter.AllowN(now, 1)
+}
+
+// sourceIP returns the IP address that the request came from. Behind a proxy or load balancer that does not preserve
+// client addresses, this is the address of the proxy.
+func sourceIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,163 @@
+package webhookreceiver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/settings"
+)
+
+var _ = Describe("deliveryCache", func() {
+	It("should only accept a delivery ID once within the window", func() {
+		cache := newDeliveryCache()
+		now := time.Now()
+
+		Expect(cache.add("github/1", time.Minute, now)).To(BeTrue())
+		Expect(cache.add("github/1", time.Minute, now.Add(30*time.Second))).To(BeFalse())
+		Expect(cache.add("github/2", time.Minute, now.Add(30*time.Second))).To(BeTrue())
+		Expect(cache.add("github/1", time.Minute, now.Add(time.Minute))).To(BeTrue())
+	})
+
+	It("should accept a delivery ID again after it was removed", func() {
+		cache := newDeliveryCache()
+		now := time.Now()
+
+		Expect(cache.add("github/1", time.Minute, now)).To(BeTrue())
+		cache.remove("github/1")
+		Expect(cache.add("github/1", time.Minute, now)).To(BeTrue())
+	})
+
+	It("should forget expired delivery IDs", func() {
+		cache := newDeliveryCache()
+		now := time.Now()
+
+		Expect(cache.add("github/1", time.Second, now)).To(BeTrue())
+		Expect(cache.add("github/2", time.Hour, now.Add(2*pruneInterval))).To(BeTrue())
+		Expect(cache.expiries).To(HaveLen(1))
+	})
+})
+
+var _ = Describe("sourceRateLimiter", func() {
+	bucket := promoterv1alpha1.Bucket{Qps: 1, Bucket: 2}
+
+	It("should limit each source separately", func() {
+		limiter := newSourceRateLimiter()
+		now := time.Now()
+
+		Expect(limiter.allow("10.0.0.1", bucket, now)).To(BeTrue())
+		Expect(limiter.allow("10.0.0.1", bucket, now)).To(BeTrue())
+		Expect(limiter.allow("10.0.0.1", bucket, now)).To(BeFalse())
+		Expect(limiter.allow("10.0.0.2", bucket, now)).To(BeTrue())
+		Expect(limiter.allow("10.0.0.1", bucket, now.Add(time.Second))).To(BeTrue())
+	})
+
+	It("should apply changes to the bucket to existing sources", func() {
+		limiter := newSourceRateLimiter()
+		now := time.Now()
+
+		Expect(limiter.allow("10.0.0.1", bucket, now)).To(BeTrue())
+		Expect(limiter.allow("10.0.0.1", bucket, now)).To(BeTrue())
+		Expect(limiter.allow("10.0.0.1", bucket, now.Add(100*time.Millisecond))).To(BeFalse())
+
+		faster := promoterv1alpha1.Bucket{Qps: 100, Bucket: 100}
+		Expect(limiter.allow("10.0.0.1", faster, now.Add(100*time.Millisecond))).To(BeFalse())
+		Expect(limiter.allow("10.0.0.1", faster, now.Add(120*time.Millisecond))).To(BeTrue())
+	})
+
+	It("should drop sources whose bucket is full", func() {
+		limiter := newSourceRateLimiter()
+		now := time.Now()
+
+		Expect(limiter.allow("10.0.0.1", bucket, now)).To(BeTrue())
+		Expect(limiter.allow("10.0.0.2", bucket, now.Add(2*pruneInterval))).To(BeTrue())
+		Expect(limiter.limiters).To(HaveLen(1))
+	})
+})
+
+var _ = Describe("postRoot limits", func() {
+	const controllerNamespace = "promoter-system"
+	const pushBody = `{"ref":"refs/heads/environment/prod-next","before":"abc","pusher":{}}`
+
+	var enqueued []string
+
+	newReceiver := func(webhookReceiver promoterv1alpha1.WebhookReceiverConfiguration) *WebhookReceiver {
+		ctp := &promoterv1alpha1.ChangeTransferPolicy{ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "default"}}
+		ctp.Status.Proposed.Hydrated.Sha = "abc"
+		k8sClient := newTestClient(ctp, &promoterv1alpha1.ControllerConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Name: settings.ControllerConfigurationName, Namespace: controllerNamespace},
+			Spec:       promoterv1alpha1.ControllerConfigurationSpec{WebhookReceiver: webhookReceiver},
+		})
+
+		enqueued = nil
+		return &WebhookReceiver{
+			k8sClient:   k8sClient,
+			settingsMgr: settings.NewManager(k8sClient, k8sClient, settings.ManagerConfig{ControllerNamespace: controllerNamespace}),
+			enqueueCTP: func(namespace, name string) {
+				enqueued = append(enqueued, namespace+"/"+name)
+			},
+			deliveries:  newDeliveryCache(),
+			rateLimiter: newSourceRateLimiter(),
+		}
+	}
+
+	deliver := func(wr *WebhookReceiver, deliveryID string, body string) int {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		req.Header.Set("X-Github-Event", "push")
+		req.Header.Set("X-Github-Delivery", deliveryID)
+		rec := httptest.NewRecorder()
+		wr.postRoot(rec, req)
+		return rec.Code
+	}
+
+	It("should reject payloads larger than the maximum", func() {
+		wr := newReceiver(promoterv1alpha1.WebhookReceiverConfiguration{MaxPayloadBytes: 1024})
+
+		Expect(deliver(wr, "1", pushBody)).To(Equal(http.StatusNoContent))
+		Expect(deliver(wr, "2", pushBody+strings.Repeat(" ", 1024))).To(Equal(http.StatusRequestEntityTooLarge))
+		Expect(enqueued).To(Equal([]string{"default/prod"}))
+	})
+
+	It("should process a delivery ID only once within the deduplication window", func() {
+		wr := newReceiver(promoterv1alpha1.WebhookReceiverConfiguration{})
+
+		Expect(deliver(wr, "1", pushBody)).To(Equal(http.StatusNoContent))
+		Expect(deliver(wr, "1", pushBody)).To(Equal(http.StatusNoContent))
+		Expect(enqueued).To(Equal([]string{"default/prod"}))
+
+		Expect(deliver(wr, "2", pushBody)).To(Equal(http.StatusNoContent))
+		Expect(enqueued).To(HaveLen(2))
+	})
+
+	It("should process repeated delivery IDs when deduplication is disabled", func() {
+		wr := newReceiver(promoterv1alpha1.WebhookReceiverConfiguration{DeliveryDeduplicationWindow: &metav1.Duration{}})
+
+		Expect(deliver(wr, "1", pushBody)).To(Equal(http.StatusNoContent))
+		Expect(deliver(wr, "1", pushBody)).To(Equal(http.StatusNoContent))
+		Expect(enqueued).To(HaveLen(2))
+	})
+
+	It("should not deduplicate Bitbucket Cloud deliveries by the webhook's UUID", func() {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(pushBody))
+		req.Header.Set("X-Hook-UUID", "{webhook}")
+		req.Header.Set("X-Event-Key", "repo:push")
+		Expect((&WebhookReceiver{}).extractDeliveryID(req)).To(BeEmpty())
+
+		req.Header.Set("X-Request-UUID", "{delivery}")
+		Expect((&WebhookReceiver{}).extractDeliveryID(req)).To(Equal("{delivery}"))
+	})
+
+	It("should reject deliveries over the rate limit", func() {
+		wr := newReceiver(promoterv1alpha1.WebhookReceiverConfiguration{RateLimit: &promoterv1alpha1.Bucket{Qps: 1, Bucket: 1}})
+
+		Expect(deliver(wr, "1", pushBody)).To(Equal(http.StatusNoContent))
+		Expect(deliver(wr, "2", pushBody)).To(Equal(http.StatusTooManyRequests))
+		Expect(enqueued).To(HaveLen(1))
+	})
+})
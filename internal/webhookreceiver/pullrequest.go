@@ -0,0 +1,127 @@
+package webhookreceiver
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/tidwall/gjson"
+	"k8s.io/apimachinery/pkg/fields"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+)
+
+// pullRequestEvent identifies the pull request that a pull request webhook delivery is about.
+type pullRequestEvent struct {
+	// id is the ID of the pull request on the SCM, as stored in the PullRequest's status.id.
+	id string
+	// sourceBranch and targetBranch are the branches of the pull request, without the refs/heads/ prefix.
+	sourceBranch string
+	targetBranch string
+	// action is what happened to the pull request, such as opened, closed, merged or approved, when the payload says.
+	action string
+}
+
+// parsePullRequestEvent returns the pull request that a pull request, merge request or review webhook delivery is
+// about. It returns false if the delivery is not about a pull request, for example because it is a push event.
+func parsePullRequestEvent(provider string, jsonBytes []byte) (pullRequestEvent, bool) {
+	var event pullRequestEvent
+
+	switch provider {
+	case ProviderGitHub, ProviderForgejo, ProviderGitea:
+		// pull_request and pull_request_review events. Gitea and Forgejo use the GitHub payload format.
+		pr := gjson.GetBytes(jsonBytes, "pull_request")
+		if !pr.Get("number").Exists() || gjson.GetBytes(jsonBytes, "pusher").Exists() {
+			return event, false
+		}
+		event.id = pr.Get("number").String()
+		event.sourceBranch = pr.Get("head.ref").String()
+		event.targetBranch = pr.Get("base.ref").String()
+		event.action = gjson.GetBytes(jsonBytes, "action").String()
+		if event.action == "closed" && pr.Get("merged").Bool() {
+			event.action = "merged"
+		}
+		if review := gjson.GetBytes(jsonBytes, "review.state"); review.Exists() {
+			event.action = "review " + review.String()
+		}
+	case ProviderGitLab:
+		// Merge request events, which include approvals.
+		if gjson.GetBytes(jsonBytes, "object_kind").String() != "merge_request" {
+			return event, false
+		}
+		attributes := gjson.GetBytes(jsonBytes, "object_attributes")
+		event.id = attributes.Get("iid").String()
+		event.sourceBranch = attributes.Get("source_branch").String()
+		event.targetBranch = attributes.Get("target_branch").String()
+		event.action = attributes.Get("action").String()
+	case ProviderBitbucketCloud:
+		// pullrequest:* events.
+		pr := gjson.GetBytes(jsonBytes, "pullrequest")
+		if !pr.Get("id").Exists() {
+			return event, false
+		}
+		event.id = pr.Get("id").String()
+		event.sourceBranch = pr.Get("source.branch.name").String()
+		event.targetBranch = pr.Get("destination.branch.name").String()
+		event.action = strings.ToLower(pr.Get("state").String())
+	case ProviderBitbucketServer:
+		// pr:* events.
+		pr := gjson.GetBytes(jsonBytes, "pullRequest")
+		if !pr.Get("id").Exists() {
+			return event, false
+		}
+		event.id = pr.Get("id").String()
+		event.sourceBranch = pr.Get("fromRef.displayId").String()
+		event.targetBranch = pr.Get("toRef.displayId").String()
+		event.action = strings.ToLower(pr.Get("state").String())
+	case ProviderAzureDevops:
+		// git.pullrequest.created, git.pullrequest.updated and git.pullrequest.merged events.
+		eventType := gjson.GetBytes(jsonBytes, "eventType").String()
+		if !strings.HasPrefix(eventType, "git.pullrequest.") {
+			return event, false
+		}
+		resource := gjson.GetBytes(jsonBytes, "resource")
+		event.id = resource.Get("pullRequestId").String()
+		event.sourceBranch = strings.TrimPrefix(resource.Get("sourceRefName").String(), "refs/heads/")
+		event.targetBranch = strings.TrimPrefix(resource.Get("targetRefName").String(), "refs/heads/")
+		event.action = strings.TrimPrefix(eventType, "git.pullrequest.")
+		if status := resource.Get("status").String(); status == "completed" || status == "abandoned" {
+			event.action = status
+		}
+	default:
+		return event, false
+	}
+
+	if _, err := strconv.ParseInt(event.id, 10, 64); err != nil {
+		logger.V(4).Info("unable to extract pull request ID from provider payload", "provider", provider)
+		return event, false
+	}
+	return event, true
+}
+
+// findPullRequests returns the PullRequests that a pull request webhook delivery is about and is authorized for. Pull
+// request IDs are only unique within a repository, so the PullRequests must also have the same source and target
+// branches.
+func (wr *WebhookReceiver) findPullRequests(ctx context.Context, auth deliveryAuthorization, event pullRequestEvent) ([]promoterv1alpha1.PullRequest, error) {
+	var prList promoterv1alpha1.PullRequestList
+	err := wr.k8sClient.List(ctx, &prList, &client.ListOptions{
+		FieldSelector: fields.SelectorFromSet(map[string]string{
+			".status.id": event.id,
+		}),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pullrequests for webhook receiver: %w", err)
+	}
+
+	var prs []promoterv1alpha1.PullRequest
+	for _, pr := range prList.Items {
+		if pr.Spec.SourceBranch == event.sourceBranch && pr.Spec.TargetBranch == event.targetBranch {
+			prs = append(prs, pr)
+		}
+	}
+	return filterAuthorized(ctx, wr, auth, prs, func(pr promoterv1alpha1.PullRequest) client.ObjectKey {
+		return client.ObjectKey{Namespace: pr.Namespace, Name: pr.Spec.RepositoryReference.Name}
+	})
+}
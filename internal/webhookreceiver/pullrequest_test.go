@@ -0,0 +1,72 @@
+package webhookreceiver
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("parsePullRequestEvent", func() {
+	tests := map[string]struct {
+		provider string
+		body     string
+		expected pullRequestEvent
+	}{
+		"GitHub pull request merged": {
+			provider: ProviderGitHub,
+			body:     `{"action":"closed","pull_request":{"number":12,"merged":true,"head":{"ref":"environment/prod-next"},"base":{"ref":"environment/prod"}}}`,
+			expected: pullRequestEvent{id: "12", sourceBranch: "environment/prod-next", targetBranch: "environment/prod", action: "merged"},
+		},
+		"GitHub review submitted": {
+			provider: ProviderGitHub,
+			body:     `{"action":"submitted","review":{"state":"approved"},"pull_request":{"number":12,"head":{"ref":"environment/prod-next"},"base":{"ref":"environment/prod"}}}`,
+			expected: pullRequestEvent{id: "12", sourceBranch: "environment/prod-next", targetBranch: "environment/prod", action: "review approved"},
+		},
+		"Gitea pull request closed": {
+			provider: ProviderGitea,
+			body:     `{"action":"closed","pull_request":{"number":3,"merged":false,"head":{"ref":"environment/dev-next"},"base":{"ref":"environment/dev"}}}`,
+			expected: pullRequestEvent{id: "3", sourceBranch: "environment/dev-next", targetBranch: "environment/dev", action: "closed"},
+		},
+		"GitLab merge request approved": {
+			provider: ProviderGitLab,
+			body:     `{"object_kind":"merge_request","object_attributes":{"iid":7,"action":"approved","source_branch":"environment/prod-next","target_branch":"environment/prod"}}`,
+			expected: pullRequestEvent{id: "7", sourceBranch: "environment/prod-next", targetBranch: "environment/prod", action: "approved"},
+		},
+		"Bitbucket Cloud pull request fulfilled": {
+			provider: ProviderBitbucketCloud,
+			body:     `{"actor":{},"pullrequest":{"id":4,"state":"MERGED","source":{"branch":{"name":"environment/prod-next"}},"destination":{"branch":{"name":"environment/prod"}}}}`,
+			expected: pullRequestEvent{id: "4", sourceBranch: "environment/prod-next", targetBranch: "environment/prod", action: "merged"},
+		},
+		"Bitbucket Data Center pull request declined": {
+			provider: ProviderBitbucketServer,
+			body:     `{"eventKey":"pr:declined","pullRequest":{"id":9,"state":"DECLINED","fromRef":{"displayId":"environment/prod-next"},"toRef":{"displayId":"environment/prod"}}}`,
+			expected: pullRequestEvent{id: "9", sourceBranch: "environment/prod-next", targetBranch: "environment/prod", action: "declined"},
+		},
+		"Azure DevOps pull request abandoned": {
+			provider: ProviderAzureDevops,
+			body:     `{"eventType":"git.pullrequest.updated","publisherId":"tfs","resource":{"pullRequestId":21,"status":"abandoned","sourceRefName":"refs/heads/environment/prod-next","targetRefName":"refs/heads/environment/prod"}}`,
+			expected: pullRequestEvent{id: "21", sourceBranch: "environment/prod-next", targetBranch: "environment/prod", action: "abandoned"},
+		},
+	}
+
+	for name, test := range tests {
+		It("should parse a "+name+" event", func() {
+			event, ok := parsePullRequestEvent(test.provider, []byte(test.body))
+			Expect(ok).To(BeTrue())
+			Expect(event).To(Equal(test.expected))
+		})
+	}
+
+	It("should not parse push events", func() {
+		_, ok := parsePullRequestEvent(ProviderGitHub, []byte(`{"ref":"refs/heads/environment/prod","before":"abc","pusher":{},"pull_request":{"number":1}}`))
+		Expect(ok).To(BeFalse())
+		_, ok = parsePullRequestEvent(ProviderGitLab, []byte(`{"object_kind":"push","before":"abc","user_name":"jane"}`))
+		Expect(ok).To(BeFalse())
+		_, ok = parsePullRequestEvent(ProviderAzureDevops, []byte(`{"eventType":"git.push","publisherId":"tfs","resource":{"refUpdates":[]}}`))
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should not parse Gerrit events", func() {
+		_, ok := parsePullRequestEvent(ProviderGerrit, []byte(`{"type":"ref-updated","refUpdate":{"oldRev":"abc"}}`))
+		Expect(ok).To(BeFalse())
+	})
+})
@@ -0,0 +1,279 @@
+package webhookreceiver
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+)
+
+// newTestClient returns a fake client with the ChangeTransferPolicy field indexes that the webhook receiver uses.
+func newTestClient(objs ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+	Expect(promoterv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+	ctpIndex := func(value func(ctp *promoterv1alpha1.ChangeTransferPolicy) string) client.IndexerFunc {
+		return func(obj client.Object) []string {
+			return []string{value(obj.(*promoterv1alpha1.ChangeTransferPolicy))} //nolint:forcetypeassert
+		}
+	}
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).
+		WithIndex(&promoterv1alpha1.ChangeTransferPolicy{}, ".status.proposed.hydrated.sha", ctpIndex(func(ctp *promoterv1alpha1.ChangeTransferPolicy) string {
+			return ctp.Status.Proposed.Hydrated.Sha
+		})).
+		WithIndex(&promoterv1alpha1.ChangeTransferPolicy{}, ".status.active.hydrated.sha", ctpIndex(func(ctp *promoterv1alpha1.ChangeTransferPolicy) string {
+			return ctp.Status.Active.Hydrated.Sha
+		})).
+		WithIndex(&promoterv1alpha1.ChangeTransferPolicy{}, ".spec.proposedBranch", ctpIndex(func(ctp *promoterv1alpha1.ChangeTransferPolicy) string {
+			return ctp.Spec.ProposedBranch
+		})).
+		WithIndex(&promoterv1alpha1.ChangeTransferPolicy{}, ".spec.activeBranch", ctpIndex(func(ctp *promoterv1alpha1.ChangeTransferPolicy) string {
+			return ctp.Spec.ActiveBranch
+		})).
+		Build()
+}
+
+var _ = Describe("parseRefUpdates", func() {
+	tests := map[string]struct {
+		provider string
+		body     string
+		expected []refUpdate
+	}{
+		"GitHub push": {
+			provider: ProviderGitHub,
+			body:     `{"ref":"refs/heads/environment/prod-next","before":"abc","pusher":{}}`,
+			expected: []refUpdate{{ref: "refs/heads/environment/prod-next", beforeSha: "abc"}},
+		},
+		"GitLab push": {
+			provider: ProviderGitLab,
+			body:     `{"object_kind":"push","ref":"refs/heads/environment/prod-next","before":"abc","user_name":"jane"}`,
+			expected: []refUpdate{{ref: "refs/heads/environment/prod-next", beforeSha: "abc"}},
+		},
+		"Bitbucket Cloud push of a branch and a new tag": {
+			provider: ProviderBitbucketCloud,
+			body: `{"actor":{},"push":{"changes":[` +
+				`{"old":{"type":"branch","name":"environment/prod-next","target":{"hash":"abc"}},"new":{"type":"branch","name":"environment/prod-next"}},` +
+				`{"old":null,"new":{"type":"tag","name":"v1"}}]}}`,
+			expected: []refUpdate{
+				{ref: "refs/heads/environment/prod-next", beforeSha: "abc"},
+				{ref: "refs/tags/v1", beforeSha: ""},
+			},
+		},
+		"Bitbucket Cloud branch deletion": {
+			provider: ProviderBitbucketCloud,
+			body:     `{"actor":{},"push":{"changes":[{"old":{"type":"branch","name":"environment/prod-next","target":{"hash":"abc"}},"new":null}]}}`,
+			expected: []refUpdate{{ref: "refs/heads/environment/prod-next", beforeSha: "abc"}},
+		},
+		"Bitbucket Data Center push of two branches": {
+			provider: ProviderBitbucketServer,
+			body:     `{"actor":{},"changes":[{"refId":"refs/heads/environment/dev-next","fromHash":"abc"},{"refId":"refs/heads/environment/prod-next","fromHash":"def"}]}`,
+			expected: []refUpdate{
+				{ref: "refs/heads/environment/dev-next", beforeSha: "abc"},
+				{ref: "refs/heads/environment/prod-next", beforeSha: "def"},
+			},
+		},
+		"Azure DevOps push of two branches": {
+			provider: ProviderAzureDevops,
+			body:     `{"eventType":"git.push","publisherId":"tfs","resource":{"refUpdates":[{"name":"refs/heads/environment/dev-next","oldObjectId":"abc"},{"name":"refs/heads/environment/prod-next","oldObjectId":"def"}]}}`,
+			expected: []refUpdate{
+				{ref: "refs/heads/environment/dev-next", beforeSha: "abc"},
+				{ref: "refs/heads/environment/prod-next", beforeSha: "def"},
+			},
+		},
+		"Gerrit ref-updated": {
+			provider: ProviderGerrit,
+			body:     `{"type":"ref-updated","refUpdate":{"oldRev":"abc","refName":"refs/heads/environment/prod-next"}}`,
+			expected: []refUpdate{{ref: "refs/heads/environment/prod-next", beforeSha: "abc"}},
+		},
+	}
+
+	for name, test := range tests {
+		It("should parse a "+name+" event", func() {
+			Expect(parseRefUpdates(test.provider, []byte(test.body))).To(Equal(test.expected))
+		})
+	}
+
+	It("should not parse pull request events", func() {
+		Expect(parseRefUpdates(ProviderGitHub, []byte(`{"action":"opened","pull_request":{"number":1}}`))).To(BeEmpty())
+	})
+})
+
+var _ = Describe("findChangeTransferPolicies", func() {
+	newCTP := func(name, proposedBranch, activeBranch, proposedSha, activeSha string) *promoterv1alpha1.ChangeTransferPolicy {
+		ctp := &promoterv1alpha1.ChangeTransferPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: promoterv1alpha1.ChangeTransferPolicySpec{
+				RepositoryReference: promoterv1alpha1.ObjectReference{Name: "deployments"},
+				ProposedBranch:      proposedBranch,
+				ActiveBranch:        activeBranch,
+			},
+		}
+		ctp.Status.Proposed.Hydrated.Sha = proposedSha
+		ctp.Status.Active.Hydrated.Sha = activeSha
+		return ctp
+	}
+	names := func(ctps []promoterv1alpha1.ChangeTransferPolicy) []string {
+		var result []string
+		for _, ctp := range ctps {
+			result = append(result, ctp.Name)
+		}
+		return result
+	}
+
+	var wr *WebhookReceiver
+	BeforeEach(func() {
+		otherRepoCTP := newCTP("other-repo", "environment/dev-next", "environment/dev", "666", "777")
+		otherRepoCTP.Spec.RepositoryReference.Name = "other"
+		wr = &WebhookReceiver{k8sClient: newTestClient(
+			newCTP("dev", "environment/dev-next", "environment/dev", "abc", "111"),
+			newCTP("prod", "environment/prod-next", "environment/prod", "def", "222"),
+			newCTP("other-strategy-prod", "environment/prod-next", "environment/prod", "abc", "333"),
+			newCTP("unrelated", "environment/qa-next", "environment/qa", "444", "555"),
+			otherRepoCTP,
+			&promoterv1alpha1.GitRepository{
+				ObjectMeta: metav1.ObjectMeta{Name: "deployments", Namespace: "default"},
+				Spec: promoterv1alpha1.GitRepositorySpec{
+					ScmProviderRef: promoterv1alpha1.ScmProviderObjectReference{Name: "azdo"},
+					AzureDevOps:    &promoterv1alpha1.AzureDevOpsRepo{Project: "platform", Name: "deployments"},
+				},
+			},
+			&promoterv1alpha1.GitRepository{
+				ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "default"},
+				Spec: promoterv1alpha1.GitRepositorySpec{
+					ScmProviderRef: promoterv1alpha1.ScmProviderObjectReference{Name: "azdo"},
+					AzureDevOps:    &promoterv1alpha1.AzureDevOpsRepo{Project: "platform", Name: "other"},
+				},
+			},
+		)}
+	})
+
+	It("should return every ChangeTransferPolicy with the before SHA", func() {
+		ctps, _, err := wr.findChangeTransferPolicies(context.Background(), deliveryAuthorization{}, ProviderGitHub,
+			[]byte(`{"ref":"refs/heads/feature","before":"abc","pusher":{}}`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(names(ctps)).To(ConsistOf("dev", "other-strategy-prod"))
+	})
+
+	It("should return the ChangeTransferPolicies of every branch in the push", func() {
+		ctps, _, err := wr.findChangeTransferPolicies(context.Background(), deliveryAuthorization{}, ProviderAzureDevops,
+			[]byte(`{"eventType":"git.push","publisherId":"tfs","resource":{"refUpdates":[{"name":"refs/heads/environment/dev","oldObjectId":"999"},{"name":"refs/heads/environment/prod-next","oldObjectId":"0000000000000000000000000000000000000000"}],"repository":{"name":"deployments","project":{"name":"platform"}}}}`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(names(ctps)).To(ConsistOf("dev", "prod", "other-strategy-prod"))
+	})
+
+	It("should only match branches against the ChangeTransferPolicies of the pushed repository", func() {
+		ctps, _, err := wr.findChangeTransferPolicies(context.Background(), deliveryAuthorization{}, ProviderAzureDevops,
+			[]byte(`{"eventType":"git.push","publisherId":"tfs","resource":{"refUpdates":[{"name":"refs/heads/environment/dev-next","oldObjectId":"999"}],"repository":{"name":"other","project":{"name":"platform"}}}}`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(names(ctps)).To(ConsistOf("other-repo"))
+
+		ctps, _, err = wr.findChangeTransferPolicies(context.Background(), deliveryAuthorization{}, ProviderAzureDevops,
+			[]byte(`{"eventType":"git.push","publisherId":"tfs","resource":{"refUpdates":[{"name":"refs/heads/environment/dev-next","oldObjectId":"999"}],"repository":{"name":"unknown","project":{"name":"platform"}}}}`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ctps).To(BeEmpty())
+	})
+
+	It("should only return the ChangeTransferPolicies of the repositories the delivery is authorized for", func() {
+		auth := deliveryAuthorization{
+			signature: SignatureValid,
+			providers: map[providerKey]bool{{kind: promoterv1alpha1.ScmProviderKind, namespace: "default", name: "azdo"}: true},
+		}
+		ctps, _, err := wr.findChangeTransferPolicies(context.Background(), auth, ProviderAzureDevops,
+			[]byte(`{"eventType":"git.push","publisherId":"tfs","resource":{"refUpdates":[{"name":"refs/heads/environment/dev","oldObjectId":"999"}],"repository":{"name":"deployments","project":{"name":"platform"}}}}`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(names(ctps)).To(ConsistOf("dev"))
+
+		auth.providers = map[providerKey]bool{{kind: promoterv1alpha1.ScmProviderKind, namespace: "default", name: "other-azdo"}: true}
+		ctps, _, err = wr.findChangeTransferPolicies(context.Background(), auth, ProviderAzureDevops,
+			[]byte(`{"eventType":"git.push","publisherId":"tfs","resource":{"refUpdates":[{"name":"refs/heads/environment/dev","oldObjectId":"999"}],"repository":{"name":"deployments","project":{"name":"platform"}}}}`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ctps).To(BeEmpty())
+	})
+
+	It("should return every ChangeTransferPolicy of the repository for pushes to the hydrator notes ref", func() {
+		ctps, notesRepos, err := wr.findChangeTransferPolicies(context.Background(), deliveryAuthorization{}, ProviderAzureDevops,
+			[]byte(`{"eventType":"git.push","publisherId":"tfs","resource":{"refUpdates":[{"name":"refs/notes/hydrator.metadata","oldObjectId":"999"}],"repository":{"name":"Deployments","project":{"name":"platform"}}}}`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(names(ctps)).To(ConsistOf("dev", "prod", "other-strategy-prod", "unrelated"))
+		Expect(notesRepos).To(HaveLen(1))
+		Expect(notesRepos[0].Name).To(Equal("deployments"))
+	})
+
+	It("should not report a notes push for pushes to other non-branch refs", func() {
+		ctps, notesRepos, err := wr.findChangeTransferPolicies(context.Background(), deliveryAuthorization{}, ProviderAzureDevops,
+			[]byte(`{"eventType":"git.push","publisherId":"tfs","resource":{"refUpdates":[{"name":"refs/tags/v1","oldObjectId":"0000000000000000000000000000000000000000"}],"repository":{"name":"deployments","project":{"name":"platform"}}}}`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ctps).To(HaveLen(4))
+		Expect(notesRepos).To(BeEmpty())
+	})
+
+	It("should return nothing for pushes to unrelated refs", func() {
+		ctps, _, err := wr.findChangeTransferPolicies(context.Background(), deliveryAuthorization{}, ProviderGitHub,
+			[]byte(`{"ref":"refs/heads/feature","before":"999","pusher":{}}`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ctps).To(BeEmpty())
+	})
+})
+
+var _ = Describe("repositoryMatches", func() {
+	tests := map[string]struct {
+		provider string
+		body     string
+		spec     promoterv1alpha1.GitRepositorySpec
+	}{
+		"GitHub": {
+			provider: ProviderGitHub,
+			body:     `{"repository":{"full_name":"Argoproj/deployments"}}`,
+			spec:     promoterv1alpha1.GitRepositorySpec{GitHub: &promoterv1alpha1.GitHubRepo{Owner: "argoproj", Name: "deployments"}},
+		},
+		"Gitea": {
+			provider: ProviderGitea,
+			body:     `{"repository":{"full_name":"argoproj/deployments"}}`,
+			spec:     promoterv1alpha1.GitRepositorySpec{Gitea: &promoterv1alpha1.GiteaRepo{Owner: "argoproj", Name: "deployments"}},
+		},
+		"GitLab by project ID": {
+			provider: ProviderGitLab,
+			body:     `{"project_id":42,"project":{"path_with_namespace":"renamed/deployments"}}`,
+			spec:     promoterv1alpha1.GitRepositorySpec{GitLab: &promoterv1alpha1.GitLabRepo{Namespace: "group/sub", Name: "deployments", ProjectID: 42}},
+		},
+		"Bitbucket Cloud": {
+			provider: ProviderBitbucketCloud,
+			body:     `{"repository":{"full_name":"workspace/deployments"}}`,
+			spec:     promoterv1alpha1.GitRepositorySpec{BitbucketCloud: &promoterv1alpha1.BitbucketCloudRepo{Owner: "workspace", Name: "deployments"}},
+		},
+		"Bitbucket Data Center": {
+			provider: ProviderBitbucketServer,
+			body:     `{"repository":{"slug":"deployments","project":{"key":"PLAT"}}}`,
+			spec:     promoterv1alpha1.GitRepositorySpec{BitbucketServer: &promoterv1alpha1.BitbucketServerRepo{Project: "PLAT", Name: "deployments"}},
+		},
+		"Gerrit": {
+			provider: ProviderGerrit,
+			body:     `{"type":"ref-updated","refUpdate":{"project":"platform/deployments"}}`,
+			spec:     promoterv1alpha1.GitRepositorySpec{Gerrit: &promoterv1alpha1.GerritRepo{Project: "platform/deployments"}},
+		},
+	}
+
+	for name, test := range tests {
+		It("should match a "+name+" repository", func() {
+			Expect(repositoryMatches(test.provider, []byte(test.body), test.spec)).To(BeTrue())
+		})
+	}
+
+	It("should not match repositories of another provider or name", func() {
+		body := []byte(`{"repository":{"full_name":"argoproj/deployments"}}`)
+		Expect(repositoryMatches(ProviderGitHub, body, promoterv1alpha1.GitRepositorySpec{
+			Gitea: &promoterv1alpha1.GiteaRepo{Owner: "argoproj", Name: "deployments"},
+		})).To(BeFalse())
+		Expect(repositoryMatches(ProviderGitHub, body, promoterv1alpha1.GitRepositorySpec{
+			GitHub: &promoterv1alpha1.GitHubRepo{Owner: "argoproj", Name: "other"},
+		})).To(BeFalse())
+	})
+})
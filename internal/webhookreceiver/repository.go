@@ -0,0 +1,113 @@
+package webhookreceiver
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/tidwall/gjson"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+)
+
+// repositoryMatches returns true if the GitRepository is the repository that a push webhook delivery is for. Owner,
+// project and repository names are compared case-insensitively, since the SCMs treat them that way.
+func repositoryMatches(provider string, jsonBytes []byte, spec promoterv1alpha1.GitRepositorySpec) bool {
+	fullName := func(path string) string {
+		return gjson.GetBytes(jsonBytes, path).String()
+	}
+
+	switch provider {
+	case ProviderGitHub:
+		return spec.GitHub != nil && strings.EqualFold(fullName("repository.full_name"), spec.GitHub.Owner+"/"+spec.GitHub.Name)
+	case ProviderForgejo:
+		return spec.Forgejo != nil && strings.EqualFold(fullName("repository.full_name"), spec.Forgejo.Owner+"/"+spec.Forgejo.Name)
+	case ProviderGitea:
+		return spec.Gitea != nil && strings.EqualFold(fullName("repository.full_name"), spec.Gitea.Owner+"/"+spec.Gitea.Name)
+	case ProviderGitLab:
+		if spec.GitLab == nil {
+			return false
+		}
+		if projectID := gjson.GetBytes(jsonBytes, "project_id"); projectID.Exists() && spec.GitLab.ProjectID != 0 {
+			return projectID.Int() == int64(spec.GitLab.ProjectID)
+		}
+		return strings.EqualFold(fullName("project.path_with_namespace"), spec.GitLab.Namespace+"/"+spec.GitLab.Name)
+	case ProviderBitbucketCloud:
+		return spec.BitbucketCloud != nil && strings.EqualFold(fullName("repository.full_name"), spec.BitbucketCloud.Owner+"/"+spec.BitbucketCloud.Name)
+	case ProviderBitbucketServer:
+		return spec.BitbucketServer != nil &&
+			strings.EqualFold(fullName("repository.project.key"), spec.BitbucketServer.Project) &&
+			strings.EqualFold(fullName("repository.slug"), spec.BitbucketServer.Name)
+	case ProviderAzureDevops:
+		return spec.AzureDevOps != nil &&
+			strings.EqualFold(fullName("resource.repository.project.name"), spec.AzureDevOps.Project) &&
+			strings.EqualFold(fullName("resource.repository.name"), spec.AzureDevOps.Name)
+	case ProviderGerrit:
+		// Gerrit project names are case-sensitive.
+		return spec.Gerrit != nil && fullName("refUpdate.project") == spec.Gerrit.Project
+	default:
+		return false
+	}
+}
+
+// findGitRepositories returns the GitRepositories that a push webhook delivery is for and is authorized for.
+func (wr *WebhookReceiver) findGitRepositories(ctx context.Context, auth deliveryAuthorization, provider string, jsonBytes []byte) ([]promoterv1alpha1.GitRepository, error) {
+	var gitRepoList promoterv1alpha1.GitRepositoryList
+	if err := wr.k8sClient.List(ctx, &gitRepoList); err != nil {
+		return nil, fmt.Errorf("failed to list gitrepositories for webhook receiver: %w", err)
+	}
+
+	var gitRepos []promoterv1alpha1.GitRepository
+	for _, gitRepo := range gitRepoList.Items {
+		if repositoryMatches(provider, jsonBytes, gitRepo.Spec) && auth.allowsRepository(gitRepo) {
+			gitRepos = append(gitRepos, gitRepo)
+		}
+	}
+	return gitRepos, nil
+}
+
+// listRepositoryChangeTransferPolicies returns every ChangeTransferPolicy of the GitRepository.
+func (wr *WebhookReceiver) listRepositoryChangeTransferPolicies(ctx context.Context, gitRepo promoterv1alpha1.GitRepository) ([]promoterv1alpha1.ChangeTransferPolicy, error) {
+	var ctpList promoterv1alpha1.ChangeTransferPolicyList
+	if err := wr.k8sClient.List(ctx, &ctpList, client.InNamespace(gitRepo.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list changetransferpolicies of gitrepository %s/%s for webhook receiver: %w", gitRepo.Namespace, gitRepo.Name, err)
+	}
+
+	var ctps []promoterv1alpha1.ChangeTransferPolicy
+	for _, ctp := range ctpList.Items {
+		if ctp.Spec.RepositoryReference.Name == gitRepo.Name {
+			ctps = append(ctps, ctp)
+		}
+	}
+	return ctps, nil
+}
+
+// filterAuthorized returns the objects whose GitRepository the delivery is authorized for. repository returns the key of
+// an object's GitRepository. Objects whose GitRepository does not exist are left out.
+func filterAuthorized[T any](ctx context.Context, wr *WebhookReceiver, auth deliveryAuthorization, objs []T, repository func(T) client.ObjectKey) ([]T, error) {
+	if auth.providers == nil {
+		return objs, nil
+	}
+
+	allowed := map[client.ObjectKey]bool{}
+	var result []T
+	for _, obj := range objs {
+		key := repository(obj)
+		ok, checked := allowed[key]
+		if !checked {
+			var gitRepo promoterv1alpha1.GitRepository
+			if err := wr.k8sClient.Get(ctx, key, &gitRepo); err != nil && !k8serrors.IsNotFound(err) {
+				return nil, fmt.Errorf("failed to get gitrepository %s for webhook receiver: %w", key, err)
+			} else if err == nil {
+				ok = auth.allowsRepository(gitRepo)
+			}
+			allowed[key] = ok
+		}
+		if ok {
+			result = append(result, obj)
+		}
+	}
+	return result, nil
+}
@@ -7,10 +7,13 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"time"
 
 	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/git"
 	"github.com/argoproj-labs/gitops-promoter/internal/metrics"
+	"github.com/argoproj-labs/gitops-promoter/internal/settings"
 
 	"github.com/tidwall/gjson"
 
@@ -24,32 +27,46 @@ var logger = ctrl.Log.WithName("webhookReceiver")
 
 // Provider type constants
 const (
-	ProviderGitHub         = "github"
-	ProviderGitLab         = "gitlab"
-	ProviderForgejo        = "forgejo"
-	ProviderGitea          = "gitea"
-	ProviderBitbucketCloud = "bitbucketCloud"
-	ProviderAzureDevops    = "azureDevOps"
-	ProviderUnknown        = ""
+	ProviderGitHub          = "github"
+	ProviderGitLab          = "gitlab"
+	ProviderForgejo         = "forgejo"
+	ProviderGitea           = "gitea"
+	ProviderBitbucketCloud  = "bitbucketCloud"
+	ProviderBitbucketServer = "bitbucketServer"
+	ProviderAzureDevops     = "azureDevOps"
+	ProviderGerrit          = "gerrit"
+	ProviderUnknown         = ""
 )
 
-// EnqueueFunc is a function type that can be used to enqueue CTP reconcile requests
-// without modifying the CTP object. This matches controller.CTPEnqueueFunc.
+// EnqueueFunc is a function type that can be used to enqueue CTP or PullRequest reconcile requests
+// without modifying the object, or to report a push to the hydrator notes ref of a GitRepository. This matches
+// controller.CTPEnqueueFunc, controller.PullRequestEnqueueFunc and controller.NotesPushFunc.
 type EnqueueFunc func(namespace, name string)
 
-// WebhookReceiver is a server that listens for webhooks and triggers reconciles of ChangeTransferPolicies.
+// WebhookReceiver is a server that listens for webhooks and triggers reconciles of ChangeTransferPolicies on pushes,
+// and of PullRequests on pull request events.
 type WebhookReceiver struct {
-	mgr        controllerruntime.Manager
-	k8sClient  client.Client
-	enqueueCTP EnqueueFunc
+	mgr         controllerruntime.Manager
+	k8sClient   client.Client
+	settingsMgr *settings.Manager
+	enqueueCTP  EnqueueFunc
+	enqueuePR   EnqueueFunc
+	notesPushed EnqueueFunc
+	deliveries  *deliveryCache
+	rateLimiter *sourceRateLimiter
 }
 
 // NewWebhookReceiver creates a new instance of WebhookReceiver.
-func NewWebhookReceiver(mgr controllerruntime.Manager, enqueueCTP EnqueueFunc) WebhookReceiver {
+func NewWebhookReceiver(mgr controllerruntime.Manager, settingsMgr *settings.Manager, enqueueCTP EnqueueFunc, enqueuePR EnqueueFunc, notesPushed EnqueueFunc) WebhookReceiver {
 	return WebhookReceiver{
-		mgr:        mgr,
-		k8sClient:  mgr.GetClient(),
-		enqueueCTP: enqueueCTP,
+		mgr:         mgr,
+		k8sClient:   mgr.GetClient(),
+		settingsMgr: settingsMgr,
+		enqueueCTP:  enqueueCTP,
+		enqueuePR:   enqueuePR,
+		notesPushed: notesPushed,
+		deliveries:  newDeliveryCache(),
+		rateLimiter: newSourceRateLimiter(),
 	}
 }
 
@@ -85,7 +102,8 @@ func (wr *WebhookReceiver) Start(ctx context.Context, addr string) error {
 }
 
 // DetectProvider determines the SCM provider based on webhook headers.
-// Returns ProviderGitHub, ProviderGitLab, ProviderForgejo, ProviderGitea, ProviderBitbucketCloud, ProviderAzureDevops or ProviderUnknown.
+// Returns ProviderGitHub, ProviderGitLab, ProviderForgejo, ProviderGitea, ProviderBitbucketCloud, ProviderBitbucketServer,
+// ProviderAzureDevops, ProviderGerrit or ProviderUnknown.
 func (wr *WebhookReceiver) DetectProvider(r *http.Request) string {
 	// Check for GitHub webhook headers
 	if r.Header.Get("X-Github-Event") != "" || r.Header.Get("X-Github-Delivery") != "" {
@@ -112,6 +130,12 @@ func (wr *WebhookReceiver) DetectProvider(r *http.Request) string {
 		return ProviderBitbucketCloud
 	}
 
+	// Check for Bitbucket Data Center webhook headers. Bitbucket Cloud also sends X-Event-Key, but always with
+	// X-Hook-UUID, which was checked above.
+	if r.Header.Get("X-Event-Key") != "" {
+		return ProviderBitbucketServer
+	}
+
 	if r.ContentLength > 0 {
 		bodyBytes, err := io.ReadAll(r.Body)
 		if err != nil {
@@ -125,6 +149,11 @@ func (wr *WebhookReceiver) DetectProvider(r *http.Request) string {
 		if gjson.GetBytes(bodyBytes, "eventType").Exists() && gjson.GetBytes(bodyBytes, "publisherId").Exists() {
 			return ProviderAzureDevops
 		}
+
+		// Gerrit: the webhooks plugin sends stream events without identifying headers, so check for a ref-updated event
+		if gjson.GetBytes(bodyBytes, "type").String() == "ref-updated" && gjson.GetBytes(bodyBytes, "refUpdate").Exists() {
+			return ProviderGerrit
+		}
 	}
 
 	return ProviderUnknown
@@ -133,6 +162,7 @@ func (wr *WebhookReceiver) DetectProvider(r *http.Request) string {
 func (wr *WebhookReceiver) postRoot(w http.ResponseWriter, r *http.Request) {
 	var responseCode int
 	var ctpFound bool
+	var signature string
 	startTime := time.Now()
 	var updateDuration time.Duration
 
@@ -140,7 +170,7 @@ func (wr *WebhookReceiver) postRoot(w http.ResponseWriter, r *http.Request) {
 	// We also subtract the update duration from the total time to get a more accurate measurement of how long actual
 	// processing took.
 	defer func() {
-		metrics.RecordWebhookCall(ctpFound, responseCode, time.Since(startTime)-updateDuration)
+		metrics.RecordWebhookCall(ctpFound, signature, responseCode, time.Since(startTime)-updateDuration)
 	}()
 
 	if r.Method != http.MethodPost {
@@ -149,6 +179,45 @@ func (wr *WebhookReceiver) postRoot(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	config, err := wr.settingsMgr.GetWebhookReceiverConfiguration(r.Context())
+	if err != nil {
+		logger.Error(err, "could not get webhook receiver configuration")
+		responseCode = http.StatusInternalServerError
+		http.Error(w, "error getting webhook receiver configuration", responseCode)
+		return
+	}
+
+	if config.RateLimit != nil && !wr.rateLimiter.allow(sourceIP(r), *config.RateLimit, startTime) {
+		logger.V(4).Info("rate limited webhook delivery", "source", sourceIP(r))
+		metrics.RecordWebhookDeliveryDropped(metrics.WebhookDropReasonRateLimited)
+		responseCode = http.StatusTooManyRequests
+		http.Error(w, "too many webhook deliveries", responseCode)
+		return
+	}
+
+	// Read the body before detecting the provider, which may also read it, so that the size limit applies to both.
+	if r.ContentLength > config.MaxPayloadBytes {
+		metrics.RecordWebhookDeliveryDropped(metrics.WebhookDropReasonPayloadTooLarge)
+		responseCode = http.StatusRequestEntityTooLarge
+		http.Error(w, "webhook payload is too large", responseCode)
+		return
+	}
+	jsonBytes, err := io.ReadAll(http.MaxBytesReader(w, r.Body, config.MaxPayloadBytes))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			metrics.RecordWebhookDeliveryDropped(metrics.WebhookDropReasonPayloadTooLarge)
+			responseCode = http.StatusRequestEntityTooLarge
+			http.Error(w, "webhook payload is too large", responseCode)
+			return
+		}
+		responseCode = http.StatusInternalServerError
+		http.Error(w, "error reading body", responseCode)
+		return
+	}
+	r.Body = io.NopCloser(bytes.NewReader(jsonBytes))
+	r.ContentLength = int64(len(jsonBytes))
+
 	// Determine provider from headers
 	provider := wr.DetectProvider(r)
 
@@ -163,124 +232,293 @@ func (wr *WebhookReceiver) postRoot(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// TODO: add a configurable payload max side for DoS protection.
-	jsonBytes, err := io.ReadAll(r.Body)
+	auth, err := wr.verifyDelivery(r.Context(), provider, r.Header, jsonBytes)
 	if err != nil {
+		logger.Error(err, "could not look up webhook secrets for webhook delivery")
 		responseCode = http.StatusInternalServerError
-		http.Error(w, "error reading body", responseCode)
+		http.Error(w, "error looking up webhook secrets", responseCode)
 		return
 	}
-
-	ctp, err := wr.findChangeTransferPolicy(r.Context(), provider, jsonBytes)
-	if err != nil {
-		logger.V(4).Info("could not find any matching ChangeTransferPolicies", "error", err)
-		responseCode = http.StatusNoContent
-		w.WriteHeader(responseCode)
+	signature = auth.signature
+	if !auth.accepted() {
+		logger.Info("rejected webhook delivery", "signature", signature)
+		responseCode = http.StatusUnauthorized
+		http.Error(w, "webhook delivery is not signed with a configured webhook secret", responseCode)
 		return
 	}
-	if ctp == nil {
-		logger.Info("no ChangeTransferPolicy found for webhook delivery")
+
+	// Only remember deliveries whose signature was checked, so that forged deliveries cannot block real ones.
+	if window := config.DeliveryDeduplicationWindow.Duration; window > 0 && deliveryID != "" {
+		key := provider + "/" + deliveryID
+		if !wr.deliveries.add(key, window, startTime) {
+			logger.Info("ignoring webhook delivery that was already processed")
+			metrics.RecordWebhookDeliveryDropped(metrics.WebhookDropReasonDuplicate)
+			responseCode = http.StatusNoContent
+			w.WriteHeader(responseCode)
+			return
+		}
+		defer func() {
+			// Let the SCM retry deliveries that could not be processed.
+			if responseCode >= http.StatusInternalServerError {
+				wr.deliveries.remove(key)
+			}
+		}()
+	}
+
+	if event, ok := parsePullRequestEvent(provider, jsonBytes); ok {
+		logger = logger.WithValues("pullRequestID", event.id, "action", event.action)
+		prs, err := wr.findPullRequests(r.Context(), auth, event)
+		if err != nil {
+			logger.Error(err, "could not look up PullRequests for webhook delivery")
+			responseCode = http.StatusInternalServerError
+			http.Error(w, "error looking up pull requests", responseCode)
+			return
+		}
+		if len(prs) == 0 {
+			logger.V(4).Info("no PullRequest found for webhook delivery")
+		}
+		ctpFound = len(prs) > 0
+
+		startUpdate := time.Now()
+		for _, pr := range prs {
+			if wr.enqueuePR != nil {
+				wr.enqueuePR(pr.Namespace, pr.Name)
+			}
+			logger.Info("Triggered reconcile of PullRequest via webhook", "namespace", pr.Namespace, "name", pr.Name)
+		}
+		updateDuration = time.Since(startUpdate)
+
 		responseCode = http.StatusNoContent
 		w.WriteHeader(responseCode)
 		return
 	}
 
-	ctpFound = true
+	ctps, notesRepos, err := wr.findChangeTransferPolicies(r.Context(), auth, provider, jsonBytes)
+	if err != nil {
+		logger.Error(err, "could not look up ChangeTransferPolicies for webhook delivery")
+		responseCode = http.StatusInternalServerError
+		http.Error(w, "error looking up change transfer policies", responseCode)
+		return
+	}
+	if len(ctps) == 0 {
+		logger.V(4).Info("no ChangeTransferPolicy found for webhook delivery")
+	}
+	ctpFound = len(ctps) > 0
 
 	// Use the enqueue function to trigger reconciliation.
 	startUpdate := time.Now()
-	if wr.enqueueCTP != nil {
-		wr.enqueueCTP(ctp.Namespace, ctp.Name)
+	for _, ctp := range ctps {
+		if wr.enqueueCTP != nil {
+			wr.enqueueCTP(ctp.Namespace, ctp.Name)
+		}
+		logger.Info("Triggered reconcile of ChangeTransferPolicy via webhook", "namespace", ctp.Namespace, "name", ctp.Name)
+	}
+	for _, gitRepo := range notesRepos {
+		if wr.notesPushed != nil {
+			wr.notesPushed(gitRepo.Namespace, gitRepo.Name)
+		}
+		logger.V(4).Info("Recorded push to the hydrator notes ref via webhook", "namespace", gitRepo.Namespace, "name", gitRepo.Name)
 	}
 	updateDuration = time.Since(startUpdate)
-	logger.Info("Triggered reconcile of ChangeTransferPolicy via webhook", "namespace", ctp.Namespace, "name", ctp.Name)
 
 	responseCode = http.StatusNoContent
 	w.WriteHeader(responseCode)
 }
 
-func (wr *WebhookReceiver) findChangeTransferPolicy(ctx context.Context, provider string, jsonBytes []byte) (*promoterv1alpha1.ChangeTransferPolicy, error) {
-	var beforeSha string
-	var ref string
-	ctpLists := promoterv1alpha1.ChangeTransferPolicyList{}
+// refUpdate is a ref that a push webhook delivery updated.
+type refUpdate struct {
+	// ref is the full name of the ref, such as refs/heads/environment/prod.
+	ref string
+	// beforeSha is the SHA the ref pointed to before the push. It is all zeros or empty when the ref was created.
+	beforeSha string
+}
+
+// parseRefUpdates returns every ref that a push webhook delivery updated. It returns nothing if the delivery is not a
+// push.
+func parseRefUpdates(provider string, jsonBytes []byte) []refUpdate {
+	var updates []refUpdate
 
 	// Extract webhook data based on provider
 	switch provider {
 	case ProviderGitHub, ProviderForgejo, ProviderGitea:
 		// GitHub, Forgejo, and Gitea webhook format (all use 'pusher')
 		if gjson.GetBytes(jsonBytes, "before").Exists() && gjson.GetBytes(jsonBytes, "pusher").Exists() {
-			beforeSha = gjson.GetBytes(jsonBytes, "before").String()
-			ref = gjson.GetBytes(jsonBytes, "ref").String()
+			updates = append(updates, refUpdate{
+				ref:       gjson.GetBytes(jsonBytes, "ref").String(),
+				beforeSha: gjson.GetBytes(jsonBytes, "before").String(),
+			})
 		}
 	case ProviderGitLab:
 		// GitLab webhook format
 		if gjson.GetBytes(jsonBytes, "before").Exists() && gjson.GetBytes(jsonBytes, "user_name").Exists() {
-			beforeSha = gjson.GetBytes(jsonBytes, "before").String()
-			ref = gjson.GetBytes(jsonBytes, "ref").String()
+			updates = append(updates, refUpdate{
+				ref:       gjson.GetBytes(jsonBytes, "ref").String(),
+				beforeSha: gjson.GetBytes(jsonBytes, "before").String(),
+			})
 		}
 	case ProviderBitbucketCloud:
-		// Bitbucket Cloud webhook format
+		// Bitbucket Cloud webhook format, with one change per updated branch or tag
 		if gjson.GetBytes(jsonBytes, "push.changes").Exists() && gjson.GetBytes(jsonBytes, "actor").Exists() {
-			changes := gjson.GetBytes(jsonBytes, "push.changes")
-			if changes.IsArray() && len(changes.Array()) > 0 {
-				firstChange := changes.Array()[0]
-				beforeSha = firstChange.Get("old.target.hash").String()
-				if newName := firstChange.Get("new.name"); newName.Exists() {
-					ref = "refs/heads/" + newName.String()
-				} else if oldName := firstChange.Get("old.name"); oldName.Exists() {
-					ref = "refs/heads/" + oldName.String()
+			for _, change := range gjson.GetBytes(jsonBytes, "push.changes").Array() {
+				target := change.Get("new")
+				if !target.Exists() || target.Type == gjson.Null {
+					target = change.Get("old")
 				}
+				prefix := "refs/heads/"
+				if target.Get("type").String() == "tag" {
+					prefix = "refs/tags/"
+				}
+				updates = append(updates, refUpdate{
+					ref:       prefix + target.Get("name").String(),
+					beforeSha: change.Get("old.target.hash").String(),
+				})
 			}
 		}
-	case ProviderAzureDevops:
-		// Azure DevOps webhook format
-		if gjson.GetBytes(jsonBytes, "resource.refUpdates").Exists() {
-			refUpdates := gjson.GetBytes(jsonBytes, "resource.refUpdates")
-			if refUpdates.IsArray() && len(refUpdates.Array()) > 0 {
-				firstUpdate := refUpdates.Array()[0]
-				beforeSha = firstUpdate.Get("oldObjectId").String()
-				ref = firstUpdate.Get("name").String()
+	case ProviderBitbucketServer:
+		// Bitbucket Data Center webhook format (repo:refs_changed), with one change per updated ref
+		if gjson.GetBytes(jsonBytes, "changes").Exists() && gjson.GetBytes(jsonBytes, "actor").Exists() {
+			for _, change := range gjson.GetBytes(jsonBytes, "changes").Array() {
+				updates = append(updates, refUpdate{
+					ref:       change.Get("refId").String(),
+					beforeSha: change.Get("fromHash").String(),
+				})
 			}
 		}
+	case ProviderAzureDevops:
+		// Azure DevOps webhook format, with one ref update per updated ref
+		for _, update := range gjson.GetBytes(jsonBytes, "resource.refUpdates").Array() {
+			updates = append(updates, refUpdate{
+				ref:       update.Get("name").String(),
+				beforeSha: update.Get("oldObjectId").String(),
+			})
+		}
+	case ProviderGerrit:
+		// Gerrit webhooks plugin format (ref-updated stream event)
+		if gjson.GetBytes(jsonBytes, "refUpdate").Exists() {
+			updates = append(updates, refUpdate{
+				ref:       gjson.GetBytes(jsonBytes, "refUpdate.refName").String(),
+				beforeSha: gjson.GetBytes(jsonBytes, "refUpdate.oldRev").String(),
+			})
+		}
 	default:
 		logger.V(4).Info("unsupported provider", "provider", provider)
-		return nil, nil
 	}
 
-	if beforeSha == "" {
-		logger.V(4).Info("unable to extract commit SHA from provider payload", "provider", provider)
-		return nil, nil
-	}
+	return updates
+}
 
-	err := wr.k8sClient.List(ctx, &ctpLists, &client.ListOptions{
-		FieldSelector: fields.SelectorFromSet(map[string]string{
-			".status.proposed.hydrated.sha": beforeSha,
-		}),
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to list changetransferpolicies for webhook receiver: %w", err)
+// findChangeTransferPolicies returns every ChangeTransferPolicy that a push webhook delivery is relevant to: those
+// whose proposed or active hydrated SHA is the SHA a pushed branch pointed to before the push, and those of the pushed
+// repository whose proposed or active branch is a pushed branch. Matching on the branch catches pushes that create a
+// branch or that move a branch the ChangeTransferPolicy has not seen yet. Branch names are only unique within a
+// repository, so they are only matched against the ChangeTransferPolicies of the GitRepositories the delivery is for.
+//
+// SCMs that report pushes to refs other than branches, such as the hydrator notes ref, do not say which branches the
+// push is about, so for those every ChangeTransferPolicy of the pushed repository is returned. The GitRepositories whose
+// hydrator notes ref was pushed are returned as well. Only ChangeTransferPolicies of repositories that the delivery is
+// authorized for are returned.
+func (wr *WebhookReceiver) findChangeTransferPolicies(ctx context.Context, auth deliveryAuthorization, provider string, jsonBytes []byte) ([]promoterv1alpha1.ChangeTransferPolicy, []promoterv1alpha1.GitRepository, error) {
+	updates := parseRefUpdates(provider, jsonBytes)
+	if len(updates) == 0 {
+		logger.V(4).Info("unable to extract ref updates from provider payload", "provider", provider)
+		return nil, nil, nil
 	}
 
-	if len(ctpLists.Items) == 0 {
-		// List again, this time checking the active sha. This lets us catch cases where someone manually merged a PR in the SCM.
-		err = wr.k8sClient.List(ctx, &ctpLists, &client.ListOptions{
+	seen := map[client.ObjectKey]bool{}
+	var ctps []promoterv1alpha1.ChangeTransferPolicy
+	add := func(found []promoterv1alpha1.ChangeTransferPolicy) {
+		for _, ctp := range found {
+			key := client.ObjectKeyFromObject(&ctp)
+			if !seen[key] {
+				seen[key] = true
+				ctps = append(ctps, ctp)
+			}
+		}
+	}
+	list := func(field, value string) ([]promoterv1alpha1.ChangeTransferPolicy, error) {
+		var ctpList promoterv1alpha1.ChangeTransferPolicyList
+		err := wr.k8sClient.List(ctx, &ctpList, &client.ListOptions{
 			FieldSelector: fields.SelectorFromSet(map[string]string{
-				".status.active.hydrated.sha": beforeSha,
+				field: value,
 			}),
 		})
 		if err != nil {
-			return nil, fmt.Errorf("failed to list changetransferpolicies for webhook receiver: %w", err)
+			return nil, fmt.Errorf("failed to list changetransferpolicies by %s for webhook receiver: %w", field, err)
+		}
+		return ctpList.Items, nil
+	}
+
+	var branchCTPs []promoterv1alpha1.ChangeTransferPolicy
+	var otherRefs, notesRef bool
+	for _, update := range updates {
+		branch, ok := strings.CutPrefix(update.ref, "refs/heads/")
+		if !ok {
+			otherRefs = otherRefs || strings.HasPrefix(update.ref, "refs/")
+			notesRef = notesRef || update.ref == git.HydratorNotesRef
+			continue
+		}
+
+		// The active SHA lets us catch cases where someone manually merged a PR in the SCM.
+		if update.beforeSha != "" && strings.Trim(update.beforeSha, "0") != "" {
+			for _, field := range []string{".status.proposed.hydrated.sha", ".status.active.hydrated.sha"} {
+				found, err := list(field, update.beforeSha)
+				if err != nil {
+					return nil, nil, err
+				}
+				add(found)
+			}
+		}
+		if branch != "" {
+			for _, field := range []string{".spec.proposedBranch", ".spec.activeBranch"} {
+				found, err := list(field, branch)
+				if err != nil {
+					return nil, nil, err
+				}
+				branchCTPs = append(branchCTPs, found...)
+			}
 		}
 	}
 
-	if len(ctpLists.Items) == 0 {
-		return nil, fmt.Errorf("no changetransferpolicies found from webhook receiver sha: %s, ref: %s", beforeSha, ref)
+	ctps, err := filterAuthorized(ctx, wr, auth, ctps, func(ctp promoterv1alpha1.ChangeTransferPolicy) client.ObjectKey {
+		return client.ObjectKey{Namespace: ctp.Namespace, Name: ctp.Spec.RepositoryReference.Name}
+	})
+	if err != nil {
+		return nil, nil, err
 	}
-	if len(ctpLists.Items) > 1 {
-		return nil, fmt.Errorf("too many changetranferpolicies found for sha: %s, ref: %s", beforeSha, ref)
+	if len(branchCTPs) == 0 && !otherRefs {
+		return ctps, nil, nil
+	}
+
+	gitRepos, err := wr.findGitRepositories(ctx, auth, provider, jsonBytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	pushedRepos := map[client.ObjectKey]bool{}
+	for _, gitRepo := range gitRepos {
+		pushedRepos[client.ObjectKeyFromObject(&gitRepo)] = true
+	}
+	for _, ctp := range branchCTPs {
+		if pushedRepos[client.ObjectKey{Namespace: ctp.Namespace, Name: ctp.Spec.RepositoryReference.Name}] {
+			add([]promoterv1alpha1.ChangeTransferPolicy{ctp})
+		}
+	}
+	if !otherRefs {
+		return ctps, nil, nil
+	}
+
+	var notesRepos []promoterv1alpha1.GitRepository
+	for _, gitRepo := range gitRepos {
+		repoCTPs, err := wr.listRepositoryChangeTransferPolicies(ctx, gitRepo)
+		if err != nil {
+			return nil, nil, err
+		}
+		add(repoCTPs)
+		if notesRef {
+			notesRepos = append(notesRepos, gitRepo)
+		}
 	}
 
-	return &ctpLists.Items[0], nil
+	return ctps, notesRepos, nil
 }
 
 // extractDeliveryID inspects common webhook headers and returns the first non-empty delivery ID string found (provider-agnostic).
@@ -310,12 +548,13 @@ func (wr *WebhookReceiver) extractDeliveryID(r *http.Request) string {
 	}
 	// Bitbucket Cloud
 	// X-Request-UUID: Unique identifier for the webhook request
-	// X-Hook-UUID: Unique identifier for the webhook itself (also used for provider detection)
+	// X-Hook-UUID identifies the webhook itself and is the same for all of its deliveries, so it is not a delivery ID.
 	// Note: Go's http.Header.Get is case-insensitive, so this will match X-Request-UUID correctly
 	if id := r.Header.Get("X-Request-Uuid"); id != "" {
 		return id
 	}
-	if id := r.Header.Get("X-Hook-Uuid"); id != "" {
+	// Bitbucket Data Center
+	if id := r.Header.Get("X-Request-Id"); id != "" {
 		return id
 	}
 	return ""
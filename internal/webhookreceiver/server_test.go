@@ -1,7 +1,9 @@
 package webhookreceiver_test
 
 import (
+	"io"
 	"net/http"
+	"strings"
 
 	"github.com/argoproj-labs/gitops-promoter/internal/webhookreceiver"
 	. "github.com/onsi/ginkgo/v2"
@@ -61,6 +63,20 @@ var _ = Describe("DetectProvider", func() {
 			},
 			expectedResult: webhookreceiver.ProviderBitbucketCloud,
 		},
+		"Bitbucket Cloud webhook with X-Hook-UUID and X-Event-Key": {
+			headers: map[string]string{
+				"X-Hook-UUID": "12345-abcde",
+				"X-Event-Key": "repo:push",
+			},
+			expectedResult: webhookreceiver.ProviderBitbucketCloud,
+		},
+		"Bitbucket Data Center webhook with X-Event-Key": {
+			headers: map[string]string{
+				"X-Event-Key":  "repo:refs_changed",
+				"X-Request-Id": "12345-abcde",
+			},
+			expectedResult: webhookreceiver.ProviderBitbucketServer,
+		},
 		"Unknown provider - no headers": {
 			headers:        map[string]string{},
 			expectedResult: webhookreceiver.ProviderUnknown,
@@ -87,6 +103,26 @@ var _ = Describe("DetectProvider", func() {
 		})
 	}
 
+	It("should detect a Gerrit ref-updated event from the body", func() {
+		body := `{"type":"ref-updated","refUpdate":{"oldRev":"abc","newRev":"def","refName":"refs/heads/environment/dev","project":"platform/deployments"}}`
+		req, err := http.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		Expect(err).NotTo(HaveOccurred())
+
+		result := wr.DetectProvider(req)
+		Expect(result).To(Equal(webhookreceiver.ProviderGerrit))
+
+		By("Restoring the body for the handler")
+		restored, err := io.ReadAll(req.Body)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(restored)).To(Equal(body))
+	})
+
+	It("should not detect Gerrit events other than ref-updated", func() {
+		req, err := http.NewRequest(http.MethodPost, "/", strings.NewReader(`{"type":"comment-added","change":{}}`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(wr.DetectProvider(req)).To(Equal(webhookreceiver.ProviderUnknown))
+	})
+
 	It("should detect GitHub first when multiple provider headers are present", func() {
 		req, err := http.NewRequest(http.MethodPost, "/", nil)
 		Expect(err).NotTo(HaveOccurred())
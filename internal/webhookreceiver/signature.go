@@ -0,0 +1,285 @@
+package webhookreceiver
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+)
+
+// WebhookSecretKey is the key in an ScmProvider's or ClusterScmProvider's Secret that holds the secret that webhook
+// deliveries from the SCM are signed with.
+const WebhookSecretKey = "webhookSecret"
+
+// Values of the signature label of the webhook metrics.
+const (
+	// SignatureValid means the delivery was signed with one of the configured webhook secrets.
+	SignatureValid = "valid"
+	// SignatureInvalid means the delivery was signed, but not with any of the configured webhook secrets, and every
+	// provider of its SCM has a webhook secret.
+	SignatureInvalid = "invalid"
+	// SignatureMissing means the delivery was not signed, but every provider of its SCM has a webhook secret, or
+	// requires signed deliveries.
+	SignatureMissing = "missing"
+	// SignatureNotRequired means no webhook secrets are configured, or the delivery was not signed with one, but is
+	// accepted for the providers of its SCM without a webhook secret, including Gerrit providers that accept unsigned
+	// deliveries.
+	SignatureNotRequired = "not_required"
+)
+
+// hubSignaturePrefix prefixes the hex encoded HMAC-SHA256 in the X-Hub-Signature-256 and X-Hub-Signature headers.
+const hubSignaturePrefix = "sha256="
+
+// deliveryAuthorization is the result of checking a webhook delivery against the webhook secrets.
+type deliveryAuthorization struct {
+	// signature is one of the Signature* values.
+	signature string
+	// providers are the ScmProviders and ClusterScmProviders whose repositories the delivery may trigger reconciles
+	// of. It is nil if the delivery may trigger reconciles of any repository, because no webhook secret is configured.
+	providers map[providerKey]bool
+}
+
+// providerKey identifies an ScmProvider or ClusterScmProvider. The namespace is empty for ClusterScmProviders.
+type providerKey struct {
+	kind      string
+	namespace string
+	name      string
+}
+
+// accepted returns true if the delivery may be processed.
+func (a deliveryAuthorization) accepted() bool {
+	return a.signature == SignatureValid || a.signature == SignatureNotRequired
+}
+
+// allowsRepository returns true if the delivery may trigger reconciles of the GitRepository.
+func (a deliveryAuthorization) allowsRepository(gitRepo promoterv1alpha1.GitRepository) bool {
+	if a.providers == nil {
+		return true
+	}
+	key := providerKey{kind: gitRepo.Spec.ScmProviderRef.Kind, name: gitRepo.Spec.ScmProviderRef.Name}
+	if key.kind != promoterv1alpha1.ClusterScmProviderKind {
+		key.kind = promoterv1alpha1.ScmProviderKind
+		key.namespace = gitRepo.Namespace
+	}
+	return a.providers[key]
+}
+
+// scmProviderSecret is an ScmProvider or ClusterScmProvider with the webhook secret of its Secret, if any.
+type scmProviderSecret struct {
+	key           providerKey
+	spec          *promoterv1alpha1.ScmProviderSpec
+	webhookSecret []byte
+}
+
+// verifyDelivery checks a webhook delivery against the webhook secrets of the ScmProviders and ClusterScmProviders.
+// While no webhook secret is configured, deliveries are accepted for every repository. Once any provider has one,
+// a delivery is only accepted for the repositories of the providers of its type that have no webhook secret, and of
+// those whose webhook secret it is signed with, so that a delivery cannot reach the repositories of other providers by
+// claiming to be from another SCM. Gerrit cannot sign deliveries, so they are only accepted for the repositories of
+// Gerrit providers that allow unsigned webhooks.
+func (wr *WebhookReceiver) verifyDelivery(ctx context.Context, provider string, header http.Header, body []byte) (deliveryAuthorization, error) {
+	scmProviders, err := wr.scmProviderSecrets(ctx)
+	if err != nil {
+		return deliveryAuthorization{}, err
+	}
+
+	auth := deliveryAuthorization{providers: map[providerKey]bool{}}
+	if provider == ProviderGerrit {
+		for _, scmProvider := range scmProviders {
+			if scmProvider.spec.Gerrit != nil && scmProvider.spec.Gerrit.AllowUnsignedWebhooks {
+				auth.providers[scmProvider.key] = true
+			}
+		}
+		auth.signature = SignatureMissing
+		if len(auth.providers) > 0 {
+			auth.signature = SignatureNotRequired
+		}
+		return auth, nil
+	}
+
+	if !slices.ContainsFunc(scmProviders, func(scmProvider scmProviderSecret) bool { return len(scmProvider.webhookSecret) > 0 }) {
+		return deliveryAuthorization{signature: SignatureNotRequired}, nil
+	}
+	signed := hasSignature(provider, header)
+	verified := false
+	for _, scmProvider := range scmProviders {
+		if !matchesProvider(provider, scmProvider.spec) {
+			continue
+		}
+		switch {
+		case len(scmProvider.webhookSecret) == 0:
+			auth.providers[scmProvider.key] = true
+		case signed && verifySignature(provider, header, body, scmProvider.webhookSecret):
+			auth.providers[scmProvider.key] = true
+			verified = true
+		}
+	}
+	switch {
+	case verified:
+		auth.signature = SignatureValid
+	case len(auth.providers) > 0:
+		auth.signature = SignatureNotRequired
+	case signed:
+		auth.signature = SignatureInvalid
+	default:
+		auth.signature = SignatureMissing
+	}
+	return auth, nil
+}
+
+// scmProviderSecrets returns all ScmProviders and ClusterScmProviders with the webhook secrets of their Secrets.
+// Providers whose Secret does not exist have no webhook secret.
+func (wr *WebhookReceiver) scmProviderSecrets(ctx context.Context) ([]scmProviderSecret, error) {
+	var scmProviders promoterv1alpha1.ScmProviderList
+	if err := wr.k8sClient.List(ctx, &scmProviders); err != nil {
+		return nil, fmt.Errorf("failed to list ScmProviders for webhook receiver: %w", err)
+	}
+	var clusterScmProviders promoterv1alpha1.ClusterScmProviderList
+	if err := wr.k8sClient.List(ctx, &clusterScmProviders); err != nil {
+		return nil, fmt.Errorf("failed to list ClusterScmProviders for webhook receiver: %w", err)
+	}
+
+	var result []scmProviderSecret
+	var secretKeys []client.ObjectKey
+	for _, scmProvider := range scmProviders.Items {
+		result = append(result, scmProviderSecret{
+			key:  providerKey{kind: promoterv1alpha1.ScmProviderKind, namespace: scmProvider.Namespace, name: scmProvider.Name},
+			spec: scmProvider.GetSpec(),
+		})
+		secretKeys = append(secretKeys, secretKey(scmProvider.Namespace, scmProvider.GetSpec()))
+	}
+	for _, clusterScmProvider := range clusterScmProviders.Items {
+		result = append(result, scmProviderSecret{
+			key:  providerKey{kind: promoterv1alpha1.ClusterScmProviderKind, name: clusterScmProvider.Name},
+			spec: clusterScmProvider.GetSpec(),
+		})
+		secretKeys = append(secretKeys, secretKey(wr.settingsMgr.GetControllerNamespace(), clusterScmProvider.GetSpec()))
+	}
+
+	for i, key := range secretKeys {
+		if key.Name == "" {
+			continue
+		}
+		var secret v1.Secret
+		if err := wr.k8sClient.Get(ctx, key, &secret); err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get Secret %s for webhook receiver: %w", key, err)
+		}
+		result[i].webhookSecret = secret.Data[WebhookSecretKey]
+	}
+	return result, nil
+}
+
+// secretKey returns the key of the Secret of a provider in the given namespace, or an empty key if it has none.
+func secretKey(namespace string, spec *promoterv1alpha1.ScmProviderSpec) client.ObjectKey {
+	if spec.SecretRef == nil {
+		return client.ObjectKey{}
+	}
+	return client.ObjectKey{Namespace: namespace, Name: spec.SecretRef.Name}
+}
+
+// matchesProvider returns true if the ScmProvider spec is for the given provider type.
+func matchesProvider(provider string, spec *promoterv1alpha1.ScmProviderSpec) bool {
+	switch provider {
+	case ProviderGitHub:
+		return spec.GitHub != nil
+	case ProviderGitLab:
+		return spec.GitLab != nil
+	case ProviderForgejo:
+		return spec.Forgejo != nil
+	case ProviderGitea:
+		return spec.Gitea != nil
+	case ProviderBitbucketCloud:
+		return spec.BitbucketCloud != nil
+	case ProviderBitbucketServer:
+		return spec.BitbucketServer != nil
+	case ProviderAzureDevops:
+		return spec.AzureDevOps != nil
+	default:
+		return false
+	}
+}
+
+// hasSignature returns true if the delivery carries the header that the provider signs deliveries with.
+func hasSignature(provider string, header http.Header) bool {
+	switch provider {
+	case ProviderGitHub:
+		return header.Get("X-Hub-Signature-256") != ""
+	case ProviderGitLab:
+		return header.Get("X-Gitlab-Token") != ""
+	case ProviderForgejo:
+		return header.Get("X-Forgejo-Signature") != "" || header.Get("X-Gitea-Signature") != ""
+	case ProviderGitea:
+		return header.Get("X-Gitea-Signature") != ""
+	case ProviderBitbucketCloud, ProviderBitbucketServer:
+		return header.Get("X-Hub-Signature") != ""
+	case ProviderAzureDevops:
+		_, _, ok := basicAuth(header)
+		return ok
+	default:
+		return false
+	}
+}
+
+// verifySignature returns true if the delivery was signed with the given secret:
+//   - GitHub sends the HMAC-SHA256 of the body in X-Hub-Signature-256, prefixed with "sha256=".
+//   - Bitbucket Cloud and Bitbucket Data Center send the same in X-Hub-Signature.
+//   - Gitea and Forgejo send the hex encoded HMAC-SHA256 of the body in X-Gitea-Signature and X-Forgejo-Signature.
+//   - GitLab sends the secret token itself in X-Gitlab-Token.
+//   - Azure DevOps service hooks send the secret as the basic authentication password.
+func verifySignature(provider string, header http.Header, body []byte, secret []byte) bool {
+	switch provider {
+	case ProviderGitHub:
+		return verifyHMAC(strings.TrimPrefix(header.Get("X-Hub-Signature-256"), hubSignaturePrefix), body, secret)
+	case ProviderBitbucketCloud, ProviderBitbucketServer:
+		signature := header.Get("X-Hub-Signature")
+		if !strings.HasPrefix(signature, hubSignaturePrefix) {
+			return false
+		}
+		return verifyHMAC(strings.TrimPrefix(signature, hubSignaturePrefix), body, secret)
+	case ProviderForgejo:
+		if signature := header.Get("X-Forgejo-Signature"); signature != "" {
+			return verifyHMAC(signature, body, secret)
+		}
+		return verifyHMAC(header.Get("X-Gitea-Signature"), body, secret)
+	case ProviderGitea:
+		return verifyHMAC(header.Get("X-Gitea-Signature"), body, secret)
+	case ProviderGitLab:
+		return subtle.ConstantTimeCompare([]byte(header.Get("X-Gitlab-Token")), secret) == 1
+	case ProviderAzureDevops:
+		_, password, ok := basicAuth(header)
+		return ok && subtle.ConstantTimeCompare([]byte(password), secret) == 1
+	default:
+		return false
+	}
+}
+
+// verifyHMAC returns true if signature is the hex encoded HMAC-SHA256 of body with the given secret.
+func verifyHMAC(signature string, body []byte, secret []byte) bool {
+	signatureBytes, err := hex.DecodeString(signature)
+	if err != nil || len(signatureBytes) == 0 {
+		return false
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return hmac.Equal(signatureBytes, mac.Sum(nil))
+}
+
+// basicAuth returns the basic authentication credentials of the delivery.
+func basicAuth(header http.Header) (string, string, bool) {
+	r := http.Request{Header: header}
+	return r.BasicAuth()
+}
@@ -0,0 +1,260 @@
+package webhookreceiver
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	promoterv1alpha1 "github.com/argoproj-labs/gitops-promoter/api/v1alpha1"
+	"github.com/argoproj-labs/gitops-promoter/internal/settings"
+)
+
+func sign(body []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+var _ = Describe("verifySignature", func() {
+	body := []byte(`{"ref":"refs/heads/environment/prod"}`)
+	const secret = "s3cret"
+
+	tests := map[string]struct {
+		provider string
+		header   http.Header
+		valid    bool
+	}{
+		"GitHub signed": {
+			provider: ProviderGitHub,
+			header:   http.Header{"X-Hub-Signature-256": {"sha256=" + sign(body, secret)}},
+			valid:    true,
+		},
+		"GitHub signed with another secret": {
+			provider: ProviderGitHub,
+			header:   http.Header{"X-Hub-Signature-256": {"sha256=" + sign(body, "other")}},
+		},
+		"Gitea signed": {
+			provider: ProviderGitea,
+			header:   http.Header{"X-Gitea-Signature": {sign(body, secret)}},
+			valid:    true,
+		},
+		"Forgejo signed": {
+			provider: ProviderForgejo,
+			header:   http.Header{"X-Forgejo-Signature": {sign(body, secret)}},
+			valid:    true,
+		},
+		"Forgejo with a malformed signature": {
+			provider: ProviderForgejo,
+			header:   http.Header{"X-Forgejo-Signature": {"not-hex"}},
+		},
+		"GitLab with the secret token": {
+			provider: ProviderGitLab,
+			header:   http.Header{"X-Gitlab-Token": {secret}},
+			valid:    true,
+		},
+		"GitLab with another token": {
+			provider: ProviderGitLab,
+			header:   http.Header{"X-Gitlab-Token": {"other"}},
+		},
+		"Bitbucket Cloud signed": {
+			provider: ProviderBitbucketCloud,
+			header:   http.Header{"X-Hub-Signature": {"sha256=" + sign(body, secret)}},
+			valid:    true,
+		},
+		"Bitbucket Data Center signed without the algorithm prefix": {
+			provider: ProviderBitbucketServer,
+			header:   http.Header{"X-Hub-Signature": {sign(body, secret)}},
+		},
+		"Azure DevOps with the secret as password": {
+			provider: ProviderAzureDevops,
+			header:   http.Header{"Authorization": {"Basic " + "cHJvbW90ZXI6czNjcmV0"}}, // promoter:s3cret
+			valid:    true,
+		},
+		"Azure DevOps with another password": {
+			provider: ProviderAzureDevops,
+			header:   http.Header{"Authorization": {"Basic " + "cHJvbW90ZXI6b3RoZXI="}}, // promoter:other
+		},
+	}
+
+	for name, test := range tests {
+		It("should verify a "+name+" delivery", func() {
+			Expect(hasSignature(test.provider, test.header)).To(BeTrue())
+			Expect(verifySignature(test.provider, test.header, body, []byte(secret))).To(Equal(test.valid))
+		})
+	}
+
+	It("should not verify a delivery whose body was changed", func() {
+		header := http.Header{"X-Hub-Signature-256": {"sha256=" + sign(body, secret)}}
+		Expect(verifySignature(ProviderGitHub, header, []byte(`{"ref":"refs/heads/main"}`), []byte(secret))).To(BeFalse())
+	})
+})
+
+var _ = Describe("verifyDelivery", func() {
+	const controllerNamespace = "promoter-system"
+	body := []byte(`{"ref":"refs/heads/environment/prod"}`)
+
+	newReceiver := func(objs ...client.Object) *WebhookReceiver {
+		k8sClient := newTestClient(objs...)
+		return &WebhookReceiver{
+			k8sClient:   k8sClient,
+			settingsMgr: settings.NewManager(k8sClient, k8sClient, settings.ManagerConfig{ControllerNamespace: controllerNamespace}),
+		}
+	}
+	gitHubProvider := &promoterv1alpha1.ScmProvider{
+		ObjectMeta: metav1.ObjectMeta{Name: "github", Namespace: "default"},
+		Spec: promoterv1alpha1.ScmProviderSpec{
+			SecretRef: &v1.LocalObjectReference{Name: "github"},
+			GitHub:    &promoterv1alpha1.GitHub{},
+		},
+	}
+	gitLabProvider := &promoterv1alpha1.ClusterScmProvider{
+		ObjectMeta: metav1.ObjectMeta{Name: "gitlab"},
+		Spec: promoterv1alpha1.ScmProviderSpec{
+			SecretRef: &v1.LocalObjectReference{Name: "gitlab"},
+			GitLab:    &promoterv1alpha1.GitLab{},
+		},
+	}
+	gitHubSecret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "github", Namespace: "default"},
+		Data:       map[string][]byte{WebhookSecretKey: []byte("github-secret")},
+	}
+	gitLabSecret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "gitlab", Namespace: controllerNamespace},
+		Data:       map[string][]byte{WebhookSecretKey: []byte("gitlab-secret")},
+	}
+
+	It("should accept unsigned deliveries for every repository when no webhook secret is configured", func() {
+		wr := newReceiver(gitHubProvider, &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "github", Namespace: "default"}})
+		auth, err := wr.verifyDelivery(context.Background(), ProviderGitHub, http.Header{}, body)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(auth.signature).To(Equal(SignatureNotRequired))
+		Expect(auth.allowsRepository(gitRepository("default", "repo", promoterv1alpha1.ScmProviderKind, "other"))).To(BeTrue())
+	})
+
+	It("should verify deliveries against the Secret of an ScmProvider", func() {
+		wr := newReceiver(gitHubProvider, gitHubSecret, gitLabProvider, gitLabSecret)
+
+		auth, err := wr.verifyDelivery(context.Background(), ProviderGitHub, http.Header{}, body)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(auth.signature).To(Equal(SignatureMissing))
+
+		auth, err = wr.verifyDelivery(context.Background(), ProviderGitHub, http.Header{"X-Hub-Signature-256": {"sha256=" + sign(body, "gitlab-secret")}}, body)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(auth.signature).To(Equal(SignatureInvalid))
+
+		auth, err = wr.verifyDelivery(context.Background(), ProviderGitHub, http.Header{"X-Hub-Signature-256": {"sha256=" + sign(body, "github-secret")}}, body)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(auth.signature).To(Equal(SignatureValid))
+	})
+
+	It("should only allow the repositories of the ScmProviders whose secret signed the delivery", func() {
+		otherProvider := gitHubProvider.DeepCopy()
+		otherProvider.Name = "other-github"
+		otherProvider.Spec.SecretRef = &v1.LocalObjectReference{Name: "other-github"}
+		otherSecret := &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "other-github", Namespace: "default"},
+			Data:       map[string][]byte{WebhookSecretKey: []byte("other-secret")},
+		}
+		wr := newReceiver(gitHubProvider, gitHubSecret, otherProvider, otherSecret)
+
+		auth, err := wr.verifyDelivery(context.Background(), ProviderGitHub, http.Header{"X-Hub-Signature-256": {"sha256=" + sign(body, "github-secret")}}, body)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(auth.signature).To(Equal(SignatureValid))
+		Expect(auth.allowsRepository(gitRepository("default", "repo", promoterv1alpha1.ScmProviderKind, "github"))).To(BeTrue())
+		Expect(auth.allowsRepository(gitRepository("default", "repo", "", "github"))).To(BeTrue())
+		Expect(auth.allowsRepository(gitRepository("default", "repo", promoterv1alpha1.ScmProviderKind, "other-github"))).To(BeFalse())
+		Expect(auth.allowsRepository(gitRepository("other", "repo", promoterv1alpha1.ScmProviderKind, "github"))).To(BeFalse())
+	})
+
+	It("should only accept unsigned deliveries for the providers without a webhook secret", func() {
+		wr := newReceiver(gitHubProvider, gitHubSecret, gitLabProvider)
+
+		auth, err := wr.verifyDelivery(context.Background(), ProviderGitLab, http.Header{}, body)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(auth.signature).To(Equal(SignatureNotRequired))
+		Expect(auth.accepted()).To(BeTrue())
+		Expect(auth.allowsRepository(gitRepository("default", "repo", promoterv1alpha1.ClusterScmProviderKind, "gitlab"))).To(BeTrue())
+		Expect(auth.allowsRepository(gitRepository("default", "repo", promoterv1alpha1.ScmProviderKind, "github"))).To(BeFalse())
+
+		auth, err = wr.verifyDelivery(context.Background(), ProviderGitHub, http.Header{}, body)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(auth.signature).To(Equal(SignatureMissing))
+		Expect(auth.accepted()).To(BeFalse())
+	})
+
+	It("should require a valid signature only for the repositories of the provider with a webhook secret", func() {
+		unsignedProvider := gitHubProvider.DeepCopy()
+		unsignedProvider.Name = "unsigned-github"
+		unsignedProvider.Spec.SecretRef = &v1.LocalObjectReference{Name: "unsigned-github"}
+		wr := newReceiver(gitHubProvider, gitHubSecret, unsignedProvider)
+		signedRepo := gitRepository("default", "repo", promoterv1alpha1.ScmProviderKind, "github")
+		unsignedRepo := gitRepository("default", "repo", promoterv1alpha1.ScmProviderKind, "unsigned-github")
+
+		auth, err := wr.verifyDelivery(context.Background(), ProviderGitHub, http.Header{"X-Hub-Signature-256": {"sha256=" + sign(body, "github-secret")}}, body)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(auth.signature).To(Equal(SignatureValid))
+		Expect(auth.allowsRepository(signedRepo)).To(BeTrue())
+		Expect(auth.allowsRepository(unsignedRepo)).To(BeTrue())
+
+		for _, header := range []http.Header{{}, {"X-Hub-Signature-256": {"sha256=" + sign(body, "other-secret")}}} {
+			auth, err = wr.verifyDelivery(context.Background(), ProviderGitHub, header, body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(auth.signature).To(Equal(SignatureNotRequired))
+			Expect(auth.accepted()).To(BeTrue())
+			Expect(auth.allowsRepository(signedRepo)).To(BeFalse())
+			Expect(auth.allowsRepository(unsignedRepo)).To(BeTrue())
+		}
+	})
+
+	It("should read the Secret of a ClusterScmProvider from the controller namespace", func() {
+		wr := newReceiver(gitHubProvider, gitHubSecret, gitLabProvider, gitLabSecret)
+
+		auth, err := wr.verifyDelivery(context.Background(), ProviderGitLab, http.Header{"X-Gitlab-Token": {"gitlab-secret"}}, body)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(auth.signature).To(Equal(SignatureValid))
+		Expect(auth.allowsRepository(gitRepository("default", "repo", promoterv1alpha1.ClusterScmProviderKind, "gitlab"))).To(BeTrue())
+	})
+
+	It("should only accept unsigned Gerrit deliveries for Gerrit providers that allow them", func() {
+		wr := newReceiver()
+		auth, err := wr.verifyDelivery(context.Background(), ProviderGerrit, http.Header{}, body)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(auth.signature).To(Equal(SignatureMissing))
+
+		gerritProvider := &promoterv1alpha1.ScmProvider{
+			ObjectMeta: metav1.ObjectMeta{Name: "gerrit", Namespace: "default"},
+			Spec: promoterv1alpha1.ScmProviderSpec{
+				Gerrit: &promoterv1alpha1.Gerrit{Domain: "gerrit.example.com"},
+			},
+		}
+		wr = newReceiver(gerritProvider)
+		auth, err = wr.verifyDelivery(context.Background(), ProviderGerrit, http.Header{}, body)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(auth.signature).To(Equal(SignatureMissing))
+
+		gerritProvider.Spec.Gerrit.AllowUnsignedWebhooks = true
+		wr = newReceiver(gerritProvider, gitHubProvider, gitHubSecret)
+		auth, err = wr.verifyDelivery(context.Background(), ProviderGerrit, http.Header{}, body)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(auth.signature).To(Equal(SignatureNotRequired))
+		Expect(auth.allowsRepository(gitRepository("default", "repo", promoterv1alpha1.ScmProviderKind, "gerrit"))).To(BeTrue())
+		Expect(auth.allowsRepository(gitRepository("default", "repo", promoterv1alpha1.ScmProviderKind, "github"))).To(BeFalse())
+	})
+})
+
+func gitRepository(namespace, name, providerKind, providerName string) promoterv1alpha1.GitRepository {
+	return promoterv1alpha1.GitRepository{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: promoterv1alpha1.GitRepositorySpec{
+			ScmProviderRef: promoterv1alpha1.ScmProviderObjectReference{Kind: providerKind, Name: providerName},
+		},
+	}
+}
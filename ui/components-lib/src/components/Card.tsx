@@ -159,6 +159,7 @@ const Card: React.FC<CardProps> = ({ environments }) => {
                     mergeTimeAgo={
                       inHistoryMode ? (env.historyMergeTimeAgo ?? undefined) : undefined
                     }
+                    changes={env.activeChanges}
                   />
 
                   {/* Proposed Commits Section (normal mode only) */}
@@ -178,6 +179,7 @@ const Card: React.FC<CardProps> = ({ environments }) => {
                         healthSummary={env.proposedChecksSummary}
                         prUrl={env.prUrl}
                         prNumber={env.prNumber?.toString()}
+                        changes={env.proposedChanges}
                       />
                     )}
                 </div>
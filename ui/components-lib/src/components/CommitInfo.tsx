@@ -6,7 +6,7 @@ import React, { useState, useRef, useCallback } from 'react';
 import TimeAgo from './TimeAgo';
 import HealthSummary from './HealthSummary';
 import './CommitInfo.scss';
-import { ReferenceCommit } from '@shared/types/promotion';
+import { ChangeSummary, ReferenceCommit } from '@shared/types/promotion';
 
 export interface CommitInfoProps {
   title?: string;
@@ -28,6 +28,7 @@ export interface CommitInfoProps {
   primaryChecksTitle?: string;
   primaryChecksTitleTooltip?: string;
   mergeTimeAgo?: string;
+  changes?: ChangeSummary | null;
 }
 
 // Combined component to display commit information and groups
@@ -51,6 +52,7 @@ const CommitInfo: React.FC<CommitInfoProps> = ({
   primaryChecksTitle,
   primaryChecksTitleTooltip,
   mergeTimeAgo,
+  changes,
 }) => {
   const [showDeploymentTooltip, setShowDeploymentTooltip] = useState(false);
   const [showCodeTooltip, setShowCodeTooltip] = useState(false);
@@ -189,6 +191,27 @@ const CommitInfo: React.FC<CommitInfoProps> = ({
     }
   };
 
+  const renderChanges = (summary: ChangeSummary) => {
+    const directories = summary.directories || [];
+    const hiddenCount = (summary.directoryCount || directories.length) - directories.length;
+    const fileCount = summary.filesChanged || 0;
+    return (
+      <div className="change-summary" title={directories.join('\n')}>
+        <span className="change-files">
+          {fileCount} {fileCount === 1 ? 'file' : 'files'}
+        </span>
+        <span className="change-additions">+{summary.additions || 0}</span>
+        <span className="change-deletions">-{summary.deletions || 0}</span>
+        {directories.length > 0 && (
+          <span className="change-directories">
+            {directories.join(', ')}
+            {hiddenCount > 0 && ` and ${hiddenCount} more`}
+          </span>
+        )}
+      </div>
+    );
+  };
+
   if (!title) {
     return (
       <div className="commits-section">
@@ -226,6 +249,7 @@ const CommitInfo: React.FC<CommitInfoProps> = ({
           {codeCommit && renderCommit(codeCommit, 'code', codeCommitUrl || '')}
         </div>
       )}
+      {changes && renderChanges(changes)}
 
       {/* Display checks for this section */}
       {(healthSummary?.shouldDisplay || (additionalChecks && additionalChecks.length > 0)) &&
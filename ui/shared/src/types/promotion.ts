@@ -27,6 +27,14 @@ export interface ReferenceCommit {
   repoURL?: string;
 }
 
+export interface ChangeSummary {
+  filesChanged?: number;
+  additions?: number;
+  deletions?: number;
+  directories?: string[];
+  directoryCount?: number;
+}
+
 export interface PullRequest {
   id: string;
   url?: string;
@@ -38,6 +46,7 @@ export interface History {
     dry?: Commit;
     hydrated?: Commit;
     commitStatuses?: CommitStatus[];
+    changes?: ChangeSummary;
   };
   proposed: {
     hydrated?: Commit;
@@ -52,11 +61,13 @@ export interface Environment {
     dry?: Commit;
     hydrated?: Commit;
     commitStatuses?: CommitStatus[];
+    changes?: ChangeSummary;
   };
   proposed: {
     dry?: Commit;
     hydrated?: Commit;
     commitStatuses?: CommitStatus[];
+    changes?: ChangeSummary;
   };
   pullRequest?: PullRequest;
   history?: History[];
@@ -119,6 +130,7 @@ export interface EnrichedEnvDetails {
 
   activeReferenceCommit: ReferenceCommit | null;
   activeReferenceCommitUrl: string | null;
+  activeChanges: ChangeSummary | null;
 
   // Proposed commits
   proposedSha: string;
@@ -135,6 +147,7 @@ export interface EnrichedEnvDetails {
 
   proposedReferenceCommit: ReferenceCommit | null;
   proposedReferenceCommitUrl: string | null;
+  proposedChanges: ChangeSummary | null;
 
   // History
   historyMergeTimeAgo: string | null;
@@ -112,6 +112,7 @@ function getEnvDetails(environment: Environment, index: number = 0): EnrichedEnv
     activeReferenceCommitUrl: activeReferenceData ? (activeReferenceData.url ?? null) : null,
     activeChecks,
     activeChecksSummary,
+    activeChanges: activeHistory.changes || null,
 
     // PROPOSED
     proposedStatus: isHistoric
@@ -131,6 +132,7 @@ function getEnvDetails(environment: Environment, index: number = 0): EnrichedEnv
     proposedReferenceCommitUrl: proposedReferenceData ? (proposedReferenceData.url ?? null) : null,
     proposedChecks,
     proposedChecksSummary,
+    proposedChanges: isHistoric ? null : proposed.changes || null,
 
     // History
     historyMergeTimeAgo,